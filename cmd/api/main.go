@@ -16,19 +16,31 @@ import (
 	"github.com/rakahikmah/finance-tracking/config"
 	_ "github.com/rakahikmah/finance-tracking/docs"
 	"github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
 	"github.com/rakahikmah/finance-tracking/internal/http/auth"
 	"github.com/rakahikmah/finance-tracking/internal/http/handler"
+	"github.com/rakahikmah/finance-tracking/internal/http/middleware"
 	"github.com/rakahikmah/finance-tracking/internal/parser"
+	"github.com/rakahikmah/finance-tracking/internal/presenter/csv"
 	"github.com/rakahikmah/finance-tracking/internal/presenter/json"
+	"github.com/rakahikmah/finance-tracking/internal/queue"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mongodb"
 	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
 	"github.com/rakahikmah/finance-tracking/internal/usecase"
 	todo_list_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/todo_list"
 	category_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/category"
 	transactions_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/transactions" // Import usecase transaksi
+	report_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/report"
+	template_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/template"
+	notification_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/notification"
+	period_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/period"
+	recurring_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/recurring"
+	budget_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/budget"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/redis/go-redis/v9"
 	"github.com/subosito/gotenv"
 )
 
@@ -63,16 +75,34 @@ func main() {
 	// logger = logger.WithOptions(zap.AddCallerSkip(1))
 
 	presenterJson := json.NewJsonPresenter()
+	presenterCsv := csv.NewCsvPresenter()
 	parser := parser.NewParser()
 
-	// RabbitMQ Configuration (if needed)
-	// queue, err := config.NewRabbitMQInstance(context.Background(), &cfg.RabbitMQOption)
-	// if err != nil {zp
-	// 	log.Fatal(err)
-	// }
+	// RabbitMQ & Redis Configuration
+	// RabbitMQ is only connected when the bank webhook, background import jobs or transaction events are enabled, all publish to it.
+	// Redis keeps seen webhook signatures to reject replayed requests, and logged out tokens when JWT_BLACKLIST_STORE=redis.
+	var rabbitQueue *queue.RabbitMQ
+	var redisDB *redis.Client
+	if cfg.WebhookOption.BankSecret != "" || cfg.ImportJobOption.Enabled || cfg.TransactionEventOption.Enabled {
+		var err error
+		rabbitQueue, err = config.NewRabbitMQInstance(context.Background(), &cfg.RabbitMQOption)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer rabbitQueue.Close()
+	}
+	if cfg.WebhookOption.BankSecret != "" || cfg.JwtOption.BlacklistStore == "redis" {
+		redisDB = config.NewRedis(&cfg.RedisOption)
+		defer redisDB.Close()
+	}
 
-	// Redis Configuration (if needed)
-	// redisDB := config.NewRedis(&cfg.RedisOption)
+	// Transaction events are published in the background so a slow broker never delays the request
+	var transactionPublisher queue.Publisher = queue.NoopPublisher{}
+	if cfg.TransactionEventOption.Enabled {
+		asyncPublisher := queue.NewAsyncPublisher(rabbitQueue, cfg.TransactionEventOption.BufferSize)
+		defer asyncPublisher.Close()
+		transactionPublisher = asyncPublisher
+	}
 
 	// MySQL/MariaDB Initialization
 	gormLogger := config.NewGormLogMysqlConfig(&cfg.MysqlOption)
@@ -81,6 +111,13 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// MongoDB is only used by the readiness probe here, the connection is not awaited so startup does not depend on it
+	mongoDB, err := config.ConnectMongodb(context.Background(), &cfg.MongodbOption)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer mongoDB.Client().Disconnect(context.Background())
+
 	// PostgreSQL Initialization
 	// gormLogger := config.NewGormLogPostgreConfig(&cfg.MysqlOption)
 	// postgreDB, err := config.NewPostgreSQL(cfg.AppEnv, &cfg.PostgreSqlOption, gormLogger)
@@ -89,34 +126,100 @@ func main() {
 	// }
 
 	// AUTH : Write authetincation mechanism method (JWT, Basic Auth, etc.)
-	jwtAuth := auth.NewJWTAuth()
+	// Keys are read once here; the API issues tokens, so the current private key is required
+	jwtKeys, err := auth.LoadKeySet(cfg.JwtOption)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !jwtKeys.CanSign() {
+		log.Fatal(auth.ErrNoSigningKey)
+	}
+	tokenTTL := time.Duration(cfg.JwtExpireDaysCount) * 24 * time.Hour
+	auth.SetTokenKeys(jwtKeys, tokenTTL)
+	jwtAuth := auth.NewJWTAuth(jwtKeys, tokenTTL)
+	if cfg.JwtOption.BlacklistStore == "redis" {
+		auth.SetTokenBlacklist(auth.NewRedisBlacklist(redisDB, "jwt:blacklist:"))
+	} else {
+		auth.SetTokenBlacklist(auth.NewMemoryBlacklist())
+	}
 
 	// --- REPOSITORY : Write repository code here (database, cache, etc.) ---
 	userRepo := mysql.NewUserRepository(mysqlDB)
 	todoListRepo := mysql.NewTodoListRepository(mysqlDB)
 	CategoryRepo := mysql.NewCategoryRepository(mysqlDB)
 	TransactionRepo := mysql.NewTransactionRepository(mysqlDB)
-
-
+	notificationPreferenceRepo := mysql.NewNotificationPreferenceRepository(mysqlDB)
+	transactionTemplateRepo := mysql.NewTransactionTemplateRepository(mysqlDB)
+	periodLockRepo := mysql.NewPeriodLockRepository(mysqlDB)
+	importJobRepo := mysql.NewImportJobRepository(mysqlDB)
+	budgetRepo := mysql.NewBudgetRepository(mysqlDB)
+
+	// Transaksi lama (sebelum kolom currency ada) memakai mata uang dasar dari konfigurasi
+	baseCurrency := helper.BaseCurrency(cfg.CurrencyOption.Code)
+	if err := helper.ValidateCurrencyCode(baseCurrency); err != nil {
+		log.Fatalf("invalid CURRENCY_CODE: %v", err)
+	}
+	if _, err := TransactionRepo.BackfillCurrency(context.Background(), baseCurrency); err != nil {
+		log.Fatal(err)
+	}
 
 	// --- USECASE : Write bussines logic code here (validation, business logic, etc.) ---
 	// _ = usecase.NewLogUsecase(queue) // LogUsecase is a sample usecase for sending log to queue (Mongodb, ElasticSearch, etc.)
 	userUsecase := usecase.NewUserUsecase(userRepo, jwtAuth)
 	crudTodoListUsecase := todo_list_usecase.NewCrudTodoListUsecase(todoListRepo)
-	crudCategoryUsecase := category_usecase.NewCrudCategory(CategoryRepo)
-	crudTransactionUsecase := transactions_usecase.NewCrudTransaction(TransactionRepo, CategoryRepo)
+	userStatusChecker := usecase.NewUserStatusChecker(userRepo, 30*time.Second)
+	userSettingsUsecase := usecase.NewUserSettings(userRepo)
+	periodLockUsecase := period_usecase.NewPeriodLock(periodLockRepo, userStatusChecker)
+	crudTransactionUsecase := transactions_usecase.NewCrudTransaction(TransactionRepo, CategoryRepo, cfg.SummaryOption, cfg.CurrencyOption, cfg.ResponseOption, cfg.SearchOption, cfg.SpendingCapOption, userStatusChecker, userSettingsUsecase, periodLockUsecase, transactionPublisher)
+	crudCategoryUsecase := category_usecase.NewCrudCategory(CategoryRepo, TransactionRepo, userStatusChecker, crudTransactionUsecase)
+	transactionTemplateUsecase := template_usecase.NewCrudTransactionTemplate(transactionTemplateRepo, CategoryRepo, crudTransactionUsecase, userStatusChecker)
+	reportUsecase := report_usecase.NewReport(TransactionRepo, CategoryRepo, cfg.CurrencyOption)
+	notificationPreferenceUsecase := notification_usecase.NewNotificationPreference(notificationPreferenceRepo)
+	budgetUsecase := budget_usecase.NewCrudBudget(budgetRepo, CategoryRepo, cfg.CurrencyOption, userStatusChecker)
 	
 
 	// --- HANDLER : Register HTTP endpoints ---
-	api := app.Group("/api/v1")
+	// Deadline per request, report routes aggregate a whole range and get their own (longer) timeout
+	api := app.Group("/api/v1", middleware.Timeout(time.Duration(cfg.RequestTimeoutOption.Default)*time.Second))
+	reportTimeout := middleware.Timeout(time.Duration(cfg.RequestTimeoutOption.Report) * time.Second)
+	api.Use("/reports", reportTimeout)
+	api.Use("/insights", reportTimeout)
 
 	handler.NewAuthHandler(parser, presenterJson, userUsecase).Register(api)
 	handler.NewTodoListHandler(parser, presenterJson, crudTodoListUsecase).Register(api)
-	handler.NewCategoryHandler(parser, presenterJson, crudCategoryUsecase).Register(api)
-	handler.NewTransactionHandler(parser, presenterJson, crudTransactionUsecase).Register(api)
+	// One bucket per user shared by transaction and category writes
+	writeLimit := middleware.RateLimit(cfg.RateLimitOption.WritePerMinute)
+	handler.NewCategoryHandler(parser, presenterJson, crudCategoryUsecase, writeLimit).Register(api)
+	handler.NewTransactionHandler(parser, presenterJson, presenterCsv, crudTransactionUsecase, cfg.CurrencyOption, writeLimit).Register(api)
+	handler.NewTransactionTemplateHandler(parser, presenterJson, transactionTemplateUsecase).Register(api)
+	handler.NewReportHandler(parser, presenterJson, reportUsecase).Register(api)
+	handler.NewNotificationHandler(parser, presenterJson, notificationPreferenceUsecase).Register(api)
+	handler.NewPeriodHandler(parser, presenterJson, periodLockUsecase).Register(api)
+	handler.NewRecurringHandler(parser, presenterJson, recurring_usecase.NewRecurring()).Register(api)
+	handler.NewBudgetHandler(parser, presenterJson, budgetUsecase).Register(api)
+	handler.NewUserSettingsHandler(parser, presenterJson, userSettingsUsecase).Register(api)
+
+	// Bank webhook is only registered when the shared secret is configured
+	if cfg.WebhookOption.BankSecret != "" {
+		webhookUsecase := usecase.NewWebhookUsecase(rabbitQueue)
+		tolerance := time.Duration(cfg.WebhookOption.ToleranceSeconds) * time.Second
+		replayGuard := auth.NewRedisReplayGuard(redisDB, "webhook:bank:")
+		handler.NewWebhookHandler(presenterJson, webhookUsecase, cfg.WebhookOption.BankSecret, tolerance, replayGuard).Register(api)
+	}
+
+	// Background import jobs are only registered when enabled, jobs are processed by the worker (topic import.job)
+	if cfg.ImportJobOption.Enabled {
+		importJobUsecase := transactions_usecase.NewImportJob(importJobRepo, TransactionRepo, CategoryRepo, cfg.CurrencyOption, cfg.ImportJobOption, userStatusChecker, periodLockUsecase, rabbitQueue)
+		handler.NewImportJobHandler(parser, presenterJson, importJobUsecase).Register(api)
+	}
 	
 
 	app.Get("/health-check", healthCheck)
+	healthUsecase := usecase.NewHealth(2*time.Second,
+		usecase.HealthDependency{Name: "mysql", Checker: TransactionRepo},
+		usecase.HealthDependency{Name: "mongodb", Checker: mongodb.NewLogRepository(mongoDB)},
+	)
+	handler.NewHealthHandler(healthUsecase).Register(app)
 	app.Get("/metrics", monitor.New())
 
 	// Handle Route not found
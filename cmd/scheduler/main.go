@@ -1,13 +1,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/go-co-op/gocron/v2"
+	"github.com/rakahikmah/finance-tracking/config"
 	"github.com/rakahikmah/finance-tracking/entity"
 	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
+	notification_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/notification"
 	"github.com/subosito/gotenv"
 )
 
@@ -53,9 +59,45 @@ func main() {
 		// handle error
 	}
 
+	cfg := config.NewConfig()
+	if cfg.MonthlyRecapOption.Enabled {
+		registerMonthlyRecap(s, cfg)
+	}
+
 	s.Start()
 	fmt.Println("Scheduler started!")
 
 	// Keep the main program running indefinitely
 	select {} // Infinite loop
 }
+
+// registerMonthlyRecap schedules the job that enqueues one monthly.recap message per subscribed user.
+func registerMonthlyRecap(s gocron.Scheduler, cfg *config.Config) {
+	gormLogger := config.NewGormLogMysqlConfig(&cfg.MysqlOption)
+	mysqlDB, err := config.NewMysql(cfg.AppEnv, &cfg.MysqlOption, gormLogger)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rabbitQueue, err := config.NewRabbitMQInstance(context.Background(), &cfg.RabbitMQOption)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	preferenceUsecase := notification_usecase.NewNotificationPreference(mysql.NewNotificationPreferenceRepository(mysqlDB))
+	monthlyRecapUsecase := usecase.NewMonthlyRecapUsecase(rabbitQueue, preferenceUsecase)
+
+	_, err = s.NewJob(
+		gocron.CronJob(cfg.MonthlyRecapOption.Schedule, false),
+		gocron.NewTask(func() {
+			published, err := monthlyRecapUsecase.EnqueueMonthlyRecaps(context.Background(), helper.DatetimeNowJakarta())
+			if err != nil {
+				helper.LogError("MonthlyRecap", "EnqueueMonthlyRecaps", err, entity.CaptureFields{}, "")
+			}
+			helper.LogInfo("MonthlyRecap", "EnqueueMonthlyRecaps", entity.CaptureFields{"published": strconv.Itoa(published)}, "monthly recap jobs enqueued")
+		}),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
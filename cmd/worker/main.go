@@ -6,11 +6,19 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/rakahikmah/finance-tracking/config"
+	"github.com/rakahikmah/finance-tracking/internal/mailer"
 	"github.com/rakahikmah/finance-tracking/internal/queue"
 	"github.com/rakahikmah/finance-tracking/internal/queue/consumer"
 	"github.com/rakahikmah/finance-tracking/internal/repository/mongodb"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
+	notification_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/notification"
+	period_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/period"
+	report_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/report"
+	transactions_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/transactions"
 	"github.com/subosito/gotenv"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -44,11 +52,11 @@ func main() {
 	}
 	defer app.mongoDB.Client().Disconnect(app.ctx)
 
-	// gormLogger := config.NewGormLogConfig(&cfg.MysqlOption)
-	// mysqlDB, err := config.NewMysql(cfg.AppEnv, &cfg.MysqlOption, gormLogger)
-	// if err != nil {
-	// 	log.Fatal(err)
-	// }
+	gormLogger := config.NewGormLogMysqlConfig(&cfg.MysqlOption)
+	mysqlDB, err := config.NewMysql(cfg.AppEnv, &cfg.MysqlOption, gormLogger)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	app.queue, err = config.NewRabbitMQInstance(app.ctx, &cfg.RabbitMQOption)
 	if err != nil {
@@ -58,9 +66,31 @@ func main() {
 	// MongoDB Repository
 	logMongoRepo := mongodb.NewLogRepository(app.mongoDB)
 
+	// MySQL Repository
+	notificationPreferenceRepo := mysql.NewNotificationPreferenceRepository(mysqlDB)
+	userRepo := mysql.NewUserRepository(mysqlDB)
+	transactionRepo := mysql.NewTransactionRepository(mysqlDB)
+	categoryRepo := mysql.NewCategoryRepository(mysqlDB)
+	importJobRepo := mysql.NewImportJobRepository(mysqlDB)
+	periodLockRepo := mysql.NewPeriodLockRepository(mysqlDB)
+
+	// Usecase
+	notificationPreferenceUsecase := notification_usecase.NewNotificationPreference(notificationPreferenceRepo)
+	reportUsecase := report_usecase.NewReport(transactionRepo, categoryRepo, cfg.CurrencyOption)
+	userStatusChecker := usecase.NewUserStatusChecker(userRepo, 30*time.Second)
+	periodLockUsecase := period_usecase.NewPeriodLock(periodLockRepo, userStatusChecker)
+	importJobUsecase := transactions_usecase.NewImportJob(importJobRepo, transactionRepo, categoryRepo, cfg.CurrencyOption, cfg.ImportJobOption, userStatusChecker, periodLockUsecase, app.queue)
+
+	// Mailer
+	smtpMailer := mailer.NewSMTPMailer(cfg.MailerOption)
+
 	// Consumer
 	logConsumer := consumer.NewLogConsumer(context.Background(), logMongoRepo)
 	exampleConsumer := consumer.NewExampleConsumer(context.Background(), logMongoRepo)
+	webhookConsumer := consumer.NewWebhookConsumer(context.Background(), logMongoRepo)
+	alertConsumer := consumer.NewAlertConsumer(context.Background(), logMongoRepo, notificationPreferenceUsecase)
+	recapConsumer := consumer.NewRecapConsumer(context.Background(), userRepo, reportUsecase, smtpMailer, cfg.CurrencyOption.Code)
+	importConsumer := consumer.NewImportConsumer(context.Background(), importJobUsecase)
 
 	var interrupt = make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
@@ -72,6 +102,21 @@ func main() {
 	case queue.ProcessExample:
 		log.Printf("[Worker] Listening to %v", queue.ProcessExample)
 		go app.queue.HandleConsumedDeliveries(queue.ProcessExample, exampleConsumer.Process)
+	case queue.ProcessTransactionCreated:
+		log.Printf("[Worker] Listening to %v", queue.ProcessTransactionCreated)
+		go app.queue.HandleConsumedDeliveries(queue.ProcessTransactionCreated, exampleConsumer.Process)
+	case queue.ProcessBankWebhook:
+		log.Printf("[Worker] Listening to %v", queue.ProcessBankWebhook)
+		go app.queue.HandleConsumedDeliveries(queue.ProcessBankWebhook, webhookConsumer.ProcessBankWebhook)
+	case queue.ProcessLargeTransactionAlert:
+		log.Printf("[Worker] Listening to %v", queue.ProcessLargeTransactionAlert)
+		go app.queue.HandleConsumedDeliveries(queue.ProcessLargeTransactionAlert, alertConsumer.ProcessLargeTransaction)
+	case queue.ProcessMonthlyRecap:
+		log.Printf("[Worker] Listening to %v", queue.ProcessMonthlyRecap)
+		go app.queue.HandleConsumedDeliveries(queue.ProcessMonthlyRecap, recapConsumer.ProcessMonthlyRecap)
+	case queue.ProcessImportJob:
+		log.Printf("[Worker] Listening to %v", queue.ProcessImportJob)
+		go app.queue.HandleConsumedDeliveries(queue.ProcessImportJob, importConsumer.ProcessImportJob)
 	default:
 		log.Fatalf("[Worker] topic not found : %v", os.Args[1])
 	}
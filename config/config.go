@@ -16,18 +16,46 @@ type Config struct {
 	AllowedCredentialOrigins []string `env:"ALLOWED_CREDENTIAL_ORIGINS"`
 	MiddlewareAddress        string   `env:"MIDDLEWARE_ADDR"`
 	JwtExpireDaysCount       int      `env:"JWT_EXPIRE_DAYS_COUNT"`
+	JwtOption
 	MysqlOption
 	RabbitMQOption
 	MongodbOption
 	RedisOption
 	PostgreSqlOption
+	WebhookOption
+	SummaryOption
+	CurrencyOption
+	ResponseOption
+	SearchOption
+	RateLimitOption
+	RequestTimeoutOption
+	SpendingCapOption
+	MailerOption
+	MonthlyRecapOption
+	ImportJobOption
+	TransactionEventOption
 }
 
-// MysqlOption contains mySQL connection options
+// JwtOption contains the RSA key pairs used to sign and verify JWTs.
+// KeyIDs is an ordered list (separated by ;): the first key signs new tokens, the rest are only accepted for
+// verification during a rotation window. Each id is read from <KeyDirectory>/<id>_private_key.pem and
+// <id>_public_key.pem. Without KeyIDs, private_key.pem and public_key.pem in the working directory are used.
+type JwtOption struct {
+	KeyIDs       []string `env:"JWT_KEY_IDS"`
+	KeyDirectory string   `env:"JWT_KEY_DIRECTORY,default=./keys"`
+	// BlacklistStore is where logged out tokens are kept: memory (single instance) or redis (shared between instances).
+	BlacklistStore string `env:"JWT_BLACKLIST_STORE,default=memory"`
+}
+
+// MysqlOption contains mySQL connection options.
+// MaxOpenConns (MYSQL_POOL) caps open connections, MaxIdleConns caps connections kept open while unused and
+// ConnMaxLifetimeSeconds closes connections older than that before MySQL's wait_timeout drops them. 0 means no limit.
 type MysqlOption struct {
-	URI           string `env:"MYSQL_URI,default="`
-	Pool          int    `env:"MYSQL_POOL,required"`
-	SlowThreshold int    `env:"MYSQL_SLOW_LOG_THRESHOLD,required"`
+	URI                    string `env:"MYSQL_URI,default="`
+	MaxOpenConns           int    `env:"MYSQL_POOL,default=50"`
+	MaxIdleConns           int    `env:"MYSQL_MAX_IDLE_CONNS,default=10"`
+	ConnMaxLifetimeSeconds int    `env:"MYSQL_CONN_MAX_LIFETIME_SECONDS,default=300"`
+	SlowThreshold          int    `env:"MYSQL_SLOW_LOG_THRESHOLD,required"`
 }
 
 type PostgreSqlOption struct {
@@ -56,6 +84,86 @@ type RedisOption struct {
 	WriteTimeoutMs int16  `env:"REDIS_WRITE_TIMEOUT,required"`
 }
 
+// WebhookOption contains inbound webhook verification options
+type WebhookOption struct {
+	BankSecret       string `env:"WEBHOOK_BANK_SECRET"`
+	ToleranceSeconds int    `env:"WEBHOOK_TOLERANCE_SECONDS,default=300"`
+}
+
+// SummaryOption contains thresholds (in days) to downsample daily summary into weekly or monthly buckets.
+// Set a threshold to 0 to disable it.
+type SummaryOption struct {
+	WeeklyThresholdDays  int `env:"SUMMARY_WEEKLY_THRESHOLD_DAYS,default=90"`
+	MonthlyThresholdDays int `env:"SUMMARY_MONTHLY_THRESHOLD_DAYS,default=730"`
+}
+
+// CurrencyOption contains the currency used to format and validate amounts
+type CurrencyOption struct {
+	Code string `env:"CURRENCY_CODE,default=IDR"`
+}
+
+// ResponseOption contains defaults for how nullable fields are represented in API responses.
+// NullAsEmpty maps NULL descriptions and category names to empty strings; it can be overridden per request.
+type ResponseOption struct {
+	NullAsEmpty bool `env:"RESPONSE_NULL_AS_EMPTY,default=false"`
+}
+
+// SearchOption contains limits for transaction search.
+// MinQueryLength is the minimum number of characters of q after trimming spaces.
+type SearchOption struct {
+	MinQueryLength int `env:"SEARCH_MIN_QUERY_LENGTH,default=2"`
+}
+
+// RateLimitOption limits create/update/delete requests per user.
+// WritePerMinute is the bucket size and refill rate per minute, 0 disables the limit.
+type RateLimitOption struct {
+	WritePerMinute int `env:"RATE_LIMIT_WRITE_PER_MINUTE,default=60"`
+}
+
+// RequestTimeoutOption sets the deadline of each API request in seconds, 0 disables it.
+// Report applies to /reports and /insights, which aggregate a whole date range; Default applies to the other routes.
+type RequestTimeoutOption struct {
+	Default int `env:"API_REQUEST_TIMEOUT_SECONDS,default=10"`
+	Report  int `env:"API_REPORT_TIMEOUT_SECONDS,default=30"`
+}
+
+// SpendingCapOption is the monthly expense cap in the base currency, checked against each user's own expenses.
+// Creating an expense that pushes the month over the cap publishes a spending cap event (see queue.EventSpendingCapExceeded).
+// A cap of 0 disables the check.
+type SpendingCapOption struct {
+	MonthlyCap float64 `env:"SPENDING_CAP_MONTHLY,default=0"`
+}
+
+// MailerOption contains SMTP settings used to send notification emails
+type MailerOption struct {
+	Host     string `env:"SMTP_HOST"`
+	Port     int    `env:"SMTP_PORT,default=587"`
+	Username string `env:"SMTP_USERNAME"`
+	Password string `env:"SMTP_PASSWORD"`
+	From     string `env:"SMTP_FROM"`
+}
+
+// MonthlyRecapOption controls the scheduled monthly recap email.
+// Schedule is a cron expression evaluated in Asia/Jakarta; the job is not registered unless Enabled is true.
+type MonthlyRecapOption struct {
+	Enabled  bool   `env:"MONTHLY_RECAP_ENABLED,default=false"`
+	Schedule string `env:"MONTHLY_RECAP_SCHEDULE,default=0 7 1 * *"`
+}
+
+// ImportJobOption controls background import jobs processed by the worker (topic import.job).
+// Endpoints are not registered unless Enabled is true; rows are committed BatchSize at a time.
+type ImportJobOption struct {
+	Enabled   bool `env:"IMPORT_JOB_ENABLED,default=false"`
+	BatchSize int  `env:"IMPORT_JOB_BATCH_SIZE,default=500"`
+}
+
+// TransactionEventOption controls publishing of transaction events (topic transaction.created) for downstream consumers.
+// Events are buffered in memory (BufferSize) and dropped when the buffer is full, so a queue outage never fails a request.
+type TransactionEventOption struct {
+	Enabled    bool `env:"TRANSACTION_EVENTS_ENABLED,default=false"`
+	BufferSize int  `env:"TRANSACTION_EVENTS_BUFFER_SIZE,default=1000"`
+}
+
 func NewConfig() *Config {
 	var cfg Config
 	if err := envdecode.Decode(&cfg); err != nil {
@@ -10,18 +10,27 @@ import (
 )
 
 func NewMongodb(ctx context.Context, cfg *MongodbOption) (*mongo.Database, error) {
-	opts := mongoOptions(cfg)
-
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	client, err := mongo.Connect(ctx, opts)
+	db, err := ConnectMongodb(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	// check connection
-	err = client.Ping(ctx, readpref.Primary())
+	err = db.Client().Ping(ctx, readpref.Primary())
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// ConnectMongodb creates the client without waiting for the server, the driver connects in the background.
+// Used by the API where MongoDB is only checked by the readiness probe and must not block startup.
+func ConnectMongodb(ctx context.Context, cfg *MongodbOption) (*mongo.Database, error) {
+	client, err := mongo.Connect(ctx, mongoOptions(cfg))
 	if err != nil {
 		return nil, err
 	}
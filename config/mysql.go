@@ -1,6 +1,9 @@
 package config
 
 import (
+	"database/sql"
+	"time"
+
 	gmysql "gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	glogger "gorm.io/gorm/logger"
@@ -24,6 +27,18 @@ func NewMysql(env string, cfg *MysqlOption, dbLogger glogger.Interface) (*Mysql,
 	}
 
 	sqlDB, err := db.DB()
-	sqlDB.SetMaxOpenConns(cfg.Pool)
-	return &Mysql{DB: db}, err
+	if err != nil {
+		return nil, err
+	}
+
+	applyMysqlPool(sqlDB, cfg)
+
+	return &Mysql{DB: db}, nil
+}
+
+// applyMysqlPool applies the connection pool limits of cfg to the underlying *sql.DB.
+func applyMysqlPool(sqlDB *sql.DB, cfg *MysqlOption) {
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetimeSeconds) * time.Second)
 }
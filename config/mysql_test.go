@@ -0,0 +1,59 @@
+package config
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+// poolTestDriver hands out connections that do nothing, so pool limits can be observed without a database.
+type poolTestDriver struct{}
+
+func (poolTestDriver) Open(string) (driver.Conn, error) { return poolTestConn{}, nil }
+
+type poolTestConn struct{}
+
+func (poolTestConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (poolTestConn) Close() error                        { return nil }
+func (poolTestConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func init() {
+	sql.Register("pooltest", poolTestDriver{})
+}
+
+func TestApplyMysqlPool(t *testing.T) {
+	sqlDB, err := sql.Open("pooltest", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	applyMysqlPool(sqlDB, &MysqlOption{MaxOpenConns: 5, MaxIdleConns: 2, ConnMaxLifetimeSeconds: 300})
+
+	if got := sqlDB.Stats().MaxOpenConnections; got != 5 {
+		t.Errorf("MaxOpenConnections = %d, want 5", got)
+	}
+
+	// Release 4 connections at once: only MaxIdleConns stay in the pool, the rest are closed
+	conns := make([]*sql.Conn, 0, 4)
+	for i := 0; i < 4; i++ {
+		conn, err := sqlDB.Conn(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		conns = append(conns, conn)
+	}
+	for _, conn := range conns {
+		conn.Close()
+	}
+
+	stats := sqlDB.Stats()
+	if stats.Idle != 2 {
+		t.Errorf("Idle = %d, want 2", stats.Idle)
+	}
+	if stats.MaxIdleClosed != 2 {
+		t.Errorf("MaxIdleClosed = %d, want 2", stats.MaxIdleClosed)
+	}
+}
@@ -13,6 +13,10 @@ import (
 )
 
 func NewZapLog(env string) (*zap.Logger, error) {
+	if os.Getenv("LOG_FORMAT") == entity.LOG_FORMAT_JSON {
+		return NewJSONLogger()
+	}
+
 	if env == entity.PRODUCTION_ENV && os.Getenv("DEBUG_MODE") == "false" {
 		return NewProductionLogger()
 	}
@@ -20,6 +24,22 @@ func NewZapLog(env string) (*zap.Logger, error) {
 	return NewDevelopmentLogger()
 }
 
+// NewJSONLogger initializes and returns a zap.Logger that writes one JSON object per line to stdout.
+//
+// It is used when LOG_FORMAT=json so logs can be shipped to a collector without a parser.
+// Every entry carries level, timestamp, caller, and message keys; the remaining keys come from the log fields.
+func NewJSONLogger() (*zap.Logger, error) {
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder // ISO8601 time format
+	encoderConfig.TimeKey = "timestamp"
+	encoderConfig.MessageKey = "message"
+	encoderConfig.LevelKey = "level"
+
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.Lock(os.Stdout), zapcore.DebugLevel)
+
+	return zap.New(core, zap.AddCaller()), nil
+}
+
 // NewDevelopmentLogger initializes and returns a zap.Logger configured for development use.
 //
 // It creates a structured JSON logger using zap's development configuration,
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `notification_preferences` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `channel` varchar(50) COLLATE utf8mb4_general_ci NOT NULL,
+  `enabled` tinyint(1) NOT NULL DEFAULT 0,
+  `threshold` decimal(15,2) NOT NULL DEFAULT 0,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE KEY `uq_notification_preferences_user_channel` (`user_id`, `channel`) USING BTREE,
+  CONSTRAINT `fk_notification_preferences_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
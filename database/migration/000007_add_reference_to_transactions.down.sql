@@ -0,0 +1,3 @@
+ALTER TABLE `transactions`
+  DROP INDEX `uq_transactions_user_reference`,
+  DROP COLUMN `reference`;
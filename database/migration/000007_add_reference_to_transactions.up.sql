@@ -0,0 +1,3 @@
+ALTER TABLE `transactions`
+  ADD COLUMN `reference` varchar(100) COLLATE utf8mb4_general_ci DEFAULT NULL AFTER `description`,
+  ADD UNIQUE KEY `uq_transactions_user_reference` (`user_id`, `reference`) USING BTREE;
@@ -0,0 +1,2 @@
+ALTER TABLE `users`
+  ADD COLUMN `status` TINYINT(4) NOT NULL DEFAULT 1 COMMENT 'Status user (1: Active, 2: Suspended)' AFTER `role`;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS transaction_templates;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE `transactions`
+  ADD COLUMN `latitude` decimal(9,6) DEFAULT NULL AFTER `reference`,
+  ADD COLUMN `longitude` decimal(9,6) DEFAULT NULL AFTER `latitude`;
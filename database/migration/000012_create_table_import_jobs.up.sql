@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS `import_jobs` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `status` varchar(20) NOT NULL,
+  `create_categories` tinyint(1) NOT NULL DEFAULT 0,
+  `total` int unsigned NOT NULL,
+  `processed` int unsigned NOT NULL DEFAULT 0,
+  `errors` json NOT NULL,
+  `rows` longtext NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`) USING BTREE,
+  KEY `idx_import_jobs_user_id` (`user_id`) USING BTREE,
+  CONSTRAINT `fk_import_jobs_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
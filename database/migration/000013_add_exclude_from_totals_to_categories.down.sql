@@ -0,0 +1 @@
+ALTER TABLE `categories` DROP COLUMN `exclude_from_totals`;
@@ -0,0 +1 @@
+ALTER TABLE `categories` ADD COLUMN `exclude_from_totals` tinyint(1) NOT NULL DEFAULT 0 AFTER `name`;
@@ -0,0 +1 @@
+ALTER TABLE `transactions` ADD COLUMN `metadata` json DEFAULT NULL AFTER `longitude`;
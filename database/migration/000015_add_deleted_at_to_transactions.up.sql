@@ -0,0 +1,3 @@
+ALTER TABLE `transactions`
+  ADD COLUMN `deleted_at` timestamp NULL DEFAULT NULL AFTER `updated_at`,
+  ADD KEY `idx_transactions_user_deleted_at` (`user_id`, `deleted_at`) USING BTREE;
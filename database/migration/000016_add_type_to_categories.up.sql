@@ -0,0 +1 @@
+ALTER TABLE `categories` ADD COLUMN `type` enum('','income','expense') COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' AFTER `name`;
@@ -0,0 +1,3 @@
+-- Transaksi lama dibiarkan NULL, API mengisinya dengan mata uang dasar (CURRENCY_CODE) saat start
+-- lewat TransactionRepository.BackfillCurrency, sehingga migrasi tidak mengunci satu mata uang tertentu.
+ALTER TABLE `transactions` ADD COLUMN `currency` char(3) COLLATE utf8mb4_general_ci NULL DEFAULT NULL AFTER `amount`;
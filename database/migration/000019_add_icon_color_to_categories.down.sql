@@ -0,0 +1 @@
+ALTER TABLE `categories` DROP COLUMN `icon`, DROP COLUMN `color`;
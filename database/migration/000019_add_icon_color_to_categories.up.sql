@@ -0,0 +1,3 @@
+ALTER TABLE `categories`
+  ADD COLUMN `icon` varchar(32) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' AFTER `type`,
+  ADD COLUMN `color` char(7) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' AFTER `icon`;
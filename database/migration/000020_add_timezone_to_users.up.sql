@@ -0,0 +1,2 @@
+ALTER TABLE `users`
+  ADD COLUMN `timezone` VARCHAR(64) NULL DEFAULT NULL COMMENT 'Zona waktu IANA user, NULL berarti Asia/Jakarta' AFTER `status`;
@@ -0,0 +1,4 @@
+ALTER TABLE `categories`
+  ADD COLUMN `parent_id` bigint unsigned NULL DEFAULT NULL AFTER `created_by`,
+  ADD KEY `idx_categories_parent_id` (`parent_id`) USING BTREE,
+  ADD CONSTRAINT `fk_categories_parent` FOREIGN KEY (`parent_id`) REFERENCES `categories` (`id`) ON DELETE RESTRICT;
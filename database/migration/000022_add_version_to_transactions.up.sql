@@ -0,0 +1 @@
+ALTER TABLE `transactions` ADD COLUMN `version` int unsigned NOT NULL DEFAULT 1 AFTER `metadata`;
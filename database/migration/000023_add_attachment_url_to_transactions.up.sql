@@ -0,0 +1 @@
+ALTER TABLE `transactions` ADD COLUMN `attachment_url` varchar(2048) COLLATE utf8mb4_general_ci DEFAULT NULL AFTER `metadata`;
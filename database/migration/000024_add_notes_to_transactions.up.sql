@@ -0,0 +1 @@
+ALTER TABLE `transactions` ADD COLUMN `notes` text COLLATE utf8mb4_general_ci DEFAULT NULL AFTER `description`;
@@ -39,3 +39,6 @@ func (c *Log) LoadFromMap(m map[string]interface{}) error {
 const (
 	PRODUCTION_ENV = "production"
 )
+
+// LOG_FORMAT_JSON is the LOG_FORMAT value that switches helper logs to structured JSON on stdout.
+const LOG_FORMAT_JSON = "json"
@@ -20,6 +20,17 @@ const (
 	CONFLICT_CODE          = "04" // Kode untuk konflik data (misal: duplikasi)
 	CONFLICT_MSG           = "Data conflict"
 
+	FORBIDDEN_CODE         = "06" // Kode untuk user yang sudah login tapi tidak berhak atas resource
+	FORBIDDEN_MSG          = "Forbidden access"
+
+	TOO_MANY_REQUESTS_CODE = "07" // Kode untuk user yang melebihi batas request per menit
+	TOO_MANY_REQUESTS_MSG  = "Too many requests"
+
+	GATEWAY_TIMEOUT_CODE   = "08" // Kode untuk request yang melewati batas waktu (deadline context)
+	GATEWAY_TIMEOUT_MSG    = "Request timed out"
+
+	INTERNAL_SERVER_ERROR_CODE = "09" // Kode untuk error tak terduga (database, dsb.), detailnya hanya dicatat di log
+
 
 	GENERAL_ERROR_MESSAGE = "Something went wrong. Please try again later."
 )
@@ -30,6 +41,15 @@ type GeneralResponse struct {
 	Data    interface{} `json:"data"`
 }
 
+// PaginationMeta adalah informasi halaman yang dikirim di field meta envelope response daftar ber-halaman.
+type PaginationMeta struct {
+	Total      int64 `json:"total"`
+	Page       int   `json:"page"`
+	PerPage    int   `json:"per_page"`
+	TotalPages int   `json:"total_pages"`
+	HasNext    bool  `json:"has_next"`
+}
+
 type ValidationError struct {
 	Field string `json:"field"`
 	Error string `json:"error"`
@@ -0,0 +1,12 @@
+package entity
+
+// UpdateUserSettingsReq is the body of PUT /users/me/settings. A nil field keeps the stored value,
+// an empty timezone resets it to the default (Asia/Jakarta).
+type UpdateUserSettingsReq struct {
+	Timezone *string `json:"timezone"`
+}
+
+// UserSettingsResponse contains the effective settings of the user.
+type UserSettingsResponse struct {
+	Timezone string `json:"timezone"`
+}
@@ -39,6 +39,17 @@ func (c CustomErrorResponse) Error() string {
 	return c.Message
 }
 
+// Is membuat errors.Is cocok dengan sentinel dasarnya walaupun Detail berbeda.
+// Message ikut dibandingkan karena beberapa sentinel berbagi ErrCode dan HTTPCode
+// (misalnya ErrRecordNotFound dan ErrUserNotFound).
+func (c CustomErrorResponse) Is(target error) bool {
+	t, ok := target.(CustomErrorResponse)
+	if !ok {
+		return false
+	}
+	return c.ErrCode == t.ErrCode && c.HTTPCode == t.HTTPCode && c.Message == t.Message
+}
+
 // --- Fungsi Pembuat Error Umum ---
 
 func ErrRecordNotFound() CustomErrorResponse {
@@ -107,6 +118,17 @@ func ErrUnauthorized() CustomErrorResponse {
 	}
 }
 
+// ErrForbidden mengembalikan CustomErrorResponse untuk akses ke resource milik user lain (403).
+// Berbeda dengan ErrUnauthorized (401) yang dipakai saat token tidak ada atau tidak valid,
+// sehingga client hanya perlu login ulang pada 401.
+func ErrForbidden() CustomErrorResponse {
+	return CustomErrorResponse{
+		Message:  entity.FORBIDDEN_MSG,
+		ErrCode:  entity.FORBIDDEN_CODE,
+		HTTPCode: http.StatusForbidden,
+	}
+}
+
 // ErrConflict mengembalikan CustomErrorResponse untuk konflik data (misalnya, duplikasi).
 func ErrConflict() CustomErrorResponse {
 	return CustomErrorResponse{
@@ -116,6 +138,34 @@ func ErrConflict() CustomErrorResponse {
 	}
 }
 
+// ErrTooManyRequests mengembalikan CustomErrorResponse saat user melebihi batas rate limit (429).
+func ErrTooManyRequests() CustomErrorResponse {
+	return CustomErrorResponse{
+		Message:  entity.TOO_MANY_REQUESTS_MSG,
+		ErrCode:  entity.TOO_MANY_REQUESTS_CODE,
+		HTTPCode: http.StatusTooManyRequests,
+	}
+}
+
+// ErrGatewayTimeout mengembalikan CustomErrorResponse saat request melewati batas waktu yang diatur middleware Timeout (504).
+func ErrGatewayTimeout() CustomErrorResponse {
+	return CustomErrorResponse{
+		Message:  entity.GATEWAY_TIMEOUT_MSG,
+		ErrCode:  entity.GATEWAY_TIMEOUT_CODE,
+		HTTPCode: http.StatusGatewayTimeout,
+	}
+}
+
+// ErrInternalServer mengembalikan CustomErrorResponse generik untuk error tak terduga (500).
+// Pesan error aslinya (misalnya error SQL) tidak dikirim ke client, hanya dicatat di log server.
+func ErrInternalServer() CustomErrorResponse {
+	return CustomErrorResponse{
+		Message:  entity.GENERAL_ERROR_MESSAGE,
+		ErrCode:  entity.INTERNAL_SERVER_ERROR_CODE,
+		HTTPCode: http.StatusInternalServerError,
+	}
+}
+
 func CustomError(message string, errCode string, httpCode int) CustomErrorResponse {
 	return CustomErrorResponse{
 		Message:  message,
@@ -0,0 +1,56 @@
+package error_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomErrorResponseIs(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{
+			name:   "detail does not break the match",
+			err:    apperr.ErrRecordNotFound().SetDetail("Category not found."),
+			target: apperr.ErrRecordNotFound(),
+			want:   true,
+		},
+		{
+			name:   "wrapped error with detail",
+			err:    fmt.Errorf("CrudCategory.Create: %w", apperr.ErrInvalidRequest().SetDetail("name is required")),
+			target: apperr.ErrInvalidRequest(),
+			want:   true,
+		},
+		{
+			name:   "different sentinel with the same code",
+			err:    apperr.ErrUserNotFound().SetDetail("id 7"),
+			target: apperr.ErrRecordNotFound(),
+			want:   false,
+		},
+		{
+			name:   "different sentinel",
+			err:    apperr.ErrForbidden().SetDetail("not your category"),
+			target: apperr.ErrUnauthorized(),
+			want:   false,
+		},
+		{
+			name:   "plain error",
+			err:    errors.New("record not found"),
+			target: apperr.ErrRecordNotFound(),
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errors.Is(tt.err, tt.target))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// AttachmentURLMaxLength is the maximum attachment URL length, matching the varchar(2048) column.
+const AttachmentURLMaxLength = 2048
+
+// ValidateAttachmentURL checks that value is an absolute http or https URL, e.g. a receipt photo in object storage.
+// Only the link is stored, the file itself is uploaded by the client.
+func ValidateAttachmentURL(value string) error {
+	if len(value) > AttachmentURLMaxLength {
+		return fmt.Errorf("must be at most %d characters", AttachmentURLMaxLength)
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return errors.New("must be a valid URL")
+	}
+	scheme := strings.ToLower(parsed.Scheme)
+	if (scheme != "http" && scheme != "https") || parsed.Host == "" {
+		return errors.New("must be an absolute http or https URL")
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package helper_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+func TestValidateAttachmentURL(t *testing.T) {
+	testCases := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "https", value: "https://storage.example.com/receipts/2024/01/05/abc.jpg"},
+		{name: "http with query", value: "http://cdn.example.com/r.png?sig=abc&exp=123"},
+		{name: "uppercase scheme", value: "HTTPS://storage.example.com/r.jpg"},
+		{name: "relative path", value: "/receipts/abc.jpg", wantErr: true},
+		{name: "missing host", value: "https:///abc.jpg", wantErr: true},
+		{name: "other scheme", value: "ftp://example.com/abc.jpg", wantErr: true},
+		{name: "javascript", value: "javascript:alert(1)", wantErr: true},
+		{name: "not a url", value: "://bad", wantErr: true},
+		{name: "too long", value: "https://example.com/" + strings.Repeat("a", helper.AttachmentURLMaxLength), wantErr: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := helper.ValidateAttachmentURL(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAttachmentURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
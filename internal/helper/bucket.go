@@ -0,0 +1,114 @@
+package helper
+
+import (
+	"math"
+	"time"
+)
+
+// Granularity adalah ukuran bucket waktu untuk data time series (chart).
+type Granularity string
+
+const (
+	GranularityDay   Granularity = "day"
+	GranularityWeek  Granularity = "week"
+	GranularityMonth Granularity = "month"
+)
+
+// IsValidGranularity memeriksa apakah granularity termasuk yang didukung.
+func IsValidGranularity(g Granularity) bool {
+	switch g {
+	case GranularityDay, GranularityWeek, GranularityMonth:
+		return true
+	default:
+		return false
+	}
+}
+
+// BucketStart mengembalikan awal bucket dari t. Minggu dimulai hari Senin.
+func BucketStart(t time.Time, g Granularity) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+
+	switch g {
+	case GranularityWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case GranularityMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return day
+	}
+}
+
+// NextBucket mengembalikan awal bucket setelah bucket yang dimulai pada start.
+func NextBucket(start time.Time, g Granularity) time.Time {
+	switch g {
+	case GranularityWeek:
+		return start.AddDate(0, 0, 7)
+	case GranularityMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// BucketLabel memformat awal bucket menjadi label periode (YYYY-MM untuk bulanan, YYYY-MM-DD selain itu).
+func BucketLabel(start time.Time, g Granularity) string {
+	if g == GranularityMonth {
+		return start.Format("2006-01")
+	}
+
+	return start.Format("2006-01-02")
+}
+
+// MaxBuckets adalah jumlah bucket maksimum satu time series agar rentang tanggal yang ekstrem
+// tidak membangun jutaan bucket di memori.
+const MaxBuckets = 1000
+
+// CountBuckets menghitung jumlah bucket yang akan dihasilkan GenerateBuckets untuk rentang [start, end]
+// tanpa membangun bucket-nya, sehingga rentang bisa ditolak sebelum dialokasikan.
+func CountBuckets(start, end time.Time, g Granularity) int {
+	first := BucketStart(start, g)
+	last := BucketStart(end, g)
+	if last.Before(first) {
+		return 0
+	}
+
+	switch g {
+	case GranularityMonth:
+		return (last.Year()-first.Year())*12 + int(last.Month()-first.Month()) + 1
+	case GranularityWeek:
+		return int(math.Round(last.Sub(first).Hours()/24))/7 + 1
+	default:
+		return int(math.Round(last.Sub(first).Hours()/24)) + 1
+	}
+}
+
+// GenerateBuckets mengembalikan awal semua bucket yang beririsan dengan rentang [start, end].
+func GenerateBuckets(start, end time.Time, g Granularity) []time.Time {
+	var buckets []time.Time
+	for b := BucketStart(start, g); !b.After(end); b = NextBucket(b, g) {
+		buckets = append(buckets, b)
+	}
+
+	return buckets
+}
+
+// ResolveGranularity menentukan granularity efektif untuk rentang [start, end].
+// Granularity yang diminta secara eksplisit selalu dipakai. Jika kosong, rentang yang lebih panjang dari
+// monthlyAfterDays menjadi bulanan, lebih panjang dari weeklyAfterDays menjadi mingguan, selain itu harian.
+// Threshold bernilai 0 berarti tidak aktif.
+func ResolveGranularity(requested Granularity, start, end time.Time, weeklyAfterDays, monthlyAfterDays int) Granularity {
+	if requested != "" {
+		return requested
+	}
+
+	days := int(end.Sub(start).Hours()/24) + 1
+	switch {
+	case monthlyAfterDays > 0 && days > monthlyAfterDays:
+		return GranularityMonth
+	case weeklyAfterDays > 0 && days > weeklyAfterDays:
+		return GranularityWeek
+	default:
+		return GranularityDay
+	}
+}
@@ -0,0 +1,196 @@
+package helper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+func mustDate(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	d, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		t.Fatalf("time.Parse(%q) error = %v", value, err)
+	}
+
+	return d
+}
+
+func TestBucketStart(t *testing.T) {
+	testCases := []struct {
+		name        string
+		date        string
+		granularity helper.Granularity
+		want        string
+	}{
+		{
+			name:        "week starts on monday",
+			date:        "2024-05-16", // Thursday
+			granularity: helper.GranularityWeek,
+			want:        "2024-05-13",
+		},
+		{
+			name:        "sunday belongs to the previous monday",
+			date:        "2024-05-19",
+			granularity: helper.GranularityWeek,
+			want:        "2024-05-13",
+		},
+		{
+			name:        "monday is its own week start",
+			date:        "2024-05-13",
+			granularity: helper.GranularityWeek,
+			want:        "2024-05-13",
+		},
+		{
+			name:        "month end falls into the same month",
+			date:        "2024-01-31",
+			granularity: helper.GranularityMonth,
+			want:        "2024-01-01",
+		},
+		{
+			name:        "day keeps the date",
+			date:        "2024-02-29",
+			granularity: helper.GranularityDay,
+			want:        "2024-02-29",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := helper.BucketStart(mustDate(t, tt.date), tt.granularity)
+			if got.Format("2006-01-02") != tt.want {
+				t.Errorf("BucketStart() = %s, want %s", got.Format("2006-01-02"), tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateBuckets(t *testing.T) {
+	testCases := []struct {
+		name        string
+		start       string
+		end         string
+		granularity helper.Granularity
+		want        []string
+	}{
+		{
+			name:        "start at month end does not skip february",
+			start:       "2024-01-31",
+			end:         "2024-03-01",
+			granularity: helper.GranularityMonth,
+			want:        []string{"2024-01", "2024-02", "2024-03"},
+		},
+		{
+			name:        "range shorter than one bucket",
+			start:       "2024-05-14",
+			end:         "2024-05-16",
+			granularity: helper.GranularityWeek,
+			want:        []string{"2024-05-13"},
+		},
+		{
+			name:        "range shorter than one month",
+			start:       "2024-05-10",
+			end:         "2024-05-20",
+			granularity: helper.GranularityMonth,
+			want:        []string{"2024-05"},
+		},
+		{
+			name:        "weeks crossing a month boundary",
+			start:       "2024-04-29",
+			end:         "2024-05-12",
+			granularity: helper.GranularityWeek,
+			want:        []string{"2024-04-29", "2024-05-06"},
+		},
+		{
+			name:        "single day",
+			start:       "2024-05-10",
+			end:         "2024-05-10",
+			granularity: helper.GranularityDay,
+			want:        []string{"2024-05-10"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets := helper.GenerateBuckets(mustDate(t, tt.start), mustDate(t, tt.end), tt.granularity)
+
+			var got []string
+			for _, b := range buckets {
+				got = append(got, helper.BucketLabel(b, tt.granularity))
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("GenerateBuckets() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("GenerateBuckets()[%d] = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCountBuckets(t *testing.T) {
+	testCases := []struct {
+		name        string
+		start       string
+		end         string
+		granularity helper.Granularity
+	}{
+		{name: "days in a leap year", start: "2024-01-01", end: "2024-12-31", granularity: helper.GranularityDay},
+		{name: "weeks crossing a month boundary", start: "2024-04-30", end: "2024-05-12", granularity: helper.GranularityWeek},
+		{name: "months across years", start: "2023-11-30", end: "2025-02-01", granularity: helper.GranularityMonth},
+		{name: "single day", start: "2024-05-10", end: "2024-05-10", granularity: helper.GranularityDay},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := mustDate(t, tt.start), mustDate(t, tt.end)
+			want := len(helper.GenerateBuckets(start, end, tt.granularity))
+			if got := helper.CountBuckets(start, end, tt.granularity); got != want {
+				t.Errorf("CountBuckets() = %d, want %d", got, want)
+			}
+		})
+	}
+
+	t.Run("end before start", func(t *testing.T) {
+		if got := helper.CountBuckets(mustDate(t, "2024-05-10"), mustDate(t, "2024-05-01"), helper.GranularityDay); got != 0 {
+			t.Errorf("CountBuckets() = %d, want 0", got)
+		}
+	})
+}
+
+func TestResolveGranularity(t *testing.T) {
+	testCases := []struct {
+		name      string
+		requested helper.Granularity
+		start     string
+		end       string
+		want      helper.Granularity
+	}{
+		{name: "exactly 90 days stays daily", start: "2024-01-01", end: "2024-03-30", want: helper.GranularityDay},
+		{name: "91 days becomes weekly", start: "2024-01-01", end: "2024-03-31", want: helper.GranularityWeek},
+		{name: "exactly 730 days stays weekly", start: "2023-01-01", end: "2024-12-30", want: helper.GranularityWeek},
+		{name: "731 days becomes monthly", start: "2023-01-01", end: "2024-12-31", want: helper.GranularityMonth},
+		{name: "explicit granularity is honored", requested: helper.GranularityDay, start: "2020-01-01", end: "2024-12-31", want: helper.GranularityDay},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := helper.ResolveGranularity(tt.requested, mustDate(t, tt.start), mustDate(t, tt.end), 90, 730)
+			if got != tt.want {
+				t.Errorf("ResolveGranularity() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("zero thresholds disable downsampling", func(t *testing.T) {
+		got := helper.ResolveGranularity("", mustDate(t, "2020-01-01"), mustDate(t, "2024-12-31"), 0, 0)
+		if got != helper.GranularityDay {
+			t.Errorf("ResolveGranularity() = %s, want %s", got, helper.GranularityDay)
+		}
+	})
+}
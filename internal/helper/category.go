@@ -0,0 +1,44 @@
+package helper
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// NormalizeCategoryName trims a category name and collapses repeated inner whitespace,
+// so " Makan   Siang " and "Makan Siang" are stored the same way.
+func NormalizeCategoryName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// CategoryNameKey returns the key used to compare category names of the same user case-insensitively.
+func CategoryNameKey(name string) string {
+	return strings.ToLower(NormalizeCategoryName(name))
+}
+
+// CategoryIcons is the allow-list of icon names the apps can render for a category.
+var CategoryIcons = []string{
+	"bills", "education", "entertainment", "food", "gift", "health", "home",
+	"investment", "other", "salary", "shopping", "transport", "travel",
+}
+
+// categoryColorPattern matches a #RRGGBB hex color.
+var categoryColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ValidateCategoryIcon checks that icon is empty (no icon) or one of CategoryIcons.
+func ValidateCategoryIcon(icon string) error {
+	if icon == "" || slices.Contains(CategoryIcons, icon) {
+		return nil
+	}
+	return fmt.Errorf("icon %q is not supported, use one of: %s", icon, strings.Join(CategoryIcons, ", "))
+}
+
+// ValidateCategoryColor checks that color is empty (no color) or a #RRGGBB hex color.
+func ValidateCategoryColor(color string) error {
+	if color == "" || categoryColorPattern.MatchString(color) {
+		return nil
+	}
+	return fmt.Errorf("color %q must be a hex color in #RRGGBB format", color)
+}
@@ -0,0 +1,53 @@
+package helper_test
+
+import (
+	"testing"
+
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+func TestValidateCategoryIcon(t *testing.T) {
+	testCases := []struct {
+		icon    string
+		wantErr bool
+	}{
+		{icon: ""},
+		{icon: "food"},
+		{icon: "travel"},
+		{icon: "Food", wantErr: true},
+		{icon: "rocket", wantErr: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.icon, func(t *testing.T) {
+			err := helper.ValidateCategoryIcon(tt.icon)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCategoryIcon(%q) error = %v, wantErr %v", tt.icon, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCategoryColor(t *testing.T) {
+	testCases := []struct {
+		color   string
+		wantErr bool
+	}{
+		{color: ""},
+		{color: "#1A2b3C"},
+		{color: "#ffffff"},
+		{color: "1A2B3C", wantErr: true},
+		{color: "#FFF", wantErr: true},
+		{color: "#GGGGGG", wantErr: true},
+		{color: "#1A2B3C4D", wantErr: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.color, func(t *testing.T) {
+			err := helper.ValidateCategoryColor(tt.color)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCategoryColor(%q) error = %v, wantErr %v", tt.color, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package helper
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is used when no currency code is configured.
+const DefaultCurrency = "IDR"
+
+// defaultMinorUnits is the scale used for currencies missing from currencyMinorUnits.
+const defaultMinorUnits = 2
+
+// currencyMinorUnits maps ISO 4217 currency codes to their standard number of decimal places.
+var currencyMinorUnits = map[string]int{
+	"IDR": 0,
+	"JPY": 0,
+	"KRW": 0,
+	"VND": 0,
+	"USD": 2,
+	"EUR": 2,
+	"SGD": 2,
+	"MYR": 2,
+	"GBP": 2,
+	"AUD": 2,
+	"BHD": 3,
+	"KWD": 3,
+	"OMR": 3,
+	"JOD": 3,
+}
+
+// CurrencyMinorUnits returns the number of decimal places of the given currency code.
+// Unknown codes fall back to 2 decimal places.
+func CurrencyMinorUnits(code string) int {
+	if units, ok := currencyMinorUnits[strings.ToUpper(code)]; ok {
+		return units
+	}
+
+	return defaultMinorUnits
+}
+
+// FormatAmount formats amount with the standard number of decimal places of the currency, e.g. 1500 IDR -> "1500", 1500 USD -> "1500.00".
+func FormatAmount(amount float64, code string) string {
+	return strconv.FormatFloat(amount, 'f', CurrencyMinorUnits(code), 64)
+}
+
+// FormatStoredAmount formats amount like FormatAmount, but falls back to the scale of the decimal(15,2)
+// amount columns when the currency scale would round a stored value, e.g. 1500.5 IDR -> "1500.50".
+func FormatStoredAmount(amount float64, code string) string {
+	units := CurrencyMinorUnits(code)
+	if units < storedAmountScale {
+		scaled := amount * math.Pow10(units)
+		if math.Abs(scaled-math.Round(scaled)) > 1e-6 {
+			units = storedAmountScale
+		}
+	}
+
+	return strconv.FormatFloat(amount, 'f', units, 64)
+}
+
+// ValidateAmountScale returns an error when amount has more decimal places than the currency allows.
+func ValidateAmountScale(amount float64, code string) error {
+	units := CurrencyMinorUnits(code)
+	scaled := amount * math.Pow10(units)
+
+	if math.Abs(scaled-math.Round(scaled)) > 1e-6 {
+		if units == 0 {
+			return fmt.Errorf("%s amount must not have decimal places", strings.ToUpper(code))
+		}
+		return fmt.Errorf("%s amount must not have more than %d decimal places", strings.ToUpper(code), units)
+	}
+
+	return nil
+}
+
+// MaxStoredAmount is the largest amount the decimal(15,2) amount columns can hold: 13 integer digits and 2 decimals.
+const MaxStoredAmount = 9999999999999.99
+
+// storedAmountScale is the number of decimal places kept by the decimal(15,2) amount columns.
+const storedAmountScale = 2
+
+// ValidateStoredAmount returns an error when amount does not fit the decimal(15,2) amount columns:
+// more than 13 integer digits, or more than 2 decimal places which the database would silently round.
+func ValidateStoredAmount(amount float64) error {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) || math.Abs(amount) > MaxStoredAmount {
+		return fmt.Errorf("amount must not exceed %s", strconv.FormatFloat(MaxStoredAmount, 'f', storedAmountScale, 64))
+	}
+
+	scaled := amount * math.Pow10(storedAmountScale)
+	if math.Abs(scaled-math.Round(scaled)) > 1e-6 {
+		return fmt.Errorf("amount must not have more than %d decimal places", storedAmountScale)
+	}
+
+	return nil
+}
+
+// ValidateCurrencyCode returns an error unless code is a 3-letter uppercase ISO 4217 code, e.g. "IDR" or "USD".
+func ValidateCurrencyCode(code string) error {
+	if len(code) != 3 {
+		return fmt.Errorf("currency must be a 3-letter ISO 4217 code")
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return fmt.Errorf("currency must be a 3-letter uppercase ISO 4217 code")
+		}
+	}
+
+	return nil
+}
+
+// BaseCurrency returns the configured base currency in uppercase, falling back to DefaultCurrency when code is empty.
+func BaseCurrency(code string) string {
+	if code == "" {
+		return DefaultCurrency
+	}
+
+	return strings.ToUpper(code)
+}
@@ -0,0 +1,124 @@
+package helper_test
+
+import (
+	"testing"
+
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+func TestFormatAmount(t *testing.T) {
+	testCases := []struct {
+		name   string
+		amount float64
+		code   string
+		want   string
+	}{
+		{name: "IDR has no decimals", amount: 1500, code: "IDR", want: "1500"},
+		{name: "USD has two decimals", amount: 1500, code: "USD", want: "1500.00"},
+		{name: "BHD has three decimals", amount: 12.5, code: "BHD", want: "12.500"},
+		{name: "lowercase code", amount: 99.9, code: "usd", want: "99.90"},
+		{name: "unknown code falls back to two decimals", amount: 7, code: "XYZ", want: "7.00"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := helper.FormatAmount(tt.amount, tt.code); got != tt.want {
+				t.Errorf("FormatAmount(%v, %q) = %s, want %s", tt.amount, tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatStoredAmount(t *testing.T) {
+	testCases := []struct {
+		name   string
+		amount float64
+		code   string
+		want   string
+	}{
+		{name: "IDR whole amount has no decimals", amount: 1500, code: "IDR", want: "1500"},
+		{name: "IDR fractional amount keeps stored decimals", amount: 1500.5, code: "IDR", want: "1500.50"},
+		{name: "USD has two decimals", amount: 1500.25, code: "USD", want: "1500.25"},
+		{name: "BHD has three decimals", amount: 12.5, code: "BHD", want: "12.500"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := helper.FormatStoredAmount(tt.amount, tt.code); got != tt.want {
+				t.Errorf("FormatStoredAmount(%v, %q) = %s, want %s", tt.amount, tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAmountScale(t *testing.T) {
+	testCases := []struct {
+		name    string
+		amount  float64
+		code    string
+		wantErr bool
+	}{
+		{name: "IDR whole amount", amount: 150000, code: "IDR", wantErr: false},
+		{name: "IDR with decimals", amount: 1500.5, code: "IDR", wantErr: true},
+		{name: "USD two decimals", amount: 10.25, code: "USD", wantErr: false},
+		{name: "USD three decimals", amount: 10.255, code: "USD", wantErr: true},
+		{name: "BHD three decimals", amount: 1.125, code: "BHD", wantErr: false},
+		{name: "BHD four decimals", amount: 1.1255, code: "BHD", wantErr: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := helper.ValidateAmountScale(tt.amount, tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAmountScale(%v, %q) error = %v, wantErr %v", tt.amount, tt.code, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateStoredAmount(t *testing.T) {
+	testCases := []struct {
+		name    string
+		amount  float64
+		wantErr bool
+	}{
+		{name: "max value", amount: 9999999999999.99, wantErr: false},
+		{name: "one cent over max", amount: 10000000000000.00, wantErr: true},
+		{name: "astronomically large", amount: 1e300, wantErr: true},
+		{name: "two decimals", amount: 10.25, wantErr: false},
+		{name: "three decimals", amount: 1.125, wantErr: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := helper.ValidateStoredAmount(tt.amount)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateStoredAmount(%v) error = %v, wantErr %v", tt.amount, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCurrencyCode(t *testing.T) {
+	testCases := []struct {
+		name    string
+		code    string
+		wantErr bool
+	}{
+		{name: "uppercase code", code: "USD", wantErr: false},
+		{name: "lowercase code", code: "usd", wantErr: true},
+		{name: "too short", code: "US", wantErr: true},
+		{name: "too long", code: "USDT", wantErr: true},
+		{name: "digits", code: "U5D", wantErr: true},
+		{name: "empty", code: "", wantErr: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := helper.ValidateCurrencyCode(tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCurrencyCode(%q) error = %v, wantErr %v", tt.code, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,9 +1,16 @@
 package helper
 
 import (
+	"fmt"
 	"time"
 )
 
+// DateLayout adalah format tanggal (YYYY-MM-DD) yang diterima semua endpoint.
+const DateLayout = "2006-01-02"
+
+// MonthLayout adalah format bulan (YYYY-MM) yang diterima endpoint bulanan.
+const MonthLayout = "2006-01"
+
 func DateNowJakarta() string {
 	loc, _ := time.LoadLocation("Asia/Jakarta")
 
@@ -35,3 +42,29 @@ func ParseDate(dateStr string) (time.Time, error) {
 	const layout = "2006-01-02"
 	return time.Parse(layout, dateStr)
 }
+
+// ParseDateStrict mem-parse tanggal YYYY-MM-DD dan memastikan tanggal tersebut benar-benar ada di kalender.
+// Hasil parse diformat ulang dan dibandingkan dengan input, sehingga parsing yang longgar
+// (misalnya 2023-02-30 menjadi 2 Maret) tetap ditolak.
+func ParseDateStrict(s string) (time.Time, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a valid calendar date in YYYY-MM-DD format", s)
+	}
+
+	if t.Format(DateLayout) != s {
+		return time.Time{}, fmt.Errorf("%q is not a valid calendar date in YYYY-MM-DD format", s)
+	}
+
+	return t, nil
+}
+
+// ParseMonthStrict mem-parse bulan YYYY-MM dan mengembalikan tanggal 1 bulan tersebut.
+func ParseMonthStrict(s string) (time.Time, error) {
+	t, err := time.Parse(MonthLayout, s)
+	if err != nil || t.Format(MonthLayout) != s {
+		return time.Time{}, fmt.Errorf("%q is not a valid month in YYYY-MM format", s)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,37 @@
+package helper
+
+import "time"
+
+// DateFormat adalah format tanggal response yang boleh dipilih client.
+type DateFormat string
+
+const (
+	DateFormatISO      DateFormat = "YYYY-MM-DD" // Default
+	DateFormatDMYSlash DateFormat = "DD/MM/YYYY"
+	DateFormatMDYSlash DateFormat = "MM/DD/YYYY"
+	DateFormatDMYDash  DateFormat = "DD-MM-YYYY"
+)
+
+// dateFormatLayouts adalah allowlist layout Go untuk tiap DateFormat. Layout tidak pernah diambil langsung dari input.
+var dateFormatLayouts = map[DateFormat]string{
+	DateFormatISO:      DateLayout,
+	DateFormatDMYSlash: "02/01/2006",
+	DateFormatMDYSlash: "01/02/2006",
+	DateFormatDMYDash:  "02-01-2006",
+}
+
+// DateFormatLayout mengembalikan layout Go untuk format tanggal. Format kosong berarti YYYY-MM-DD,
+// ok false jika format tidak ada di allowlist.
+func DateFormatLayout(format DateFormat) (layout string, ok bool) {
+	if format == "" {
+		return DateLayout, true
+	}
+	layout, ok = dateFormatLayouts[format]
+	return layout, ok
+}
+
+// FormatJakartaDatetime memformat waktu ke zona Asia/Jakarta dengan layout tanggal diikuti jam (15:04:05).
+func FormatJakartaDatetime(t time.Time, dateLayout string) string {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	return t.In(loc).Format(dateLayout + " 15:04:05")
+}
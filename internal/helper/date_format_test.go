@@ -0,0 +1,46 @@
+package helper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+func TestDateFormatLayout(t *testing.T) {
+	date := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		format helper.DateFormat
+		want   string
+		ok     bool
+	}{
+		{format: "", want: "2024-03-05", ok: true},
+		{format: helper.DateFormatISO, want: "2024-03-05", ok: true},
+		{format: helper.DateFormatDMYSlash, want: "05/03/2024", ok: true},
+		{format: helper.DateFormatMDYSlash, want: "03/05/2024", ok: true},
+		{format: helper.DateFormatDMYDash, want: "05-03-2024", ok: true},
+		{format: "2006-01-02", ok: false},
+		{format: "%s", ok: false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(string(tt.format), func(t *testing.T) {
+			layout, ok := helper.DateFormatLayout(tt.format)
+			if ok != tt.ok {
+				t.Fatalf("DateFormatLayout() ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && date.Format(layout) != tt.want {
+				t.Errorf("formatted = %q, want %q", date.Format(layout), tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatJakartaDatetime(t *testing.T) {
+	utc := time.Date(2024, time.March, 4, 20, 30, 0, 0, time.UTC)
+
+	if got := helper.FormatJakartaDatetime(utc, "02/01/2006"); got != "05/03/2024 03:30:00" {
+		t.Errorf("FormatJakartaDatetime() = %q", got)
+	}
+}
@@ -0,0 +1,64 @@
+package helper_test
+
+import (
+	"testing"
+
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+func TestParseDateStrict(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "non leap year february 29", input: "2023-02-29", wantErr: true},
+		{name: "leap year february 29", input: "2024-02-29", wantErr: false},
+		{name: "month 13", input: "2023-13-01", wantErr: true},
+		{name: "february 30", input: "2024-02-30", wantErr: true},
+		{name: "missing zero padding", input: "2024-1-05", wantErr: true},
+		{name: "wrong separator", input: "2024/01/05", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+		{name: "regular date", input: "2024-12-31", wantErr: false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := helper.ParseDateStrict(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDateStrict(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+
+			if !tt.wantErr && got.Format(helper.DateLayout) != tt.input {
+				t.Errorf("ParseDateStrict(%q) = %s", tt.input, got.Format(helper.DateLayout))
+			}
+		})
+	}
+}
+
+func TestParseMonthStrict(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "regular month", input: "2024-02", wantErr: false},
+		{name: "month 13", input: "2024-13", wantErr: true},
+		{name: "missing zero padding", input: "2024-2", wantErr: true},
+		{name: "full date", input: "2024-02-01", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := helper.ParseMonthStrict(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMonthStrict(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+
+			if !tt.wantErr && got.Format(helper.DateLayout) != tt.input+"-01" {
+				t.Errorf("ParseMonthStrict(%q) = %s", tt.input, got.Format(helper.DateLayout))
+			}
+		})
+	}
+}
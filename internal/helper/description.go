@@ -0,0 +1,35 @@
+package helper
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// DescriptionMaxLength is the maximum description length in characters, matching the varchar(255) column.
+const DescriptionMaxLength = 255
+
+// NotesMaxBytes is the maximum notes size in bytes, matching the TEXT column which limits bytes rather than characters.
+const NotesMaxBytes = 65535
+
+// ValidateDescription checks that description is at most DescriptionMaxLength characters.
+// Length is counted in runes because MySQL varchar limits characters, not bytes. A nil description is valid.
+func ValidateDescription(description *string) error {
+	if description == nil {
+		return nil
+	}
+	if utf8.RuneCountInString(*description) > DescriptionMaxLength {
+		return fmt.Errorf("must be at most %d characters", DescriptionMaxLength)
+	}
+	return nil
+}
+
+// ValidateNotes checks that notes fit in the TEXT column (NotesMaxBytes). A nil notes is valid.
+func ValidateNotes(notes *string) error {
+	if notes == nil {
+		return nil
+	}
+	if len(*notes) > NotesMaxBytes {
+		return fmt.Errorf("must be at most %d bytes", NotesMaxBytes)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package helper_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+func TestValidateDescription(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+
+	testCases := []struct {
+		name        string
+		description *string
+		wantErr     bool
+	}{
+		{name: "nil", description: nil},
+		{name: "empty", description: ptr("")},
+		{name: "exactly max length", description: ptr(strings.Repeat("x", helper.DescriptionMaxLength))},
+		{name: "max length in multi-byte characters", description: ptr(strings.Repeat("é", helper.DescriptionMaxLength))},
+		{name: "one over max length", description: ptr(strings.Repeat("x", helper.DescriptionMaxLength+1)), wantErr: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := helper.ValidateDescription(tt.description)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDescription() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateNotes(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+
+	testCases := []struct {
+		name    string
+		notes   *string
+		wantErr bool
+	}{
+		{name: "nil", notes: nil},
+		{name: "longer than description", notes: ptr(strings.Repeat("x", helper.DescriptionMaxLength+1))},
+		{name: "exactly max bytes", notes: ptr(strings.Repeat("x", helper.NotesMaxBytes))},
+		{name: "multi-byte characters over max bytes", notes: ptr(strings.Repeat("é", helper.NotesMaxBytes/2+1)), wantErr: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := helper.ValidateNotes(tt.notes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateNotes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package helper
+
+import "strings"
+
+// Levenshtein returns the edit distance (insertions, deletions, substitutions) between a and b, counted in runes.
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// FuzzyDistance returns the smallest case-insensitive edit distance between query and any run of consecutive
+// words in text with the same word count as query, so "grocries" is close to "Weekly groceries run".
+func FuzzyDistance(query, text string) int {
+	queryWords := strings.Fields(strings.ToLower(query))
+	textWords := strings.Fields(strings.ToLower(text))
+	if len(queryWords) == 0 {
+		return 0
+	}
+
+	needle := strings.Join(queryWords, " ")
+	if len(textWords) <= len(queryWords) {
+		return Levenshtein(needle, strings.Join(textWords, " "))
+	}
+
+	best := -1
+	for i := 0; i+len(queryWords) <= len(textWords); i++ {
+		distance := Levenshtein(needle, strings.Join(textWords[i:i+len(queryWords)], " "))
+		if best < 0 || distance < best {
+			best = distance
+		}
+	}
+	return best
+}
+
+// FuzzyMaxDistance returns the largest FuzzyDistance still treated as a match for query:
+// one edit for short queries, growing by one for every three runes.
+func FuzzyMaxDistance(query string) int {
+	return max(1, len([]rune(strings.TrimSpace(query)))/3)
+}
@@ -0,0 +1,72 @@
+package helper_test
+
+import (
+	"testing"
+
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+func TestLevenshtein(t *testing.T) {
+	testCases := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{name: "both empty", a: "", b: "", want: 0},
+		{name: "one empty", a: "abc", b: "", want: 3},
+		{name: "classic", a: "kitten", b: "sitting", want: 3},
+		{name: "missing letter", a: "grocries", b: "groceries", want: 1},
+		{name: "equal", a: "kopi", b: "kopi", want: 0},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := helper.Levenshtein(tt.a, tt.b); got != tt.want {
+				t.Errorf("Levenshtein() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuzzyDistance(t *testing.T) {
+	testCases := []struct {
+		name        string
+		query, text string
+		want        int
+	}{
+		{name: "typo inside longer description", query: "grocries", text: "Weekly groceries run", want: 1},
+		{name: "case insensitive", query: "GROCRIES", text: "groceries", want: 1},
+		{name: "multi word query", query: "bensin mobil", text: "isi bensin mobl kantor", want: 1},
+		{name: "exact word", query: "kopi", text: "Kopi susu", want: 0},
+		{name: "empty text", query: "kopi", text: "", want: 4},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := helper.FuzzyDistance(tt.query, tt.text); got != tt.want {
+				t.Errorf("FuzzyDistance() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuzzyMaxDistance(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+		want  int
+	}{
+		{name: "short query allows one edit", query: "ab", want: 1},
+		{name: "four runes", query: "kopi", want: 1},
+		{name: "eight runes", query: "grocries", want: 2},
+		{name: "surrounding spaces ignored", query: "  bensin mobil ", want: 4},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := helper.FuzzyMaxDistance(tt.query); got != tt.want {
+				t.Errorf("FuzzyMaxDistance() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
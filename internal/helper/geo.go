@@ -0,0 +1,22 @@
+package helper
+
+import "errors"
+
+// ValidateCoordinates checks an optional latitude/longitude pair.
+// Both must be given together, latitude within [-90, 90] and longitude within [-180, 180].
+// Nil for both means the location is not set.
+func ValidateCoordinates(latitude, longitude *float64) error {
+	if latitude == nil && longitude == nil {
+		return nil
+	}
+	if latitude == nil || longitude == nil {
+		return errors.New("latitude and longitude must be provided together")
+	}
+	if *latitude < -90 || *latitude > 90 {
+		return errors.New("latitude must be between -90 and 90")
+	}
+	if *longitude < -180 || *longitude > 180 {
+		return errors.New("longitude must be between -180 and 180")
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package helper_test
+
+import (
+	"testing"
+
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+func TestValidateCoordinates(t *testing.T) {
+	float := func(v float64) *float64 { return &v }
+
+	testCases := []struct {
+		name      string
+		latitude  *float64
+		longitude *float64
+		wantErr   bool
+	}{
+		{name: "not set", latitude: nil, longitude: nil},
+		{name: "valid", latitude: float(-6.2088), longitude: float(106.8456)},
+		{name: "boundaries", latitude: float(90), longitude: float(-180)},
+		{name: "latitude out of range", latitude: float(90.0001), longitude: float(106.8), wantErr: true},
+		{name: "longitude out of range", latitude: float(-6.2), longitude: float(180.5), wantErr: true},
+		{name: "latitude without longitude", latitude: float(-6.2), longitude: nil, wantErr: true},
+		{name: "longitude without latitude", latitude: nil, longitude: float(106.8), wantErr: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := helper.ValidateCoordinates(tt.latitude, tt.longitude)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCoordinates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package helper
+
+import "strings"
+
+// likeEscaper escapes the LIKE wildcards and the escape character itself, so user input matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// LikeContains returns a LIKE pattern matching values that contain s, with wildcards in s escaped.
+func LikeContains(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
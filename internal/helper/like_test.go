@@ -0,0 +1,27 @@
+package helper_test
+
+import (
+	"testing"
+
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+func TestLikeContains(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "plain", input: "starbucks", want: "%starbucks%"},
+		{name: "wildcards", input: "50% off_promo", want: `%50\% off\_promo%`},
+		{name: "escape character", input: `a\b`, want: `%a\\b%`},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := helper.LikeContains(tt.input); got != tt.want {
+				t.Errorf("LikeContains() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
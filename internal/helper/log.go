@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/rakahikmah/finance-tracking/config"
 	"github.com/rakahikmah/finance-tracking/entity"
@@ -32,22 +33,30 @@ func WriteLogToFile(data string, channel string) error {
 // Function to Write Log
 // If the app environment is set to production, the log will be written to a file.
 // If the app environment is set to development, the log will be written to the terminal.
+// If LOG_FORMAT is set to json, the log is written to stdout as one JSON object (see jsonLogFields).
 func Log(status entity.LogType, message string, funcName string, err error, logFields entity.CaptureFields, processName string) {
 	logger, _ := config.NewZapLog(GetAppEnv())
 	logger = logger.WithOptions(zap.AddCallerSkip(2))
 	defer logger.Sync()
 
-	fields := []zap.Field{
-		zap.String("process", processName),
-		zap.String("funcName", funcName),
-		zap.String("message", message),
-		zap.String("errorMessage", err.Error()),
-		zap.Any("logFields", logFields),
+	var fields []zap.Field
+	if os.Getenv("LOG_FORMAT") == entity.LOG_FORMAT_JSON {
+		fields = jsonLogFields(processName, funcName, err, logFields)
+	} else {
+		fields = []zap.Field{
+			zap.String("process", processName),
+			zap.String("funcName", funcName),
+			zap.String("message", message),
+			zap.String("errorMessage", err.Error()),
+			zap.Any("logFields", logFields),
+		}
 	}
 
 	switch status {
 	case entity.LogError:
 		logger.Error(message, fields...)
+	case entity.LogWarning:
+		logger.Warn(message, fields...)
 	case entity.LogInfo:
 		logger.Info(message, fields...)
 	case entity.LogDebug:
@@ -56,6 +65,36 @@ func Log(status entity.LogType, message string, funcName string, err error, logF
 
 }
 
+// jsonLogReservedKeys are the top-level keys of a JSON log entry, CaptureFields with the same name are prefixed with "field_".
+var jsonLogReservedKeys = map[string]bool{
+	"level": true, "timestamp": true, "caller": true, "message": true, "func": true, "step": true, "error": true,
+}
+
+// jsonLogFields builds the structured fields of a JSON log entry: func, step, error (only when not empty),
+// and every CaptureFields key merged into the same object.
+func jsonLogFields(processName string, funcName string, err error, logFields entity.CaptureFields) []zap.Field {
+	fields := make([]zap.Field, 0, len(logFields)+3)
+	fields = append(fields, zap.String("func", processName), zap.String("step", funcName))
+	if err != nil && err.Error() != "" {
+		fields = append(fields, zap.String("error", err.Error()))
+	}
+
+	keys := make([]string, 0, len(logFields))
+	for key := range logFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		name := key
+		if jsonLogReservedKeys[name] {
+			name = "field_" + name
+		}
+		fields = append(fields, zap.String(name, logFields[key]))
+	}
+
+	return fields
+}
+
 // Process writing log Error to file and console.
 // Parameters :
 //   - processName : name of process (optional, this can be use to track bug by process name) and make sure using Type Safety to write process name
@@ -63,7 +102,10 @@ func Log(status entity.LogType, message string, funcName string, err error, logF
 //   - err : error response from function
 //   - logFields : additional data to track error (Ex. Indetifier ID, User ID, etc.)
 func LogError(process string, funcName string, err error, logFields entity.CaptureFields, message string) {
-	Log(entity.LogError, process, funcName, err, logFields, process)
+	if message == "" {
+		message = process
+	}
+	Log(entity.LogError, message, funcName, err, logFields, process)
 }
 
 // Process writing log Info to file and console.
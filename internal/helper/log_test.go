@@ -0,0 +1,71 @@
+package helper_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureStdout menjalankan fn dengan os.Stdout dialihkan ke pipe dan mengembalikan baris pertama yang ditulis.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	fn()
+	require.NoError(t, w.Close())
+
+	line, err := bufio.NewReader(r).ReadString('\n')
+	require.NoError(t, err)
+	return line
+}
+
+func TestLogErrorJSONFormat(t *testing.T) {
+	t.Setenv("LOG_FORMAT", entity.LOG_FORMAT_JSON)
+
+	line := captureStdout(t, func() {
+		helper.LogError("CrudTransaction.Create", "TransactionRepo.Create", errors.New("duplicate entry"),
+			entity.CaptureFields{"user_id": "7", "message": "from fields"}, "Gagal menyimpan transaksi")
+	})
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(line), &decoded))
+
+	assert.Equal(t, "error", decoded["level"])
+	assert.NotEmpty(t, decoded["timestamp"])
+	assert.Equal(t, "CrudTransaction.Create", decoded["func"])
+	assert.Equal(t, "TransactionRepo.Create", decoded["step"])
+	assert.Equal(t, "Gagal menyimpan transaksi", decoded["message"])
+	assert.Equal(t, "duplicate entry", decoded["error"])
+	// CaptureFields digabung ke objek yang sama, key yang bentrok diberi prefix
+	assert.Equal(t, "7", decoded["user_id"])
+	assert.Equal(t, "from fields", decoded["field_message"])
+}
+
+func TestLogInfoJSONFormatOmitsEmptyError(t *testing.T) {
+	t.Setenv("LOG_FORMAT", entity.LOG_FORMAT_JSON)
+
+	line := captureStdout(t, func() {
+		helper.LogInfo("ImportJob", "ImportJobUsecase.Process", entity.CaptureFields{"job_id": "3"}, "Import selesai")
+	})
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(line), &decoded))
+
+	assert.Equal(t, "info", decoded["level"])
+	assert.Equal(t, "Import selesai", decoded["message"])
+	assert.Equal(t, "3", decoded["job_id"])
+	assert.NotContains(t, decoded, "error")
+}
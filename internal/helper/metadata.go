@@ -0,0 +1,54 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+const (
+	MetadataMaxKeys        = 20   // Jumlah key maksimal per transaksi
+	MetadataMaxKeyLength   = 64   // Panjang maksimal satu key
+	MetadataMaxValueLength = 255  // Panjang maksimal satu value
+	MetadataMaxBytes       = 4096 // Ukuran maksimal metadata setelah di-encode ke JSON
+)
+
+// metadataKeyPattern membatasi key agar selalu aman dipakai sebagai JSON path ($."key").
+var metadataKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateMetadataKey checks that key is non-empty, at most MetadataMaxKeyLength characters
+// and only contains letters, digits, underscores and hyphens.
+func ValidateMetadataKey(key string) error {
+	if len(key) > MetadataMaxKeyLength {
+		return fmt.Errorf("key %q must be at most %d characters", key, MetadataMaxKeyLength)
+	}
+	if !metadataKeyPattern.MatchString(key) {
+		return fmt.Errorf("key %q may only contain letters, digits, underscores and hyphens", key)
+	}
+	return nil
+}
+
+// ValidateMetadata checks the key count, every key and value, and the encoded size of metadata.
+// Nil and empty maps are valid.
+func ValidateMetadata(metadata map[string]string) error {
+	if len(metadata) > MetadataMaxKeys {
+		return fmt.Errorf("at most %d keys are allowed", MetadataMaxKeys)
+	}
+	for key, value := range metadata {
+		if err := ValidateMetadataKey(key); err != nil {
+			return err
+		}
+		if len(value) > MetadataMaxValueLength {
+			return fmt.Errorf("value of %q must be at most %d characters", key, MetadataMaxValueLength)
+		}
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	if len(encoded) > MetadataMaxBytes {
+		return fmt.Errorf("encoded size must be at most %d bytes", MetadataMaxBytes)
+	}
+	return nil
+}
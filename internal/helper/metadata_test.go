@@ -0,0 +1,46 @@
+package helper_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+func TestValidateMetadata(t *testing.T) {
+	tooManyKeys := map[string]string{}
+	for i := 0; i <= helper.MetadataMaxKeys; i++ {
+		tooManyKeys[fmt.Sprintf("key_%d", i)] = "x"
+	}
+	tooLarge := map[string]string{}
+	for i := 0; i < helper.MetadataMaxKeys; i++ {
+		tooLarge[fmt.Sprintf("key_%d", i)] = strings.Repeat("x", helper.MetadataMaxValueLength)
+	}
+
+	testCases := []struct {
+		name     string
+		metadata map[string]string
+		wantErr  bool
+	}{
+		{name: "nil", metadata: nil},
+		{name: "empty", metadata: map[string]string{}},
+		{name: "valid", metadata: map[string]string{"project_code": "PRJ-7", "trip-name": "Bali 2024"}},
+		{name: "empty key", metadata: map[string]string{"": "x"}, wantErr: true},
+		{name: "key with dot", metadata: map[string]string{"trip.name": "x"}, wantErr: true},
+		{name: "key with quote", metadata: map[string]string{`trip"`: "x"}, wantErr: true},
+		{name: "key too long", metadata: map[string]string{strings.Repeat("k", helper.MetadataMaxKeyLength+1): "x"}, wantErr: true},
+		{name: "value too long", metadata: map[string]string{"note": strings.Repeat("x", helper.MetadataMaxValueLength+1)}, wantErr: true},
+		{name: "too many keys", metadata: tooManyKeys, wantErr: true},
+		{name: "encoded size too large", metadata: tooLarge, wantErr: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := helper.ValidateMetadata(tt.metadata)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package helper
+
+import (
+	"time"
+)
+
+// Period adalah shorthand rentang tanggal yang bisa dipakai sebagai pengganti start_date dan end_date.
+type Period string
+
+const (
+	PeriodThisMonth  Period = "this_month"
+	PeriodLastMonth  Period = "last_month"
+	PeriodThisYear   Period = "this_year"
+	PeriodLast7Days  Period = "last_7_days"
+	PeriodLast30Days Period = "last_30_days"
+)
+
+// PeriodRange menerjemahkan period menjadi rentang tanggal inklusif relatif terhadap now.
+// ok bernilai false jika period tidak dikenali.
+func PeriodRange(p Period, now time.Time) (start, end time.Time, ok bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	switch p {
+	case PeriodThisMonth:
+		return firstOfMonth, firstOfMonth.AddDate(0, 1, -1), true
+	case PeriodLastMonth:
+		return firstOfMonth.AddDate(0, -1, 0), firstOfMonth.AddDate(0, 0, -1), true
+	case PeriodThisYear:
+		return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location()),
+			time.Date(now.Year(), time.December, 31, 0, 0, 0, 0, now.Location()), true
+	case PeriodLast7Days:
+		return today.AddDate(0, 0, -6), today, true
+	case PeriodLast30Days:
+		return today.AddDate(0, 0, -29), today, true
+	default:
+		return time.Time{}, time.Time{}, false
+	}
+}
@@ -0,0 +1,43 @@
+package helper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+func TestPeriodRange(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 10, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		period    helper.Period
+		wantStart string
+		wantEnd   string
+		wantOK    bool
+	}{
+		{period: helper.PeriodThisMonth, wantStart: "2024-03-01", wantEnd: "2024-03-31", wantOK: true},
+		{period: helper.PeriodLastMonth, wantStart: "2024-02-01", wantEnd: "2024-02-29", wantOK: true},
+		{period: helper.PeriodThisYear, wantStart: "2024-01-01", wantEnd: "2024-12-31", wantOK: true},
+		{period: helper.PeriodLast7Days, wantStart: "2024-03-09", wantEnd: "2024-03-15", wantOK: true},
+		{period: helper.PeriodLast30Days, wantStart: "2024-02-15", wantEnd: "2024-03-15", wantOK: true},
+		{period: helper.Period("next_month"), wantOK: false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(string(tt.period), func(t *testing.T) {
+			start, end, ok := helper.PeriodRange(tt.period, now)
+			if ok != tt.wantOK {
+				t.Fatalf("PeriodRange() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+
+			if start.Format(helper.DateLayout) != tt.wantStart || end.Format(helper.DateLayout) != tt.wantEnd {
+				t.Errorf("PeriodRange() = %s..%s, want %s..%s",
+					start.Format(helper.DateLayout), end.Format(helper.DateLayout), tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
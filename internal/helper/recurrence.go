@@ -0,0 +1,70 @@
+package helper
+
+import (
+	"time"
+)
+
+// Frequency adalah interval pengulangan aturan transaksi berulang.
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "daily"
+	FrequencyWeekly  Frequency = "weekly"
+	FrequencyMonthly Frequency = "monthly"
+	FrequencyYearly  Frequency = "yearly"
+)
+
+// IsValidFrequency memeriksa apakah f termasuk Frequency yang didukung.
+func IsValidFrequency(f Frequency) bool {
+	switch f {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+		return true
+	default:
+		return false
+	}
+}
+
+// NthOccurrence mengembalikan kejadian ke-n (0 adalah start) dari aturan berulang yang dimulai pada start.
+// Selalu dihitung dari start, bukan dari kejadian sebelumnya, sehingga aturan tanggal 31 kembali ke tanggal 31
+// setelah melewati bulan yang lebih pendek (31 Jan, 29 Feb, 31 Mar). Tanggal yang tidak ada di bulan tujuan
+// dipotong ke hari terakhir bulan tersebut. ok bernilai false jika frequency tidak dikenali.
+func NthOccurrence(start time.Time, frequency Frequency, n int) (occurrence time.Time, ok bool) {
+	switch frequency {
+	case FrequencyDaily:
+		return start.AddDate(0, 0, n), true
+	case FrequencyWeekly:
+		return start.AddDate(0, 0, 7*n), true
+	case FrequencyMonthly:
+		return addMonthsClamped(start, n), true
+	case FrequencyYearly:
+		return addMonthsClamped(start, 12*n), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// Occurrences mengembalikan maksimal count kejadian pertama aturan berulang mulai dari start.
+// Jika end tidak nil, kejadian setelah end (inklusif) tidak ikut sehingga hasilnya bisa kurang dari count.
+func Occurrences(start time.Time, end *time.Time, frequency Frequency, count int) []time.Time {
+	result := make([]time.Time, 0, count)
+	for n := 0; n < count; n++ {
+		occurrence, ok := NthOccurrence(start, frequency, n)
+		if !ok || (end != nil && occurrence.After(*end)) {
+			break
+		}
+		result = append(result, occurrence)
+	}
+	return result
+}
+
+// addMonthsClamped menambahkan months bulan ke t tanpa meluber ke bulan berikutnya seperti time.AddDate
+// (31 Jan + 1 bulan menjadi 29 Feb, bukan 2 Mar).
+func addMonthsClamped(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()).AddDate(0, months, 0)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
@@ -0,0 +1,55 @@
+package helper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+func TestOccurrences(t *testing.T) {
+	date := func(value string) time.Time {
+		parsed, err := time.Parse(helper.DateLayout, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+	endOfMarch := date("2024-03-31")
+
+	testCases := []struct {
+		name      string
+		start     string
+		end       *time.Time
+		frequency helper.Frequency
+		count     int
+		want      []string
+	}{
+		{name: "daily", start: "2024-02-27", frequency: helper.FrequencyDaily, count: 4, want: []string{"2024-02-27", "2024-02-28", "2024-02-29", "2024-03-01"}},
+		{name: "weekly", start: "2024-01-29", frequency: helper.FrequencyWeekly, count: 3, want: []string{"2024-01-29", "2024-02-05", "2024-02-12"}},
+		{name: "monthly keeps the 31st after shorter months", start: "2024-01-31", frequency: helper.FrequencyMonthly, count: 5, want: []string{"2024-01-31", "2024-02-29", "2024-03-31", "2024-04-30", "2024-05-31"}},
+		{name: "monthly on the 30th", start: "2023-12-30", frequency: helper.FrequencyMonthly, count: 3, want: []string{"2023-12-30", "2024-01-30", "2024-02-29"}},
+		{name: "yearly on leap day", start: "2024-02-29", frequency: helper.FrequencyYearly, count: 3, want: []string{"2024-02-29", "2025-02-28", "2026-02-28"}},
+		{name: "end date cuts the list", start: "2024-01-31", end: &endOfMarch, frequency: helper.FrequencyMonthly, count: 12, want: []string{"2024-01-31", "2024-02-29", "2024-03-31"}},
+		{name: "unknown frequency", start: "2024-01-01", frequency: helper.Frequency("hourly"), count: 3, want: []string{}},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			occurrences := helper.Occurrences(date(tt.start), tt.end, tt.frequency, tt.count)
+
+			got := make([]string, 0, len(occurrences))
+			for _, occurrence := range occurrences {
+				got = append(got, occurrence.Format(helper.DateLayout))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Occurrences() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Occurrences() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
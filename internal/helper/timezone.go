@@ -0,0 +1,47 @@
+package helper
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// DefaultTimezone adalah zona waktu yang dipakai jika user belum mengatur zona atau zonanya tidak valid.
+const DefaultTimezone = "Asia/Jakarta"
+
+// ValidateTimezone memastikan tz adalah nama zona IANA (contoh Asia/Makassar, Europe/Berlin).
+// "Local" ditolak karena bergantung pada zona server.
+func ValidateTimezone(tz string) error {
+	tz = strings.TrimSpace(tz)
+	if tz == "" || tz == "Local" {
+		return errors.New("timezone must be an IANA zone name, e.g. Asia/Jakarta")
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return errors.New("unknown timezone " + tz)
+	}
+	return nil
+}
+
+// LoadTimezone mengembalikan lokasi untuk tz, zona kosong atau tidak valid jatuh ke DefaultTimezone.
+func LoadTimezone(tz string) *time.Location {
+	if ValidateTimezone(tz) == nil {
+		loc, _ := time.LoadLocation(strings.TrimSpace(tz))
+		return loc
+	}
+	loc, err := time.LoadLocation(DefaultTimezone)
+	if err != nil {
+		// Tanpa database zona waktu, pakai offset tetap WIB
+		return time.FixedZone("WIB", 7*60*60)
+	}
+	return loc
+}
+
+// ConvertToTimezone memformat t (YYYY-MM-DD HH:MM:SS) dalam zona tz, fallback ke DefaultTimezone.
+func ConvertToTimezone(t time.Time, tz string) string {
+	return t.In(LoadTimezone(tz)).Format("2006-01-02 15:04:05")
+}
+
+// FormatDatetimeIn memformat waktu ke zona loc dengan layout tanggal diikuti jam (15:04:05).
+func FormatDatetimeIn(t time.Time, loc *time.Location, dateLayout string) string {
+	return t.In(loc).Format(dateLayout + " 15:04:05")
+}
@@ -0,0 +1,44 @@
+package helper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+func TestConvertToTimezone(t *testing.T) {
+	utc := time.Date(2024, time.March, 4, 20, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		tz   string
+		want string
+	}{
+		{tz: "Asia/Makassar", want: "2024-03-05 04:30:00"},
+		{tz: "Europe/Berlin", want: "2024-03-04 21:30:00"},
+		{tz: "UTC", want: "2024-03-04 20:30:00"},
+		// Zona kosong atau tidak valid jatuh ke Asia/Jakarta
+		{tz: "", want: "2024-03-05 03:30:00"},
+		{tz: "Mars/Olympus", want: "2024-03-05 03:30:00"},
+		{tz: "Local", want: "2024-03-05 03:30:00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tz, func(t *testing.T) {
+			if got := helper.ConvertToTimezone(utc, tc.tz); got != tc.want {
+				t.Errorf("ConvertToTimezone(%q) = %q, want %q", tc.tz, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateTimezone(t *testing.T) {
+	if err := helper.ValidateTimezone("America/New_York"); err != nil {
+		t.Errorf("ValidateTimezone() unexpected error: %v", err)
+	}
+	for _, tz := range []string{"", "  ", "Local", "Asia/Nowhere"} {
+		if err := helper.ValidateTimezone(tz); err == nil {
+			t.Errorf("ValidateTimezone(%q) expected error", tz)
+		}
+	}
+}
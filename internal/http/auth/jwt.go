@@ -1,8 +1,13 @@
 package auth
 
 import (
+	"crypto/rsa"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -10,6 +15,8 @@ import (
 	"github.com/rakahikmah/finance-tracking/config"
 	"github.com/rakahikmah/finance-tracking/entity"
 	mentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
 )
 
 const (
@@ -17,125 +24,226 @@ const (
 	publicKeyPath  = "public_key.pem"
 )
 
-type JWT struct{}
+// ErrNoSigningKey is returned by Sign when the key set was loaded without the current private key.
+var ErrNoSigningKey = errors.New("jwt signing key is not configured")
+
+// JWT issues tokens with a key set loaded once at startup.
+type JWT struct {
+	keys     *KeySet
+	tokenTTL time.Duration
+}
 
-func NewJWTAuth() *JWT {
-	return &JWT{}
+func NewJWTAuth(keys *KeySet, tokenTTL time.Duration) *JWT {
+	return &JWT{keys: keys, tokenTTL: tokenTTL}
 }
 
 type JWTAuth interface {
 	GenerateToken(user *mentity.User) (string, error)
 }
 
-func (j *JWT) GenerateToken(user *mentity.User) (string, error) {
-	cfg := config.NewConfig()
+// verificationKey is a public key accepted for verification, identified by the kid header.
+type verificationKey struct {
+	id  string
+	key *rsa.PublicKey
+}
 
-	privateKeyBytes, err := os.ReadFile(privateKeyPath)
-	if err != nil {
-		return "", err
+// KeySet holds the key that signs new tokens and every key still accepted for verification.
+// The first verification key always belongs to the signing key.
+type KeySet struct {
+	signingID  string
+	signingKey *rsa.PrivateKey
+	verifiers  []verificationKey
+}
+
+// LoadKeySet reads the RSA key pairs configured in JwtOption. It is meant to be called once at startup.
+// Without key ids, the legacy private_key.pem and public_key.pem are used and tokens carry no kid header.
+// A missing private key file is not an error: the set can still verify tokens, but Sign returns ErrNoSigningKey.
+func LoadKeySet(opt config.JwtOption) (*KeySet, error) {
+	if len(opt.KeyIDs) == 0 {
+		return loadKeySetFromFiles("", privateKeyPath, []string{""}, []string{publicKeyPath})
 	}
 
-	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyBytes)
-	if err != nil {
-		return "", err
+	publicPaths := make([]string, 0, len(opt.KeyIDs))
+	for _, id := range opt.KeyIDs {
+		publicPaths = append(publicPaths, filepath.Join(opt.KeyDirectory, id+"_public_key.pem"))
 	}
+	current := opt.KeyIDs[0]
+	return loadKeySetFromFiles(current, filepath.Join(opt.KeyDirectory, current+"_private_key.pem"), opt.KeyIDs, publicPaths)
+}
 
-	claims := &entity.Claims{
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(cfg.JwtExpireDaysCount) * 24 * time.Hour)),
-		},
-		Email:      user.Email,
-		UserID:     user.ID,
-		RoleAccess: user.Role,
+func loadKeySetFromFiles(signingID, privatePath string, ids, publicPaths []string) (*KeySet, error) {
+	set := &KeySet{signingID: signingID}
+
+	privateKeyBytes, err := os.ReadFile(privatePath)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		// Verification only, e.g. a service that never issues tokens
+	case err != nil:
+		return nil, err
+	default:
+		if set.signingKey, err = jwt.ParseRSAPrivateKeyFromPEM(privateKeyBytes); err != nil {
+			return nil, err
+		}
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS512, claims)
-	signedToken, err := token.SignedString(privateKey)
-	if err != nil {
-		return "", err
+	for i, path := range publicPaths {
+		publicKeyBytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicKeyBytes)
+		if err != nil {
+			return nil, err
+		}
+		set.verifiers = append(set.verifiers, verificationKey{id: ids[i], key: publicKey})
 	}
 
-	return signedToken, nil
+	return set, nil
 }
 
-func VerifyToken(c *fiber.Ctx) error {
-	authHeader := c.Get("Authorization")
-	if authHeader == "" {
-		return fmt.Errorf("EMPTY TOKEN")
-	}
+// CanSign reports whether the private key of the current key was loaded.
+func (k *KeySet) CanSign() bool {
+	return k.signingKey != nil
+}
 
-	token := authHeader[7:]
+// Sign signs claims with the current key and sets its id as the kid header.
+func (k *KeySet) Sign(claims *entity.Claims) (string, error) {
+	if k.signingKey == nil {
+		return "", ErrNoSigningKey
+	}
 
-	publicKeyBytes, err := os.ReadFile(publicKeyPath)
-	if err != nil {
-		return err
+	token := jwt.NewWithClaims(jwt.SigningMethodRS512, claims)
+	if k.signingID != "" {
+		token.Header["kid"] = k.signingID
 	}
 
-	publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicKeyBytes)
-	if err != nil {
-		return err
+	return token.SignedString(k.signingKey)
+}
+
+// Verify parses token into claims. A token with a kid header is only checked against that key,
+// a token without one is checked against every configured key in order.
+// apperr.ErrInvalidToken is returned when no key validates the token, including when its kid has been retired.
+func (k *KeySet) Verify(token string) (*entity.Claims, error) {
+	var kid string
+	if parsed, _, err := jwt.NewParser().ParseUnverified(token, &entity.Claims{}); err == nil {
+		kid, _ = parsed.Header["kid"].(string)
 	}
 
-	claims := &entity.Claims{}
-	tkn, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
-		return publicKey, nil
-	})
-	if err != nil || !tkn.Valid {
-		return err
+	for _, verifier := range k.verifiers {
+		if kid != "" && verifier.id != kid {
+			continue
+		}
+
+		claims := &entity.Claims{}
+		tkn, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+			if _, ok := t.Method.(*jwt.SigningMethodRSA); !ok {
+				return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+			}
+			return verifier.key, nil
+		})
+		if err == nil && tkn.Valid {
+			return claims, nil
+		}
 	}
 
-	// Set data in Local Context
-	c.Locals("user_id", claims.UserID)
+	return nil, apperr.ErrInvalidToken()
+}
 
-	return nil
+var (
+	tokenKeys *KeySet
+	tokenTTL  time.Duration
+)
+
+// SetTokenKeys registers the key set used by VerifyToken, RevokeToken and RefreshToken and the lifetime of
+// refreshed tokens. Like SetTokenBlacklist, it must be called once at startup, before the server accepts requests.
+func SetTokenKeys(keys *KeySet, ttl time.Duration) {
+	tokenKeys = keys
+	tokenTTL = ttl
 }
 
-func RefreshToken(c *fiber.Ctx) (string, error) {
-	authHeader := c.Get("Authorization")
-	if authHeader == "" {
-		return "", fmt.Errorf("EMPTY TOKEN")
+func (j *JWT) GenerateToken(user *mentity.User) (string, error) {
+	claims := &entity.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.tokenTTL)),
+		},
+		Email:      user.Email,
+		UserID:     user.ID,
+		RoleAccess: user.Role,
 	}
 
-	oldToken := authHeader[7:]
+	return j.keys.Sign(claims)
+}
 
-	cfg := config.NewConfig()
+// bearerToken returns the token of the "Authorization: Bearer <token>" header.
+func bearerToken(c *fiber.Ctx) (string, error) {
+	token, found := strings.CutPrefix(c.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		return "", apperr.ErrInvalidToken()
+	}
+	return token, nil
+}
 
-	publicKeyBytes, err := os.ReadFile(publicKeyPath)
-	if err != nil {
-		return "", err
+// verifyRequest verifies the bearer token of the request with the key set registered by SetTokenKeys.
+func verifyRequest(c *fiber.Ctx) (string, *entity.Claims, error) {
+	if tokenKeys == nil {
+		return "", nil, errors.New("jwt key set is not configured")
 	}
 
-	publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicKeyBytes)
+	token, err := bearerToken(c)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	claims := &entity.Claims{}
-	tkn, err := jwt.ParseWithClaims(oldToken, claims, func(t *jwt.Token) (interface{}, error) {
-		return publicKey, nil
-	})
-	if err != nil || !tkn.Valid {
-		return "", fmt.Errorf("invalid token: %w", err)
+	claims, err := tokenKeys.Verify(token)
+	if err != nil {
+		return "", nil, err
 	}
 
-	// Update expiry
-	claims.RegisteredClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Duration(cfg.JwtExpireDaysCount) * 24 * time.Hour))
+	return token, claims, nil
+}
 
-	privateKeyBytes, err := os.ReadFile(privateKeyPath)
+// VerifyToken validates the bearer token and stores its claims in c.Locals: user_id (int64) and
+// role (mentity.RoleType, taken from the role claim) for handlers and middleware.RequireRole.
+func VerifyToken(c *fiber.Ctx) error {
+	token, claims, err := verifyRequest(c)
 	if err != nil {
-		return "", err
+		return err
+	}
+	if tokenBlacklist != nil {
+		if err := CheckNotRevoked(c.UserContext(), tokenBlacklist, token, claims); err != nil {
+			return err
+		}
+	}
+
+	// Set data in Local Context
+	c.Locals("user_id", claims.UserID)
+	c.Locals("role", mentity.RoleType(claims.RoleAccess))
+
+	return nil
+}
+
+// RevokeToken blacklists the bearer token of the request until it expires, used by logout.
+func RevokeToken(c *fiber.Ctx) error {
+	if tokenBlacklist == nil {
+		return ErrBlacklistDisabled
 	}
 
-	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyBytes)
+	token, claims, err := verifyRequest(c)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	newToken := jwt.NewWithClaims(jwt.SigningMethodRS512, claims)
-	signedToken, err := newToken.SignedString(privateKey)
+	return Revoke(c.UserContext(), tokenBlacklist, token, claims, tokenTTL)
+}
+
+func RefreshToken(c *fiber.Ctx) (string, error) {
+	_, claims, err := verifyRequest(c)
 	if err != nil {
 		return "", err
 	}
 
-	return signedToken, nil
+	// Update expiry, the refreshed token is always signed with the current key
+	claims.RegisteredClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(tokenTTL))
+
+	return tokenKeys.Sign(claims)
 }
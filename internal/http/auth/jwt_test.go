@@ -0,0 +1,222 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rakahikmah/finance-tracking/config"
+	"github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/http/auth"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// writeKeyPair generates an RSA key pair and writes it as <id>_private_key.pem and <id>_public_key.pem in dir.
+func writeKeyPair(t *testing.T, dir, id string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicBytes, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicBytes})
+	if err := os.WriteFile(filepath.Join(dir, id+"_private_key.pem"), privatePEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, id+"_public_key.pem"), publicPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func loadKeySet(t *testing.T, dir string, ids ...string) *auth.KeySet {
+	t.Helper()
+
+	keys, err := auth.LoadKeySet(config.JwtOption{KeyIDs: ids, KeyDirectory: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func testClaims() *entity.Claims {
+	return &entity.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+		UserID:           7,
+		Email:            "user@example.com",
+	}
+}
+
+func TestKeySetRotation(t *testing.T) {
+	dir := t.TempDir()
+	writeKeyPair(t, dir, "old")
+	writeKeyPair(t, dir, "new")
+
+	// Token issued while "old" was still the current key
+	oldToken, err := loadKeySet(t, dir, "old").Sign(testClaims())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("new tokens use the current key", func(t *testing.T) {
+		token, err := loadKeySet(t, dir, "new", "old").Sign(testClaims())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		parsed, _, err := jwt.NewParser().ParseUnverified(token, &entity.Claims{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kid := parsed.Header["kid"]; kid != "new" {
+			t.Errorf("kid = %v, want new", kid)
+		}
+	})
+
+	t.Run("old but still configured key validates", func(t *testing.T) {
+		claims, err := loadKeySet(t, dir, "new", "old").Verify(oldToken)
+		if err != nil {
+			t.Fatalf("Verify() error = %v", err)
+		}
+		if claims.UserID != 7 {
+			t.Errorf("UserID = %d, want 7", claims.UserID)
+		}
+	})
+
+	t.Run("retired key is rejected", func(t *testing.T) {
+		_, err := loadKeySet(t, dir, "new").Verify(oldToken)
+		if !errors.Is(err, apperr.ErrInvalidToken()) {
+			t.Errorf("Verify() error = %v, want ErrInvalidToken", err)
+		}
+	})
+
+	t.Run("token without kid is tried against every key", func(t *testing.T) {
+		parsed, _, err := jwt.NewParser().ParseUnverified(oldToken, &entity.Claims{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		delete(parsed.Header, "kid")
+		privateBytes, err := os.ReadFile(filepath.Join(dir, "old_private_key.pem"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privateBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		token, err := parsed.SignedString(privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := loadKeySet(t, dir, "new", "old").Verify(token); err != nil {
+			t.Errorf("Verify() error = %v", err)
+		}
+	})
+
+	t.Run("expired token is rejected even with a valid key", func(t *testing.T) {
+		claims := testClaims()
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+		keys := loadKeySet(t, dir, "new", "old")
+		token, err := keys.Sign(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := keys.Verify(token); !errors.Is(err, apperr.ErrInvalidToken()) {
+			t.Errorf("Verify() error = %v, want ErrInvalidToken", err)
+		}
+	})
+}
+
+func TestKeySetWithoutPrivateKey(t *testing.T) {
+	dir := t.TempDir()
+	writeKeyPair(t, dir, "current")
+	token, err := loadKeySet(t, dir, "current").Sign(testClaims())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Remove(filepath.Join(dir, "current_private_key.pem")); err != nil {
+		t.Fatal(err)
+	}
+	keys := loadKeySet(t, dir, "current")
+
+	if keys.CanSign() {
+		t.Error("CanSign() = true, want false")
+	}
+	if _, err := keys.Verify(token); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+	if _, err := keys.Sign(testClaims()); !errors.Is(err, auth.ErrNoSigningKey) {
+		t.Errorf("Sign() error = %v, want ErrNoSigningKey", err)
+	}
+}
+
+func TestVerifyToken(t *testing.T) {
+	dir := t.TempDir()
+	writeKeyPair(t, dir, "current")
+	keys := loadKeySet(t, dir, "current")
+	auth.SetTokenKeys(keys, time.Hour)
+	t.Cleanup(func() { auth.SetTokenKeys(nil, 0) })
+
+	token, err := keys.Sign(testClaims())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		if err := auth.VerifyToken(c); err != nil {
+			var appErr apperr.CustomErrorResponse
+			if !errors.As(err, &appErr) {
+				return c.SendStatus(http.StatusInternalServerError)
+			}
+			return c.SendStatus(appErr.HTTPCode)
+		}
+		return c.JSON(c.Locals("user_id"))
+	})
+
+	testCases := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{name: "valid token", header: "Bearer " + token, want: http.StatusOK},
+		{name: "missing header", header: "", want: http.StatusUnauthorized},
+		{name: "not a bearer token", header: "Basic abc", want: http.StatusUnauthorized},
+		{name: "tampered token", header: "Bearer " + token + "x", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/entity"
+	"github.com/redis/go-redis/v9"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// BlacklistRepository stores revoked tokens until they expire on their own.
+type BlacklistRepository interface {
+	Add(ctx context.Context, key string, ttl time.Duration) error
+	Exists(ctx context.Context, key string) (bool, error)
+}
+
+// ErrBlacklistDisabled is returned by RevokeToken when no blacklist store is configured.
+var ErrBlacklistDisabled = errors.New("token blacklist is not configured")
+
+var tokenBlacklist BlacklistRepository
+
+// SetTokenBlacklist registers the store consulted by VerifyToken and written by RevokeToken.
+// It must be called once at startup, before the server accepts requests.
+func SetTokenBlacklist(blacklist BlacklistRepository) {
+	tokenBlacklist = blacklist
+}
+
+// TokenBlacklistKey identifies token in the blacklist. The jti claim is used when present,
+// otherwise the sha256 of the raw token so the token itself is never stored.
+func TokenBlacklistKey(token string, claims *entity.Claims) string {
+	if claims != nil && claims.ID != "" {
+		return "jti:" + claims.ID
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Revoke records token in blacklist until its expiry. A token without expiry is kept for fallbackTTL.
+func Revoke(ctx context.Context, blacklist BlacklistRepository, token string, claims *entity.Claims, fallbackTTL time.Duration) error {
+	ttl := fallbackTTL
+	if claims != nil && claims.ExpiresAt != nil {
+		ttl = time.Until(claims.ExpiresAt.Time)
+	}
+	if ttl <= 0 {
+		// Already expired, Verify rejects it anyway
+		return nil
+	}
+
+	return blacklist.Add(ctx, TokenBlacklistKey(token, claims), ttl)
+}
+
+// CheckNotRevoked returns apperr.ErrInvalidToken when token was revoked. Storage errors are returned as is,
+// callers must treat them as a rejection.
+func CheckNotRevoked(ctx context.Context, blacklist BlacklistRepository, token string, claims *entity.Claims) error {
+	revoked, err := blacklist.Exists(ctx, TokenBlacklistKey(token, claims))
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return apperr.ErrInvalidToken()
+	}
+
+	return nil
+}
+
+// MemoryBlacklist keeps revoked tokens in process memory. Only suitable for single instance deployment and tests.
+type MemoryBlacklist struct {
+	mu   sync.Mutex
+	keys map[string]time.Time
+}
+
+func NewMemoryBlacklist() *MemoryBlacklist {
+	return &MemoryBlacklist{keys: make(map[string]time.Time)}
+}
+
+func (b *MemoryBlacklist) Add(ctx context.Context, key string, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	for k, expiredAt := range b.keys {
+		if now.After(expiredAt) {
+			delete(b.keys, k)
+		}
+	}
+
+	b.keys[key] = now.Add(ttl)
+	return nil
+}
+
+func (b *MemoryBlacklist) Exists(ctx context.Context, key string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	expiredAt, ok := b.keys[key]
+	if !ok {
+		return false, nil
+	}
+	if time.Now().After(expiredAt) {
+		delete(b.keys, key)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// RedisBlacklist shares revoked tokens between API instances, each key expires together with its token.
+type RedisBlacklist struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisBlacklist(client *redis.Client, prefix string) *RedisBlacklist {
+	return &RedisBlacklist{client: client, prefix: prefix}
+}
+
+func (b *RedisBlacklist) Add(ctx context.Context, key string, ttl time.Duration) error {
+	return b.client.Set(ctx, b.prefix+key, 1, ttl).Err()
+}
+
+func (b *RedisBlacklist) Exists(ctx context.Context, key string) (bool, error) {
+	count, err := b.client.Exists(ctx, b.prefix+key).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
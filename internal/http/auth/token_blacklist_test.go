@@ -0,0 +1,94 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rakahikmah/finance-tracking/internal/http/auth"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+func TestTokenRevocation(t *testing.T) {
+	dir := t.TempDir()
+	writeKeyPair(t, dir, "current")
+	keys := loadKeySet(t, dir, "current")
+	ctx := context.Background()
+
+	loggedOut, err := keys.Sign(testClaims())
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherClaims := testClaims()
+	otherClaims.UserID = 8
+	other, err := keys.Sign(otherClaims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blacklist := auth.NewMemoryBlacklist()
+	claims, err := keys.Verify(loggedOut)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := auth.Revoke(ctx, blacklist, loggedOut, claims, time.Hour); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+
+	t.Run("logged out token is rejected", func(t *testing.T) {
+		if err := auth.CheckNotRevoked(ctx, blacklist, loggedOut, claims); !errors.Is(err, apperr.ErrInvalidToken()) {
+			t.Errorf("CheckNotRevoked() error = %v, want ErrInvalidToken", err)
+		}
+	})
+
+	t.Run("other tokens still work", func(t *testing.T) {
+		claims, err := keys.Verify(other)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := auth.CheckNotRevoked(ctx, blacklist, other, claims); err != nil {
+			t.Errorf("CheckNotRevoked() error = %v", err)
+		}
+	})
+
+	t.Run("jti is used as key when present", func(t *testing.T) {
+		withID := testClaims()
+		withID.ID = "abc"
+		if got := auth.TokenBlacklistKey("ignored", withID); got != "jti:abc" {
+			t.Errorf("TokenBlacklistKey() = %q, want jti:abc", got)
+		}
+	})
+}
+
+func TestMemoryBlacklistExpiry(t *testing.T) {
+	ctx := context.Background()
+	blacklist := auth.NewMemoryBlacklist()
+
+	if err := blacklist.Add(ctx, "short", 20*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if exists, _ := blacklist.Exists(ctx, "short"); !exists {
+		t.Fatal("Exists() = false right after Add")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if exists, _ := blacklist.Exists(ctx, "short"); exists {
+		t.Error("Exists() = true after ttl expired")
+	}
+}
+
+func TestRevokeExpiredTokenIsNoop(t *testing.T) {
+	blacklist := auth.NewMemoryBlacklist()
+	claims := testClaims()
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+
+	if err := auth.Revoke(context.Background(), blacklist, "expired", claims, time.Hour); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if exists, _ := blacklist.Exists(context.Background(), auth.TokenBlacklistKey("expired", claims)); exists {
+		t.Error("expired token should not be stored")
+	}
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+)
+
+var (
+	ErrWebhookMalformed        = errors.New("MALFORMED WEBHOOK REQUEST")
+	ErrWebhookStaleTimestamp   = errors.New("STALE WEBHOOK TIMESTAMP")
+	ErrWebhookInvalidSignature = errors.New("INVALID WEBHOOK SIGNATURE")
+	ErrWebhookReplayed         = errors.New("REPLAYED WEBHOOK REQUEST")
+)
+
+// SignWebhookPayload returns hex encoded HMAC-SHA256 of "<timestamp>.<body>" using the shared secret.
+func SignWebhookPayload(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature validate timestamp (unix seconds) and signature of inbound webhook.
+// Request with timestamp older (or newer) than tolerance is treated as replay and rejected.
+// Replay inside the tolerance window is handled separately by CheckWebhookReplay.
+func VerifyWebhookSignature(secret string, timestamp string, signature string, body []byte, tolerance time.Duration, now time.Time) error {
+	if secret == "" || timestamp == "" || signature == "" {
+		return ErrWebhookMalformed
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrWebhookMalformed
+	}
+
+	diff := now.Sub(time.Unix(unix, 0))
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > tolerance {
+		return ErrWebhookStaleTimestamp
+	}
+
+	expected, err := hex.DecodeString(SignWebhookPayload(secret, timestamp, body))
+	if err != nil {
+		return ErrWebhookMalformed
+	}
+
+	given, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrWebhookMalformed
+	}
+
+	// hmac.Equal melakukan perbandingan constant-time
+	if !hmac.Equal(expected, given) {
+		return ErrWebhookInvalidSignature
+	}
+
+	return nil
+}
+
+// CheckWebhookReplay rejects a verified request which (timestamp, signature) pair was already accepted.
+// The pair is remembered for twice the tolerance so it outlives the timestamp window on both sides.
+// Recording the pair before processing keeps concurrent duplicates out; call ForgetWebhookReplay when
+// the request then fails so the sender's retry is accepted.
+func CheckWebhookReplay(ctx context.Context, guard WebhookReplayGuard, timestamp string, signature string, tolerance time.Duration) error {
+	seen, err := guard.Seen(ctx, webhookReplayKey(timestamp, signature), 2*tolerance)
+	if err != nil {
+		return err
+	}
+	if seen {
+		return ErrWebhookReplayed
+	}
+
+	return nil
+}
+
+// ForgetWebhookReplay removes the (timestamp, signature) pair recorded by CheckWebhookReplay.
+func ForgetWebhookReplay(ctx context.Context, guard WebhookReplayGuard, timestamp string, signature string) error {
+	return guard.Forget(ctx, webhookReplayKey(timestamp, signature))
+}
+
+func webhookReplayKey(timestamp string, signature string) string {
+	return timestamp + ":" + signature
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// WebhookReplayGuard remembers already accepted webhook requests.
+// Seen returns true when key was recorded before and its ttl has not expired yet, otherwise it records key.
+// Forget removes a recorded key so a request that could not be processed can be retried by the sender.
+type WebhookReplayGuard interface {
+	Seen(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	Forget(ctx context.Context, key string) error
+}
+
+// MemoryReplayGuard keeps seen keys in process memory. Only suitable for single instance deployment and tests.
+type MemoryReplayGuard struct {
+	mu   sync.Mutex
+	keys map[string]time.Time
+}
+
+func NewMemoryReplayGuard() *MemoryReplayGuard {
+	return &MemoryReplayGuard{keys: make(map[string]time.Time)}
+}
+
+func (g *MemoryReplayGuard) Seen(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	for k, expiredAt := range g.keys {
+		if now.After(expiredAt) {
+			delete(g.keys, k)
+		}
+	}
+
+	if _, ok := g.keys[key]; ok {
+		return true, nil
+	}
+
+	g.keys[key] = now.Add(ttl)
+	return false, nil
+}
+
+func (g *MemoryReplayGuard) Forget(ctx context.Context, key string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.keys, key)
+	return nil
+}
+
+// RedisReplayGuard shares seen keys between API instances using Redis SETNX.
+type RedisReplayGuard struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisReplayGuard(client *redis.Client, prefix string) *RedisReplayGuard {
+	return &RedisReplayGuard{client: client, prefix: prefix}
+}
+
+func (g *RedisReplayGuard) Seen(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	stored, err := g.client.SetNX(ctx, g.prefix+key, 1, ttl).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return !stored, nil
+}
+
+func (g *RedisReplayGuard) Forget(ctx context.Context, key string) error {
+	return g.client.Del(ctx, g.prefix+key).Err()
+}
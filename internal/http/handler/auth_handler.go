@@ -32,6 +32,7 @@ func (w *AuthHandler) Register(app fiber.Router) {
 	app.Post("/auth/login", w.Login)
 	app.Get("/auth/check-token", middleware.VerifyJWTToken, w.CheckToken)
 	app.Get("/auth/refresh-token", middleware.VerifyJWTToken, w.RefreshToken)
+	app.Post("/auth/logout", middleware.VerifyJWTToken, w.Logout)
 }
 
 // @Summary			Create User as Guest
@@ -53,7 +54,7 @@ func (w *AuthHandler) CreateAsGuest(c *fiber.Ctx) error {
 		return w.presenter.BuildError(c, err)
 	}
 
-	login, err := w.userUsecase.CreateAsGuest(c.Context(), req)
+	login, err := w.userUsecase.CreateAsGuest(c.UserContext(), req)
 	if err != nil {
 		return w.presenter.BuildError(c, err)
 	}
@@ -80,7 +81,7 @@ func (w *AuthHandler) Login(c *fiber.Ctx) error {
 		return w.presenter.BuildError(c, err)
 	}
 
-	login, err := w.userUsecase.VerifyByEmailAndPassword(c.Context(), req)
+	login, err := w.userUsecase.VerifyByEmailAndPassword(c.UserContext(), req)
 	if err != nil {
 		return w.presenter.BuildError(c, err)
 	}
@@ -111,3 +112,21 @@ func (w *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 
 	return w.presenter.BuildSuccess(c, newToken, "Success", http.StatusOK)
 }
+
+// Logout
+// @Summary			Logout
+// @Description		Revoke the access token so it is rejected until it expires
+// @Tags			Auth
+// @Produce			json
+// @Security 		Bearer
+// @Success			200 {object} entity.GeneralResponse "Success"
+// @Failure			401 {object} entity.CustomErrorResponse "Invalid Access Token"
+// @Failure			500 {object} entity.CustomErrorResponse "Internal server Error"
+// @Router			/api/v1/auth/logout [post]
+func (w *AuthHandler) Logout(c *fiber.Ctx) error {
+	if err := auth.RevokeToken(c); err != nil {
+		return w.presenter.BuildError(c, err)
+	}
+
+	return w.presenter.BuildSuccess(c, nil, "Logged out", http.StatusOK)
+}
@@ -1,13 +1,19 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 	"strconv" // Untuk mengkonversi string ke int64
+	"strings"
 
 	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/config"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
 	"github.com/rakahikmah/finance-tracking/internal/http/middleware"
 	"github.com/rakahikmah/finance-tracking/internal/parser"
+	"github.com/rakahikmah/finance-tracking/internal/presenter/csv"
 	"github.com/rakahikmah/finance-tracking/internal/presenter/json"
+	mentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
 	transactions_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/transactions" // Import usecase Transactions Anda
 	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity" // Import DTO usecase Transactions Anda
 
@@ -18,30 +24,60 @@ import (
 type TransactionHandler struct {
 	parser            parser.Parser
 	presenter         json.JsonPresenter
+	csvPresenter      csv.CsvPresenter
 	CrudTransactionUsecase transactions_usecase.ICrudTransaction // Menggunakan interface usecase Transaction
+	currencyOption    config.CurrencyOption // Mata uang dasar untuk format amount CSV yang tidak membawa mata uang
+	writeLimit        fiber.Handler         // Rate limit per user untuk rute create/update/delete
 }
 
 // NewTransactionHandler adalah konstruktor untuk TransactionHandler.
 func NewTransactionHandler(
 	parser parser.Parser,
 	presenter json.JsonPresenter,
+	csvPresenter csv.CsvPresenter,
 	CrudTransactionUsecase transactions_usecase.ICrudTransaction,
+	currencyOption config.CurrencyOption,
+	writeLimit fiber.Handler,
 ) *TransactionHandler {
-	return &TransactionHandler{parser, presenter, CrudTransactionUsecase}
+	return &TransactionHandler{parser, presenter, csvPresenter, CrudTransactionUsecase, currencyOption, writeLimit}
 }
 
 // Register mendaftarkan rute-rute API untuk Transaction.
 func (h *TransactionHandler) Register(app fiber.Router) {
-	// Semua rute ini akan memerlukan otentikasi JWT
-	app.Post("/transactions", middleware.VerifyJWTToken, h.Create)
+	// Semua rute ini akan memerlukan otentikasi JWT, rute yang mengubah data juga dibatasi writeLimit
+	app.Post("/transactions", middleware.VerifyJWTToken, h.writeLimit, h.Create)
+	app.Post("/transactions/import", middleware.VerifyJWTToken, h.writeLimit, h.Import)
+	app.Post("/transactions/sync", middleware.VerifyJWTToken, h.writeLimit, h.Sync)
+	app.Post("/transactions/replace-description", middleware.VerifyJWTToken, h.writeLimit, h.ReplaceDescription)
+	app.Post("/transactions/cleanup-orphans", middleware.VerifyJWTToken, middleware.RequireRole(mentity.RoleNameAdmin), h.writeLimit, h.CleanupOrphans)
 	app.Get("/transactions", middleware.VerifyJWTToken, h.GetAll)
+	app.Get("/transactions/paged", middleware.VerifyJWTToken, h.List)
+	app.Get("/transactions/export", middleware.VerifyJWTToken, h.Export)
+	app.Get("/transactions/export.csv", middleware.VerifyJWTToken, h.ExportCSV)
+	app.Get("/transactions/suggest-category", middleware.VerifyJWTToken, h.SuggestCategory)
+	app.Get("/transactions/years", middleware.VerifyJWTToken, h.GetYears)
+	app.Get("/transactions/date-range", middleware.VerifyJWTToken, h.GetDateRange)
+	app.Get("/transactions/filter-options", middleware.VerifyJWTToken, h.GetFilterOptions)
+	app.Get("/transactions/search", middleware.VerifyJWTToken, h.Search)
+	app.Get("/transactions/balance", middleware.VerifyJWTToken, h.GetBalance)
+	app.Get("/transactions/balance/timeline", middleware.VerifyJWTToken, h.GetBalanceTimeline)
+	app.Get("/transactions/stats/spending", middleware.VerifyJWTToken, h.GetSpendingStats)
 	app.Get("/transactions/summary", middleware.VerifyJWTToken, h.GetDailySummary) // Rute baru untuk summary
-	app.Put("/transactions/:id", middleware.VerifyJWTToken, h.Update)
+	app.Get("/transactions/summary/monthly", middleware.VerifyJWTToken, h.GetMonthlySummary)
+	app.Get("/transactions/report/yearly", middleware.VerifyJWTToken, h.GetYearlyReport)
+	app.Get("/transactions/calendar", middleware.VerifyJWTToken, h.GetCalendar)
+	app.Get("/transactions/summary.csv", middleware.VerifyJWTToken, h.ExportDailySummaryCSV)
+	app.Get("/transactions/summary-by-category-type.csv", middleware.VerifyJWTToken, h.ExportSummaryByCategoryAndTypeCSV)
+	app.Put("/transactions/:id", middleware.VerifyJWTToken, h.writeLimit, h.Update)
 	app.Get("/transactions/summary-by-category-type", middleware.VerifyJWTToken, h.GetSummaryByCategoryAndType)
-	app.Delete("/transactions/:id", middleware.VerifyJWTToken, h.Delete)
+	app.Get("/transactions/top-categories", middleware.VerifyJWTToken, h.GetTopCategories)
+	app.Get("/transactions/:id", middleware.VerifyJWTToken, h.GetByID)
+	app.Delete("/transactions/:id", middleware.VerifyJWTToken, h.writeLimit, h.Delete)
+	app.Post("/transactions/:id/restore", middleware.VerifyJWTToken, h.writeLimit, h.Restore)
 }
 
 // Create menangani permintaan POST untuk membuat transaksi baru.
+// Transaksi yang sama dengan transaksi beberapa menit terakhir ditolak (409) kecuali force=true.
 func (h *TransactionHandler) Create(c *fiber.Ctx) error {
 	var req usecaseEntity.TransactionReq // Menggunakan TransactionReq dari usecase entity
 
@@ -60,8 +96,16 @@ func (h *TransactionHandler) Create(c *fiber.Ctx) error {
 		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
 	}
 
+	if req.OverridePeriodLock, err = periodLockOverrideQuery(c); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+	// force=true bisa dikirim di body atau query untuk melewati pengecekan duplikat
+	if c.QueryBool("force") {
+		req.Force = true
+	}
+
 	// Memanggil usecase.Create dengan userID sebagai parameter terpisah
-	err = h.CrudTransactionUsecase.Create(c.Context(), userID, req)
+	err = h.CrudTransactionUsecase.Create(c.UserContext(), userID, req)
 	if err != nil {
 		return h.presenter.BuildError(c, err)
 	}
@@ -69,7 +113,137 @@ func (h *TransactionHandler) Create(c *fiber.Ctx) error {
 	return h.presenter.BuildSuccess(c, nil, "Transaction created successfully", http.StatusCreated)
 }
 
-// GetAll menangani permintaan GET untuk mendapatkan semua transaksi user.
+// Import menangani permintaan POST untuk meng-import banyak transaksi sekaligus.
+// Query create_categories=true membuat kategori yang belum ada berdasarkan nama.
+// Request multipart/form-data diproses sebagai upload file CSV (lihat importCSV).
+func (h *TransactionHandler) Import(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		return h.importCSV(c, userID)
+	}
+
+	var req usecaseEntity.ImportTransactionReq
+	if err := h.parser.ParserBodyRequestWithUserID(c, &req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.CrudTransactionUsecase.Import(c.UserContext(), userID, req, c.QueryBool("create_categories", false))
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Transactions imported successfully", http.StatusCreated)
+}
+
+// importCSV meng-import transaksi dari field file berisi CSV (date, type, category_name, amount, description).
+// create_missing=true (query atau field form) membuat kategori yang belum ada. Baris yang gagal dilaporkan
+// beserta nomor barisnya, baris yang valid tetap disimpan.
+func (h *TransactionHandler) importCSV(c *fiber.Ctx, userID int64) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("file is required (multipart field \"file\" with a CSV file)."))
+	}
+
+	createMissing := c.QueryBool("create_missing", false)
+	if value := c.FormValue("create_missing"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid create_missing format. Use true or false."))
+		}
+		createMissing = parsed
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Unable to read the uploaded file."))
+	}
+	defer file.Close()
+
+	result, err := h.CrudTransactionUsecase.ImportCSV(c.UserContext(), userID, file, createMissing)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Transactions CSV processed", http.StatusOK)
+}
+
+// Sync menangani permintaan POST untuk upsert transaksi dari export bank berdasarkan reference.
+func (h *TransactionHandler) Sync(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	var req usecaseEntity.SyncTransactionReq
+	if err := h.parser.ParserBodyRequestWithUserID(c, &req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.CrudTransactionUsecase.Sync(c.UserContext(), userID, req)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Transactions synced successfully", http.StatusOK)
+}
+
+// ReplaceDescription menangani permintaan POST untuk find-and-replace description transaksi user, opsional dry_run.
+func (h *TransactionHandler) ReplaceDescription(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	var req usecaseEntity.ReplaceDescriptionReq
+	if err := h.parser.ParserBodyRequestWithUserID(c, &req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.CrudTransactionUsecase.ReplaceDescription(c.UserContext(), userID, req)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Transaction descriptions replaced successfully", http.StatusOK)
+}
+
+// CleanupOrphans menangani permintaan POST (khusus admin) untuk mengosongkan category_id transaksi milik user_id
+// yang kategorinya sudah tidak ada. Query dry_run=true hanya menghitung tanpa mengubah data.
+func (h *TransactionHandler) CleanupOrphans(c *fiber.Ctx) error {
+	userIDStr := c.Query("user_id")
+	if userIDStr == "" {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("user_id query is required."))
+	}
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil || userID <= 0 {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid user_id format."))
+	}
+
+	dryRun := false
+	if value := c.Query("dry_run"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid dry_run format. Use true or false."))
+		}
+	}
+
+	result, err := h.CrudTransactionUsecase.CleanupOrphanedCategories(c.UserContext(), userID, dryRun)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Orphaned transactions cleaned up successfully", http.StatusOK)
+}
+
+// GetAll menangani permintaan GET untuk daftar transaksi user berbasis cursor (limit, cursor)
+// dengan filter opsional (type, start_date, end_date, category_id, meta.<key>=<value>, by) dan urutan sort (date_desc, date_asc, amount_desc, amount_asc).
+// by=created_at memakai tanggal pencatatan untuk rentang tanggal dan urutan, default transaction_date.
+// next_cursor pada response dipakai sebagai cursor untuk halaman berikutnya, query page ditolak karena pagination nomor halaman
+// ada di GET /transactions/paged. include_deleted=true (hanya admin) ikut menampilkan
+// transaksi yang sudah di-soft delete beserta deleted_at, user biasa mendapat 403.
 func (h *TransactionHandler) GetAll(c *fiber.Ctx) error {
 	// Ambil userID dari Fiber context
 	userID, ok := c.Locals("user_id").(int64)
@@ -77,8 +251,223 @@ func (h *TransactionHandler) GetAll(c *fiber.Ctx) error {
 		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
 	}
 
-	// Memanggil usecase.GetAll dengan userID
-	result, err := h.CrudTransactionUsecase.GetAll(c.Context(), userID)
+	// Pagination berbasis nomor halaman punya rute dan bentuk response sendiri
+	if c.Query("page") != "" {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("page is not supported here, use cursor or GET /transactions/paged"))
+	}
+
+	req := usecaseEntity.TransactionCursorReq{
+		StartDate: c.Query("start_date"),
+		EndDate:   c.Query("end_date"),
+		Type:      usecaseEntity.TransactionTypeString(c.Query("type")),
+		By:        c.Query("by"),
+		Sort:      c.Query("sort"),
+	}
+	categoryID, err := categoryIDQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+	req.CategoryID = categoryID
+	req.Metadata = metadataQuery(c)
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid limit format."))
+		}
+		req.Limit = limit
+	}
+	req.Cursor = c.Query("cursor")
+	format, err := responseFormatQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+	req.Format = format
+	if req.IncludeDeleted, err = includeDeletedQuery(c); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.CrudTransactionUsecase.GetAll(c.UserContext(), userID, req)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Transactions retrieved successfully", http.StatusOK)
+}
+
+// GetByID menangani permintaan GET untuk satu transaksi milik user.
+func (h *TransactionHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid transaction ID format."))
+	}
+
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	result, err := h.CrudTransactionUsecase.GetByID(c.UserContext(), id, userID)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Transaction retrieved successfully", http.StatusOK)
+}
+
+// Export menangani permintaan GET untuk mengunduh seluruh riwayat transaksi user sebagai JSON yang di-stream per baris,
+// sehingga memori server tetap datar untuk riwayat yang sangat panjang. Daftar ber-halaman tetap memakai GetAll.
+func (h *TransactionHandler) Export(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	format, err := responseFormatQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	// Stream dijalankan setelah handler selesai, jadi hanya RequestCtx (context.Context) yang dibawa, bukan fiber.Ctx
+	ctx := c.Context()
+	return h.presenter.BuildSuccessStream(c, "Transactions exported successfully", http.StatusOK, func(emit func(item interface{}) error) error {
+		return h.CrudTransactionUsecase.StreamAll(ctx, userID, usecaseEntity.TransactionExportReq{Format: format}, func(item usecaseEntity.TransactionResponse) error {
+			return emit(item)
+		})
+	})
+}
+
+// ExportCSV menangani permintaan GET untuk mengunduh transaksi user dalam rentang tanggal sebagai CSV yang di-stream per baris.
+func (h *TransactionHandler) ExportCSV(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+	// Status sudah terkirim saat stream berjalan, jadi tanggal yang tidak valid harus ditolak sebelum stream dimulai
+	for _, date := range []string{startDate, endDate} {
+		if _, err := helper.ParseDateStrict(date); err != nil {
+			return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid date format: "+err.Error()))
+		}
+	}
+
+	req := usecaseEntity.TransactionExportReq{StartDate: startDate, EndDate: endDate}
+	header := []string{"id", "date", "type", "category_name", "amount", "currency", "description"}
+	filename := fmt.Sprintf("transactions_%s_%s.csv", startDate, endDate)
+
+	// Stream dijalankan setelah handler selesai, jadi hanya RequestCtx (context.Context) yang dibawa, bukan fiber.Ctx
+	ctx := c.Context()
+	return h.csvPresenter.BuildCSVStream(c, filename, header, func(write func(record []string) error) error {
+		return h.CrudTransactionUsecase.StreamAll(ctx, userID, req, func(item usecaseEntity.TransactionResponse) error {
+			categoryName, description := "", ""
+			if item.CategoryName != nil {
+				categoryName = *item.CategoryName
+			}
+			if item.Description != nil {
+				description = *item.Description
+			}
+			return write([]string{
+				strconv.FormatInt(item.ID, 10),
+				item.TransactionDate,
+				string(item.Type),
+				categoryName,
+				h.formatAmount(item.Amount, item.Currency),
+				item.Currency,
+				description,
+			})
+		})
+	})
+}
+
+// SuggestCategory menangani permintaan GET untuk saran kategori berdasarkan query description.
+func (h *TransactionHandler) SuggestCategory(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	result, err := h.CrudTransactionUsecase.SuggestCategory(c.UserContext(), userID, c.Query("description"))
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Category suggestion retrieved successfully", http.StatusOK)
+}
+
+// GetYears menangani permintaan GET untuk daftar tahun (menurun) yang memiliki transaksi milik user.
+func (h *TransactionHandler) GetYears(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	result, err := h.CrudTransactionUsecase.GetYears(c.UserContext(), userID)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Transaction years retrieved successfully", http.StatusOK)
+}
+
+// GetDateRange menangani permintaan GET untuk transaction_date paling awal dan paling akhir milik user.
+// earliest dan latest bernilai null jika user belum memiliki transaksi.
+func (h *TransactionHandler) GetDateRange(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	result, err := h.CrudTransactionUsecase.GetDateRange(c.UserContext(), userID)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Transaction date range retrieved successfully", http.StatusOK)
+}
+
+// GetFilterOptions menangani permintaan GET untuk pilihan filter transaksi (kategori, rentang amount dan tanggal, tipe).
+func (h *TransactionHandler) GetFilterOptions(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	result, err := h.CrudTransactionUsecase.GetFilterOptions(c.UserContext(), userID)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Transaction filter options retrieved successfully", http.StatusOK)
+}
+
+// Search menangani permintaan GET untuk pencarian transaksi berdasarkan description (q), opsional fuzzy=true.
+func (h *TransactionHandler) Search(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	req := usecaseEntity.TransactionSearchReq{
+		Query: c.Query("q"),
+		Fuzzy: c.QueryBool("fuzzy", false),
+	}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid limit format."))
+		}
+		req.Limit = limit
+	}
+	format, err := responseFormatQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+	req.Format = format
+
+	result, err := h.CrudTransactionUsecase.Search(c.UserContext(), userID, req)
 	if err != nil {
 		return h.presenter.BuildError(c, err)
 	}
@@ -86,6 +475,257 @@ func (h *TransactionHandler) GetAll(c *fiber.Ctx) error {
 	return h.presenter.BuildSuccess(c, result, "Transactions retrieved successfully", http.StatusOK)
 }
 
+// periodLockOverrideQuery membaca query override_lock. Hanya admin yang boleh mengubah transaksi di periode terkunci.
+func periodLockOverrideQuery(c *fiber.Ctx) (bool, error) {
+	value := c.Query("override_lock")
+	if value == "" {
+		return false, nil
+	}
+	override, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, apperr.ErrInvalidRequest().SetDetail("Invalid override_lock format. Use true or false.")
+	}
+	if override {
+		if role, _ := c.Locals("role").(mentity.RoleType); role != mentity.RoleTypeAdmin {
+			return false, apperr.ErrForbidden().SetDetail("Only admins can override a period lock.")
+		}
+	}
+	return override, nil
+}
+
+// includeDeletedQuery membaca query include_deleted. Hanya admin yang boleh melihat transaksi yang sudah di-soft delete.
+func includeDeletedQuery(c *fiber.Ctx) (bool, error) {
+	value := c.Query("include_deleted")
+	if value == "" {
+		return false, nil
+	}
+	includeDeleted, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, apperr.ErrInvalidRequest().SetDetail("Invalid include_deleted format. Use true or false.")
+	}
+	if includeDeleted {
+		if role, _ := c.Locals("role").(mentity.RoleType); role != mentity.RoleTypeAdmin {
+			return false, apperr.ErrForbidden().SetDetail("Only admins can include deleted transactions.")
+		}
+	}
+	return includeDeleted, nil
+}
+
+// nullAsEmptyQuery membaca query null_as_empty. Nil jika tidak diberikan sehingga usecase memakai default konfigurasi.
+func nullAsEmptyQuery(c *fiber.Ctx) (*bool, error) {
+	value := c.Query("null_as_empty")
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid null_as_empty format. Use true or false.")
+	}
+	return &parsed, nil
+}
+
+// responseFormatQuery membaca opsi representasi response (null_as_empty, date_format).
+// date_format divalidasi terhadap allowlist di usecase.
+func responseFormatQuery(c *fiber.Ctx) (usecaseEntity.ResponseFormatReq, error) {
+	nullAsEmpty, err := nullAsEmptyQuery(c)
+	if err != nil {
+		return usecaseEntity.ResponseFormatReq{}, err
+	}
+	return usecaseEntity.ResponseFormatReq{NullAsEmpty: nullAsEmpty, DateFormat: c.Query("date_format")}, nil
+}
+
+// categoryIDQuery membaca query category_id. Nil jika tidak diberikan.
+func categoryIDQuery(c *fiber.Ctx) (*int64, error) {
+	value := c.Query("category_id")
+	if value == "" {
+		return nil, nil
+	}
+	categoryID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid category_id format.")
+	}
+	return &categoryID, nil
+}
+
+// metadataQuery membaca filter meta.<key>=<value> dari query. Nil jika tidak ada.
+func metadataQuery(c *fiber.Ctx) map[string]string {
+	var metadata map[string]string
+	for key, value := range c.Queries() {
+		if metaKey, found := strings.CutPrefix(key, "meta."); found {
+			if metadata == nil {
+				metadata = map[string]string{}
+			}
+			metadata[metaKey] = value
+		}
+	}
+	return metadata
+}
+
+// List menangani GET /transactions/paged dengan pagination nomor halaman (page default 1, per_page) dan filter opsional
+// (start_date, end_date, type, category_id, meta.<key>=<value>). Query by=created_at memakai tanggal pencatatan untuk filter tanggal dan urutan.
+// Daftar transaksi ada di data, info halaman (total, page, per_page, total_pages, has_next) di meta envelope.
+func (h *TransactionHandler) List(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	var err error
+	page := 1
+	if value := c.Query("page"); value != "" {
+		page, err = strconv.Atoi(value)
+		if err != nil {
+			return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid page format."))
+		}
+	}
+
+	perPage := 0
+	if value := c.Query("per_page"); value != "" {
+		perPage, err = strconv.Atoi(value)
+		if err != nil {
+			return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid per_page format."))
+		}
+	}
+
+	req := usecaseEntity.TransactionListReq{
+		Page:      page,
+		PerPage:   perPage,
+		StartDate: c.Query("start_date"),
+		EndDate:   c.Query("end_date"),
+		Type:      usecaseEntity.TransactionTypeString(c.Query("type")),
+		By:        c.Query("by"),
+		Sort:      c.Query("sort"),
+	}
+	if req.Format, err = responseFormatQuery(c); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+	if req.CategoryID, err = categoryIDQuery(c); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+	if req.IncludeDeleted, err = includeDeletedQuery(c); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+	req.Metadata = metadataQuery(c)
+
+	result, err := h.CrudTransactionUsecase.List(c.UserContext(), userID, req)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccessWithMeta(c, result.Data, result.Meta, "Transactions retrieved successfully", http.StatusOK)
+}
+
+// GetMonthlySummary menangani permintaan GET untuk ringkasan transaksi per bulan dalam satu tahun (default tahun berjalan).
+// Bulan tanpa transaksi tidak ada di response dan perlu diisi nol oleh frontend.
+func (h *TransactionHandler) GetMonthlySummary(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	year := helper.DatetimeNowJakarta().Year()
+	if c.Query("year") != "" {
+		parsed, err := strconv.Atoi(c.Query("year"))
+		if err != nil {
+			return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid year format."))
+		}
+		year = parsed
+	}
+
+	result, err := h.CrudTransactionUsecase.GetMonthlySummary(c.UserContext(), userID, year, c.QueryBool("include_all", false))
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Monthly summary retrieved successfully", http.StatusOK)
+}
+
+// GetYearlyReport menangani permintaan GET untuk laporan income dan expense 12 bulan dalam satu tahun (default tahun berjalan).
+func (h *TransactionHandler) GetYearlyReport(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	year := helper.DatetimeNowJakarta().Year()
+	if c.Query("year") != "" {
+		parsed, err := strconv.Atoi(c.Query("year"))
+		if err != nil {
+			return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid year format."))
+		}
+		year = parsed
+	}
+
+	result, err := h.CrudTransactionUsecase.GetYearlyReport(c.UserContext(), userID, year)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Yearly report retrieved successfully", http.StatusOK)
+}
+
+// GetBalance menangani permintaan GET untuk total income, expense, dan net dalam rentang tanggal.
+// data berupa array dengan satu elemen per mata uang (field currency), karena nominal beda mata uang tidak dijumlahkan.
+// Periode tanpa transaksi tetap mengembalikan satu elemen bernilai 0 dalam mata uang dasar.
+func (h *TransactionHandler) GetBalance(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.CrudTransactionUsecase.GetBalance(c.UserContext(), userID, startDate, endDate, c.QueryBool("include_all", false))
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Balance retrieved successfully", http.StatusOK)
+}
+
+// GetBalanceTimeline menangani permintaan GET untuk income, expense, dan saldo berjalan per hari dalam rentang tanggal.
+func (h *TransactionHandler) GetBalanceTimeline(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.CrudTransactionUsecase.GetBalanceTimeline(c.UserContext(), userID, startDate, endDate)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Balance timeline retrieved successfully", http.StatusOK)
+}
+
+// GetSpendingStats menangani permintaan GET untuk total, rata-rata per hari/minggu, dan hari dengan expense terbesar/terkecil.
+func (h *TransactionHandler) GetSpendingStats(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.CrudTransactionUsecase.GetSpendingStats(c.UserContext(), userID, startDate, endDate)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Spending statistics retrieved successfully", http.StatusOK)
+}
+
 // GetDailySummary menangani permintaan GET untuk ringkasan transaksi harian.
 func (h *TransactionHandler) GetDailySummary(c *fiber.Ctx) error {
 	userID, ok := c.Locals("user_id").(int64)
@@ -93,16 +733,14 @@ func (h *TransactionHandler) GetDailySummary(c *fiber.Ctx) error {
 		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
 	}
 
-	// Ambil parameter tanggal dari query string (misal: /summary?start_date=2023-01-01&end_date=2023-01-31)
-	startDate := c.Query("start_date")
-	endDate := c.Query("end_date")
-
-	// Validasi dasar parameter tanggal
-	if startDate == "" || endDate == "" {
-		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("start_date and end_date query parameters are required for summary."))
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
 	}
 
-	result, err := h.CrudTransactionUsecase.GetDailySummary(c.Context(), userID, startDate, endDate)
+	granularity := helper.Granularity(c.Query("granularity"))
+
+	result, err := h.CrudTransactionUsecase.GetDailySummary(c.UserContext(), userID, startDate, endDate, granularity, c.QueryBool("include_all", false))
 	if err != nil {
 		return h.presenter.BuildError(c, err)
 	}
@@ -110,6 +748,30 @@ func (h *TransactionHandler) GetDailySummary(c *fiber.Ctx) error {
 	return h.presenter.BuildSuccess(c, result, "Daily transaction summary retrieved successfully", http.StatusOK)
 }
 
+// GetCalendar menangani permintaan GET untuk transaksi satu bulan yang dikelompokkan per hari.
+func (h *TransactionHandler) GetCalendar(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	month := c.Query("month")
+	if month == "" {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("month is required (YYYY-MM)."))
+	}
+
+	format, err := responseFormatQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.CrudTransactionUsecase.GetCalendar(c.UserContext(), userID, month, format)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Transaction calendar retrieved successfully", http.StatusOK)
+}
 
 // Update menangani permintaan PUT untuk memperbarui transaksi.
 func (h *TransactionHandler) Update(c *fiber.Ctx) error {
@@ -132,8 +794,12 @@ func (h *TransactionHandler) Update(c *fiber.Ctx) error {
 		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
 	}
 
+	if req.OverridePeriodLock, err = periodLockOverrideQuery(c); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
 	// Memanggil usecase.Update dengan ID transaksi dan userID
-	err = h.CrudTransactionUsecase.Update(c.Context(), id, userID, req)
+	err = h.CrudTransactionUsecase.Update(c.UserContext(), id, userID, req)
 	if err != nil {
 		return h.presenter.BuildError(c, err)
 	}
@@ -155,8 +821,13 @@ func (h *TransactionHandler) Delete(c *fiber.Ctx) error {
 		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
 	}
 
+	overridePeriodLock, err := periodLockOverrideQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
 	// Memanggil usecase.Delete dengan ID transaksi dan userID
-	err = h.CrudTransactionUsecase.Delete(c.Context(), id, userID)
+	err = h.CrudTransactionUsecase.Delete(c.UserContext(), id, userID, overridePeriodLock)
 	if err != nil {
 		return h.presenter.BuildError(c, err)
 	}
@@ -164,25 +835,150 @@ func (h *TransactionHandler) Delete(c *fiber.Ctx) error {
 	return h.presenter.BuildSuccess(c, nil, "Transaction deleted successfully", http.StatusOK)
 }
 
+// Restore menangani permintaan POST untuk mengembalikan transaksi yang sudah dihapus.
+func (h *TransactionHandler) Restore(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid transaction ID format."))
+	}
+
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	err = h.CrudTransactionUsecase.Restore(c.UserContext(), id, userID)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, nil, "Transaction restored successfully", http.StatusOK)
+}
+
 
 // GetSummaryByCategoryAndType menangani permintaan GET untuk ringkasan transaksi per kategori dan tipe.
+// Query type (income atau expense) opsional, tanpa type kedua tipe dikembalikan.
 func (h *TransactionHandler) GetSummaryByCategoryAndType(c *fiber.Ctx) error {
 	userID, ok := c.Locals("user_id").(int64)
 	if !ok || userID == 0 {
 		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
 	}
 
-	startDate := c.Query("start_date")
-	endDate := c.Query("end_date")
-
-	if startDate == "" || endDate == "" {
-		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("start_date and end_date query parameters are required for summary."))
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
 	}
 
-	result, err := h.CrudTransactionUsecase.GetSummaryByCategoryAndType(c.Context(), userID, startDate, endDate)
+	result, err := h.CrudTransactionUsecase.GetSummaryByCategoryAndType(c.UserContext(), userID, startDate, endDate, usecaseEntity.TransactionTypeString(c.Query("type")))
 	if err != nil {
 		return h.presenter.BuildError(c, err)
 	}
 
 	return h.presenter.BuildSuccess(c, result, "Transaction summary by category and type retrieved successfully", http.StatusOK)
-}
\ No newline at end of file
+}
+
+// GetTopCategories menangani permintaan GET untuk kategori dengan pengeluaran terbesar dalam rentang tanggal
+// (start_date/end_date atau period), query limit opsional (default 5).
+func (h *TransactionHandler) GetTopCategories(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid limit format."))
+		}
+	}
+
+	result, err := h.CrudTransactionUsecase.GetTopCategories(c.UserContext(), userID, startDate, endDate, limit)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Top spending categories retrieved successfully", http.StatusOK)
+}
+
+// ExportDailySummaryCSV menangani permintaan GET untuk mengunduh ringkasan transaksi harian sebagai CSV.
+func (h *TransactionHandler) ExportDailySummaryCSV(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	granularity := helper.Granularity(c.Query("granularity"))
+
+	result, err := h.CrudTransactionUsecase.GetDailySummary(c.UserContext(), userID, startDate, endDate, granularity, c.QueryBool("include_all", false))
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	rows := [][]string{}
+	for _, row := range result.Data {
+		rows = append(rows, []string{
+			row.Day,
+			row.Currency,
+			string(row.Type),
+			h.formatAmount(row.TotalAmount, row.Currency),
+		})
+	}
+
+	filename := fmt.Sprintf("summary_%s_%s.csv", startDate, endDate)
+	return h.csvPresenter.BuildCSV(c, filename, []string{"transaction_day", "currency", "type", "total_amount"}, rows)
+}
+
+// ExportSummaryByCategoryAndTypeCSV menangani permintaan GET untuk mengunduh ringkasan per kategori dan tipe sebagai CSV.
+func (h *TransactionHandler) ExportSummaryByCategoryAndTypeCSV(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.CrudTransactionUsecase.GetSummaryByCategoryAndType(c.UserContext(), userID, startDate, endDate, usecaseEntity.TransactionTypeString(c.Query("type")))
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	rows := [][]string{}
+	for _, row := range result {
+		categoryName := ""
+		if row.CategoryName != nil {
+			categoryName = *row.CategoryName
+		}
+		rows = append(rows, []string{
+			categoryName,
+			row.Currency,
+			string(row.Type),
+			h.formatAmount(row.TotalAmount, row.Currency),
+		})
+	}
+
+	filename := fmt.Sprintf("summary_by_category_type_%s_%s.csv", startDate, endDate)
+	return h.csvPresenter.BuildCSV(c, filename, []string{"category_name", "currency", "type", "total_amount"}, rows)
+}
+
+// formatAmount memformat amount CSV sesuai skala mata uangnya tanpa membulatkan nilai yang tersimpan,
+// mata uang kosong memakai mata uang dasar.
+func (h *TransactionHandler) formatAmount(amount float64, currency string) string {
+	if currency == "" {
+		currency = h.currencyOption.Code
+	}
+	return helper.FormatStoredAmount(amount, currency)
+}
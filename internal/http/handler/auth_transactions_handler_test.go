@@ -0,0 +1,556 @@
+package handler_test
+
+import (
+	"bytes"
+	encjson "encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/config"
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/http/handler"
+	"github.com/rakahikmah/finance-tracking/internal/http/middleware"
+	"github.com/rakahikmah/finance-tracking/internal/parser"
+	"github.com/rakahikmah/finance-tracking/internal/presenter/csv"
+	"github.com/rakahikmah/finance-tracking/internal/presenter/json"
+	mentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TransactionHandlerTestSuite struct {
+	suite.Suite
+	usecase *mocks.ICrudTransaction
+	handler *handler.TransactionHandler
+	app     *fiber.App
+}
+
+func (s *TransactionHandlerTestSuite) SetupTest() {
+	s.usecase = &mocks.ICrudTransaction{}
+	s.handler = handler.NewTransactionHandler(parser.NewParser(), json.NewJsonPresenter(), csv.NewCsvPresenter(), s.usecase, config.CurrencyOption{Code: "USD"}, middleware.RateLimit(0))
+	s.app = fiber.New()
+}
+
+func TestTransactionHandler(t *testing.T) {
+	suite.Run(t, new(TransactionHandlerTestSuite))
+}
+
+// withUser menggantikan middleware JWT dengan user_id tetap.
+func withUser(userID int64) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals("user_id", userID)
+		return c.Next()
+	}
+}
+
+// asRole menggantikan middleware JWT dengan role tetap.
+func asRole(role mentity.RoleType) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals("role", role)
+		return c.Next()
+	}
+}
+
+func (s *TransactionHandlerTestSuite) get(path string) (*http.Response, string) {
+	resp, err := s.app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+	s.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+
+	return resp, string(body)
+}
+
+func (s *TransactionHandlerTestSuite) TestExportSummaryByCategoryAndTypeCSV() {
+	s.app.Get("/transactions/summary-by-category-type.csv", withUser(1), s.handler.ExportSummaryByCategoryAndTypeCSV)
+
+	s.Run("rows with header", func() {
+		food := "Makan"
+		s.usecase.On("GetSummaryByCategoryAndType", mock.Anything, int64(1), "2024-01-01", "2024-01-31", usecaseEntity.TransactionTypeString("")).
+			Return([]usecaseEntity.TransactionSummaryResponse{
+				{CategoryName: &food, Currency: "USD", Type: usecaseEntity.TransactionTypeExpenseStr, TotalAmount: 150000},
+				{CategoryName: &food, Currency: "IDR", Type: usecaseEntity.TransactionTypeExpenseStr, TotalAmount: 75000},
+				{CategoryName: &food, Currency: "IDR", Type: usecaseEntity.TransactionTypeIncomeStr, TotalAmount: 1500.5},
+				{CategoryName: nil, Currency: "USD", Type: usecaseEntity.TransactionTypeIncomeStr, TotalAmount: 2500.5},
+			}, nil).Once()
+
+		resp, body := s.get("/transactions/summary-by-category-type.csv?start_date=2024-01-01&end_date=2024-01-31")
+
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.Contains(resp.Header.Get(fiber.HeaderContentType), "text/csv")
+		s.Equal("category_name,currency,type,total_amount\nMakan,USD,expense,150000.00\nMakan,IDR,expense,75000\nMakan,IDR,income,1500.50\n,USD,income,2500.50\n", body)
+	})
+
+	s.Run("empty result is header only", func() {
+		s.usecase.On("GetSummaryByCategoryAndType", mock.Anything, int64(1), "2024-02-01", "2024-02-29", usecaseEntity.TransactionTypeString("")).
+			Return([]usecaseEntity.TransactionSummaryResponse{}, nil).Once()
+
+		resp, body := s.get("/transactions/summary-by-category-type.csv?start_date=2024-02-01&end_date=2024-02-29")
+
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.Equal("category_name,currency,type,total_amount\n", body)
+	})
+
+	s.Run("missing date params", func() {
+		resp, _ := s.get("/transactions/summary-by-category-type.csv?start_date=2024-02-01")
+
+		s.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
+
+func (s *TransactionHandlerTestSuite) TestExportDailySummaryCSV() {
+	s.app.Get("/transactions/summary.csv", withUser(1), s.handler.ExportDailySummaryCSV)
+
+	s.usecase.On("GetDailySummary", mock.Anything, int64(1), "2024-01-01", "2024-01-31", helper.Granularity(""), false).
+		Return(&usecaseEntity.DailySummaryResponse{
+			Granularity: "day",
+			Data: []usecaseEntity.DailySummaryRow{
+				{Day: "2024-01-05", Currency: "USD", Type: usecaseEntity.TransactionTypeExpenseStr, TotalAmount: 12000},
+			},
+		}, nil).Once()
+
+	resp, body := s.get("/transactions/summary.csv?start_date=2024-01-01&end_date=2024-01-31")
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("transaction_day,currency,type,total_amount\n2024-01-05,USD,expense,12000.00\n", body)
+}
+
+func (s *TransactionHandlerTestSuite) TestExportDailySummaryCSVZeroDecimalCurrency() {
+	s.handler = handler.NewTransactionHandler(parser.NewParser(), json.NewJsonPresenter(), csv.NewCsvPresenter(), s.usecase, config.CurrencyOption{Code: "IDR"}, middleware.RateLimit(0))
+	s.app.Get("/transactions/summary.csv", withUser(1), s.handler.ExportDailySummaryCSV)
+
+	s.usecase.On("GetDailySummary", mock.Anything, int64(1), "2024-01-01", "2024-01-31", helper.Granularity(""), false).
+		Return(&usecaseEntity.DailySummaryResponse{
+			Granularity: "day",
+			Data: []usecaseEntity.DailySummaryRow{
+				{Day: "2024-01-05", Type: usecaseEntity.TransactionTypeExpenseStr, TotalAmount: 1500},
+			},
+		}, nil).Once()
+
+	_, body := s.get("/transactions/summary.csv?start_date=2024-01-01&end_date=2024-01-31")
+
+	s.Equal("transaction_day,currency,type,total_amount\n2024-01-05,,expense,1500\n", body)
+}
+
+func (s *TransactionHandlerTestSuite) TestGetDailySummaryDateRange() {
+	s.app.Get("/transactions/summary", withUser(1), s.handler.GetDailySummary)
+
+	s.Run("period together with dates is rejected", func() {
+		resp, _ := s.get("/transactions/summary?period=this_month&start_date=2024-01-01&end_date=2024-01-31")
+		s.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+
+		resp, _ = s.get("/transactions/summary?period=this_month&end_date=2024-01-31")
+		s.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	s.Run("unknown period is rejected", func() {
+		resp, _ := s.get("/transactions/summary?period=next_decade")
+		s.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	s.Run("period alone", func() {
+		start, end, _ := helper.PeriodRange(helper.PeriodThisMonth, helper.DatetimeNowJakarta())
+		s.usecase.On("GetDailySummary", mock.Anything, int64(1), start.Format(helper.DateLayout), end.Format(helper.DateLayout), helper.Granularity(""), false).
+			Return(&usecaseEntity.DailySummaryResponse{Granularity: "day"}, nil).Once()
+
+		resp, _ := s.get("/transactions/summary?period=this_month")
+		s.Equal(http.StatusOK, resp.StatusCode)
+	})
+
+	s.Run("dates alone", func() {
+		s.usecase.On("GetDailySummary", mock.Anything, int64(1), "2024-01-01", "2024-01-31", helper.Granularity(""), false).
+			Return(&usecaseEntity.DailySummaryResponse{Granularity: "day"}, nil).Once()
+
+		resp, _ := s.get("/transactions/summary?start_date=2024-01-01&end_date=2024-01-31")
+		s.Equal(http.StatusOK, resp.StatusCode)
+	})
+
+	s.usecase.AssertExpectations(s.T())
+}
+
+func (s *TransactionHandlerTestSuite) TestGetDailySummaryNumericJSON() {
+	s.app.Get("/transactions/summary", withUser(1), s.handler.GetDailySummary)
+
+	s.usecase.On("GetDailySummary", mock.Anything, int64(1), "2024-01-01", "2024-01-31", helper.Granularity(""), false).
+		Return(&usecaseEntity.DailySummaryResponse{
+			Granularity: "day",
+			Data: []usecaseEntity.DailySummaryRow{
+				{Day: "2024-01-05", Type: usecaseEntity.TransactionTypeExpenseStr, TotalAmount: 12000.5},
+			},
+		}, nil).Once()
+
+	resp, body := s.get("/transactions/summary?start_date=2024-01-01&end_date=2024-01-31")
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var decoded struct {
+		Data struct {
+			Data []map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	s.Require().NoError(encjson.Unmarshal([]byte(body), &decoded))
+	s.Require().Len(decoded.Data.Data, 1)
+
+	row := decoded.Data.Data[0]
+	s.Equal("2024-01-05", row["transaction_day"])
+	s.Equal("expense", row["type"])
+	s.IsType(float64(0), row["total_amount"], "total_amount must be a JSON number")
+	s.Equal(12000.5, row["total_amount"])
+}
+
+func (s *TransactionHandlerTestSuite) TestGetBalancePerCurrency() {
+	s.app.Get("/transactions/balance", withUser(1), s.handler.GetBalance)
+
+	s.usecase.On("GetBalance", mock.Anything, int64(1), "2024-01-01", "2024-01-31", false).
+		Return([]usecaseEntity.BalanceResponse{
+			{Currency: "IDR", TotalIncome: 500000, TotalExpense: 150000, Net: 350000},
+			{Currency: "USD", TotalExpense: 42.5, Net: -42.5},
+		}, nil).Once()
+
+	resp, body := s.get("/transactions/balance?start_date=2024-01-01&end_date=2024-01-31")
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var decoded struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	s.Require().NoError(encjson.Unmarshal([]byte(body), &decoded))
+	s.Require().Len(decoded.Data, 2)
+
+	s.Equal("IDR", decoded.Data[0]["currency"])
+	s.Equal(350000.0, decoded.Data[0]["net"])
+	s.Equal("USD", decoded.Data[1]["currency"])
+	s.Equal(-42.5, decoded.Data[1]["net"])
+	s.usecase.AssertExpectations(s.T())
+}
+
+func (s *TransactionHandlerTestSuite) TestListPaginationMeta() {
+	s.app.Get("/transactions/paged", withUser(1), s.handler.List)
+
+	s.usecase.On("List", mock.Anything, int64(1), mock.MatchedBy(func(req usecaseEntity.TransactionListReq) bool {
+		return req.Page == 2 && req.PerPage == 10
+	})).Return(&usecaseEntity.TransactionListResponse{
+		Data: []usecaseEntity.TransactionResponse{{ID: 11}, {ID: 12}},
+		Meta: generalEntity.PaginationMeta{Total: 25, Page: 2, PerPage: 10, TotalPages: 3, HasNext: true},
+	}, nil).Once()
+
+	resp, body := s.get("/transactions/paged?page=2&per_page=10")
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var decoded struct {
+		Data []map[string]interface{} `json:"data"`
+		Meta map[string]interface{}   `json:"meta"`
+	}
+	s.Require().NoError(encjson.Unmarshal([]byte(body), &decoded))
+	s.Len(decoded.Data, 2)
+	s.Equal(map[string]interface{}{"total": 25.0, "page": 2.0, "per_page": 10.0, "total_pages": 3.0, "has_next": true}, decoded.Meta)
+	s.usecase.AssertExpectations(s.T())
+}
+
+func (s *TransactionHandlerTestSuite) TestGetAllFilters() {
+	s.app.Get("/transactions", withUser(1), s.handler.GetAll)
+	s.usecase.On("GetAll", mock.Anything, int64(1), mock.MatchedBy(func(req usecaseEntity.TransactionCursorReq) bool {
+		return req.By == "created_at" && req.Cursor == "eyJpIjo5fQ" && req.Metadata["trip"] == "bali"
+	})).Return(&usecaseEntity.TransactionCursorResponse{Data: []usecaseEntity.TransactionResponse{}}, nil).Once()
+
+	resp, _ := s.get("/transactions?cursor=eyJpIjo5fQ&by=created_at&meta.trip=bali")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.usecase.AssertExpectations(s.T())
+}
+
+func (s *TransactionHandlerTestSuite) TestGetAllRejectsPage() {
+	s.app.Get("/transactions", withUser(1), s.handler.GetAll)
+
+	resp, _ := s.get("/transactions?page=2")
+	s.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	s.usecase.AssertNotCalled(s.T(), "GetAll", mock.Anything, mock.Anything, mock.Anything)
+	s.usecase.AssertNotCalled(s.T(), "List", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TransactionHandlerTestSuite) TestListDefaultsToFirstPage() {
+	s.app.Get("/transactions/paged", withUser(1), s.handler.List)
+	s.usecase.On("List", mock.Anything, int64(1), mock.MatchedBy(func(req usecaseEntity.TransactionListReq) bool {
+		return req.Page == 1 && req.Metadata["trip"] == "bali"
+	})).Return(&usecaseEntity.TransactionListResponse{Data: []usecaseEntity.TransactionResponse{}}, nil).Once()
+
+	resp, _ := s.get("/transactions/paged?meta.trip=bali")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.usecase.AssertExpectations(s.T())
+}
+
+func (s *TransactionHandlerTestSuite) TestGetAllIncludeDeleted() {
+	s.Run("non-admin is forbidden", func() {
+		s.SetupTest()
+		s.app.Get("/transactions", withUser(1), asRole(mentity.RoleTypeUser), s.handler.GetAll)
+		s.app.Get("/transactions/paged", withUser(1), asRole(mentity.RoleTypeUser), s.handler.List)
+
+		resp, _ := s.get("/transactions?include_deleted=true")
+		s.Equal(http.StatusForbidden, resp.StatusCode)
+
+		resp, _ = s.get("/transactions/paged?page=1&include_deleted=true")
+		s.Equal(http.StatusForbidden, resp.StatusCode)
+		s.usecase.AssertNotCalled(s.T(), "GetAll", mock.Anything, mock.Anything, mock.Anything)
+		s.usecase.AssertNotCalled(s.T(), "List", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("admin sees deleted rows", func() {
+		s.SetupTest()
+		s.app.Get("/transactions", withUser(1), asRole(mentity.RoleTypeAdmin), s.handler.GetAll)
+		s.usecase.On("GetAll", mock.Anything, int64(1), mock.MatchedBy(func(req usecaseEntity.TransactionCursorReq) bool {
+			return req.IncludeDeleted
+		})).Return(&usecaseEntity.TransactionCursorResponse{Data: []usecaseEntity.TransactionResponse{}}, nil).Once()
+
+		resp, _ := s.get("/transactions?include_deleted=true")
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.usecase.AssertExpectations(s.T())
+	})
+
+	s.Run("invalid value", func() {
+		s.SetupTest()
+		s.app.Get("/transactions", withUser(1), asRole(mentity.RoleTypeAdmin), s.handler.GetAll)
+
+		resp, _ := s.get("/transactions?include_deleted=maybe")
+		s.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
+
+func (s *TransactionHandlerTestSuite) TestImportCSVUpload() {
+	upload := func(fields map[string]string, content string) *http.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		for key, value := range fields {
+			s.Require().NoError(writer.WriteField(key, value))
+		}
+		if content != "" {
+			part, err := writer.CreateFormFile("file", "transactions.csv")
+			s.Require().NoError(err)
+			_, err = part.Write([]byte(content))
+			s.Require().NoError(err)
+		}
+		s.Require().NoError(writer.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/transactions/import", body)
+		req.Header.Set(fiber.HeaderContentType, writer.FormDataContentType())
+		return req
+	}
+
+	s.Run("multipart file goes to the CSV import", func() {
+		s.SetupTest()
+		s.app.Post("/transactions/import", withUser(1), s.handler.Import)
+		content := "date,type,category_name,amount,description\n2024-01-05,expense,Makan,15000,\n"
+		s.usecase.On("ImportCSV", mock.Anything, int64(1), mock.MatchedBy(func(file io.Reader) bool {
+			data, err := io.ReadAll(file)
+			return err == nil && string(data) == content
+		}), true).Return(&usecaseEntity.ImportCSVResponse{Imported: 1, Errors: []usecaseEntity.ImportRowError{}}, nil).Once()
+
+		resp, err := s.app.Test(upload(map[string]string{"create_missing": "true"}, content))
+		s.Require().NoError(err)
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.usecase.AssertExpectations(s.T())
+	})
+
+	s.Run("missing file", func() {
+		s.SetupTest()
+		s.app.Post("/transactions/import", withUser(1), s.handler.Import)
+
+		resp, err := s.app.Test(upload(nil, ""))
+		s.Require().NoError(err)
+		s.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+		s.usecase.AssertNotCalled(s.T(), "ImportCSV", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *TransactionHandlerTestSuite) TestExportStreamsLargeResult() {
+	s.app.Get("/transactions/export", withUser(1), s.handler.Export)
+
+	const total = 50000
+	s.usecase.On("StreamAll", mock.Anything, int64(1), usecaseEntity.TransactionExportReq{}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(3).(func(usecaseEntity.TransactionResponse) error)
+			for i := 1; i <= total; i++ {
+				s.Require().NoError(fn(usecaseEntity.TransactionResponse{ID: int64(i), Type: usecaseEntity.TransactionTypeExpenseStr, Amount: 1000}))
+			}
+		}).Return(nil).Once()
+
+	resp, body := s.get("/transactions/export")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(resp.Header.Get(fiber.HeaderContentType), "application/json")
+
+	var decoded struct {
+		Data    []usecaseEntity.TransactionResponse `json:"data"`
+		Message string                              `json:"message"`
+		Code    string                              `json:"code"`
+	}
+	s.Require().NoError(encjson.Unmarshal([]byte(body), &decoded))
+	s.Require().Len(decoded.Data, total)
+	s.Equal(int64(1), decoded.Data[0].ID)
+	s.Equal(int64(total), decoded.Data[total-1].ID)
+	s.Equal("Transactions exported successfully", decoded.Message)
+	s.NotEmpty(decoded.Code)
+}
+
+func (s *TransactionHandlerTestSuite) TestExportEmptyResult() {
+	s.app.Get("/transactions/export", withUser(1), s.handler.Export)
+
+	s.usecase.On("StreamAll", mock.Anything, int64(1), usecaseEntity.TransactionExportReq{}, mock.Anything).Return(nil).Once()
+
+	resp, body := s.get("/transactions/export")
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	var decoded struct {
+		Data []usecaseEntity.TransactionResponse `json:"data"`
+	}
+	s.Require().NoError(encjson.Unmarshal([]byte(body), &decoded))
+	s.NotNil(decoded.Data)
+	s.Empty(decoded.Data)
+}
+
+func (s *TransactionHandlerTestSuite) TestExportCSV() {
+	s.app.Get("/transactions/export.csv", withUser(1), s.handler.ExportCSV)
+
+	s.Run("streams rows with escaped description", func() {
+		food := "Makan"
+		description := `Makan "siang", kantor`
+		req := usecaseEntity.TransactionExportReq{StartDate: "2024-01-01", EndDate: "2024-01-31"}
+		s.usecase.On("StreamAll", mock.Anything, int64(1), req, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(3).(func(usecaseEntity.TransactionResponse) error)
+				s.Require().NoError(fn(usecaseEntity.TransactionResponse{ID: 7, TransactionDate: "2024-01-05", Type: usecaseEntity.TransactionTypeExpenseStr,
+					CategoryName: &food, Amount: 12000.5, Currency: "USD", Description: &description}))
+				s.Require().NoError(fn(usecaseEntity.TransactionResponse{ID: 6, TransactionDate: "2024-01-03", Type: usecaseEntity.TransactionTypeIncomeStr, Amount: 500, Currency: "IDR"}))
+			}).Return(nil).Once()
+
+		resp, body := s.get("/transactions/export.csv?start_date=2024-01-01&end_date=2024-01-31")
+
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.Contains(resp.Header.Get(fiber.HeaderContentType), "text/csv")
+		s.Equal(`attachment; filename="transactions_2024-01-01_2024-01-31.csv"`, resp.Header.Get(fiber.HeaderContentDisposition))
+		s.Equal("id,date,type,category_name,amount,currency,description\n"+
+			"7,2024-01-05,expense,Makan,12000.50,USD,\"Makan \"\"siang\"\", kantor\"\n"+
+			"6,2024-01-03,income,,500,IDR,\n", body)
+	})
+
+	s.Run("invalid date is rejected before streaming", func() {
+		resp, _ := s.get("/transactions/export.csv?start_date=2024-13-01&end_date=2024-01-31")
+
+		s.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+		s.Contains(resp.Header.Get(fiber.HeaderContentType), "application/json")
+	})
+
+	s.usecase.AssertExpectations(s.T())
+}
+
+func (s *TransactionHandlerTestSuite) TestGetByID() {
+	s.app.Get("/transactions/:id", withUser(1), s.handler.GetByID)
+
+	s.Run("own transaction", func() {
+		categoryName := "Makan"
+		s.usecase.On("GetByID", mock.Anything, int64(5), int64(1)).
+			Return(&usecaseEntity.TransactionResponse{ID: 5, UserID: 1, CategoryName: &categoryName}, nil).Once()
+
+		resp, body := s.get("/transactions/5")
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.Contains(body, `"category_name":"Makan"`)
+	})
+
+	s.Run("transaction of another user", func() {
+		s.usecase.On("GetByID", mock.Anything, int64(6), int64(1)).Return(nil, apperr.ErrRecordNotFound()).Once()
+
+		resp, _ := s.get("/transactions/6")
+		s.Equal(http.StatusNotFound, resp.StatusCode)
+	})
+
+	s.Run("invalid id", func() {
+		resp, _ := s.get("/transactions/abc")
+		s.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	s.usecase.AssertExpectations(s.T())
+}
+
+func (s *TransactionHandlerTestSuite) TestUpdateStaleVersion() {
+	s.app.Put("/transactions/:id", withUser(1), s.handler.Update)
+
+	s.usecase.On("Update", mock.Anything, int64(20), int64(1), mock.MatchedBy(func(req usecaseEntity.TransactionReq) bool {
+		return req.Version != nil && *req.Version == 3
+	})).Return(apperr.ErrConflict().SetDetail("Transaction was modified by another request")).Once()
+
+	req := httptest.NewRequest(http.MethodPut, "/transactions/20", bytes.NewBufferString(`{"amount": 17500, "type": "expense", "transaction_date": "2024-01-05", "version": 3}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := s.app.Test(req)
+	s.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+	s.Equal(http.StatusConflict, resp.StatusCode)
+	s.Contains(string(body), "Transaction was modified by another request")
+}
+
+func (s *TransactionHandlerTestSuite) TestCleanupOrphans() {
+	post := func(path string) (*http.Response, string) {
+		resp, err := s.app.Test(httptest.NewRequest(http.MethodPost, path, nil))
+		s.Require().NoError(err)
+		body, err := io.ReadAll(resp.Body)
+		s.Require().NoError(err)
+		return resp, string(body)
+	}
+	register := func(role mentity.RoleType) {
+		s.SetupTest()
+		s.app.Post("/transactions/cleanup-orphans", withUser(1), asRole(role), middleware.RequireRole(mentity.RoleNameAdmin), s.handler.CleanupOrphans)
+	}
+
+	s.Run("admin fixes rows of the target user", func() {
+		register(mentity.RoleTypeAdmin)
+		s.usecase.On("CleanupOrphanedCategories", mock.Anything, int64(7), false).
+			Return(&usecaseEntity.OrphanCleanupResponse{UserID: 7, Orphaned: 3, Fixed: 3}, nil).Once()
+
+		resp, body := post("/transactions/cleanup-orphans?user_id=7")
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.Contains(body, `"fixed":3`)
+		s.usecase.AssertExpectations(s.T())
+	})
+
+	s.Run("dry run", func() {
+		register(mentity.RoleTypeAdmin)
+		s.usecase.On("CleanupOrphanedCategories", mock.Anything, int64(7), true).
+			Return(&usecaseEntity.OrphanCleanupResponse{UserID: 7, DryRun: true, Orphaned: 3}, nil).Once()
+
+		resp, _ := post("/transactions/cleanup-orphans?user_id=7&dry_run=true")
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.usecase.AssertExpectations(s.T())
+	})
+
+	s.Run("non admin is forbidden", func() {
+		register(mentity.RoleTypeUser)
+
+		resp, _ := post("/transactions/cleanup-orphans?user_id=7")
+		s.Equal(http.StatusForbidden, resp.StatusCode)
+		s.usecase.AssertNotCalled(s.T(), "CleanupOrphanedCategories", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("missing user_id", func() {
+		register(mentity.RoleTypeAdmin)
+
+		resp, _ := post("/transactions/cleanup-orphans")
+		s.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
+
+func (s *TransactionHandlerTestSuite) TestGetDateRange() {
+	s.Run("empty range is null", func() {
+		s.SetupTest()
+		s.app.Get("/transactions/date-range", withUser(1), s.handler.GetDateRange)
+		s.usecase.On("GetDateRange", mock.Anything, int64(1)).Return(&usecaseEntity.DateRangeResponse{}, nil).Once()
+
+		resp, body := s.get("/transactions/date-range")
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.Contains(body, `"earliest":null`)
+		s.Contains(body, `"latest":null`)
+	})
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/http/middleware"
+	"github.com/rakahikmah/finance-tracking/internal/parser"
+	"github.com/rakahikmah/finance-tracking/internal/presenter/json"
+	budget_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/budget"
+	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/budget/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// BudgetHandler adalah handler HTTP untuk budget bulanan per kategori.
+type BudgetHandler struct {
+	parser        parser.Parser
+	presenter     json.JsonPresenter
+	BudgetUsecase budget_usecase.ICrudBudget
+}
+
+// NewBudgetHandler adalah konstruktor untuk BudgetHandler.
+func NewBudgetHandler(
+	parser parser.Parser,
+	presenter json.JsonPresenter,
+	BudgetUsecase budget_usecase.ICrudBudget,
+) *BudgetHandler {
+	return &BudgetHandler{parser, presenter, BudgetUsecase}
+}
+
+// Register mendaftarkan rute-rute API untuk budget.
+func (h *BudgetHandler) Register(app fiber.Router) {
+	app.Post("/budgets", middleware.VerifyJWTToken, h.Create)
+	app.Get("/budgets", middleware.VerifyJWTToken, h.GetAll)
+	app.Get("/budgets/status", middleware.VerifyJWTToken, h.GetStatus)
+	app.Get("/budgets/overspent", middleware.VerifyJWTToken, h.GetOverspent)
+	app.Put("/budgets/:id", middleware.VerifyJWTToken, h.Update)
+	app.Delete("/budgets/:id", middleware.VerifyJWTToken, h.Delete)
+}
+
+// monthQuery mengambil query param month (YYYY-MM), default bulan berjalan di zona waktu Jakarta.
+func monthQuery(c *fiber.Ctx) string {
+	if month := c.Query("month"); month != "" {
+		return month
+	}
+	return helper.DatetimeNowJakarta().Format(helper.MonthLayout)
+}
+
+// Create menangani permintaan POST untuk membuat budget baru.
+func (h *BudgetHandler) Create(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	var req usecaseEntity.BudgetReq
+	if err := h.parser.ParserBodyRequestWithUserID(c, &req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.BudgetUsecase.Create(c.UserContext(), userID, req)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Budget created successfully", http.StatusCreated)
+}
+
+// GetAll menangani permintaan GET untuk mendapatkan semua budget user pada satu bulan.
+func (h *BudgetHandler) GetAll(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	result, err := h.BudgetUsecase.GetAll(c.UserContext(), userID, monthQuery(c))
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Budgets retrieved successfully", http.StatusOK)
+}
+
+// GetStatus menangani permintaan GET untuk membandingkan budget dengan pengeluaran satu bulan.
+func (h *BudgetHandler) GetStatus(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	result, err := h.BudgetUsecase.GetBudgetStatus(c.UserContext(), userID, monthQuery(c))
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Budget status retrieved successfully", http.StatusOK)
+}
+
+// GetOverspent menangani permintaan GET untuk daftar budget yang terlampaui pada satu bulan (month, default bulan berjalan).
+func (h *BudgetHandler) GetOverspent(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	result, err := h.BudgetUsecase.GetOverspent(c.UserContext(), userID, monthQuery(c))
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Overspent budgets retrieved successfully", http.StatusOK)
+}
+
+// Update menangani permintaan PUT untuk mengubah batas budget.
+func (h *BudgetHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid budget ID format."))
+	}
+
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	var req usecaseEntity.UpdateBudgetReq
+	if err := h.parser.ParserBodyRequestWithUserID(c, &req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	if err := h.BudgetUsecase.Update(c.UserContext(), id, userID, req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, nil, "Budget updated successfully", http.StatusOK)
+}
+
+// Delete menangani permintaan DELETE untuk menghapus budget.
+func (h *BudgetHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid budget ID format."))
+	}
+
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	if err := h.BudgetUsecase.Delete(c.UserContext(), id, userID); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, nil, "Budget deleted successfully", http.StatusOK)
+}
@@ -19,6 +19,7 @@ type CategoryHandler struct {
 	parser              parser.Parser
 	presenter           json.JsonPresenter
 	CrudCategoryUsecase category_usecase.ICrudCategory // Menggunakan interface usecase Category
+	writeLimit          fiber.Handler                  // Rate limit per user untuk rute create/update/delete
 }
 
 // NewCategoryHandler adalah konstruktor untuk CategoryHandler.
@@ -26,17 +27,23 @@ func NewCategoryHandler(
 	parser parser.Parser,
 	presenter json.JsonPresenter,
 	CrudCategoryUsecase category_usecase.ICrudCategory,
+	writeLimit fiber.Handler,
 ) *CategoryHandler {
-	return &CategoryHandler{parser, presenter, CrudCategoryUsecase}
+	return &CategoryHandler{parser, presenter, CrudCategoryUsecase, writeLimit}
 }
 
 // Register mendaftarkan rute-rute API untuk Category.
 func (h *CategoryHandler) Register(app fiber.Router) {
-	// Semua rute ini akan memerlukan otentikasi JWT
-	app.Post("/categories", middleware.VerifyJWTToken, h.Create)
+	// Semua rute ini akan memerlukan otentikasi JWT, rute yang mengubah data juga dibatasi writeLimit
+	app.Post("/categories", middleware.VerifyJWTToken, h.writeLimit, h.Create)
+	app.Post("/categories/with-transaction", middleware.VerifyJWTToken, h.writeLimit, h.CreateWithTransaction)
 	app.Get("/categories", middleware.VerifyJWTToken, h.GetAll)
-	app.Put("/categories/:id", middleware.VerifyJWTToken, h.Update)    // Tambahkan middleware JWT untuk Update
-	app.Delete("/categories/:id", middleware.VerifyJWTToken, h.Delete) // Tambahkan middleware JWT untuk Delete
+	app.Get("/categories/tree", middleware.VerifyJWTToken, h.GetTree)
+	app.Get("/categories/:id", middleware.VerifyJWTToken, h.GetByID)
+	app.Put("/categories/:id", middleware.VerifyJWTToken, h.writeLimit, h.Update)    // Tambahkan middleware JWT untuk Update
+	app.Delete("/categories/:id", middleware.VerifyJWTToken, h.writeLimit, h.Delete) // Tambahkan middleware JWT untuk Delete
+	app.Get("/categories/:id/delete-impact", middleware.VerifyJWTToken, h.GetDeleteImpact)
+	app.Post("/categories/:id/merge", middleware.VerifyJWTToken, h.writeLimit, h.Merge)
 }
 
 // Create menangani permintaan POST untuk membuat kategori baru.
@@ -55,7 +62,7 @@ func (h *CategoryHandler) Create(c *fiber.Ctx) error {
 	}
 
 	// Memanggil usecase.Create dengan userID sebagai parameter terpisah
-	err = h.CrudCategoryUsecase.Create(c.Context(), userID, req)
+	err = h.CrudCategoryUsecase.Create(c.UserContext(), userID, req)
 	if err != nil {
 		return h.presenter.BuildError(c, err)
 	}
@@ -63,7 +70,32 @@ func (h *CategoryHandler) Create(c *fiber.Ctx) error {
 	return h.presenter.BuildSuccess(c, nil, "Category created successfully", http.StatusCreated)
 }
 
+// CreateWithTransaction menangani permintaan POST untuk membuat kategori baru beserta transaksi pertamanya.
+// Keduanya disimpan dalam satu DB transaction, jika transaksi gagal kategori juga tidak tersimpan.
+func (h *CategoryHandler) CreateWithTransaction(c *fiber.Ctx) error {
+	var req usecaseEntity.CategoryWithTransactionReq
+
+	err := h.parser.ParserBodyRequestWithUserID(c, &req)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context."))
+	}
+
+	result, err := h.CrudCategoryUsecase.CreateCategoryWithTransaction(c.UserContext(), userID, req.Category, req.Transaction)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Category and transaction created successfully", http.StatusCreated)
+}
+
 // GetAll menangani permintaan GET untuk mendapatkan semua kategori user.
+// Tanpa query param semua kategori dikembalikan seperti sebelumnya; akun dengan banyak kategori sebaiknya memakai
+// limit, offset, dan q (pencarian nama) yang mengembalikan satu halaman beserta meta total.
 func (h *CategoryHandler) GetAll(c *fiber.Ctx) error {
 	// Ambil userID dari Fiber context
 	userID, ok := c.Locals("user_id").(int64)
@@ -71,8 +103,12 @@ func (h *CategoryHandler) GetAll(c *fiber.Ctx) error {
 		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context."))
 	}
 
+	if c.Query("limit") != "" || c.Query("offset") != "" || c.Query("q") != "" {
+		return h.list(c, userID)
+	}
+
 	// Memanggil usecase.GetAll dengan userID
-	result, err := h.CrudCategoryUsecase.GetAll(c.Context(), userID)
+	result, err := h.CrudCategoryUsecase.GetAll(c.UserContext(), userID)
 	if err != nil {
 		return h.presenter.BuildError(c, err)
 	}
@@ -80,6 +116,45 @@ func (h *CategoryHandler) GetAll(c *fiber.Ctx) error {
 	return h.presenter.BuildSuccess(c, result, "Categories retrieved successfully", http.StatusOK)
 }
 
+// list menangani GET /categories dengan limit, offset, atau q.
+func (h *CategoryHandler) list(c *fiber.Ctx, userID int64) error {
+	req := usecaseEntity.CategoryListReq{Query: c.Query("q")}
+
+	var err error
+	if value := c.Query("limit"); value != "" {
+		if req.Limit, err = strconv.Atoi(value); err != nil {
+			return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid limit format."))
+		}
+	}
+	if value := c.Query("offset"); value != "" {
+		if req.Offset, err = strconv.Atoi(value); err != nil {
+			return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid offset format."))
+		}
+	}
+
+	result, err := h.CrudCategoryUsecase.List(c.UserContext(), userID, req)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccessWithMeta(c, result.Data, result.Meta, "Categories retrieved successfully", http.StatusOK)
+}
+
+// GetTree menangani permintaan GET untuk kategori user dalam bentuk pohon (kategori induk beserta subkategorinya).
+func (h *CategoryHandler) GetTree(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context."))
+	}
+
+	result, err := h.CrudCategoryUsecase.GetTree(c.UserContext(), userID)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Category tree retrieved successfully", http.StatusOK)
+}
+
 // Update menangani permintaan PUT untuk memperbarui kategori.
 func (h *CategoryHandler) Update(c *fiber.Ctx) error {
 	// Ambil ID kategori dari parameter URL
@@ -102,7 +177,7 @@ func (h *CategoryHandler) Update(c *fiber.Ctx) error {
 	}
 
 	// Memanggil usecase.Update dengan ID kategori dan userID
-	err = h.CrudCategoryUsecase.Update(c.Context(), id, userID, req)
+	err = h.CrudCategoryUsecase.Update(c.UserContext(), id, userID, req)
 	if err != nil {
 		return h.presenter.BuildError(c, err)
 	}
@@ -125,10 +200,75 @@ func (h *CategoryHandler) Delete(c *fiber.Ctx) error {
 	}
 
 	// Memanggil usecase.Delete dengan ID kategori dan userID
-	err = h.CrudCategoryUsecase.Delete(c.Context(), id, userID)
+	err = h.CrudCategoryUsecase.Delete(c.UserContext(), id, userID)
 	if err != nil {
 		return h.presenter.BuildError(c, err)
 	}
 
 	return h.presenter.BuildSuccess(c, nil, "Category deleted successfully", http.StatusOK)
 }
+
+// GetByID menangani permintaan GET untuk detail satu kategori milik user.
+func (h *CategoryHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid category ID format."))
+	}
+
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context."))
+	}
+
+	result, err := h.CrudCategoryUsecase.GetByID(c.UserContext(), id, userID)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Category retrieved successfully", http.StatusOK)
+}
+
+// Merge menangani permintaan POST untuk menggabungkan kategori ke kategori target.
+func (h *CategoryHandler) Merge(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid category ID format."))
+	}
+
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context."))
+	}
+
+	var req usecaseEntity.CategoryMergeReq
+	if err := h.parser.ParserBodyRequest(c, &req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.CrudCategoryUsecase.Merge(c.UserContext(), id, userID, req)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Category merged successfully", http.StatusOK)
+}
+
+// GetDeleteImpact menangani permintaan GET untuk pratinjau dampak penghapusan kategori.
+func (h *CategoryHandler) GetDeleteImpact(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid category ID format."))
+	}
+
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context."))
+	}
+
+	result, err := h.CrudCategoryUsecase.GetDeleteImpact(c.UserContext(), id, userID)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Category delete impact retrieved successfully", http.StatusOK)
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+// dateRangeQuery membaca rentang tanggal dari query string untuk endpoint summary dan report.
+//
+// Aturan:
+//   - `period` (this_month, last_month, this_year, last_7_days, last_30_days) dan `start_date`/`end_date`
+//     saling eksklusif. Jika period dikirim bersama salah satu tanggal, request ditolak (422), tidak ada prioritas.
+//   - Jika hanya period, rentang dihitung dari tanggal hari ini di zona Asia/Jakarta.
+//   - Jika tanpa period, start_date dan end_date wajib diisi keduanya.
+func dateRangeQuery(c *fiber.Ctx) (startDate string, endDate string, err error) {
+	period := c.Query("period")
+	startDate = c.Query("start_date")
+	endDate = c.Query("end_date")
+
+	if period != "" {
+		if startDate != "" || endDate != "" {
+			return "", "", apperr.ErrInvalidRequest().SetDetail("period cannot be combined with start_date or end_date.")
+		}
+
+		start, end, ok := helper.PeriodRange(helper.Period(period), helper.DatetimeNowJakarta())
+		if !ok {
+			return "", "", apperr.ErrInvalidRequest().SetDetail("Invalid period. Use this_month, last_month, this_year, last_7_days, or last_30_days.")
+		}
+
+		return start.Format(helper.DateLayout), end.Format(helper.DateLayout), nil
+	}
+
+	if startDate == "" || endDate == "" {
+		return "", "", apperr.ErrInvalidRequest().SetDetail("start_date and end_date query parameters (or period) are required.")
+	}
+
+	return startDate, endDate, nil
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
+)
+
+// HealthHandler adalah handler HTTP untuk readiness probe.
+type HealthHandler struct {
+	healthUsecase usecase.HealthUsecase
+}
+
+// NewHealthHandler adalah konstruktor untuk HealthHandler.
+func NewHealthHandler(healthUsecase usecase.HealthUsecase) *HealthHandler {
+	return &HealthHandler{healthUsecase}
+}
+
+// Register mendaftarkan rute health. Rute ini tidak memakai JWT agar bisa dipanggil oleh probe.
+func (h *HealthHandler) Register(app fiber.Router) {
+	app.Get("/health", h.Check)
+}
+
+// Check mengembalikan 200 jika semua dependency bisa dihubungi dan 503 beserta dependency yang gagal jika tidak.
+// Response ditulis langsung tanpa presenter agar bentuknya tetap sederhana untuk probe.
+func (h *HealthHandler) Check(c *fiber.Ctx) error {
+	result := h.healthUsecase.Check(c.UserContext())
+	if !result.Healthy() {
+		return c.Status(http.StatusServiceUnavailable).JSON(result)
+	}
+
+	return c.Status(http.StatusOK).JSON(result)
+}
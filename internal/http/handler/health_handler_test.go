@@ -0,0 +1,71 @@
+package handler_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/internal/http/handler"
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthHandlerTestSuite struct {
+	suite.Suite
+	mysql   *mocks.HealthChecker
+	mongodb *mocks.HealthChecker
+	app     *fiber.App
+}
+
+func (s *HealthHandlerTestSuite) SetupTest() {
+	s.mysql = &mocks.HealthChecker{}
+	s.mongodb = &mocks.HealthChecker{}
+	health := usecase.NewHealth(time.Second,
+		usecase.HealthDependency{Name: "mysql", Checker: s.mysql},
+		usecase.HealthDependency{Name: "mongodb", Checker: s.mongodb},
+	)
+
+	s.app = fiber.New()
+	handler.NewHealthHandler(health).Register(s.app)
+}
+
+func TestHealthHandler(t *testing.T) {
+	suite.Run(t, new(HealthHandlerTestSuite))
+}
+
+func (s *HealthHandlerTestSuite) get() (*http.Response, string) {
+	resp, err := s.app.Test(httptest.NewRequest(http.MethodGet, "/health", nil))
+	s.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+
+	return resp, string(body)
+}
+
+func (s *HealthHandlerTestSuite) TestHealthy() {
+	s.mysql.On("Ping", mock.Anything).Return(nil).Once()
+	s.mongodb.On("Ping", mock.Anything).Return(nil).Once()
+
+	resp, body := s.get()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`{"status":"ok","dependencies":{"mysql":"ok","mongodb":"ok"}}`, body)
+}
+
+func (s *HealthHandlerTestSuite) TestMongoUnavailable() {
+	s.mysql.On("Ping", mock.Anything).Return(nil).Once()
+	s.mongodb.On("Ping", mock.Anything).Return(errors.New("server selection timeout")).Once()
+
+	resp, body := s.get()
+
+	s.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+	s.JSONEq(`{"status":"unavailable","dependencies":{"mysql":"ok","mongodb":"unavailable"}}`, body)
+	s.NotContains(body, "server selection timeout")
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/internal/http/middleware"
+	"github.com/rakahikmah/finance-tracking/internal/parser"
+	"github.com/rakahikmah/finance-tracking/internal/presenter/json"
+	transactions_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/transactions"
+	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// ImportJobHandler adalah handler HTTP untuk import transaksi besar yang diproses di background.
+type ImportJobHandler struct {
+	parser           parser.Parser
+	presenter        json.JsonPresenter
+	ImportJobUsecase transactions_usecase.IImportJob
+}
+
+// NewImportJobHandler adalah konstruktor untuk ImportJobHandler.
+func NewImportJobHandler(
+	parser parser.Parser,
+	presenter json.JsonPresenter,
+	ImportJobUsecase transactions_usecase.IImportJob,
+) *ImportJobHandler {
+	return &ImportJobHandler{parser, presenter, ImportJobUsecase}
+}
+
+// Register mendaftarkan rute-rute API untuk job import.
+func (h *ImportJobHandler) Register(app fiber.Router) {
+	app.Post("/import/jobs", middleware.VerifyJWTToken, h.Create)
+	app.Get("/import/jobs/:id", middleware.VerifyJWTToken, h.GetByID)
+	app.Post("/import/jobs/:id/resume", middleware.VerifyJWTToken, h.Resume)
+}
+
+// Create menangani permintaan POST untuk membuat job import. Body sama dengan POST /transactions/import,
+// baris divalidasi langsung dan disimpan oleh worker di background.
+func (h *ImportJobHandler) Create(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	var req usecaseEntity.ImportTransactionReq
+	if err := h.parser.ParserBodyRequestWithUserID(c, &req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.ImportJobUsecase.Create(c.UserContext(), userID, req, c.QueryBool("create_categories", false))
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Import job created successfully", http.StatusAccepted)
+}
+
+// GetByID menangani permintaan GET untuk status dan progress job import.
+func (h *ImportJobHandler) GetByID(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid import job ID format."))
+	}
+
+	result, err := h.ImportJobUsecase.GetByID(c.UserContext(), id, userID)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Import job retrieved successfully", http.StatusOK)
+}
+
+// Resume menangani permintaan POST untuk melanjutkan job import yang gagal dari baris terakhir yang tersimpan.
+func (h *ImportJobHandler) Resume(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid import job ID format."))
+	}
+
+	result, err := h.ImportJobUsecase.Resume(c.UserContext(), id, userID)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Import job resumed successfully", http.StatusAccepted)
+}
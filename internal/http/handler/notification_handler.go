@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/internal/http/middleware"
+	"github.com/rakahikmah/finance-tracking/internal/parser"
+	"github.com/rakahikmah/finance-tracking/internal/presenter/json"
+	notification_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/notification"
+	notificationEntity "github.com/rakahikmah/finance-tracking/internal/usecase/notification/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// NotificationHandler adalah handler HTTP untuk preferensi notifikasi user.
+type NotificationHandler struct {
+	parser            parser.Parser
+	presenter         json.JsonPresenter
+	PreferenceUsecase notification_usecase.INotificationPreference
+}
+
+// NewNotificationHandler adalah konstruktor untuk NotificationHandler.
+func NewNotificationHandler(
+	parser parser.Parser,
+	presenter json.JsonPresenter,
+	PreferenceUsecase notification_usecase.INotificationPreference,
+) *NotificationHandler {
+	return &NotificationHandler{parser, presenter, PreferenceUsecase}
+}
+
+// Register mendaftarkan rute-rute API untuk preferensi notifikasi.
+func (h *NotificationHandler) Register(app fiber.Router) {
+	app.Get("/notifications/preferences", middleware.VerifyJWTToken, h.GetPreferences)
+	app.Put("/notifications/preferences", middleware.VerifyJWTToken, h.UpdatePreferences)
+}
+
+// GetPreferences menangani permintaan GET untuk preferensi notifikasi user.
+func (h *NotificationHandler) GetPreferences(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	result, err := h.PreferenceUsecase.GetPreferences(c.UserContext(), userID)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Notification preferences retrieved successfully", http.StatusOK)
+}
+
+// UpdatePreferences menangani permintaan PUT untuk memperbarui preferensi notifikasi user.
+func (h *NotificationHandler) UpdatePreferences(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	var req notificationEntity.UpdatePreferencesReq
+	if err := h.parser.ParserBodyRequest(c, &req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.PreferenceUsecase.UpdatePreferences(c.UserContext(), userID, req)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Notification preferences updated successfully", http.StatusOK)
+}
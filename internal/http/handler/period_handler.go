@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/internal/http/middleware"
+	"github.com/rakahikmah/finance-tracking/internal/parser"
+	"github.com/rakahikmah/finance-tracking/internal/presenter/json"
+	period_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/period"
+	periodEntity "github.com/rakahikmah/finance-tracking/internal/usecase/period/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// PeriodHandler adalah handler HTTP untuk mengunci dan membuka kunci periode transaksi.
+type PeriodHandler struct {
+	parser            parser.Parser
+	presenter         json.JsonPresenter
+	PeriodLockUsecase period_usecase.IPeriodLock
+}
+
+// NewPeriodHandler adalah konstruktor untuk PeriodHandler.
+func NewPeriodHandler(
+	parser parser.Parser,
+	presenter json.JsonPresenter,
+	PeriodLockUsecase period_usecase.IPeriodLock,
+) *PeriodHandler {
+	return &PeriodHandler{parser, presenter, PeriodLockUsecase}
+}
+
+// Register mendaftarkan rute-rute API untuk kunci periode.
+func (h *PeriodHandler) Register(app fiber.Router) {
+	app.Post("/periods/lock", middleware.VerifyJWTToken, h.Lock)
+	app.Post("/periods/unlock", middleware.VerifyJWTToken, h.Unlock)
+}
+
+// Lock menangani permintaan POST untuk mengunci transaksi sampai locked_through_date.
+func (h *PeriodHandler) Lock(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	var req periodEntity.LockPeriodReq
+	if err := h.parser.ParserBodyRequest(c, &req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.PeriodLockUsecase.Lock(c.UserContext(), userID, req)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Period locked successfully", http.StatusOK)
+}
+
+// Unlock menangani permintaan POST untuk menghapus kunci periode user.
+func (h *PeriodHandler) Unlock(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	if err := h.PeriodLockUsecase.Unlock(c.UserContext(), userID); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, nil, "Period unlocked successfully", http.StatusOK)
+}
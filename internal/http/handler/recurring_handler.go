@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/internal/http/middleware"
+	"github.com/rakahikmah/finance-tracking/internal/parser"
+	"github.com/rakahikmah/finance-tracking/internal/presenter/json"
+	recurring_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/recurring"
+	recurringEntity "github.com/rakahikmah/finance-tracking/internal/usecase/recurring/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// RecurringHandler adalah handler HTTP untuk aturan transaksi berulang.
+type RecurringHandler struct {
+	parser           parser.Parser
+	presenter        json.JsonPresenter
+	RecurringUsecase recurring_usecase.IRecurring
+}
+
+// NewRecurringHandler adalah konstruktor untuk RecurringHandler.
+func NewRecurringHandler(
+	parser parser.Parser,
+	presenter json.JsonPresenter,
+	RecurringUsecase recurring_usecase.IRecurring,
+) *RecurringHandler {
+	return &RecurringHandler{parser, presenter, RecurringUsecase}
+}
+
+// Register mendaftarkan rute-rute API untuk aturan transaksi berulang.
+func (h *RecurringHandler) Register(app fiber.Router) {
+	app.Post("/recurring-transactions/preview", middleware.VerifyJWTToken, h.Preview)
+}
+
+// Preview menangani permintaan POST untuk melihat tanggal kejadian aturan berulang (frequency, start_date,
+// end_date opsional, count) tanpa menyimpannya.
+func (h *RecurringHandler) Preview(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	var req recurringEntity.PreviewRecurringReq
+	if err := h.parser.ParserBodyRequest(c, &req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.RecurringUsecase.Preview(c.UserContext(), userID, req)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Recurring preview generated successfully", http.StatusOK)
+}
@@ -0,0 +1,338 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/http/middleware"
+	"github.com/rakahikmah/finance-tracking/internal/parser"
+	"github.com/rakahikmah/finance-tracking/internal/presenter/json"
+	report_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/report"
+	reportEntity "github.com/rakahikmah/finance-tracking/internal/usecase/report/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// ReportHandler adalah handler HTTP untuk endpoint laporan dan chart.
+type ReportHandler struct {
+	parser        parser.Parser
+	presenter     json.JsonPresenter
+	ReportUsecase report_usecase.IReport
+}
+
+// NewReportHandler adalah konstruktor untuk ReportHandler.
+func NewReportHandler(
+	parser parser.Parser,
+	presenter json.JsonPresenter,
+	ReportUsecase report_usecase.IReport,
+) *ReportHandler {
+	return &ReportHandler{parser, presenter, ReportUsecase}
+}
+
+// Register mendaftarkan rute-rute API untuk laporan.
+func (h *ReportHandler) Register(app fiber.Router) {
+	app.Get("/categories/:id/trend", middleware.VerifyJWTToken, h.GetCategoryTrend)
+	app.Get("/reports/heatmap", middleware.VerifyJWTToken, h.GetHeatmap)
+	app.Get("/reports/networth", middleware.VerifyJWTToken, h.GetNetWorth)
+	app.Get("/reports/ytd", middleware.VerifyJWTToken, h.GetYTD)
+	app.Get("/reports/category-month", middleware.VerifyJWTToken, h.GetCategoryMonth)
+	app.Get("/reports/map", middleware.VerifyJWTToken, h.GetMap)
+	app.Get("/reports/diff", middleware.VerifyJWTToken, h.GetDiff)
+	app.Get("/reports/category-averages", middleware.VerifyJWTToken, h.GetCategoryAverages)
+	app.Get("/reports/amount-histogram", middleware.VerifyJWTToken, h.GetAmountHistogram)
+	app.Get("/insights/activity", middleware.VerifyJWTToken, h.GetActivity)
+	app.Get("/insights/anomalies", middleware.VerifyJWTToken, h.GetAnomalies)
+	app.Post("/reports/whatif", middleware.VerifyJWTToken, h.SimulateWhatIf)
+	app.Post("/reports/envelope", middleware.VerifyJWTToken, h.GetEnvelope)
+}
+
+// GetCategoryTrend menangani permintaan GET untuk time series total satu kategori.
+func (h *ReportHandler) GetCategoryTrend(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid category ID format."))
+	}
+
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	granularity := helper.Granularity(c.Query("granularity", string(helper.GranularityMonth)))
+
+	result, err := h.ReportUsecase.GetCategoryTrend(c.UserContext(), userID, id, startDate, endDate, granularity)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Category trend retrieved successfully", http.StatusOK)
+}
+
+// GetHeatmap menangani permintaan GET untuk total harian selama satu tahun (heatmap).
+func (h *ReportHandler) GetHeatmap(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	year := helper.DatetimeNowJakarta().Year()
+	if c.Query("year") != "" {
+		parsed, err := strconv.Atoi(c.Query("year"))
+		if err != nil {
+			return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid year format."))
+		}
+		year = parsed
+	}
+
+	result, err := h.ReportUsecase.GetHeatmap(c.UserContext(), userID, year, c.Query("type"))
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Heatmap retrieved successfully", http.StatusOK)
+}
+
+// GetNetWorth menangani permintaan GET untuk saldo kumulatif (net worth) per periode.
+func (h *ReportHandler) GetNetWorth(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	granularity := helper.Granularity(c.Query("granularity", string(helper.GranularityMonth)))
+
+	result, err := h.ReportUsecase.GetNetWorth(c.UserContext(), userID, startDate, endDate, granularity, c.QueryBool("include_all", false))
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Net worth retrieved successfully", http.StatusOK)
+}
+
+// GetActivity menangani permintaan GET untuk statistik streak pencatatan transaksi.
+func (h *ReportHandler) GetActivity(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	result, err := h.ReportUsecase.GetActivity(c.UserContext(), userID, helper.DatetimeNowJakarta())
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Activity retrieved successfully", http.StatusOK)
+}
+
+// SimulateWhatIf menangani permintaan POST untuk simulasi proyeksi akhir bulan dengan budget hipotetis.
+func (h *ReportHandler) SimulateWhatIf(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	var req reportEntity.WhatIfReq
+	if err := h.parser.ParserBodyRequest(c, &req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.ReportUsecase.SimulateWhatIf(c.UserContext(), userID, req, helper.DatetimeNowJakarta())
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "What-if simulation calculated successfully", http.StatusOK)
+}
+
+// GetEnvelope menangani permintaan POST untuk membandingkan alokasi envelope (persentase dari total) dengan pengeluaran bulan ini.
+func (h *ReportHandler) GetEnvelope(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	var req reportEntity.EnvelopeReq
+	if err := h.parser.ParserBodyRequest(c, &req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.ReportUsecase.GetEnvelope(c.UserContext(), userID, req, helper.DatetimeNowJakarta())
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Envelope allocation calculated successfully", http.StatusOK)
+}
+
+// GetCategoryMonth menangani permintaan GET untuk total per kategori dan bulan dalam bentuk baris datar.
+func (h *ReportHandler) GetCategoryMonth(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	year := helper.DatetimeNowJakarta().Year()
+	if c.Query("year") != "" {
+		parsed, err := strconv.Atoi(c.Query("year"))
+		if err != nil {
+			return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid year format."))
+		}
+		year = parsed
+	}
+
+	result, err := h.ReportUsecase.GetCategoryMonth(c.UserContext(), userID, year, c.Query("type"))
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Category month totals retrieved successfully", http.StatusOK)
+}
+
+// GetMap menangani permintaan GET untuk transaksi berkoordinat dalam rentang tanggal (peta pengeluaran).
+func (h *ReportHandler) GetMap(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.ReportUsecase.GetMap(c.UserContext(), userID, startDate, endDate)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Transaction map retrieved successfully", http.StatusOK)
+}
+
+// GetCategoryAverages menangani permintaan GET untuk rata-rata nominal transaksi per kategori dalam rentang tanggal.
+func (h *ReportHandler) GetCategoryAverages(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.ReportUsecase.GetCategoryAverages(c.UserContext(), userID, startDate, endDate, c.Query("type"))
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Category averages retrieved successfully", http.StatusOK)
+}
+
+// GetAmountHistogram menangani permintaan GET untuk distribusi jumlah transaksi per bucket amount (bucket_size, type, rentang tanggal).
+func (h *ReportHandler) GetAmountHistogram(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	if c.Query("bucket_size") == "" {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("bucket_size is required."))
+	}
+	bucketSize, err := strconv.ParseFloat(c.Query("bucket_size"), 64)
+	if err != nil {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid bucket_size format."))
+	}
+
+	result, err := h.ReportUsecase.GetAmountHistogram(c.UserContext(), userID, startDate, endDate, c.Query("type"), bucketSize)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Amount histogram retrieved successfully", http.StatusOK)
+}
+
+// GetDiff menangani permintaan GET untuk perbandingan dua periode (a_start, a_end, b_start, b_end).
+func (h *ReportHandler) GetDiff(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	req := reportEntity.DiffReq{
+		AStart: c.Query("a_start"),
+		AEnd:   c.Query("a_end"),
+		BStart: c.Query("b_start"),
+		BEnd:   c.Query("b_end"),
+	}
+	if req.AStart == "" || req.AEnd == "" || req.BStart == "" || req.BEnd == "" {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("a_start, a_end, b_start and b_end query parameters are required."))
+	}
+
+	result, err := h.ReportUsecase.GetDiff(c.UserContext(), userID, req)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Period diff retrieved successfully", http.StatusOK)
+}
+
+// GetAnomalies menangani permintaan GET untuk transaksi dengan amount jauh di atas baseline kategorinya.
+func (h *ReportHandler) GetAnomalies(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.ReportUsecase.GetAnomalies(c.UserContext(), userID, startDate, endDate, c.Query("type"))
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Spending anomalies retrieved successfully", http.StatusOK)
+}
+
+// GetYTD menangani permintaan GET untuk total income, expense, dan net sejak 1 Januari (opsional year untuk tahun lalu).
+func (h *ReportHandler) GetYTD(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	year := 0
+	if c.Query("year") != "" {
+		parsed, err := strconv.Atoi(c.Query("year"))
+		if err != nil {
+			return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid year format."))
+		}
+		year = parsed
+	}
+
+	result, err := h.ReportUsecase.GetYTD(c.UserContext(), userID, year, helper.DatetimeNowJakarta(), c.QueryBool("include_all", false))
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Year-to-date totals retrieved successfully", http.StatusOK)
+}
@@ -52,7 +52,7 @@ func (w *TodoListHandler) GetByID(c *fiber.Ctx) error {
 		return w.presenter.BuildError(c, err)
 	}
 
-	data, err := w.todoListCrudUsecase.GetByID(c.Context(), id)
+	data, err := w.todoListCrudUsecase.GetByID(c.UserContext(), id)
 	if err != nil {
 		return w.presenter.BuildError(c, err)
 	}
@@ -77,7 +77,7 @@ func (w *TodoListHandler) GetByUserID(c *fiber.Ctx) error {
 		return w.presenter.BuildError(c, err)
 	}
 
-	data, err := w.todoListCrudUsecase.GetByUserID(c.Context(), userID)
+	data, err := w.todoListCrudUsecase.GetByUserID(c.UserContext(), userID)
 	if err != nil {
 		return w.presenter.BuildError(c, err)
 	}
@@ -105,7 +105,7 @@ func (w *TodoListHandler) Create(c *fiber.Ctx) error {
 		return w.presenter.BuildError(c, err)
 	}
 
-	data, err := w.todoListCrudUsecase.Create(c.Context(), req)
+	data, err := w.todoListCrudUsecase.Create(c.UserContext(), req)
 	if err != nil {
 		return w.presenter.BuildError(c, err)
 	}
@@ -133,7 +133,7 @@ func (w *TodoListHandler) Update(c *fiber.Ctx) error {
 		return w.presenter.BuildError(c, err)
 	}
 
-	err = w.todoListCrudUsecase.UpdateByID(c.Context(), req)
+	err = w.todoListCrudUsecase.UpdateByID(c.UserContext(), req)
 	if err != nil {
 		return w.presenter.BuildError(c, err)
 	}
@@ -159,7 +159,7 @@ func (w *TodoListHandler) Delete(c *fiber.Ctx) error {
 		return w.presenter.BuildError(c, err)
 	}
 
-	err = w.todoListCrudUsecase.DeleteByID(c.Context(), id)
+	err = w.todoListCrudUsecase.DeleteByID(c.UserContext(), id)
 	if err != nil {
 		return w.presenter.BuildError(c, err)
 	}
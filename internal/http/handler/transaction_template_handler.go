@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/http/middleware"
+	"github.com/rakahikmah/finance-tracking/internal/parser"
+	"github.com/rakahikmah/finance-tracking/internal/presenter/json"
+	template_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/template"
+	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/template/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// TransactionTemplateHandler adalah handler HTTP untuk template transaksi (quick-add).
+type TransactionTemplateHandler struct {
+	parser          parser.Parser
+	presenter       json.JsonPresenter
+	TemplateUsecase template_usecase.ICrudTransactionTemplate
+}
+
+// NewTransactionTemplateHandler adalah konstruktor untuk TransactionTemplateHandler.
+func NewTransactionTemplateHandler(
+	parser parser.Parser,
+	presenter json.JsonPresenter,
+	TemplateUsecase template_usecase.ICrudTransactionTemplate,
+) *TransactionTemplateHandler {
+	return &TransactionTemplateHandler{parser, presenter, TemplateUsecase}
+}
+
+// Register mendaftarkan rute-rute API untuk template transaksi.
+func (h *TransactionTemplateHandler) Register(app fiber.Router) {
+	app.Post("/transaction-templates", middleware.VerifyJWTToken, h.Create)
+	app.Get("/transaction-templates", middleware.VerifyJWTToken, h.GetAll)
+	app.Put("/transaction-templates/:id", middleware.VerifyJWTToken, h.Update)
+	app.Delete("/transaction-templates/:id", middleware.VerifyJWTToken, h.Delete)
+	app.Post("/transaction-templates/:id/use", middleware.VerifyJWTToken, h.Use)
+}
+
+// Create menangani permintaan POST untuk membuat template baru.
+func (h *TransactionTemplateHandler) Create(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	var req usecaseEntity.TemplateReq
+	if err := h.parser.ParserBodyRequestWithUserID(c, &req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.TemplateUsecase.Create(c.UserContext(), userID, req)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Transaction template created successfully", http.StatusCreated)
+}
+
+// GetAll menangani permintaan GET untuk mendapatkan semua template user.
+func (h *TransactionTemplateHandler) GetAll(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	result, err := h.TemplateUsecase.GetAll(c.UserContext(), userID)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "Transaction templates retrieved successfully", http.StatusOK)
+}
+
+// Update menangani permintaan PUT untuk memperbarui template.
+func (h *TransactionTemplateHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid template ID format."))
+	}
+
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	var req usecaseEntity.TemplateReq
+	if err := h.parser.ParserBodyRequestWithUserID(c, &req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	if err := h.TemplateUsecase.Update(c.UserContext(), id, userID, req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, nil, "Transaction template updated successfully", http.StatusOK)
+}
+
+// Delete menangani permintaan DELETE untuk menghapus template.
+func (h *TransactionTemplateHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid template ID format."))
+	}
+
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	if err := h.TemplateUsecase.Delete(c.UserContext(), id, userID); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, nil, "Transaction template deleted successfully", http.StatusOK)
+}
+
+// Use menangani permintaan POST untuk membuat transaksi hari ini dari template.
+func (h *TransactionTemplateHandler) Use(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return h.presenter.BuildError(c, apperr.ErrInvalidRequest().SetDetail("Invalid template ID format."))
+	}
+
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	if err := h.TemplateUsecase.Use(c.UserContext(), id, userID, helper.DatetimeNowJakarta()); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, nil, "Transaction created from template successfully", http.StatusCreated)
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/http/middleware"
+	"github.com/rakahikmah/finance-tracking/internal/parser"
+	"github.com/rakahikmah/finance-tracking/internal/presenter/json"
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// UserSettingsHandler adalah handler HTTP untuk pengaturan user (zona waktu).
+type UserSettingsHandler struct {
+	parser          parser.Parser
+	presenter       json.JsonPresenter
+	SettingsUsecase usecase.IUserSettings
+}
+
+// NewUserSettingsHandler adalah konstruktor untuk UserSettingsHandler.
+func NewUserSettingsHandler(
+	parser parser.Parser,
+	presenter json.JsonPresenter,
+	SettingsUsecase usecase.IUserSettings,
+) *UserSettingsHandler {
+	return &UserSettingsHandler{parser, presenter, SettingsUsecase}
+}
+
+// Register mendaftarkan rute-rute API untuk pengaturan user.
+func (h *UserSettingsHandler) Register(app fiber.Router) {
+	app.Get("/users/me/settings", middleware.VerifyJWTToken, h.GetSettings)
+	app.Put("/users/me/settings", middleware.VerifyJWTToken, h.UpdateSettings)
+}
+
+// GetSettings menangani permintaan GET untuk pengaturan user.
+func (h *UserSettingsHandler) GetSettings(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	result, err := h.SettingsUsecase.GetSettings(c.UserContext(), userID)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "User settings retrieved successfully", http.StatusOK)
+}
+
+// UpdateSettings menangani permintaan PUT untuk memperbarui pengaturan user.
+func (h *UserSettingsHandler) UpdateSettings(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(int64)
+	if !ok || userID == 0 {
+		return h.presenter.BuildError(c, apperr.ErrUnauthorized().SetDetail("User ID not found in context (from JWT)."))
+	}
+
+	var req entity.UpdateUserSettingsReq
+	if err := h.parser.ParserBodyRequest(c, &req); err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	result, err := h.SettingsUsecase.UpdateSettings(c.UserContext(), userID, req)
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	return h.presenter.BuildSuccess(c, result, "User settings updated successfully", http.StatusOK)
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/internal/http/auth"
+	"github.com/rakahikmah/finance-tracking/internal/http/middleware"
+	"github.com/rakahikmah/finance-tracking/internal/presenter/json"
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
+)
+
+// WebhookHandler adalah handler HTTP untuk menerima webhook dari pihak luar (bank).
+type WebhookHandler struct {
+	presenter      json.JsonPresenter
+	webhookUsecase usecase.WebhookUsecase
+	bankSecret     string
+	tolerance      time.Duration
+	replayGuard    auth.WebhookReplayGuard
+}
+
+// NewWebhookHandler adalah konstruktor untuk WebhookHandler.
+func NewWebhookHandler(
+	presenter json.JsonPresenter,
+	webhookUsecase usecase.WebhookUsecase,
+	bankSecret string,
+	tolerance time.Duration,
+	replayGuard auth.WebhookReplayGuard,
+) *WebhookHandler {
+	return &WebhookHandler{presenter, webhookUsecase, bankSecret, tolerance, replayGuard}
+}
+
+// Register mendaftarkan rute webhook. Rute ini tidak memakai JWT, melainkan verifikasi signature HMAC.
+func (h *WebhookHandler) Register(app fiber.Router) {
+	app.Post("/webhooks/bank", middleware.VerifyWebhookSignature(h.bankSecret, h.tolerance, h.replayGuard), h.Bank)
+}
+
+// Bank menerima event webhook bank yang sudah terverifikasi lalu meneruskannya ke queue.
+func (h *WebhookHandler) Bank(c *fiber.Ctx) error {
+	err := h.webhookUsecase.EnqueueBankEvent(c.UserContext(), c.Body())
+	if err != nil {
+		return h.presenter.BuildError(c, err)
+	}
+
+	// BuildSuccess tidak mengubah status code, jadi 202 di-set terlebih dahulu
+	c.Status(http.StatusAccepted)
+	return h.presenter.BuildSuccess(c, nil, "Webhook accepted", http.StatusAccepted)
+}
@@ -0,0 +1,182 @@
+package handler_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/internal/http/auth"
+	"github.com/rakahikmah/finance-tracking/internal/http/handler"
+	"github.com/rakahikmah/finance-tracking/internal/presenter/json"
+	"github.com/rakahikmah/finance-tracking/internal/queue"
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+const webhookTestSecret = "test-secret"
+
+type WebhookHandlerTestSuite struct {
+	suite.Suite
+	queue *mocks.Queue
+	app   *fiber.App
+}
+
+func (s *WebhookHandlerTestSuite) SetupTest() {
+	s.queue = &mocks.Queue{}
+	s.app = fiber.New()
+
+	webhookUsecase := usecase.NewWebhookUsecase(s.queue)
+	handler.NewWebhookHandler(json.NewJsonPresenter(), webhookUsecase, webhookTestSecret, 5*time.Minute, auth.NewMemoryReplayGuard()).Register(s.app)
+}
+
+func TestWebhookHandler(t *testing.T) {
+	suite.Run(t, new(WebhookHandlerTestSuite))
+}
+
+func (s *WebhookHandlerTestSuite) newRequest(body []byte, timestamp string, signature string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/bank", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if timestamp != "" {
+		req.Header.Set(auth.WebhookTimestampHeader, timestamp)
+	}
+	if signature != "" {
+		req.Header.Set(auth.WebhookSignatureHeader, signature)
+	}
+
+	return req
+}
+
+func (s *WebhookHandlerTestSuite) TestBank() {
+	body := []byte(`{"reference":"TRX-001","amount":150000}`)
+
+	testCases := []struct {
+		name       string
+		timestamp  string
+		signature  func(timestamp string) string
+		mockFunc   func()
+		wantStatus int
+	}{
+		{
+			name:      "valid signature",
+			timestamp: strconv.FormatInt(time.Now().Unix(), 10),
+			signature: func(timestamp string) string {
+				return auth.SignWebhookPayload(webhookTestSecret, timestamp, body)
+			},
+			mockFunc: func() {
+				s.queue.On("Publish", queue.ProcessBankWebhook, body, int32(1)).Return(nil).Once()
+			},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:      "invalid signature",
+			timestamp: strconv.FormatInt(time.Now().Unix(), 10),
+			signature: func(timestamp string) string {
+				return auth.SignWebhookPayload("another-secret", timestamp, body)
+			},
+			mockFunc:   func() {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:      "stale timestamp",
+			timestamp: strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10),
+			signature: func(timestamp string) string {
+				return auth.SignWebhookPayload(webhookTestSecret, timestamp, body)
+			},
+			mockFunc:   func() {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:      "malformed timestamp",
+			timestamp: "yesterday",
+			signature: func(timestamp string) string {
+				return auth.SignWebhookPayload(webhookTestSecret, timestamp, body)
+			},
+			mockFunc:   func() {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:      "missing signature header",
+			timestamp: strconv.FormatInt(time.Now().Unix(), 10),
+			signature: func(timestamp string) string {
+				return ""
+			},
+			mockFunc:   func() {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:      "non hex signature",
+			timestamp: strconv.FormatInt(time.Now().Unix(), 10),
+			signature: func(timestamp string) string {
+				return "not-a-hex-signature"
+			},
+			mockFunc:   func() {},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range testCases {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			resp, err := s.app.Test(s.newRequest(body, tt.timestamp, tt.signature(tt.timestamp)))
+			if err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("Bank() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+
+	s.queue.AssertNumberOfCalls(s.T(), "Publish", 1)
+}
+
+func (s *WebhookHandlerTestSuite) TestBankReplay() {
+	body := []byte(`{"reference":"TRX-002","amount":75000}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := auth.SignWebhookPayload(webhookTestSecret, timestamp, body)
+
+	s.queue.On("Publish", queue.ProcessBankWebhook, body, int32(1)).Return(nil).Once()
+
+	resp, err := s.app.Test(s.newRequest(body, timestamp, signature))
+	s.Require().NoError(err)
+	s.Equal(http.StatusAccepted, resp.StatusCode)
+
+	resp, err = s.app.Test(s.newRequest(body, timestamp, signature))
+	s.Require().NoError(err)
+	s.Equal(http.StatusUnauthorized, resp.StatusCode)
+
+	s.queue.AssertNumberOfCalls(s.T(), "Publish", 1)
+}
+
+func (s *WebhookHandlerTestSuite) TestBankRetryAfterPublishFailure() {
+	body := []byte(`{"reference":"TRX-003","amount":25000}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := auth.SignWebhookPayload(webhookTestSecret, timestamp, body)
+
+	s.queue.On("Publish", queue.ProcessBankWebhook, body, int32(1)).Return(errors.New("broker unavailable")).Once()
+	s.queue.On("Publish", queue.ProcessBankWebhook, body, int32(1)).Return(nil).Once()
+
+	resp, err := s.app.Test(s.newRequest(body, timestamp, signature))
+	s.Require().NoError(err)
+	s.GreaterOrEqual(resp.StatusCode, http.StatusInternalServerError)
+
+	// Pengirim mencoba ulang request yang sama, tidak dianggap replay karena request pertama belum diterima
+	resp, err = s.app.Test(s.newRequest(body, timestamp, signature))
+	s.Require().NoError(err)
+	s.Equal(http.StatusAccepted, resp.StatusCode)
+
+	// Setelah diterima, request yang sama baru ditolak sebagai replay
+	resp, err = s.app.Test(s.newRequest(body, timestamp, signature))
+	s.Require().NoError(err)
+	s.Equal(http.StatusUnauthorized, resp.StatusCode)
+
+	s.queue.AssertNumberOfCalls(s.T(), "Publish", 2)
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/entity"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+// RateLimiter decides whether the request identified by key may proceed.
+// When it may not, retryAfter tells how long until the next request is allowed.
+// The in-memory TokenBucketLimiter can be swapped for a shared (e.g. Redis) implementation.
+type RateLimiter interface {
+	Allow(ctx context.Context, key string) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// RateLimit limits each authenticated user to maxPerMinute requests using an in-memory token bucket.
+// It must run after VerifyJWTToken. A maxPerMinute of 0 or less disables the limit.
+func RateLimit(maxPerMinute int) fiber.Handler {
+	if maxPerMinute <= 0 {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	return RateLimitWith(NewTokenBucketLimiter(maxPerMinute, time.Minute))
+}
+
+// RateLimitWith limits requests per user_id with limiter, requests without user_id are keyed on the client IP.
+// Exceeded requests get 429 with a Retry-After header. A limiter error lets the request through.
+func RateLimitWith(limiter RateLimiter) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := "ip:" + c.IP()
+		if userID, ok := c.Locals("user_id").(int64); ok && userID != 0 {
+			key = "user:" + strconv.FormatInt(userID, 10)
+		}
+
+		allowed, retryAfter, err := limiter.Allow(c.UserContext(), key)
+		if err != nil {
+			helper.LogError("middleware.RateLimit", "limiter.Allow", err, entity.CaptureFields{"key": key}, "rate limit skipped")
+			return c.Next()
+		}
+		if !allowed {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			errResponse := apperr.ErrTooManyRequests().SetDetail(fmt.Sprintf("Retry after %d seconds.", int(math.Ceil(retryAfter.Seconds()))))
+			return c.Status(errResponse.HTTPCode).JSON(errResponse)
+		}
+
+		return c.Next()
+	}
+}
+
+// TokenBucketLimiter keeps one bucket per key in process memory. Each bucket holds up to capacity tokens
+// and is refilled at capacity per interval, so short bursts are allowed while the average stays bounded.
+type TokenBucketLimiter struct {
+	capacity float64
+	rate     float64 // tokens per second
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+func NewTokenBucketLimiter(capacity int, interval time.Duration) *TokenBucketLimiter {
+	return &TokenBucketLimiter{
+		capacity: float64(capacity),
+		rate:     float64(capacity) / interval.Seconds(),
+		buckets:  make(map[string]*tokenBucket),
+	}
+}
+
+func (l *TokenBucketLimiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.capacity, updatedAt: now}
+		l.buckets[key] = bucket
+	}
+	bucket.tokens = math.Min(l.capacity, bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*l.rate)
+	bucket.updatedAt = now
+
+	if bucket.tokens < 1 {
+		missing := 1 - bucket.tokens
+		return false, time.Duration(missing / l.rate * float64(time.Second)), nil
+	}
+
+	bucket.tokens--
+	return true, 0, nil
+}
+
+// sweep drops buckets that are full again, they behave the same as a missing bucket.
+func (l *TokenBucketLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+
+	for key, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*l.rate >= l.capacity {
+			delete(l.buckets, key)
+		}
+	}
+}
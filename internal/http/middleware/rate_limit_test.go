@@ -0,0 +1,114 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/internal/http/middleware"
+)
+
+// newApp builds an app where the user_id local is taken from the X-User header, standing in for VerifyJWTToken.
+func newApp(limit fiber.Handler) *fiber.App {
+	app := fiber.New()
+	app.Post("/transactions", func(c *fiber.Ctx) error {
+		switch c.Get("X-User") {
+		case "1":
+			c.Locals("user_id", int64(1))
+		case "2":
+			c.Locals("user_id", int64(2))
+		}
+		return c.Next()
+	}, limit, func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusCreated)
+	})
+	return app
+}
+
+func post(t *testing.T, app *fiber.App, user string) *http.Response {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions", nil)
+	req.Header.Set("X-User", user)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Run("exceeding the limit returns 429 per user", func(t *testing.T) {
+		app := newApp(middleware.RateLimit(2))
+
+		for i := 0; i < 2; i++ {
+			if resp := post(t, app, "1"); resp.StatusCode != http.StatusCreated {
+				t.Fatalf("request %d status = %d, want 201", i+1, resp.StatusCode)
+			}
+		}
+
+		resp := post(t, app, "1")
+		if resp.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("status = %d, want 429", resp.StatusCode)
+		}
+		if resp.Header.Get("Retry-After") == "" {
+			t.Error("Retry-After header is missing")
+		}
+
+		// Other users have their own bucket
+		if resp := post(t, app, "2"); resp.StatusCode != http.StatusCreated {
+			t.Errorf("other user status = %d, want 201", resp.StatusCode)
+		}
+	})
+
+	t.Run("zero disables the limit", func(t *testing.T) {
+		app := newApp(middleware.RateLimit(0))
+
+		for i := 0; i < 5; i++ {
+			if resp := post(t, app, "1"); resp.StatusCode != http.StatusCreated {
+				t.Fatalf("request %d status = %d, want 201", i+1, resp.StatusCode)
+			}
+		}
+	})
+
+	t.Run("limiter error lets the request through", func(t *testing.T) {
+		app := newApp(middleware.RateLimitWith(failingLimiter{}))
+
+		if resp := post(t, app, "1"); resp.StatusCode != http.StatusCreated {
+			t.Errorf("status = %d, want 201", resp.StatusCode)
+		}
+	})
+}
+
+func TestTokenBucketLimiterRefill(t *testing.T) {
+	limiter := middleware.NewTokenBucketLimiter(2, 100*time.Millisecond)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if allowed, _, _ := limiter.Allow(ctx, "user:1"); !allowed {
+			t.Fatalf("request %d not allowed", i+1)
+		}
+	}
+	allowed, retryAfter, _ := limiter.Allow(ctx, "user:1")
+	if allowed {
+		t.Fatal("third request allowed before refill")
+	}
+	if retryAfter <= 0 || retryAfter > 50*time.Millisecond {
+		t.Errorf("retryAfter = %v, want (0, 50ms]", retryAfter)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if allowed, _, _ := limiter.Allow(ctx, "user:1"); !allowed {
+		t.Error("request not allowed after refill")
+	}
+}
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	return false, 0, errors.New("redis down")
+}
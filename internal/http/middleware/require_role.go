@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	mentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+)
+
+// RequireRole allows the request only when the role stored by VerifyJWTToken is one of roles
+// (mentity.RoleNameAdmin, mentity.RoleNameUser), otherwise it responds 403. It must run after VerifyJWTToken;
+// a request without a role in the context is treated as unauthenticated (401).
+func RequireRole(roles ...string) fiber.Handler {
+	allowed := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		allowed[role] = true
+	}
+
+	return func(c *fiber.Ctx) error {
+		role, ok := c.Locals("role").(mentity.RoleType)
+		if !ok {
+			errResponse := apperr.ErrUnauthorized().SetDetail("Role not found in context (from JWT).")
+			return c.Status(errResponse.HTTPCode).JSON(errResponse)
+		}
+		if !allowed[role.String()] {
+			errResponse := apperr.ErrForbidden().SetDetail("Your role is not allowed to access this resource.")
+			return c.Status(errResponse.HTTPCode).JSON(errResponse)
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,57 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/internal/http/middleware"
+	mentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+)
+
+// newRoleApp builds an app where the role local is taken from the X-Role header, standing in for VerifyJWTToken.
+func newRoleApp(roles ...string) *fiber.App {
+	app := fiber.New()
+	app.Get("/admin/reports", func(c *fiber.Ctx) error {
+		switch c.Get("X-Role") {
+		case "1":
+			c.Locals("role", mentity.RoleTypeAdmin)
+		case "2":
+			c.Locals("role", mentity.RoleTypeUser)
+		}
+		return c.Next()
+	}, middleware.RequireRole(roles...), func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+	return app
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name   string
+		roles  []string
+		header string
+		want   int
+	}{
+		{name: "admin allowed", roles: []string{mentity.RoleNameAdmin}, header: "1", want: http.StatusOK},
+		{name: "user forbidden on admin route", roles: []string{mentity.RoleNameAdmin}, header: "2", want: http.StatusForbidden},
+		{name: "any of several roles", roles: []string{mentity.RoleNameAdmin, mentity.RoleNameUser}, header: "2", want: http.StatusOK},
+		{name: "no roles allows nobody", roles: nil, header: "1", want: http.StatusForbidden},
+		{name: "missing role is unauthorized", roles: []string{mentity.RoleNameUser}, header: "", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/reports", nil)
+			req.Header.Set("X-Role", tt.header)
+			resp, err := newRoleApp(tt.roles...).Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// timeoutBaseContextKey holds the user context from before the first Timeout, so a later Timeout can replace the deadline.
+const timeoutBaseContextKey = "timeout_base_context"
+
+// Timeout sets a deadline on the request user context, handlers must pass c.UserContext() down so
+// helper.CheckDeadline in the repositories stops slow requests; the resulting context.DeadlineExceeded is
+// returned as 504 by the JSON presenter. A later Timeout replaces the deadline instead of nesting it, so a
+// route group can have a longer (or shorter) timeout than the default on /api/v1. A timeout of 0 or less
+// disables the deadline. Streamed responses run after the handler returns and are not covered.
+func Timeout(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		base, ok := c.Locals(timeoutBaseContextKey).(context.Context)
+		if !ok {
+			base = c.UserContext()
+			c.Locals(timeoutBaseContextKey, base)
+		}
+
+		if timeout <= 0 {
+			c.SetUserContext(base)
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(base, timeout)
+		defer cancel()
+
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
@@ -0,0 +1,86 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	errwrap "github.com/pkg/errors"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/http/middleware"
+	"github.com/rakahikmah/finance-tracking/internal/presenter/json"
+)
+
+// slowRepo stands in for a repository whose previous query took delay, then checks the deadline like the mysql repositories do.
+type slowRepo struct {
+	delay time.Duration
+}
+
+func (r slowRepo) GetAll(ctx context.Context) error {
+	time.Sleep(r.delay)
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errwrap.Wrap(err, "slowRepo.GetAll")
+	}
+	return nil
+}
+
+func newTimeoutApp(repo slowRepo, handlers ...fiber.Handler) *fiber.App {
+	presenter := json.NewJsonPresenter()
+
+	app := fiber.New()
+	handlers = append(handlers, func(c *fiber.Ctx) error {
+		if err := repo.GetAll(c.UserContext()); err != nil {
+			return presenter.BuildError(c, err)
+		}
+		return c.SendStatus(http.StatusOK)
+	})
+	app.Get("/reports", handlers...)
+	return app
+}
+
+func get(t *testing.T, app *fiber.App) *http.Response {
+	t.Helper()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/reports", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestTimeout(t *testing.T) {
+	t.Run("slow repository returns 504", func(t *testing.T) {
+		app := newTimeoutApp(slowRepo{delay: 50 * time.Millisecond}, middleware.Timeout(10*time.Millisecond))
+
+		if resp := get(t, app); resp.StatusCode != http.StatusGatewayTimeout {
+			t.Fatalf("status = %d, want 504", resp.StatusCode)
+		}
+	})
+
+	t.Run("fast repository is not affected", func(t *testing.T) {
+		app := newTimeoutApp(slowRepo{}, middleware.Timeout(time.Second))
+
+		if resp := get(t, app); resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %d, want 200", resp.StatusCode)
+		}
+	})
+
+	t.Run("route group timeout replaces the default", func(t *testing.T) {
+		app := newTimeoutApp(slowRepo{delay: 50 * time.Millisecond}, middleware.Timeout(10*time.Millisecond), middleware.Timeout(time.Second))
+
+		if resp := get(t, app); resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %d, want 200", resp.StatusCode)
+		}
+	})
+
+	t.Run("zero disables the deadline", func(t *testing.T) {
+		app := newTimeoutApp(slowRepo{delay: 50 * time.Millisecond}, middleware.Timeout(10*time.Millisecond), middleware.Timeout(0))
+
+		if resp := get(t, app); resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %d, want 200", resp.StatusCode)
+		}
+	})
+}
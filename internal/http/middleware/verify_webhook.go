@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/entity"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/http/auth"
+)
+
+// VerifyWebhookSignature rejects inbound webhook which signature or timestamp is not valid,
+// and webhook which was already accepted before (replay inside the tolerance window).
+// Every rejection is answered with 401, including missing or malformed headers.
+// A request the handler fails to accept (error or status >= 400) is forgotten by the replay guard so it can be retried.
+func VerifyWebhookSignature(secret string, tolerance time.Duration, guard auth.WebhookReplayGuard) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		timestamp := c.Get(auth.WebhookTimestampHeader)
+		signature := c.Get(auth.WebhookSignatureHeader)
+
+		err := auth.VerifyWebhookSignature(secret, timestamp, signature, c.Body(), tolerance, time.Now())
+		if err == nil {
+			err = auth.CheckWebhookReplay(c.UserContext(), guard, timestamp, signature, tolerance)
+		}
+		if err == nil {
+			return acceptOrForget(c, guard, timestamp, signature)
+		}
+
+		knownErrors := []error{
+			auth.ErrWebhookMalformed,
+			auth.ErrWebhookStaleTimestamp,
+			auth.ErrWebhookInvalidSignature,
+			auth.ErrWebhookReplayed,
+		}
+		for _, knownErr := range knownErrors {
+			if errors.Is(err, knownErr) {
+				errResponse := apperr.ErrUnauthorized().SetDetail(err.Error())
+				return c.Status(errResponse.HTTPCode).JSON(errResponse)
+			}
+		}
+
+		// replay guard storage failure, sender should retry later
+		errResponse := apperr.CustomError(entity.GENERAL_ERROR_MESSAGE, entity.BAD_REQUEST_CODE, http.StatusServiceUnavailable)
+		return c.Status(errResponse.HTTPCode).JSON(errResponse)
+	}
+}
+
+// acceptOrForget runs the handler and removes the replay record when the webhook was not accepted.
+func acceptOrForget(c *fiber.Ctx, guard auth.WebhookReplayGuard, timestamp string, signature string) error {
+	err := c.Next()
+	if err == nil && c.Response().StatusCode() < http.StatusBadRequest {
+		return nil
+	}
+
+	if forgetErr := auth.ForgetWebhookReplay(c.UserContext(), guard, timestamp, signature); forgetErr != nil {
+		helper.LogError("middleware.VerifyWebhookSignature", "auth.ForgetWebhookReplay", forgetErr,
+			entity.CaptureFields{"timestamp": timestamp}, "retry of this webhook will be rejected as replay")
+	}
+
+	return err
+}
@@ -0,0 +1,54 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/rakahikmah/finance-tracking/config"
+)
+
+// Mailer sends plain text emails.
+type Mailer interface {
+	Send(ctx context.Context, to string, subject string, body string) error
+}
+
+// SMTPMailer is a Mailer backed by an SMTP server.
+type SMTPMailer struct {
+	option config.MailerOption
+}
+
+// NewSMTPMailer creates a Mailer using the given SMTP settings.
+func NewSMTPMailer(option config.MailerOption) *SMTPMailer {
+	return &SMTPMailer{option}
+}
+
+// Send delivers a plain text email. Authentication is only used when a username is configured.
+func (m *SMTPMailer) Send(ctx context.Context, to string, subject string, body string) error {
+	if m.option.Host == "" || m.option.From == "" {
+		return errors.New("mailer: SMTP_HOST and SMTP_FROM must be configured")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.option.Username != "" {
+		auth = smtp.PlainAuth("", m.option.Username, m.option.Password, m.option.Host)
+	}
+
+	message := strings.Join([]string{
+		"From: " + m.option.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	addr := fmt.Sprintf("%s:%d", m.option.Host, m.option.Port)
+	return smtp.SendMail(addr, auth, m.option.From, []string{to}, []byte(message))
+}
@@ -0,0 +1,81 @@
+package csv
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+// StreamErrorMarker is written as the last row when stream fails after the 200 response has started,
+// so clients can tell a truncated export from a complete one.
+const StreamErrorMarker = "#ERROR: export incomplete"
+
+type Csv struct{}
+
+// NewCsvPresenter initialize new CSV presenter that used to write tabular response as file download
+func NewCsvPresenter() *Csv {
+	return &Csv{}
+}
+
+type CsvPresenter interface {
+	BuildCSV(c *fiber.Ctx, filename string, header []string, rows [][]string) error
+	BuildCSVStream(c *fiber.Ctx, filename string, header []string, stream StreamFunc) error
+}
+
+// StreamFunc produces the CSV rows by calling write once per row.
+// It runs after the handler has returned, so it must not use the fiber.Ctx.
+type StreamFunc func(write func(record []string) error) error
+
+// BuildCSV writes header followed by rows as CSV attachment. Empty rows produce header-only CSV.
+func (p *Csv) BuildCSV(c *fiber.Ctx, filename string, header []string, rows [][]string) error {
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	w := csv.NewWriter(c.Response().BodyWriter())
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return w.Error()
+}
+
+// BuildCSVStream writes the same attachment as BuildCSV, writing each row straight to the response so memory stays flat
+// regardless of row count. Headers are sent before the first row, so an error from stream ends the file early:
+// the error is logged and StreamErrorMarker is written as the last row.
+func (p *Csv) BuildCSVStream(c *fiber.Ctx, filename string, header []string, stream StreamFunc) error {
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	logFields := entity.CaptureFields{
+		"method":   c.Method(),
+		"path":     c.Path(),
+		"filename": filename,
+	}
+
+	c.Context().SetBodyStreamWriter(func(bw *bufio.Writer) {
+		w := csv.NewWriter(bw)
+		if err := w.Write(header); err != nil {
+			return
+		}
+		err := stream(func(record []string) error {
+			if err := w.Write(record); err != nil {
+				return err
+			}
+			return w.Error()
+		})
+		if err != nil {
+			helper.LogError("presenter.BuildCSVStream", "stream", err, logFields, "CSV export ended early")
+			w.Write([]string{StreamErrorMarker})
+		}
+		w.Flush()
+	})
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package csv_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	presenter "github.com/rakahikmah/finance-tracking/internal/presenter/csv"
+)
+
+// buildCSVStream runs BuildCSVStream for stream and returns the status and raw body sent to the client.
+func buildCSVStream(t *testing.T, stream presenter.StreamFunc) (int, string) {
+	t.Helper()
+
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		return presenter.NewCsvPresenter().BuildCSVStream(c, "export.csv", []string{"id", "amount"}, stream)
+	})
+
+	resp, testErr := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	if testErr != nil {
+		t.Fatal(testErr)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestBuildCSVStream(t *testing.T) {
+	t.Run("complete export", func(t *testing.T) {
+		status, body := buildCSVStream(t, func(write func(record []string) error) error {
+			return write([]string{"1", "1500"})
+		})
+
+		if status != http.StatusOK {
+			t.Fatalf("status = %d, want 200", status)
+		}
+		if want := "id,amount\n1,1500\n"; body != want {
+			t.Fatalf("body = %q, want %q", body, want)
+		}
+	})
+
+	t.Run("stream error ends with the marker row", func(t *testing.T) {
+		status, body := buildCSVStream(t, func(write func(record []string) error) error {
+			if err := write([]string{"1", "1500"}); err != nil {
+				return err
+			}
+			return errors.New("connection reset")
+		})
+
+		if status != http.StatusOK {
+			t.Fatalf("status = %d, want 200", status)
+		}
+		if want := "id,amount\n1,1500\n" + presenter.StreamErrorMarker + "\n"; body != want {
+			t.Fatalf("body = %q, want %q", body, want)
+		}
+	})
+}
@@ -1,13 +1,15 @@
 package json
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"errors"
-	"net/http"
 	"strings"
 
 	"github.com/rakahikmah/finance-tracking/entity"
 	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -21,12 +23,19 @@ func NewJsonPresenter() *Json {
 
 type JsonPresenter interface {
 	BuildSuccess(c *fiber.Ctx, data interface{}, message string, code int) error
+	BuildSuccessWithMeta(c *fiber.Ctx, data interface{}, meta entity.PaginationMeta, message string, code int) error
 	BuildError(c *fiber.Ctx, err error) error
+	BuildSuccessStream(c *fiber.Ctx, message string, code int, stream StreamFunc) error
 }
 
+// StreamFunc produces the items of a streamed data array by calling emit once per item.
+// It runs after the handler has returned, so it must not use the fiber.Ctx.
+type StreamFunc func(emit func(item interface{}) error) error
+
 // SuccessBody is used to define success response body data structure
 type ResponseBody struct {
 	Data    interface{} `json:"data,omitempty"`
+	Meta    interface{} `json:"meta,omitempty"`
 	Message string      `json:"message,omitempty"`
 	Code    string      `json:"code"`
 }
@@ -41,26 +50,76 @@ func (p *Json) BuildSuccess(c *fiber.Ctx, data interface{}, message string, code
 	return c.JSON(response)
 }
 
-func (p *Json) BuildError(c *fiber.Ctx, err error) error {
-	unwrappedErr := errors.Unwrap(err)
+// BuildSuccessWithMeta is BuildSuccess for paged list endpoints, meta carries the pagination info next to data
+func (p *Json) BuildSuccessWithMeta(c *fiber.Ctx, data interface{}, meta entity.PaginationMeta, message string, code int) error {
+	response := &ResponseBody{
+		Data:    data,
+		Meta:    meta,
+		Message: message,
+		Code:    entity.SUCCESS_CODE,
+	}
 
-	if unwrappedErr != nil {
-		errorData := strings.Split(unwrappedErr.Error(), "XX: ")
+	return c.Status(code).JSON(response)
+}
 
-		if len(errorData) < 2 {
-			return c.Status(apperr.ErrGeneralInvalid().HTTPCode).
-				JSON(apperr.CustomError(err.Error(),
-					entity.BAD_REQUEST_CODE,
-					http.StatusUnprocessableEntity))
+// BuildSuccessStream writes the same envelope as BuildSuccess with data as an array, encoding each item
+// straight to the response so memory stays flat regardless of item count. Status and headers are sent
+// before the first item, so an error from stream ends the body early and leaves the JSON incomplete.
+func (p *Json) BuildSuccessStream(c *fiber.Ctx, message string, code int, stream StreamFunc) error {
+	tail, err := json.Marshal(struct {
+		Message string `json:"message,omitempty"`
+		Code    string `json:"code"`
+	}{message, entity.SUCCESS_CODE})
+	if err != nil {
+		return err
+	}
+
+	c.Status(code)
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		encoder := json.NewEncoder(w)
+		first := true
+
+		w.WriteString(`{"data":[`)
+		err := stream(func(item interface{}) error {
+			if !first {
+				if err := w.WriteByte(','); err != nil {
+					return err
+				}
+			}
+			first = false
+			return encoder.Encode(item)
+		})
+		if err != nil {
+			w.Flush()
+			return
 		}
 
-		errorCode := errorData[1]
-		errorMessage := errorData[0]
+		// tail is {"message":...,"code":...}, reuse it without the opening brace to close the envelope
+		w.WriteString("],")
+		w.Write(tail[1:])
+		w.Flush()
+	})
 
-		// Handle error struct validation
-		if errorCode == entity.INVALID_PAYLOAD_CODE {
+	return nil
+}
+
+// BuildError writes err as a JSON error response. CustomErrorResponse (also when wrapped) and payload validation
+// errors keep their status and message; any other error is logged and answered with a generic 500 so database
+// or other internal details never reach the client.
+func (p *Json) BuildError(c *fiber.Ctx, err error) error {
+	// Deadline from the Timeout middleware, usually wrapped by the repository that noticed it
+	if errors.Is(err, context.DeadlineExceeded) {
+		errResponse := apperr.ErrGatewayTimeout()
+		return c.Status(errResponse.HTTPCode).JSON(errResponse)
+	}
+
+	// Handle error struct validation, the validator wraps INVALID_PAYLOAD_CODE with the field errors as "<json>XX"
+	if unwrappedErr := errors.Unwrap(err); unwrappedErr != nil {
+		errorData := strings.Split(unwrappedErr.Error(), "XX: ")
+		if len(errorData) >= 2 && errorData[1] == entity.INVALID_PAYLOAD_CODE {
 			var errResponse []entity.ErrorResponse
-			json.Unmarshal([]byte(errorMessage), &errResponse)
+			json.Unmarshal([]byte(errorData[0]), &errResponse)
 
 			return c.Status(apperr.ErrGeneralInvalid().HTTPCode).
 				JSON(
@@ -69,14 +128,15 @@ func (p *Json) BuildError(c *fiber.Ctx, err error) error {
 		}
 	}
 
-	switch err := err.(type) {
-	case apperr.CustomErrorResponse:
-		httpCode := err.HTTPCode
-		return c.Status(httpCode).JSON(err)
-	default:
-		return c.Status(apperr.ErrGeneralInvalid().HTTPCode).
-			JSON(apperr.CustomError(err.Error(),
-				entity.BAD_REQUEST_CODE,
-				http.StatusUnprocessableEntity))
+	var appErr apperr.CustomErrorResponse
+	if errors.As(err, &appErr) {
+		return c.Status(appErr.HTTPCode).JSON(appErr)
 	}
+
+	helper.LogError("presenter.BuildError", "JsonPresenter.BuildError", err, entity.CaptureFields{
+		"method": c.Method(),
+		"path":   c.Path(),
+	}, "unexpected error returned to client as 500")
+	errResponse := apperr.ErrInternalServer()
+	return c.Status(errResponse.HTTPCode).JSON(errResponse)
 }
@@ -0,0 +1,96 @@
+package json_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	errwrap "github.com/pkg/errors"
+	"github.com/rakahikmah/finance-tracking/entity"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	presenter "github.com/rakahikmah/finance-tracking/internal/presenter/json"
+)
+
+// buildError runs BuildError for err and returns the status and raw body sent to the client.
+func buildError(t *testing.T, err error) (int, string) {
+	t.Helper()
+
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		return presenter.NewJsonPresenter().BuildError(c, err)
+	})
+
+	resp, testErr := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	if testErr != nil {
+		t.Fatal(testErr)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestBuildError(t *testing.T) {
+	t.Run("raw error is a generic 500", func(t *testing.T) {
+		status, body := buildError(t, errors.New("table x doesn't exist"))
+
+		if status != http.StatusInternalServerError {
+			t.Fatalf("status = %d, want 500", status)
+		}
+		if strings.Contains(body, "table x") {
+			t.Fatalf("body leaks the underlying error: %s", body)
+		}
+		var got apperr.CustomErrorResponse
+		if err := json.Unmarshal([]byte(body), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != apperr.ErrInternalServer() {
+			t.Errorf("body = %+v, want %+v", got, apperr.ErrInternalServer())
+		}
+	})
+
+	t.Run("wrapped repository error is a generic 500", func(t *testing.T) {
+		status, body := buildError(t, errwrap.Wrap(errors.New("Error 1146: Table 'finance.x' doesn't exist"), "TransactionRepository.GetAllByUserID"))
+
+		if status != http.StatusInternalServerError {
+			t.Fatalf("status = %d, want 500", status)
+		}
+		if strings.Contains(body, "1146") || strings.Contains(body, "TransactionRepository") {
+			t.Fatalf("body leaks the underlying error: %s", body)
+		}
+	})
+
+	t.Run("custom error keeps its status and detail", func(t *testing.T) {
+		status, body := buildError(t, apperr.ErrConflict().SetDetail("Transaction was modified by another request"))
+
+		if status != http.StatusConflict {
+			t.Fatalf("status = %d, want 409", status)
+		}
+		if !strings.Contains(body, "Transaction was modified by another request") {
+			t.Errorf("body = %s, want the detail", body)
+		}
+	})
+
+	t.Run("wrapped custom error keeps its status", func(t *testing.T) {
+		status, _ := buildError(t, errwrap.Wrap(apperr.ErrInvalidRequest().SetDetail("Transaction amount must be greater than 0."), "TransactionRepository.Create"))
+
+		if status != http.StatusUnprocessableEntity {
+			t.Fatalf("status = %d, want 422", status)
+		}
+	})
+
+	t.Run("payload validation error lists the fields", func(t *testing.T) {
+		fields, _ := json.Marshal([]entity.ErrorResponse{{FailedField: "Title", Tag: "required"}})
+		status, body := buildError(t, errwrap.Wrap(errors.New(entity.INVALID_PAYLOAD_CODE), string(fields)+"XX"))
+
+		if status != http.StatusUnprocessableEntity {
+			t.Fatalf("status = %d, want 422", status)
+		}
+		if !strings.Contains(body, "Title") {
+			t.Errorf("body = %s, want the failed field", body)
+		}
+	})
+}
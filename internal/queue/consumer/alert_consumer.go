@@ -0,0 +1,68 @@
+package consumer
+
+import (
+	"context"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/queue"
+	mongoRepo "github.com/rakahikmah/finance-tracking/internal/repository/mongodb"
+	moentity "github.com/rakahikmah/finance-tracking/internal/repository/mongodb/entity"
+	notification_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/notification"
+	notificationEntity "github.com/rakahikmah/finance-tracking/internal/usecase/notification/entity"
+)
+
+type AlertQueue struct {
+	ctx               context.Context
+	logMongoRepo      mongoRepo.LogRepository
+	preferenceUsecase notification_usecase.INotificationPreference
+}
+
+type AlertConsumer interface {
+	ProcessLargeTransaction(payload map[string]interface{}) error
+}
+
+func NewAlertConsumer(
+	ctx context.Context,
+	logMongoRepo mongoRepo.LogRepository,
+	preferenceUsecase notification_usecase.INotificationPreference,
+) AlertConsumer {
+	return &AlertQueue{ctx, logMongoRepo, preferenceUsecase}
+}
+
+// ProcessLargeTransaction records a large transaction alert when the user enabled the channel and
+// the amount reaches their threshold. Payload must contain user_id and amount.
+func (a *AlertQueue) ProcessLargeTransaction(payload map[string]interface{}) error {
+	funcName := "AlertConsumer.ProcessLargeTransaction"
+
+	userID := helper.ToInt64(payload["user_id"])
+	amount, _ := payload["amount"].(float64)
+
+	notify, err := a.preferenceUsecase.ShouldNotify(a.ctx, userID, notificationEntity.ChannelLargeTransactionEmail, amount)
+	if err != nil {
+		helper.LogError(queue.ProcessLargeTransactionAlert, funcName, err, nil, "failed load notification preference")
+		return err
+	}
+	if !notify {
+		return nil
+	}
+
+	err = a.logMongoRepo.Create(a.ctx, moentity.LogCollection{
+		Status:   string(entity.LogInfo),
+		FuncName: funcName,
+		Process:  queue.ProcessLargeTransactionAlert,
+		LogFields: map[string]string{
+			"user_id": helper.ToString(userID),
+			"amount":  helper.ToString(amount),
+			"channel": notificationEntity.ChannelLargeTransactionEmail,
+		},
+		Created: time.Now().UTC().Add(7 * time.Hour),
+	})
+	if err != nil {
+		helper.LogError(queue.ProcessLargeTransactionAlert, funcName, err, nil, "failed store large transaction alert to mongodb")
+		return err
+	}
+
+	return nil
+}
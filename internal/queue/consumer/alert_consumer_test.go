@@ -0,0 +1,55 @@
+package consumer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rakahikmah/finance-tracking/internal/queue/consumer"
+	moentity "github.com/rakahikmah/finance-tracking/internal/repository/mongodb/entity"
+	notificationEntity "github.com/rakahikmah/finance-tracking/internal/usecase/notification/entity"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AlertConsumerTestSuite struct {
+	suite.Suite
+
+	logRepo    *mocks.LogRepository
+	preference *mocks.INotificationPreference
+	consumer   consumer.AlertConsumer
+}
+
+func (s *AlertConsumerTestSuite) SetupTest() {
+	s.logRepo = &mocks.LogRepository{}
+	s.preference = &mocks.INotificationPreference{}
+	s.consumer = consumer.NewAlertConsumer(context.Background(), s.logRepo, s.preference)
+}
+
+func TestAlertConsumer(t *testing.T) {
+	suite.Run(t, new(AlertConsumerTestSuite))
+}
+
+func (s *AlertConsumerTestSuite) TestProcessLargeTransaction() {
+	// Payload dari queue di-decode dari JSON, sehingga angka bertipe float64
+	payload := map[string]interface{}{"user_id": float64(1), "amount": float64(2500000)}
+
+	s.Run("disabled channel is skipped", func() {
+		s.preference.On("ShouldNotify", mock.Anything, int64(1), notificationEntity.ChannelLargeTransactionEmail, float64(2500000)).
+			Return(false, nil).Once()
+
+		s.Require().NoError(s.consumer.ProcessLargeTransaction(payload))
+		s.logRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+
+	s.Run("enabled channel records the alert", func() {
+		s.preference.On("ShouldNotify", mock.Anything, int64(1), notificationEntity.ChannelLargeTransactionEmail, float64(2500000)).
+			Return(true, nil).Once()
+		s.logRepo.On("Create", mock.Anything, mock.MatchedBy(func(log moentity.LogCollection) bool {
+			return log.LogFields["user_id"] == "1" && log.LogFields["channel"] == notificationEntity.ChannelLargeTransactionEmail
+		})).Return(nil).Once()
+
+		s.Require().NoError(s.consumer.ProcessLargeTransaction(payload))
+		s.logRepo.AssertExpectations(s.T())
+	})
+}
@@ -2,10 +2,14 @@ package consumer
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/rakahikmah/finance-tracking/entity"
 	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/queue"
 	mongoRepo "github.com/rakahikmah/finance-tracking/internal/repository/mongodb"
+	moentity "github.com/rakahikmah/finance-tracking/internal/repository/mongodb/entity"
 )
 
 type ExampleQueue struct {
@@ -24,11 +28,68 @@ func NewExampleConsumer(
 	return &ExampleQueue{ctx, logMongoRepo}
 }
 
+// Process handles the events published on topic transaction.created, branching on the payload event_type.
+// It is an example of a downstream consumer, e.g. budget alerts can react to the same events.
+//
+// Payloads (numbers arrive as JSON numbers):
+//   - event_type "transaction.created" (or no event_type, for events published before it existed):
+//     transaction_id, user_id, category_id (null without category), amount, currency, type, transaction_date (YYYY-MM-DD).
+//     The event is recorded to the MongoDB log collection.
+//   - event_type "spending_cap.exceeded": user_id, transaction_id (the expense that crossed the cap),
+//     month (YYYY-MM), currency, cap, total_expense. An alert is recorded to the MongoDB log collection.
+//
+// Unknown event types are returned as an error instead of being dropped silently.
 func (l *ExampleQueue) Process(payload map[string]interface{}) error {
-	var params entity.Log
-	params.LoadFromMap(payload)
+	eventType, _ := payload["event_type"].(string)
 
-	helper.Dump(params)
+	switch eventType {
+	case "", queue.EventTransactionCreated:
+		return l.processTransactionCreated(payload)
+	case queue.EventSpendingCapExceeded:
+		return l.processSpendingCapExceeded(payload)
+	default:
+		err := fmt.Errorf("unknown event_type %q", eventType)
+		helper.LogError(queue.ProcessTransactionCreated, "ExampleConsumer.Process", err, nil, "")
+		return err
+	}
+}
+
+// processTransactionCreated records a transaction event to the MongoDB log collection.
+func (l *ExampleQueue) processTransactionCreated(payload map[string]interface{}) error {
+	funcName := "ExampleConsumer.processTransactionCreated"
+
+	return l.storeLog(funcName, payload, []string{"transaction_id", "user_id", "category_id", "amount", "currency", "type", "transaction_date"},
+		"failed store transaction event to mongodb")
+}
+
+// processSpendingCapExceeded records a monthly spending cap alert to the MongoDB log collection.
+func (l *ExampleQueue) processSpendingCapExceeded(payload map[string]interface{}) error {
+	funcName := "ExampleConsumer.processSpendingCapExceeded"
+
+	return l.storeLog(funcName, payload, []string{"event_type", "user_id", "transaction_id", "month", "currency", "cap", "total_expense"},
+		"failed store spending cap alert to mongodb")
+}
+
+// storeLog copies the non-null keys of payload into the log fields and stores the log.
+func (l *ExampleQueue) storeLog(funcName string, payload map[string]interface{}, keys []string, failMessage string) error {
+	logFields := map[string]string{}
+	for _, key := range keys {
+		if value, ok := payload[key]; ok && value != nil {
+			logFields[key] = helper.ToString(value)
+		}
+	}
+
+	err := l.logMongoRepo.Create(l.ctx, moentity.LogCollection{
+		Status:    string(entity.LogInfo),
+		FuncName:  funcName,
+		Process:   queue.ProcessTransactionCreated,
+		LogFields: logFields,
+		Created:   time.Now().UTC().Add(7 * time.Hour),
+	})
+	if err != nil {
+		helper.LogError(queue.ProcessTransactionCreated, funcName, err, nil, failMessage)
+		return err
+	}
 
 	return nil
 }
@@ -0,0 +1,107 @@
+package consumer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rakahikmah/finance-tracking/internal/queue"
+	"github.com/rakahikmah/finance-tracking/internal/queue/consumer"
+	moentity "github.com/rakahikmah/finance-tracking/internal/repository/mongodb/entity"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExampleConsumerTestSuite struct {
+	suite.Suite
+
+	logRepo  *mocks.LogRepository
+	consumer consumer.ExampleConsumer
+}
+
+func (s *ExampleConsumerTestSuite) SetupTest() {
+	s.logRepo = &mocks.LogRepository{}
+	s.consumer = consumer.NewExampleConsumer(context.Background(), s.logRepo)
+}
+
+func TestExampleConsumer(t *testing.T) {
+	suite.Run(t, new(ExampleConsumerTestSuite))
+}
+
+func (s *ExampleConsumerTestSuite) TestProcess() {
+	payload := map[string]interface{}{
+		"transaction_id":   float64(42),
+		"user_id":          float64(1),
+		"category_id":      nil,
+		"amount":           float64(15000),
+		"currency":         "IDR",
+		"type":             "expense",
+		"transaction_date": "2024-01-05",
+	}
+
+	s.Run("transaction is logged", func() {
+		s.logRepo.On("Create", mock.Anything, mock.MatchedBy(func(log moentity.LogCollection) bool {
+			_, hasCategory := log.LogFields["category_id"]
+			return log.Process == queue.ProcessTransactionCreated &&
+				log.LogFields["transaction_id"] == "42" &&
+				log.LogFields["user_id"] == "1" &&
+				!hasCategory
+		})).Return(nil).Once()
+
+		s.Require().NoError(s.consumer.Process(payload))
+		s.logRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("store failure is returned", func() {
+		s.logRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("mongo down")).Once()
+
+		s.Require().Error(s.consumer.Process(payload))
+	})
+}
+
+func (s *ExampleConsumerTestSuite) TestProcessEventType() {
+	s.Run("transaction.created event is logged", func() {
+		s.SetupTest()
+		s.logRepo.On("Create", mock.Anything, mock.MatchedBy(func(log moentity.LogCollection) bool {
+			return log.FuncName == "ExampleConsumer.processTransactionCreated" && log.LogFields["transaction_id"] == "42"
+		})).Return(nil).Once()
+
+		s.Require().NoError(s.consumer.Process(map[string]interface{}{
+			"event_type":     queue.EventTransactionCreated,
+			"transaction_id": float64(42),
+			"user_id":        float64(1),
+		}))
+		s.logRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("spending cap alert is logged", func() {
+		s.SetupTest()
+		s.logRepo.On("Create", mock.Anything, mock.MatchedBy(func(log moentity.LogCollection) bool {
+			return log.FuncName == "ExampleConsumer.processSpendingCapExceeded" &&
+				log.LogFields["event_type"] == queue.EventSpendingCapExceeded &&
+				log.LogFields["user_id"] == "1" &&
+				log.LogFields["month"] == "2024-01" &&
+				log.LogFields["cap"] == "5000000" &&
+				log.LogFields["total_expense"] == "5010000"
+		})).Return(nil).Once()
+
+		s.Require().NoError(s.consumer.Process(map[string]interface{}{
+			"event_type":     queue.EventSpendingCapExceeded,
+			"user_id":        float64(1),
+			"transaction_id": float64(42),
+			"month":          "2024-01",
+			"currency":       "IDR",
+			"cap":            float64(5000000),
+			"total_expense":  float64(5010000),
+		}))
+		s.logRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("unknown event type is an error", func() {
+		s.SetupTest()
+
+		s.Require().Error(s.consumer.Process(map[string]interface{}{"event_type": "budget.deleted"}))
+		s.logRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+}
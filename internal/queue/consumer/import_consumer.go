@@ -0,0 +1,40 @@
+package consumer
+
+import (
+	"context"
+
+	"github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/queue"
+	transactions_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/transactions"
+)
+
+type ImportQueue struct {
+	ctx              context.Context
+	importJobUsecase transactions_usecase.IImportJob
+}
+
+type ImportConsumer interface {
+	ProcessImportJob(payload map[string]interface{}) error
+}
+
+func NewImportConsumer(
+	ctx context.Context,
+	importJobUsecase transactions_usecase.IImportJob,
+) ImportConsumer {
+	return &ImportQueue{ctx, importJobUsecase}
+}
+
+// ProcessImportJob stores the remaining rows of one import job.
+// Payload must contain job_id, see transactions_usecase.ImportJobPayload.
+func (i *ImportQueue) ProcessImportJob(payload map[string]interface{}) error {
+	funcName := "ImportConsumer.ProcessImportJob"
+
+	jobID := helper.ToInt64(payload["job_id"])
+	if err := i.importJobUsecase.Process(i.ctx, jobID); err != nil {
+		helper.LogError(queue.ProcessImportJob, funcName, err, entity.CaptureFields{"job_id": helper.ToString(jobID)}, "failed process import job")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/mailer"
+	"github.com/rakahikmah/finance-tracking/internal/queue"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	report_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/report"
+	reportEntity "github.com/rakahikmah/finance-tracking/internal/usecase/report/entity"
+)
+
+type RecapQueue struct {
+	ctx           context.Context
+	userRepo      mysql.UserRepository
+	reportUsecase report_usecase.IReport
+	mailer        mailer.Mailer
+	currencyCode  string
+}
+
+type RecapConsumer interface {
+	ProcessMonthlyRecap(payload map[string]interface{}) error
+}
+
+func NewRecapConsumer(
+	ctx context.Context,
+	userRepo mysql.UserRepository,
+	reportUsecase report_usecase.IReport,
+	mailer mailer.Mailer,
+	currencyCode string,
+) RecapConsumer {
+	return &RecapQueue{ctx, userRepo, reportUsecase, mailer, currencyCode}
+}
+
+// ProcessMonthlyRecap builds the recap of the given month for one user and emails it.
+// Payload must contain user_id and month (YYYY-MM), see usecase.MonthlyRecapPayload.
+func (r *RecapQueue) ProcessMonthlyRecap(payload map[string]interface{}) error {
+	funcName := "RecapConsumer.ProcessMonthlyRecap"
+
+	userID := helper.ToInt64(payload["user_id"])
+	month, _ := payload["month"].(string)
+	logFields := entity.CaptureFields{"user_id": helper.ToString(userID), "month": month}
+
+	user, err := r.userRepo.GetByID(r.ctx, userID)
+	if err != nil {
+		helper.LogError(queue.ProcessMonthlyRecap, funcName, err, logFields, "failed load user")
+		return err
+	}
+
+	recap, err := r.reportUsecase.GetMonthlyRecap(r.ctx, userID, month)
+	if err != nil {
+		helper.LogError(queue.ProcessMonthlyRecap, funcName, err, logFields, "failed build monthly recap")
+		return err
+	}
+
+	subject, body := BuildMonthlyRecapEmail(user.Name, recap, r.currencyCode)
+	if err := r.mailer.Send(r.ctx, user.Email, subject, body); err != nil {
+		helper.LogError(queue.ProcessMonthlyRecap, funcName, err, logFields, "failed send monthly recap email")
+		return err
+	}
+
+	return nil
+}
+
+// BuildMonthlyRecapEmail renders the subject and plain text body of the monthly recap email.
+func BuildMonthlyRecapEmail(name string, recap *reportEntity.MonthlyRecapResponse, currencyCode string) (subject string, body string) {
+	amount := func(value float64) string {
+		return currencyCode + " " + helper.FormatAmount(value, currencyCode)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Hi %s,\n\n", name)
+	fmt.Fprintf(&b, "Here is your recap for %s.\n\n", recap.Month)
+	fmt.Fprintf(&b, "Income: %s\n", amount(recap.TotalIncome))
+	fmt.Fprintf(&b, "Expense: %s\n", amount(recap.TotalExpense))
+	fmt.Fprintf(&b, "Net: %s\n\n", amount(recap.Net))
+
+	if len(recap.TopCategories) > 0 {
+		b.WriteString("Top spending categories:\n")
+		for i, category := range recap.TopCategories {
+			fmt.Fprintf(&b, "%d. %s: %s\n", i+1, category.CategoryName, amount(category.TotalAmount))
+		}
+		b.WriteString("\n")
+	}
+
+	switch {
+	case recap.ExpenseChangePercent == nil:
+		fmt.Fprintf(&b, "No expenses were recorded in %s to compare with.\n", recap.PreviousMonth)
+	case recap.ExpenseChange > 0:
+		fmt.Fprintf(&b, "You spent %s more than in %s (+%.2f%%).\n", amount(recap.ExpenseChange), recap.PreviousMonth, *recap.ExpenseChangePercent)
+	case recap.ExpenseChange < 0:
+		fmt.Fprintf(&b, "You spent %s less than in %s (%.2f%%).\n", amount(-recap.ExpenseChange), recap.PreviousMonth, *recap.ExpenseChangePercent)
+	default:
+		fmt.Fprintf(&b, "You spent the same as in %s.\n", recap.PreviousMonth)
+	}
+
+	return fmt.Sprintf("Your %s finance recap", recap.Month), b.String()
+}
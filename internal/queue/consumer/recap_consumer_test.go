@@ -0,0 +1,103 @@
+package consumer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rakahikmah/finance-tracking/internal/queue/consumer"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	reportEntity "github.com/rakahikmah/finance-tracking/internal/usecase/report/entity"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RecapConsumerTestSuite struct {
+	suite.Suite
+
+	userRepo *mocks.UserRepository
+	report   *mocks.IReport
+	mailer   *mocks.Mailer
+	consumer consumer.RecapConsumer
+}
+
+func (s *RecapConsumerTestSuite) SetupTest() {
+	s.userRepo = &mocks.UserRepository{}
+	s.report = &mocks.IReport{}
+	s.mailer = &mocks.Mailer{}
+	s.consumer = consumer.NewRecapConsumer(context.Background(), s.userRepo, s.report, s.mailer, "IDR")
+}
+
+func TestRecapConsumer(t *testing.T) {
+	suite.Run(t, new(RecapConsumerTestSuite))
+}
+
+func recapFixture() *reportEntity.MonthlyRecapResponse {
+	percent := 20.0
+	return &reportEntity.MonthlyRecapResponse{
+		Month:                "2024-03",
+		TotalIncome:          10000000,
+		TotalExpense:         3000000,
+		Net:                  7000000,
+		PreviousMonth:        "2024-02",
+		PreviousTotalExpense: 2500000,
+		ExpenseChange:        500000,
+		ExpenseChangePercent: &percent,
+		TopCategories: []reportEntity.RecapCategory{
+			{CategoryName: "Makan", TotalAmount: 1500000},
+			{CategoryName: "Transport", TotalAmount: 700000},
+		},
+	}
+}
+
+func (s *RecapConsumerTestSuite) TestBuildMonthlyRecapEmail() {
+	subject, body := consumer.BuildMonthlyRecapEmail("Raka", recapFixture(), "IDR")
+
+	s.Equal("Your 2024-03 finance recap", subject)
+	s.Equal("Hi Raka,\n\n"+
+		"Here is your recap for 2024-03.\n\n"+
+		"Income: IDR 10000000\n"+
+		"Expense: IDR 3000000\n"+
+		"Net: IDR 7000000\n\n"+
+		"Top spending categories:\n"+
+		"1. Makan: IDR 1500000\n"+
+		"2. Transport: IDR 700000\n\n"+
+		"You spent IDR 500000 more than in 2024-02 (+20.00%).\n", body)
+
+	s.Run("without previous expenses", func() {
+		recap := recapFixture()
+		recap.ExpenseChangePercent = nil
+		recap.TopCategories = nil
+
+		_, body := consumer.BuildMonthlyRecapEmail("Raka", recap, "IDR")
+		s.NotContains(body, "Top spending categories")
+		s.Contains(body, "No expenses were recorded in 2024-02 to compare with.")
+	})
+}
+
+func (s *RecapConsumerTestSuite) TestProcessMonthlyRecap() {
+	// Payload dari queue di-decode dari JSON, sehingga angka bertipe float64
+	payload := map[string]interface{}{"user_id": float64(1), "month": "2024-03"}
+
+	s.Run("sends the recap to the user email", func() {
+		s.SetupTest()
+		s.userRepo.On("GetByID", mock.Anything, int64(1)).Return(&myentity.User{ID: 1, Name: "Raka", Email: "raka@example.com"}, nil).Once()
+		s.report.On("GetMonthlyRecap", mock.Anything, int64(1), "2024-03").Return(recapFixture(), nil).Once()
+		s.mailer.On("Send", mock.Anything, "raka@example.com", "Your 2024-03 finance recap", mock.MatchedBy(func(body string) bool {
+			return len(body) > 0
+		})).Return(nil).Once()
+
+		s.Require().NoError(s.consumer.ProcessMonthlyRecap(payload))
+		s.mailer.AssertExpectations(s.T())
+	})
+
+	s.Run("recap failure does not send email", func() {
+		s.SetupTest()
+		s.userRepo.On("GetByID", mock.Anything, int64(1)).Return(&myentity.User{ID: 1, Email: "raka@example.com"}, nil).Once()
+		s.report.On("GetMonthlyRecap", mock.Anything, int64(1), "2024-03").Return(nil, errors.New("db down")).Once()
+
+		s.Error(s.consumer.ProcessMonthlyRecap(payload))
+		s.mailer.AssertNotCalled(s.T(), "Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,55 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/queue"
+	mongoRepo "github.com/rakahikmah/finance-tracking/internal/repository/mongodb"
+	moentity "github.com/rakahikmah/finance-tracking/internal/repository/mongodb/entity"
+)
+
+type WebhookQueue struct {
+	ctx          context.Context
+	logMongoRepo mongoRepo.LogRepository
+}
+
+type WebhookConsumer interface {
+	ProcessBankWebhook(payload map[string]interface{}) error
+}
+
+func NewWebhookConsumer(
+	ctx context.Context,
+	logMongoRepo mongoRepo.LogRepository,
+) WebhookConsumer {
+	return &WebhookQueue{ctx, logMongoRepo}
+}
+
+// ProcessBankWebhook records verified bank webhook event so it can be reconciled later.
+// The whole payload is stored as raw JSON so nested objects are kept intact.
+func (w *WebhookQueue) ProcessBankWebhook(payload map[string]interface{}) error {
+	funcName := "WebhookConsumer.ProcessBankWebhook"
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		helper.LogError(queue.ProcessBankWebhook, funcName, err, nil, "failed encode bank webhook payload")
+		return err
+	}
+
+	err = w.logMongoRepo.Create(w.ctx, moentity.LogCollection{
+		Status:   string(entity.LogInfo),
+		FuncName: funcName,
+		Process:  queue.ProcessBankWebhook,
+		Payload:  string(raw),
+		Created:  time.Now().UTC().Add(7 * time.Hour),
+	})
+	if err != nil {
+		helper.LogError(queue.ProcessBankWebhook, funcName, err, nil, "failed store bank webhook to mongodb")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrPublisherFull is returned when the publish buffer is full, the event is dropped.
+var ErrPublisherFull = errors.New("publisher buffer is full")
+
+// Publisher publishes an event payload to a topic for downstream consumers.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, payload map[string]interface{}) error
+}
+
+type publishEvent struct {
+	topic string
+	body  []byte
+}
+
+// AsyncPublisher buffers events and publishes them to the queue from a background goroutine,
+// so callers are never blocked by a slow or unavailable broker. Events that do not fit the buffer
+// are dropped with ErrPublisherFull and failed publishes are only logged.
+type AsyncPublisher struct {
+	queue  Queue
+	events chan publishEvent
+	done   chan struct{}
+}
+
+// NewAsyncPublisher starts the background publisher, bufferSize is the number of events that can wait to be published.
+func NewAsyncPublisher(queue Queue, bufferSize int) *AsyncPublisher {
+	p := &AsyncPublisher{
+		queue:  queue,
+		events: make(chan publishEvent, bufferSize),
+		done:   make(chan struct{}),
+	}
+	go p.run()
+
+	return p
+}
+
+// Publish encodes payload and queues it without waiting for the broker.
+func (p *AsyncPublisher) Publish(ctx context.Context, topic string, payload map[string]interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case p.events <- publishEvent{topic: topic, body: body}:
+		return nil
+	default:
+		return ErrPublisherFull
+	}
+}
+
+// Close stops accepting events and waits until the buffered events are published.
+func (p *AsyncPublisher) Close() {
+	close(p.events)
+	<-p.done
+}
+
+func (p *AsyncPublisher) run() {
+	defer close(p.done)
+
+	for event := range p.events {
+		if err := p.queue.Publish(event.topic, event.body, 1); err != nil {
+			fmt.Println(fmt.Sprintf("[PUBLISHER] Dropped event %s: %s", event.topic, err.Error()))
+		}
+	}
+}
+
+// NoopPublisher discards every event, used when event publishing is disabled.
+type NoopPublisher struct{}
+
+func (NoopPublisher) Publish(ctx context.Context, topic string, payload map[string]interface{}) error {
+	return nil
+}
@@ -0,0 +1,54 @@
+package queue_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rakahikmah/finance-tracking/internal/queue"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAsyncPublisher(t *testing.T) {
+	t.Run("events are published in the background", func(t *testing.T) {
+		q := &mocks.Queue{}
+		q.On("Publish", "transaction.created", []byte(`{"transaction_id":1}`), int32(1)).Return(nil).Once()
+
+		publisher := queue.NewAsyncPublisher(q, 10)
+		assert.NoError(t, publisher.Publish(context.Background(), "transaction.created", map[string]interface{}{"transaction_id": 1}))
+		publisher.Close()
+
+		q.AssertExpectations(t)
+	})
+
+	t.Run("broker failure does not reach the caller", func(t *testing.T) {
+		q := &mocks.Queue{}
+		q.On("Publish", mock.Anything, mock.Anything, int32(1)).Return(errors.New("connection refused")).Once()
+
+		publisher := queue.NewAsyncPublisher(q, 10)
+		assert.NoError(t, publisher.Publish(context.Background(), "transaction.created", map[string]interface{}{"transaction_id": 1}))
+		publisher.Close()
+
+		q.AssertExpectations(t)
+	})
+
+	t.Run("full buffer drops the event", func(t *testing.T) {
+		q := &mocks.Queue{}
+		release := make(chan struct{})
+		started := make(chan struct{})
+		q.On("Publish", mock.Anything, mock.Anything, int32(1)).
+			Run(func(mock.Arguments) { close(started); <-release }).Return(nil).Once()
+		q.On("Publish", mock.Anything, mock.Anything, int32(1)).Return(nil)
+
+		publisher := queue.NewAsyncPublisher(q, 1)
+		assert.NoError(t, publisher.Publish(context.Background(), "a", map[string]interface{}{}))
+		<-started
+		assert.NoError(t, publisher.Publish(context.Background(), "b", map[string]interface{}{}))
+		assert.ErrorIs(t, publisher.Publish(context.Background(), "c", map[string]interface{}{}), queue.ErrPublisherFull)
+
+		close(release)
+		publisher.Close()
+	})
+}
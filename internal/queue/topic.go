@@ -1,6 +1,19 @@
 package queue
 
 var (
-	ProcessSyncLog = "log.insert"
-	ProcessExample = "example.consumer"
+	ProcessSyncLog     = "log.insert"
+	ProcessExample     = "example.consumer"
+	ProcessBankWebhook = "webhook.bank"
+
+	ProcessLargeTransactionAlert = "alert.large_transaction"
+	ProcessMonthlyRecap          = "monthly.recap"
+	ProcessImportJob             = "import.job"
+	ProcessTransactionCreated    = "transaction.created"
+)
+
+// Event types carried in the event_type field of ProcessTransactionCreated payloads.
+// Payloads published before event_type existed have no event_type and are treated as EventTransactionCreated.
+const (
+	EventTransactionCreated  = "transaction.created"
+	EventSpendingCapExceeded = "spending_cap.exceeded"
 )
@@ -9,6 +9,7 @@ type LogCollection struct {
 	ErrorMessage  string            `bson:"error_message" json:"error_message"`
 	Process       string            `bson:"process_name" json:"process_name"`
 	LogFields     map[string]string `bson:"log_fields" json:"log_fields"`
+	Payload       string            `bson:"payload,omitempty" json:"payload,omitempty"`
 	Created       time.Time         `bson:"created" json:"created"`
 	ExecutionTime int               `bson:"exec_time" json:"exec_time"`
 }
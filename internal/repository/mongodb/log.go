@@ -7,6 +7,7 @@ import (
 	"github.com/rakahikmah/finance-tracking/internal/helper"
 	"github.com/rakahikmah/finance-tracking/internal/repository/mongodb/entity"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 type LogRepository interface {
@@ -31,3 +32,8 @@ func (r *Log) Create(ctx context.Context, params entity.LogCollection) error {
 	_, err := r.collection.InsertOne(ctx, params)
 	return err
 }
+
+// Ping checks that the primary of the log database is reachable
+func (r *Log) Ping(ctx context.Context) error {
+	return r.collection.Database().Client().Ping(ctx, readpref.Primary())
+}
@@ -0,0 +1,227 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/rakahikmah/finance-tracking/config"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+
+	errwrap "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// BudgetStatusRow menampung satu budget beserta total pengeluaran kategorinya pada bulan budget.
+type BudgetStatusRow struct {
+	BudgetID     int64   `gorm:"column:budget_id"`
+	CategoryID   int64   `gorm:"column:category_id"`
+	CategoryName string  `gorm:"column:category_name"`
+	LimitAmount  float64 `gorm:"column:limit_amount"`
+	Spent        float64 `gorm:"column:spent"`
+}
+
+// IBudgetRepository mendefinisikan interface untuk operasi CRUD pada entitas Budget.
+type IBudgetRepository interface {
+	TrxSupportRepo
+	GetByIDAndUserID(ctx context.Context, ID int64, userID int64) (result *entity.Budget, err error)
+	GetByUserIDCategoryAndMonth(ctx context.Context, userID int64, categoryID int64, month string) (result *entity.Budget, err error)
+	GetAllByUserIDAndMonth(ctx context.Context, userID int64, month string) (result []*entity.Budget, err error)
+	GetStatusByUserIDAndMonth(ctx context.Context, userID int64, currency string, month string, endDate string) (result []*BudgetStatusRow, err error)
+	GetOverspentByUserIDAndMonth(ctx context.Context, userID int64, currency string, month string, endDate string) (result []*BudgetStatusRow, err error)
+	Create(ctx context.Context, dbTrx TrxObj, params *entity.Budget, nonZeroVal bool) error
+	Update(ctx context.Context, dbTrx TrxObj, params *entity.Budget, changes *entity.Budget) error
+	DeleteByIDAndUserID(ctx context.Context, dbTrx TrxObj, id int64, userID int64) error
+}
+
+// BudgetRepository adalah implementasi repository untuk entitas Budget.
+type BudgetRepository struct {
+	GormTrxSupport
+}
+
+// NewBudgetRepository membuat instance baru dari BudgetRepository.
+func NewBudgetRepository(mysql *config.Mysql) *BudgetRepository {
+	return &BudgetRepository{GormTrxSupport{db: mysql.DB}}
+}
+
+// GetByIDAndUserID mengambil budget berdasarkan ID dan user ID-nya, budget milik user lain dianggap tidak ditemukan.
+func (r *BudgetRepository) GetByIDAndUserID(ctx context.Context, ID int64, userID int64) (result *entity.Budget, err error) {
+	funcName := "BudgetRepository.GetByIDAndUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.db.Where("id = ? AND user_id = ?", ID, userID).First(&result).Error
+	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.ErrRecordNotFound()
+	}
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetByUserIDCategoryAndMonth mengambil budget kategori pada bulan tertentu (tanggal 1, YYYY-MM-DD).
+// Mengembalikan apperr.ErrRecordNotFound jika kategori belum memiliki budget di bulan tersebut.
+func (r *BudgetRepository) GetByUserIDCategoryAndMonth(ctx context.Context, userID int64, categoryID int64, month string) (result *entity.Budget, err error) {
+	funcName := "BudgetRepository.GetByUserIDCategoryAndMonth"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.db.Where("user_id = ? AND category_id = ? AND month = ?", userID, categoryID, month).First(&result).Error
+	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.ErrRecordNotFound()
+	}
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetAllByUserIDAndMonth mengambil semua budget user pada bulan tertentu (tanggal 1, YYYY-MM-DD).
+func (r *BudgetRepository) GetAllByUserIDAndMonth(ctx context.Context, userID int64, month string) (result []*entity.Budget, err error) {
+	funcName := "BudgetRepository.GetAllByUserIDAndMonth"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.db.Where("user_id = ? AND month = ?", userID, month).Order("category_id ASC, id ASC").Find(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// budgetStatusQuery mengambil budget user pada satu bulan beserta total pengeluaran kategorinya, tanpa HAVING/ORDER BY.
+// Parameter: tipe expense, currency, tanggal awal, tanggal akhir, user ID, bulan budget.
+const budgetStatusQuery = `
+		SELECT
+			b.id as budget_id,
+			b.category_id,
+			c.name as category_name,
+			b.limit_amount,
+			COALESCE(SUM(t.amount), 0) as spent
+		FROM
+			budgets b
+		JOIN
+			categories c ON c.id = b.category_id
+		LEFT JOIN
+			transactions t ON t.category_id = b.category_id AND t.user_id = b.user_id AND t.type = ?
+				AND t.deleted_at IS NULL AND t.currency = ? AND t.transaction_date BETWEEN ? AND ?
+		WHERE
+			b.user_id = ? AND b.month = ?
+		GROUP BY
+			b.id, b.category_id, c.name, b.limit_amount`
+
+// GetStatusByUserIDAndMonth mengambil setiap budget user pada bulan tertentu beserta total pengeluaran kategorinya
+// dari tanggal month sampai endDate. Hanya transaksi expense dalam mata uang currency yang dihitung.
+// Query berangkat dari tabel budgets, sehingga kategori yang ada pengeluarannya tetapi tidak punya budget tidak ikut,
+// sedangkan budget tanpa pengeluaran tetap muncul dengan spent 0.
+func (r *BudgetRepository) GetStatusByUserIDAndMonth(ctx context.Context, userID int64, currency string, month string, endDate string) (result []*BudgetStatusRow, err error) {
+	funcName := "BudgetRepository.GetStatusByUserIDAndMonth"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := budgetStatusQuery + `
+		ORDER BY
+			c.name ASC, b.id ASC
+	`
+	err = r.db.Raw(query, entity.TransactionTypeExpense, currency, month, endDate, userID, month).Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetOverspentByUserIDAndMonth sama dengan GetStatusByUserIDAndMonth tetapi hanya mengembalikan budget yang
+// pengeluarannya melebihi limit (tepat sama dengan limit tidak ikut), urut dari selisih terbesar.
+func (r *BudgetRepository) GetOverspentByUserIDAndMonth(ctx context.Context, userID int64, currency string, month string, endDate string) (result []*BudgetStatusRow, err error) {
+	funcName := "BudgetRepository.GetOverspentByUserIDAndMonth"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := budgetStatusQuery + `
+		HAVING
+			COALESCE(SUM(t.amount), 0) > b.limit_amount
+		ORDER BY
+			COALESCE(SUM(t.amount), 0) - b.limit_amount DESC, b.id ASC
+	`
+	err = r.db.Raw(query, entity.TransactionTypeExpense, currency, month, endDate, userID, month).Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// Create membuat budget baru.
+func (r *BudgetRepository) Create(ctx context.Context, dbTrx TrxObj, params *entity.Budget, nonZeroVal bool) error {
+	funcName := "BudgetRepository.Create"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	cols := helper.NonZeroCols(params, nonZeroVal)
+	if err := r.Trx(dbTrx).Select(cols).Create(&params).Error; err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	return nil
+}
+
+// Update memperbarui budget yang ada, difilter dengan user_id untuk otorisasi.
+func (r *BudgetRepository) Update(ctx context.Context, dbTrx TrxObj, params *entity.Budget, changes *entity.Budget) error {
+	funcName := "BudgetRepository.Update"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	if params.ID == 0 || params.UserID == 0 {
+		return errwrap.Wrap(apperr.ErrInvalidRequest().SetDetail("Budget ID or User ID is missing."), funcName)
+	}
+
+	db := r.Trx(dbTrx).Model(params).Where("user_id = ?", params.UserID)
+
+	var err error
+	if changes != nil {
+		err = db.Updates(*changes).Error
+	} else {
+		err = db.Updates(helper.StructToMap(params, false)).Error
+	}
+
+	if err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	return nil
+}
+
+// DeleteByIDAndUserID menghapus budget berdasarkan ID dan user ID-nya.
+func (r *BudgetRepository) DeleteByIDAndUserID(ctx context.Context, dbTrx TrxObj, id int64, userID int64) error {
+	funcName := "BudgetRepository.DeleteByIDAndUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	err := r.Trx(dbTrx).Where("id = ? AND user_id = ?", id, userID).Delete(&entity.Budget{}).Error
+	if err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rakahikmah/finance-tracking/config"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	"github.com/stretchr/testify/suite"
+	gmysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type BudgetRepositoryTestSuite struct {
+	suite.Suite
+	mock   sqlmock.Sqlmock
+	db     *sql.DB
+	repo   *mysql.BudgetRepository
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func TestBudgetRepository(t *testing.T) {
+	suite.Run(t, new(BudgetRepositoryTestSuite))
+}
+
+func (s *BudgetRepositoryTestSuite) SetupTest() {
+	var err error
+	s.db, s.mock, err = sqlmock.New()
+	if err != nil {
+		s.Failf("an error '%s' was not expected when opening a stub database connection", err.Error())
+	}
+
+	dialector := gmysql.New(gmysql.Config{Conn: s.db, SkipInitializeWithVersion: true})
+	gormDB, _ := gorm.Open(dialector, &gorm.Config{})
+	s.repo = mysql.NewBudgetRepository(&config.Mysql{DB: gormDB})
+	s.ctx, s.cancel = context.WithDeadline(context.Background(), time.Now().Add(time.Hour))
+}
+
+func (s *BudgetRepositoryTestSuite) TearDownTest() {
+	s.cancel()
+	s.db.Close()
+}
+
+func (s *BudgetRepositoryTestSuite) TestGetStatusByUserIDAndMonth() {
+	// Pengeluaran di-join ke budget, sehingga budget tanpa transaksi tetap muncul dengan spent 0
+	rows := sqlmock.NewRows([]string{"budget_id", "category_id", "category_name", "limit_amount", "spent"}).
+		AddRow(int64(1), int64(7), "Food", []byte("1000000.00"), []byte("1250000.50")).
+		AddRow(int64(2), int64(9), "Travel", []byte("2000000.00"), []byte("0"))
+	s.mock.ExpectQuery(`FROM\s+budgets b\s+JOIN\s+categories c(.+)LEFT JOIN\s+transactions t ON t.category_id = b.category_id(.+)t.deleted_at IS NULL(.+)WHERE\s+b.user_id = \? AND b.month = \?`).
+		WithArgs(entity.TransactionTypeExpense, "IDR", "2024-02-01", "2024-02-29", int64(1), "2024-02-01").
+		WillReturnRows(rows)
+
+	result, err := s.repo.GetStatusByUserIDAndMonth(s.ctx, 1, "IDR", "2024-02-01", "2024-02-29")
+	s.Require().NoError(err)
+
+	s.Equal([]*mysql.BudgetStatusRow{
+		{BudgetID: 1, CategoryID: 7, CategoryName: "Food", LimitAmount: 1000000, Spent: 1250000.5},
+		{BudgetID: 2, CategoryID: 9, CategoryName: "Travel", LimitAmount: 2000000, Spent: 0},
+	}, result)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *BudgetRepositoryTestSuite) TestGetOverspentByUserIDAndMonth() {
+	rows := sqlmock.NewRows([]string{"budget_id", "category_id", "category_name", "limit_amount", "spent"}).
+		AddRow(int64(3), int64(8), "Transport", []byte("300000.00"), []byte("700000.00")).
+		AddRow(int64(1), int64(7), "Food", []byte("1000000.00"), []byte("1250000.50"))
+	s.mock.ExpectQuery(`WHERE\s+b.user_id = \? AND b.month = \?\s+GROUP BY(.+)HAVING\s+COALESCE\(SUM\(t.amount\), 0\) > b.limit_amount\s+ORDER BY\s+COALESCE\(SUM\(t.amount\), 0\) - b.limit_amount DESC, b.id ASC`).
+		WithArgs(entity.TransactionTypeExpense, "IDR", "2024-02-01", "2024-02-29", int64(1), "2024-02-01").
+		WillReturnRows(rows)
+
+	result, err := s.repo.GetOverspentByUserIDAndMonth(s.ctx, 1, "IDR", "2024-02-01", "2024-02-29")
+	s.Require().NoError(err)
+
+	s.Equal([]*mysql.BudgetStatusRow{
+		{BudgetID: 3, CategoryID: 8, CategoryName: "Transport", LimitAmount: 300000, Spent: 700000},
+		{BudgetID: 1, CategoryID: 7, CategoryName: "Food", LimitAmount: 1000000, Spent: 1250000.5},
+	}, result)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"context"
+	"strings"
 
 	"github.com/rakahikmah/finance-tracking/config" // Sesuaikan import path projectmu
 	"github.com/rakahikmah/finance-tracking/internal/helper" // Sesuaikan import path projectmu
@@ -21,7 +22,15 @@ type ICategoryRepository interface {
 	Update(ctx context.Context, dbTrx TrxObj, params *entity.Category, changes *entity.Category) (err error)
 	DeleteByID(ctx context.Context, dbTrx TrxObj, id int64) error
 	GetAll(ctx context.Context, userID int64) (result []*entity.Category, err error) // Menambahkan userID untuk filter
-	GetByUserIDAndName(ctx context.Context, userID int64, name string) (e *entity.Category, err error) // Tambahan untuk cek duplikasi nama per user
+	GetByUserIDAndName(ctx context.Context, userID int64, name string, excludeID int64) (e *entity.Category, err error) // Tambahan untuk cek duplikasi nama per user
+	CountChildren(ctx context.Context, parentID int64) (count int64, err error)
+	ListByUserID(ctx context.Context, userID int64, filter CategoryFilter, limit, offset int) (result []*entity.Category, err error)
+	CountByUserID(ctx context.Context, userID int64, filter CategoryFilter) (total int64, err error)
+}
+
+// CategoryFilter adalah filter opsional daftar kategori ber-halaman. Query kosong berarti tanpa filter nama.
+type CategoryFilter struct {
+	Query string
 }
 
 // CategoryRepository adalah implementasi repository untuk entitas Category.
@@ -55,6 +64,55 @@ func (r *CategoryRepository) GetAll(ctx context.Context, userID int64) (result [
 	return result, nil
 }
 
+// filterCategories membangun query kategori milik user sesuai filter.
+// ListByUserID dan CountByUserID wajib memakai fungsi ini agar total selalu sesuai dengan data yang dikembalikan.
+func (r *CategoryRepository) filterCategories(userID int64, filter CategoryFilter) *gorm.DB {
+	db := r.db.Model(&entity.Category{}).Where("created_by = ?", userID)
+	if filter.Query != "" {
+		db = db.Where("LOWER(name) LIKE ?", helper.LikeContains(strings.ToLower(filter.Query)))
+	}
+
+	return db
+}
+
+// ListByUserID mengambil maksimal limit kategori milik user mulai dari offset, diurutkan berdasarkan nama lalu id
+// agar halaman tetap stabil.
+func (r *CategoryRepository) ListByUserID(ctx context.Context, userID int64, filter CategoryFilter, limit, offset int) (result []*entity.Category, err error) {
+	funcName := "CategoryRepository.ListByUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	result = []*entity.Category{}
+	err = r.filterCategories(userID, filter).
+		Order("name ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// CountByUserID menghitung jumlah kategori milik user sesuai filter, untuk total pada pagination.
+func (r *CategoryRepository) CountByUserID(ctx context.Context, userID int64, filter CategoryFilter) (total int64, err error) {
+	funcName := "CategoryRepository.CountByUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return 0, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.filterCategories(userID, filter).Count(&total).Error
+	if err != nil {
+		return 0, errwrap.Wrap(err, funcName)
+	}
+
+	return total, nil
+}
+
 // GetByID mengambil kategori berdasarkan ID.
 // Kategori juga harus dimiliki oleh user tertentu untuk alasan keamanan.
 func (r *CategoryRepository) GetByID(ctx context.Context, ID int64) (result *entity.Category, err error) {
@@ -76,16 +134,22 @@ func (r *CategoryRepository) GetByID(ctx context.Context, ID int64) (result *ent
 	return result, nil
 }
 
-// GetByUserIDAndName mengambil kategori berdasarkan user ID dan nama.
-// Berguna untuk memeriksa duplikasi nama kategori per user.
-func (r *CategoryRepository) GetByUserIDAndName(ctx context.Context, userID int64, name string) (result *entity.Category, err error) {
+// GetByUserIDAndName mengambil kategori berdasarkan user ID dan nama (tanpa membedakan huruf besar/kecil).
+// Berguna untuk memeriksa duplikasi nama kategori per user. excludeID > 0 mengecualikan kategori itu sendiri saat update.
+func (r *CategoryRepository) GetByUserIDAndName(ctx context.Context, userID int64, name string, excludeID int64) (result *entity.Category, err error) {
 	funcName := "CategoryRepository.GetByUserIDAndName"
 
 	if err := helper.CheckDeadline(ctx); err != nil {
 		return nil, errwrap.Wrap(err, funcName)
 	}
 
-	err = r.db.Where("created_by = ? AND name = ?", userID, name).First(&result).Error
+	// Perbandingan selalu case-insensitive, tidak bergantung pada collation kolom
+	db := r.db.Where("created_by = ? AND LOWER(name) = LOWER(?)", userID, name)
+	if excludeID > 0 {
+		db = db.Where("id <> ?", excludeID)
+	}
+
+	err = db.First(&result).Error
 	if errwrap.Is(err, gorm.ErrRecordNotFound) {
 		return nil, apperr.ErrRecordNotFound()
 	}
@@ -155,4 +219,20 @@ func (r *CategoryRepository) DeleteByID(ctx context.Context, dbTrx TrxObj, id in
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// CountChildren menghitung subkategori yang parent_id-nya menunjuk ke kategori parentID.
+func (r *CategoryRepository) CountChildren(ctx context.Context, parentID int64) (count int64, err error) {
+	funcName := "CategoryRepository.CountChildren"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return 0, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.db.Model(&entity.Category{}).Where("parent_id = ?", parentID).Count(&count).Error
+	if err != nil {
+		return 0, errwrap.Wrap(err, funcName)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,104 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rakahikmah/finance-tracking/config"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	"github.com/stretchr/testify/suite"
+	gmysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type CategoryRepositoryTestSuite struct {
+	suite.Suite
+	mock   sqlmock.Sqlmock
+	db     *sql.DB
+	repo   *mysql.CategoryRepository
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func TestCategoryRepository(t *testing.T) {
+	suite.Run(t, new(CategoryRepositoryTestSuite))
+}
+
+func (s *CategoryRepositoryTestSuite) SetupTest() {
+	var err error
+	s.db, s.mock, err = sqlmock.New()
+	if err != nil {
+		s.Failf("an error '%s' was not expected when opening a stub database connection", err.Error())
+	}
+
+	dialector := gmysql.New(gmysql.Config{Conn: s.db, SkipInitializeWithVersion: true})
+	gormDB, _ := gorm.Open(dialector, &gorm.Config{})
+	s.repo = mysql.NewCategoryRepository(&config.Mysql{DB: gormDB})
+	s.ctx, s.cancel = context.WithDeadline(context.Background(), time.Now().Add(time.Hour))
+}
+
+func (s *CategoryRepositoryTestSuite) TearDownTest() {
+	s.cancel()
+	s.db.Close()
+}
+
+func (s *CategoryRepositoryTestSuite) TestListAndCountUseSameFilter() {
+	s.Run("search by name", func() {
+		s.mock.ExpectQuery(`SELECT count\(\*\) FROM .categories. WHERE created_by = \? AND LOWER\(name\) LIKE \?`).
+			WithArgs(int64(1), `%food\_%`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		s.mock.ExpectQuery(`SELECT \* FROM .categories. WHERE created_by = \? AND LOWER\(name\) LIKE \? ORDER BY name ASC, id ASC LIMIT \? OFFSET \?`).
+			WithArgs(int64(1), `%food\_%`, 10, 20).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_by", "name"}).AddRow(3, 1, "Food_1").AddRow(4, 1, "food_2"))
+
+		filter := mysql.CategoryFilter{Query: "Food_"}
+		total, err := s.repo.CountByUserID(s.ctx, 1, filter)
+		s.Require().NoError(err)
+		s.Equal(int64(2), total)
+
+		result, err := s.repo.ListByUserID(s.ctx, 1, filter, 10, 20)
+		s.Require().NoError(err)
+		s.Len(result, 2)
+		s.Equal("Food_1", result[0].Name)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("without search", func() {
+		s.mock.ExpectQuery(`SELECT \* FROM .categories. WHERE created_by = \? ORDER BY name ASC, id ASC LIMIT \?$`).
+			WithArgs(int64(1), 50).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		result, err := s.repo.ListByUserID(s.ctx, 1, mysql.CategoryFilter{}, 50, 0)
+		s.Require().NoError(err)
+		s.NotNil(result)
+		s.Empty(result)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *CategoryRepositoryTestSuite) TestGetByUserIDAndName() {
+	s.Run("case-insensitive match", func() {
+		s.mock.ExpectQuery(`SELECT \* FROM .categories. WHERE created_by = \? AND LOWER\(name\) = LOWER\(\?\) ORDER BY .categories.\..id. LIMIT \?`).
+			WithArgs(int64(1), "Food", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_by", "name"}).AddRow(3, 1, "food"))
+
+		result, err := s.repo.GetByUserIDAndName(s.ctx, 1, "Food", 0)
+		s.Require().NoError(err)
+		s.Equal(int64(3), result.ID)
+	})
+
+	s.Run("excluded id is not returned", func() {
+		s.mock.ExpectQuery(`WHERE \(created_by = \? AND LOWER\(name\) = LOWER\(\?\)\) AND id <> \?`).
+			WithArgs(int64(1), "Food", int64(3), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := s.repo.GetByUserIDAndName(s.ctx, 1, "Food", 3)
+		s.ErrorIs(err, apperr.ErrRecordNotFound())
+	})
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
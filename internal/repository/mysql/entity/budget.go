@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// Budget menyimpan batas pengeluaran bulanan user untuk satu kategori.
+// Month selalu tanggal 1 dari bulan budget.
+type Budget struct {
+	ID          int64     `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID      int64     `gorm:"column:user_id"`
+	CategoryID  int64     `gorm:"column:category_id"`
+	Month       time.Time `gorm:"column:month;type:date"`
+	LimitAmount float64   `gorm:"column:limit_amount;type:decimal(15,2)"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+// TableName mengembalikan nama tabel di database untuk model Budget.
+func (Budget) TableName() string {
+	return "budgets"
+}
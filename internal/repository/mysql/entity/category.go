@@ -1,13 +1,26 @@
 package entity
 
-import "time"
+import (
+	"database/sql"
+	"time"
+)
 
 type Category struct {
-	ID        int64     `gorm:"column:id"`
-	CreatedBy int64     `gorm:"column:created_by"` // <-- Ini tetap exported agar GORM bisa memetakan
-	Name      string    `gorm:"column:name"`
-	CreatedAt time.Time `gorm:"column:created_at"`
-	UpdatedAt time.Time `gorm:"column:updated_at"`
+	ID        int64  `gorm:"column:id"`
+	CreatedBy int64  `gorm:"column:created_by"` // <-- Ini tetap exported agar GORM bisa memetakan
+	Name      string `gorm:"column:name"`
+	// ParentID menunjuk kategori induk milik user yang sama, NULL untuk kategori level teratas
+	ParentID sql.NullInt64 `gorm:"column:parent_id"`
+	// Type membatasi tipe transaksi yang boleh memakai kategori ini.
+	// Kosong untuk kategori lama yang belum dimigrasi, boleh dipakai income maupun expense
+	Type TransactionType `gorm:"column:type"`
+	// Icon dan Color dipakai aplikasi untuk menampilkan kategori, kosong untuk kategori tanpa icon/warna
+	Icon  string `gorm:"column:icon"`
+	Color string `gorm:"column:color"`
+	// ExcludeFromTotals mengecualikan transaksi kategori ini dari saldo dan ringkasan net
+	ExcludeFromTotals bool      `gorm:"column:exclude_from_totals"`
+	CreatedAt         time.Time `gorm:"column:created_at"`
+	UpdatedAt         time.Time `gorm:"column:updated_at"`
 }
 
 func (Category) TableName() string {
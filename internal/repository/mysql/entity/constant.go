@@ -6,3 +6,28 @@ const (
 	RoleTypeAdmin RoleType = 1
 	RoleTypeUser  RoleType = 2
 )
+
+// Nama role yang dipakai middleware.RequireRole.
+const (
+	RoleNameAdmin = "admin"
+	RoleNameUser  = "user"
+)
+
+// String mengembalikan nama role (admin atau user), role lain menjadi "unknown".
+func (r RoleType) String() string {
+	switch r {
+	case RoleTypeAdmin:
+		return RoleNameAdmin
+	case RoleTypeUser:
+		return RoleNameUser
+	default:
+		return "unknown"
+	}
+}
+
+type UserStatus uint8
+
+const (
+	UserStatusActive    UserStatus = 1
+	UserStatusSuspended UserStatus = 2
+)
@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// ImportJobStatus merepresentasikan status proses import transaksi di background.
+type ImportJobStatus string
+
+const (
+	ImportJobStatusPending    ImportJobStatus = "pending"
+	ImportJobStatusProcessing ImportJobStatus = "processing"
+	ImportJobStatusCompleted  ImportJobStatus = "completed"
+	ImportJobStatusFailed     ImportJobStatus = "failed"
+)
+
+// ImportJob menyimpan satu import transaksi besar yang diproses bertahap oleh worker.
+// Processed adalah offset baris yang sudah tersimpan, sehingga job yang gagal dapat dilanjutkan tanpa insert ulang.
+type ImportJob struct {
+	ID               int64           `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID           int64           `gorm:"column:user_id"`
+	Status           ImportJobStatus `gorm:"column:status"`
+	CreateCategories bool            `gorm:"column:create_categories"`
+	Total            int             `gorm:"column:total"`
+	Processed        int             `gorm:"column:processed"`
+	Errors           string          `gorm:"column:errors"` // JSON array pesan error tiap percobaan yang gagal
+	Rows             string          `gorm:"column:rows"`   // JSON baris import yang sudah divalidasi
+	CreatedAt        time.Time       `gorm:"column:created_at"`
+	UpdatedAt        time.Time       `gorm:"column:updated_at"`
+}
+
+// TableName mengembalikan nama tabel di database untuk model ImportJob.
+func (ImportJob) TableName() string {
+	return "import_jobs"
+}
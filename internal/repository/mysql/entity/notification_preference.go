@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// NotificationPreference menyimpan status on/off dan threshold satu channel notifikasi milik user.
+type NotificationPreference struct {
+	ID        int64     `gorm:"column:id"`
+	UserID    int64     `gorm:"column:user_id"`
+	Channel   string    `gorm:"column:channel"`
+	Enabled   bool      `gorm:"column:enabled"`
+	Threshold float64   `gorm:"column:threshold;type:decimal(15,2)"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// PeriodLock menyimpan tanggal terakhir periode yang sudah ditutup (closing the books) milik user.
+// Transaksi bertanggal pada atau sebelum LockedThroughDate tidak boleh diubah.
+type PeriodLock struct {
+	ID                int64     `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID            int64     `gorm:"column:user_id"`
+	LockedThroughDate time.Time `gorm:"column:locked_through_date;type:date"`
+	CreatedAt         time.Time `gorm:"column:created_at"`
+	UpdatedAt         time.Time `gorm:"column:updated_at"`
+}
+
+// TableName mengembalikan nama tabel di database untuk model PeriodLock.
+func (PeriodLock) TableName() string {
+	return "period_locks"
+}
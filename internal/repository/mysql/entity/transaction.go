@@ -19,11 +19,20 @@ type Transaction struct {
 	UserID          int64           `gorm:"column:user_id"`
 	CategoryID      sql.NullInt64   `gorm:"column:category_id"` 
 	Amount          float64         `gorm:"column:amount;type:decimal(15,2)"` 
+	Currency        string          `gorm:"column:currency"` // Kode ISO 4217, transaksi lama memakai mata uang dasar
 	Type            TransactionType `gorm:"column:type"`                     
 	Description     sql.NullString  `gorm:"column:description"`           
+	Notes           sql.NullString  `gorm:"column:notes"` // Catatan pribadi yang lebih panjang dari description
+	Reference       sql.NullString  `gorm:"column:reference"` // Nomor referensi dari bank, unik per user
+	Latitude        sql.NullFloat64 `gorm:"column:latitude"`  // Lokasi transaksi (opsional) untuk peta pengeluaran
+	Longitude       sql.NullFloat64 `gorm:"column:longitude"`
+	Metadata        sql.NullString  `gorm:"column:metadata"`  // Key-value bebas milik user, disimpan sebagai JSON object
+	AttachmentURL   sql.NullString  `gorm:"column:attachment_url"` // URL foto struk di object storage, file tidak disimpan di sini
+	Version         int             `gorm:"column:version"`   // Naik setiap update, dipakai untuk optimistic locking
 	TransactionDate time.Time       `gorm:"column:transaction_date"`
 	CreatedAt       time.Time       `gorm:"column:created_at"`
 	UpdatedAt       time.Time       `gorm:"column:updated_at"`
+	DeletedAt       sql.NullTime    `gorm:"column:deleted_at"` // Terisi jika transaksi di-soft delete, bisa di-restore
 }
 
 // TableName mengembalikan nama tabel di database untuk model Transaction.
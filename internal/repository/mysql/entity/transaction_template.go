@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// TransactionTemplate merepresentasikan template transaksi (quick-add) milik user di database.
+type TransactionTemplate struct {
+	ID          int64           `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID      int64           `gorm:"column:user_id"`
+	Name        string          `gorm:"column:name"`
+	CategoryID  sql.NullInt64   `gorm:"column:category_id"`
+	Amount      float64         `gorm:"column:amount;type:decimal(15,2)"`
+	Type        TransactionType `gorm:"column:type"`
+	Description sql.NullString  `gorm:"column:description"`
+	CreatedAt   time.Time       `gorm:"column:created_at"`
+	UpdatedAt   time.Time       `gorm:"column:updated_at"`
+}
+
+// TableName mengembalikan nama tabel di database untuk model TransactionTemplate.
+func (TransactionTemplate) TableName() string {
+	return "transaction_templates"
+}
@@ -1,5 +1,7 @@
 package entity
 
+import "database/sql"
+
 type User struct {
 	ID       int64 `gorm:"primaryKey"`
 	Email    string
@@ -7,6 +9,8 @@ type User struct {
 	Password string
 	Name     string
 	Role     int8
+	Status   UserStatus `gorm:"default:1"`
+	Timezone sql.NullString
 }
 
 func (User) TableName() string {
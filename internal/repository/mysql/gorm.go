@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/pkg/errors"
@@ -44,6 +45,16 @@ func (repo *GormTrxSupport) Trx(trx TrxObj) *gorm.DB {
 	return repo.db
 }
 
+// Ping checks the database connection, every mysql repository can be used as usecase.HealthChecker
+func (repo *GormTrxSupport) Ping(ctx context.Context) error {
+	sqlDB, err := repo.db.DB()
+	if err != nil {
+		return errors.Wrap(err, "GormTrxSupport.Ping")
+	}
+
+	return sqlDB.PingContext(ctx)
+}
+
 // Commit Commit db transaction
 func (trx *GormTrxObj) Commit() error {
 	return trx.db.Commit().Error
@@ -0,0 +1,121 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/rakahikmah/finance-tracking/config"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+
+	errwrap "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IImportJobRepository mendefinisikan interface untuk job import transaksi.
+type IImportJobRepository interface {
+	TrxSupportRepo
+	Create(ctx context.Context, dbTrx TrxObj, params *entity.ImportJob) error
+	GetByID(ctx context.Context, ID int64) (result *entity.ImportJob, err error)
+	GetByIDAndUserID(ctx context.Context, ID int64, userID int64) (result *entity.ImportJob, err error)
+	LockByID(ctx context.Context, dbTrx TrxObj, ID int64) (result *entity.ImportJob, err error)
+	Update(ctx context.Context, dbTrx TrxObj, params *entity.ImportJob, changes *entity.ImportJob) error
+}
+
+// ImportJobRepository adalah implementasi repository untuk entitas ImportJob.
+type ImportJobRepository struct {
+	GormTrxSupport
+}
+
+// NewImportJobRepository membuat instance baru dari ImportJobRepository.
+func NewImportJobRepository(mysql *config.Mysql) *ImportJobRepository {
+	return &ImportJobRepository{GormTrxSupport{db: mysql.DB}}
+}
+
+// Create menyimpan job import baru.
+func (r *ImportJobRepository) Create(ctx context.Context, dbTrx TrxObj, params *entity.ImportJob) error {
+	funcName := "ImportJobRepository.Create"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	if err := r.Trx(dbTrx).Create(params).Error; err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	return nil
+}
+
+// GetByID mengambil job import berdasarkan ID, dipakai worker yang tidak membawa konteks user.
+func (r *ImportJobRepository) GetByID(ctx context.Context, ID int64) (result *entity.ImportJob, err error) {
+	funcName := "ImportJobRepository.GetByID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.db.Where("id = ?", ID).First(&result).Error
+	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.ErrRecordNotFound()
+	}
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetByIDAndUserID mengambil job import milik user. Mengembalikan apperr.ErrRecordNotFound jika bukan milik user.
+func (r *ImportJobRepository) GetByIDAndUserID(ctx context.Context, ID int64, userID int64) (result *entity.ImportJob, err error) {
+	funcName := "ImportJobRepository.GetByIDAndUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.db.Where("id = ? AND user_id = ?", ID, userID).First(&result).Error
+	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.ErrRecordNotFound()
+	}
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// LockByID mengambil job import dengan SELECT ... FOR UPDATE sehingga progress tidak diubah dua worker sekaligus.
+func (r *ImportJobRepository) LockByID(ctx context.Context, dbTrx TrxObj, ID int64) (result *entity.ImportJob, err error) {
+	funcName := "ImportJobRepository.LockByID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.Trx(dbTrx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ID).First(&result).Error
+	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.ErrRecordNotFound()
+	}
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// Update memperbarui kolom job import yang tidak kosong pada changes.
+func (r *ImportJobRepository) Update(ctx context.Context, dbTrx TrxObj, params *entity.ImportJob, changes *entity.ImportJob) error {
+	funcName := "ImportJobRepository.Update"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	if err := r.Trx(dbTrx).Model(params).Updates(*changes).Error; err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	return nil
+}
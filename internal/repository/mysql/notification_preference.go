@@ -0,0 +1,106 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/rakahikmah/finance-tracking/config"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+
+	errwrap "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// INotificationPreferenceRepository mendefinisikan interface untuk preferensi notifikasi user.
+type INotificationPreferenceRepository interface {
+	TrxSupportRepo
+	GetByUserID(ctx context.Context, userID int64) (result []*entity.NotificationPreference, err error)
+	GetByUserIDAndChannel(ctx context.Context, userID int64, channel string) (result *entity.NotificationPreference, err error)
+	Upsert(ctx context.Context, dbTrx TrxObj, params *entity.NotificationPreference) error
+	GetUserIDsByEnabledChannel(ctx context.Context, channel string) (result []int64, err error)
+}
+
+// NotificationPreferenceRepository adalah implementasi repository untuk entitas NotificationPreference.
+type NotificationPreferenceRepository struct {
+	GormTrxSupport
+}
+
+// NewNotificationPreferenceRepository membuat instance baru dari NotificationPreferenceRepository.
+func NewNotificationPreferenceRepository(mysql *config.Mysql) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{GormTrxSupport{db: mysql.DB}}
+}
+
+// GetByUserID mengambil semua preferensi notifikasi yang pernah disimpan user.
+func (r *NotificationPreferenceRepository) GetByUserID(ctx context.Context, userID int64) (result []*entity.NotificationPreference, err error) {
+	funcName := "NotificationPreferenceRepository.GetByUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.db.Where("user_id = ?", userID).Find(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetByUserIDAndChannel mengambil preferensi satu channel milik user.
+func (r *NotificationPreferenceRepository) GetByUserIDAndChannel(ctx context.Context, userID int64, channel string) (result *entity.NotificationPreference, err error) {
+	funcName := "NotificationPreferenceRepository.GetByUserIDAndChannel"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.db.Where("user_id = ? AND channel = ?", userID, channel).First(&result).Error
+	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.ErrRecordNotFound()
+	}
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// Upsert menyimpan preferensi, memperbarui baris yang sudah ada untuk (user_id, channel) yang sama.
+func (r *NotificationPreferenceRepository) Upsert(ctx context.Context, dbTrx TrxObj, params *entity.NotificationPreference) error {
+	funcName := "NotificationPreferenceRepository.Upsert"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	err := r.Trx(dbTrx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "channel"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "threshold", "updated_at"}),
+	}).Create(params).Error
+	if err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	return nil
+}
+
+// GetUserIDsByEnabledChannel mengambil ID semua user yang mengaktifkan channel tertentu.
+func (r *NotificationPreferenceRepository) GetUserIDsByEnabledChannel(ctx context.Context, channel string) (result []int64, err error) {
+	funcName := "NotificationPreferenceRepository.GetUserIDsByEnabledChannel"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.db.Model(&entity.NotificationPreference{}).
+		Where("channel = ? AND enabled = ?", channel, true).
+		Order("user_id ASC").
+		Pluck("user_id", &result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,86 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/rakahikmah/finance-tracking/config"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+
+	errwrap "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IPeriodLockRepository mendefinisikan interface untuk kunci periode user.
+type IPeriodLockRepository interface {
+	TrxSupportRepo
+	GetByUserID(ctx context.Context, userID int64) (result *entity.PeriodLock, err error)
+	Upsert(ctx context.Context, dbTrx TrxObj, params *entity.PeriodLock) error
+	DeleteByUserID(ctx context.Context, dbTrx TrxObj, userID int64) error
+}
+
+// PeriodLockRepository adalah implementasi repository untuk entitas PeriodLock.
+type PeriodLockRepository struct {
+	GormTrxSupport
+}
+
+// NewPeriodLockRepository membuat instance baru dari PeriodLockRepository.
+func NewPeriodLockRepository(mysql *config.Mysql) *PeriodLockRepository {
+	return &PeriodLockRepository{GormTrxSupport{db: mysql.DB}}
+}
+
+// GetByUserID mengambil kunci periode user. Mengembalikan apperr.ErrRecordNotFound jika user belum mengunci periode.
+func (r *PeriodLockRepository) GetByUserID(ctx context.Context, userID int64) (result *entity.PeriodLock, err error) {
+	funcName := "PeriodLockRepository.GetByUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.db.Where("user_id = ?", userID).First(&result).Error
+	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.ErrRecordNotFound()
+	}
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// Upsert menyimpan kunci periode, memperbarui tanggal kunci jika user sudah memilikinya.
+func (r *PeriodLockRepository) Upsert(ctx context.Context, dbTrx TrxObj, params *entity.PeriodLock) error {
+	funcName := "PeriodLockRepository.Upsert"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	err := r.Trx(dbTrx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"locked_through_date", "updated_at"}),
+	}).Create(params).Error
+	if err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	return nil
+}
+
+// DeleteByUserID menghapus kunci periode user. Tidak error jika user belum mengunci periode.
+func (r *PeriodLockRepository) DeleteByUserID(ctx context.Context, dbTrx TrxObj, userID int64) error {
+	funcName := "PeriodLockRepository.DeleteByUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	err := r.Trx(dbTrx).Where("user_id = ?", userID).Delete(&entity.PeriodLock{}).Error
+	if err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	return nil
+}
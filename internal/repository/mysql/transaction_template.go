@@ -0,0 +1,128 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/rakahikmah/finance-tracking/config"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+
+	errwrap "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// ITransactionTemplateRepository mendefinisikan interface untuk operasi CRUD pada entitas TransactionTemplate.
+type ITransactionTemplateRepository interface {
+	TrxSupportRepo
+	GetByIDAndUserID(ctx context.Context, ID int64, userID int64) (result *entity.TransactionTemplate, err error)
+	GetAllByUserID(ctx context.Context, userID int64) (result []*entity.TransactionTemplate, err error)
+	Create(ctx context.Context, dbTrx TrxObj, params *entity.TransactionTemplate, nonZeroVal bool) error
+	Update(ctx context.Context, dbTrx TrxObj, params *entity.TransactionTemplate, changes *entity.TransactionTemplate) error
+	DeleteByIDAndUserID(ctx context.Context, dbTrx TrxObj, id int64, userID int64) error
+}
+
+// TransactionTemplateRepository adalah implementasi repository untuk entitas TransactionTemplate.
+type TransactionTemplateRepository struct {
+	GormTrxSupport
+}
+
+// NewTransactionTemplateRepository membuat instance baru dari TransactionTemplateRepository.
+func NewTransactionTemplateRepository(mysql *config.Mysql) *TransactionTemplateRepository {
+	return &TransactionTemplateRepository{GormTrxSupport{db: mysql.DB}}
+}
+
+// GetByIDAndUserID mengambil template berdasarkan ID dan user ID-nya, template milik user lain dianggap tidak ditemukan.
+func (r *TransactionTemplateRepository) GetByIDAndUserID(ctx context.Context, ID int64, userID int64) (result *entity.TransactionTemplate, err error) {
+	funcName := "TransactionTemplateRepository.GetByIDAndUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.db.Where("id = ? AND user_id = ?", ID, userID).First(&result).Error
+	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.ErrRecordNotFound()
+	}
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetAllByUserID mengambil semua template milik user, diurutkan berdasarkan nama.
+func (r *TransactionTemplateRepository) GetAllByUserID(ctx context.Context, userID int64) (result []*entity.TransactionTemplate, err error) {
+	funcName := "TransactionTemplateRepository.GetAllByUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.db.Where("user_id = ?", userID).Order("name ASC, id ASC").Find(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// Create membuat template baru.
+func (r *TransactionTemplateRepository) Create(ctx context.Context, dbTrx TrxObj, params *entity.TransactionTemplate, nonZeroVal bool) error {
+	funcName := "TransactionTemplateRepository.Create"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	cols := helper.NonZeroCols(params, nonZeroVal)
+	if err := r.Trx(dbTrx).Select(cols).Create(&params).Error; err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	return nil
+}
+
+// Update memperbarui template yang ada, difilter dengan user_id untuk otorisasi.
+func (r *TransactionTemplateRepository) Update(ctx context.Context, dbTrx TrxObj, params *entity.TransactionTemplate, changes *entity.TransactionTemplate) error {
+	funcName := "TransactionTemplateRepository.Update"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	if params.ID == 0 || params.UserID == 0 {
+		return errwrap.Wrap(apperr.ErrInvalidRequest().SetDetail("Template ID or User ID is missing."), funcName)
+	}
+
+	db := r.Trx(dbTrx).Model(params).Where("user_id = ?", params.UserID)
+
+	var err error
+	if changes != nil {
+		err = db.Updates(*changes).Error
+	} else {
+		err = db.Updates(helper.StructToMap(params, false)).Error
+	}
+
+	if err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	return nil
+}
+
+// DeleteByIDAndUserID menghapus template berdasarkan ID dan user ID-nya.
+func (r *TransactionTemplateRepository) DeleteByIDAndUserID(ctx context.Context, dbTrx TrxObj, id int64, userID int64) error {
+	funcName := "TransactionTemplateRepository.DeleteByIDAndUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	err := r.Trx(dbTrx).Where("id = ? AND user_id = ?", id, userID).Delete(&entity.TransactionTemplate{}).Error
+	if err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	return nil
+}
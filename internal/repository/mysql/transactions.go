@@ -2,42 +2,303 @@ package mysql
 
 import (
 	"context"
-	"database/sql" 
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/rakahikmah/finance-tracking/config"
-	"github.com/rakahikmah/finance-tracking/internal/helper"
- 	"github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity" 
 	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
 
 	errwrap "github.com/pkg/errors"
 	"gorm.io/gorm"
 )
 
-
 type TransactionWithCategory struct {
-	entity.Transaction            
-	CategoryName sql.NullString `gorm:"column:category_name"` 
+	entity.Transaction
+	CategoryName sql.NullString `gorm:"column:category_name"`
 }
 
 // TransactionSummaryByCategory adalah struct untuk menampung hasil ringkasan per kategori dan tipe.
 type TransactionSummaryByCategory struct {
-	CategoryName sql.NullString `gorm:"column:category_name"`
-	Type         string         `gorm:"column:type"`
-	TotalAmount  float64        `gorm:"column:total_amount"`
+	CategoryName     sql.NullString `gorm:"column:category_name"`
+	Currency         string         `gorm:"column:currency"`
+	Type             string         `gorm:"column:type"`
+	TotalAmount      float64        `gorm:"column:total_amount"`
+	TransactionCount int64          `gorm:"column:transaction_count"`
+}
+
+// DailySummaryRow menampung total amount per hari, mata uang, dan tipe transaksi.
+// transaction_day diformat di SQL (YYYY-MM-DD) sehingga tidak bergantung pada parseTime driver.
+type DailySummaryRow struct {
+	TransactionDay string                 `gorm:"column:transaction_day"`
+	Currency       string                 `gorm:"column:currency"`
+	Type           entity.TransactionType `gorm:"column:type"`
+	TotalAmount    float64                `gorm:"column:total_amount"`
+}
+
+// MonthlySummaryRow menampung total amount per bulan (YYYY-MM), mata uang, dan tipe transaksi.
+type MonthlySummaryRow struct {
+	Month       string                 `gorm:"column:month"`
+	Currency    string                 `gorm:"column:currency"`
+	Type        entity.TransactionType `gorm:"column:type"`
+	TotalAmount float64                `gorm:"column:total_amount"`
+}
+
+// TypeTotal menampung total amount per mata uang dan tipe transaksi.
+type TypeTotal struct {
+	Currency    string                 `gorm:"column:currency"`
+	Type        entity.TransactionType `gorm:"column:type"`
+	TotalAmount float64                `gorm:"column:total_amount"`
+}
+
+// DailyTotal menampung total amount transaksi per hari.
+type DailyTotal struct {
+	TransactionDay time.Time `gorm:"column:transaction_day"`
+	TotalAmount    float64   `gorm:"column:total_amount"`
+}
+
+// CategoryTypeTotal menampung total amount per category_id dan tipe transaksi.
+// CategoryID tidak valid untuk transaksi tanpa kategori.
+type CategoryTypeTotal struct {
+	CategoryID  sql.NullInt64          `gorm:"column:category_id"`
+	Type        entity.TransactionType `gorm:"column:type"`
+	TotalAmount float64                `gorm:"column:total_amount"`
+}
+
+// CategoryMonthTotal menampung total amount per kategori dan bulan (YYYY-MM).
+// CategoryID tidak valid untuk transaksi tanpa kategori.
+type CategoryMonthTotal struct {
+	CategoryID   sql.NullInt64 `gorm:"column:category_id"`
+	CategoryName string        `gorm:"column:category_name"`
+	Month        string        `gorm:"column:month"`
+	TotalAmount  float64       `gorm:"column:total_amount"`
+}
+
+// CategorySpending menampung total pengeluaran dan jumlah transaksi per kategori.
+// CategoryID tidak valid untuk transaksi tanpa kategori.
+type CategorySpending struct {
+	CategoryID       sql.NullInt64 `gorm:"column:category_id"`
+	CategoryName     string        `gorm:"column:category_name"`
+	TotalAmount      float64       `gorm:"column:total_amount"`
+	TransactionCount int64         `gorm:"column:transaction_count"`
+}
+
+// DateColumn adalah kolom tanggal yang dipakai untuk filter rentang dan urutan daftar transaksi.
+type DateColumn string
+
+const (
+	DateColumnTransactionDate DateColumn = "transaction_date" // Tanggal bisnis transaksi (default)
+	DateColumnCreatedAt       DateColumn = "created_at"       // Tanggal transaksi dicatat
+)
+
+// dateColumnSQL adalah ekspresi SQL tetap untuk tiap DateColumn. Nama kolom tidak pernah diambil dari input,
+// nilai di luar allowlist selalu jatuh ke transaction_date.
+var dateColumnSQL = map[DateColumn]struct {
+	filter string
+	order  string
+}{
+	DateColumnTransactionDate: {filter: "t.transaction_date", order: "t.transaction_date"},
+	// created_at bertipe timestamp, DATE() membuat end_date inklusif untuk seluruh hari tersebut
+	DateColumnCreatedAt: {filter: "DATE(t.created_at)", order: "t.created_at"},
+}
+
+// IsValidDateColumn memeriksa apakah kolom termasuk allowlist DateColumn.
+func IsValidDateColumn(column DateColumn) bool {
+	_, ok := dateColumnSQL[column]
+	return ok
+}
+
+// TransactionSort adalah urutan daftar transaksi yang boleh diminta client.
+type TransactionSort string
+
+const (
+	TransactionSortDateDesc   TransactionSort = "date_desc" // Default
+	TransactionSortDateAsc    TransactionSort = "date_asc"
+	TransactionSortAmountDesc TransactionSort = "amount_desc"
+	TransactionSortAmountAsc  TransactionSort = "amount_asc"
+)
+
+// transactionSortSQL memetakan TransactionSort ke kolom dan arah ORDER BY yang tetap. Kolom "date" mengikuti DateColumn filter.
+// Input client tidak pernah masuk ke query, nilai di luar allowlist selalu jatuh ke date_desc.
+var transactionSortSQL = map[TransactionSort]struct {
+	amount    bool
+	direction string
+}{
+	TransactionSortDateDesc:   {direction: "DESC"},
+	TransactionSortDateAsc:    {direction: "ASC"},
+	TransactionSortAmountDesc: {amount: true, direction: "DESC"},
+	TransactionSortAmountAsc:  {amount: true, direction: "ASC"},
+}
+
+// TransactionFilter adalah filter opsional untuk daftar transaksi user. Field kosong/nil tidak difilter.
+type TransactionFilter struct {
+	StartDate  string
+	EndDate    string
+	Type       entity.TransactionType
+	CategoryID *int64
+	// Metadata mencocokkan value metadata per key, key yang tidak lolos helper.ValidateMetadataKey membuat query gagal
+	Metadata map[string]string
+	// DateColumn menentukan kolom untuk StartDate/EndDate dan urutan, kosong berarti transaction_date
+	DateColumn DateColumn
+	// Sort menentukan urutan daftar, kosong atau di luar allowlist berarti date_desc
+	Sort TransactionSort
+	// IncludeDeleted ikut mengambil transaksi yang sudah di-soft delete, hanya untuk admin (debug/support)
+	IncludeDeleted bool
+}
+
+// dateColumn mengembalikan ekspresi SQL untuk DateColumn filter, default transaction_date.
+func (f TransactionFilter) dateColumn() (filter string, order string) {
+	column, ok := dateColumnSQL[f.DateColumn]
+	if !ok {
+		column = dateColumnSQL[DateColumnTransactionDate]
+	}
+	return column.filter, column.order
+}
+
+// orderBy mengembalikan klausa ORDER BY untuk Sort filter beserta kolom dan arah urutan utamanya.
+// id selalu menjadi urutan kedua dengan arah yang sama agar urutan stabil untuk nilai yang sama.
+func (f TransactionFilter) orderBy() (clause string, column string, direction string) {
+	sortSQL, ok := transactionSortSQL[f.Sort]
+	if !ok {
+		sortSQL = transactionSortSQL[TransactionSortDateDesc]
+	}
+
+	_, column = f.dateColumn()
+	if sortSQL.amount {
+		column = "t.amount"
+	}
+
+	return column + " " + sortSQL.direction + ", t.id " + sortSQL.direction, column, sortSQL.direction
+}
+
+// TransactionCursor adalah posisi transaksi terakhir yang sudah dilihat pada urutan filter.Sort.
+// Amount hanya dipakai untuk urutan amount, TransactionDate atau CreatedAt (sesuai filter.DateColumn) untuk urutan tanggal.
+// Semua nilai posisi ikut disimpan di token sehingga cursor tetap berlaku walaupun transaksinya sudah dihapus atau diubah.
+type TransactionCursor struct {
+	TransactionDate time.Time `json:"d"`
+	CreatedAt       time.Time `json:"c"`
+	Amount          float64   `json:"a"`
+	ID              int64     `json:"i"`
+}
+
+// Encode mengubah cursor menjadi token opaque (base64url dari JSON) untuk next_cursor.
+func (c TransactionCursor) Encode() string {
+	// Marshal struct berisi time.Time, float64, dan int64 tidak pernah gagal
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeTransactionCursor membaca token hasil TransactionCursor.Encode. Token yang rusak atau tanpa ID mengembalikan error.
+func DecodeTransactionCursor(token string) (*TransactionCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errwrap.Wrap(err, "decode cursor")
+	}
+	var cursor TransactionCursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return nil, errwrap.Wrap(err, "decode cursor")
+	}
+	if cursor.ID <= 0 {
+		return nil, errwrap.New("decode cursor: missing id")
+	}
+	return &cursor, nil
+}
+
+// CategoryUsage menampung jumlah pemakaian satu kategori pada transaksi user.
+type CategoryUsage struct {
+	CategoryID   int64  `gorm:"column:category_id"`
+	CategoryName string `gorm:"column:category_name"`
+	UsageCount   int64  `gorm:"column:usage_count"`
+}
+
+// AmountBucketCount menampung jumlah transaksi pada satu bucket amount.
+// BucketIndex adalah FLOOR(amount / bucket_size), sehingga bucket mencakup [index*size, (index+1)*size).
+type AmountBucketCount struct {
+	BucketIndex      int64 `gorm:"column:bucket_index"`
+	TransactionCount int64 `gorm:"column:transaction_count"`
+}
+
+// CategoryTransactionTotal menampung jumlah dan total amount seluruh transaksi yang mereferensikan satu kategori.
+type CategoryTransactionTotal struct {
+	TransactionCount int64   `gorm:"column:transaction_count"`
+	TotalAmount      float64 `gorm:"column:total_amount"`
+}
+
+// AmountAnomaly adalah transaksi (beserta nama kategori) yang amount-nya melebihi baseline kategorinya,
+// bersama mean dan standar deviasi amount kategori tersebut dalam rentang yang sama.
+type AmountAnomaly struct {
+	TransactionWithCategory
+	CategoryMean             float64 `gorm:"column:category_mean"`
+	CategoryStddev           float64 `gorm:"column:category_stddev"`
+	CategoryTransactionCount int64   `gorm:"column:category_transaction_count"`
+}
+
+// TransactionBounds menampung amount dan transaction_date terkecil/terbesar transaksi user.
+// Semua field NULL jika user belum memiliki transaksi.
+type TransactionBounds struct {
+	MinAmount sql.NullFloat64 `gorm:"column:min_amount"`
+	MaxAmount sql.NullFloat64 `gorm:"column:max_amount"`
+	MinDate   sql.NullString  `gorm:"column:min_date"`
+	MaxDate   sql.NullString  `gorm:"column:max_date"`
+}
+
+// TransactionDateRange adalah transaction_date (YYYY-MM-DD) paling awal dan paling akhir milik user.
+// Keduanya NULL jika user belum memiliki transaksi.
+type TransactionDateRange struct {
+	Earliest sql.NullString `gorm:"column:earliest"`
+	Latest   sql.NullString `gorm:"column:latest"`
 }
 
 // ITransactionRepository mendefinisikan interface untuk operasi CRUD pada entitas Transaction.
 type ITransactionRepository interface {
 	TrxSupportRepo // Warisan dari interface transaksi (biasanya ada di file mysql/common.go)
 
-	
 	GetByIDAndUserID(ctx context.Context, ID int64, userID int64) (e *entity.Transaction, err error)
 
 	Create(ctx context.Context, dbTrx TrxObj, params *entity.Transaction, nonZeroVal bool) error
 	Update(ctx context.Context, dbTrx TrxObj, params *entity.Transaction, changes *entity.Transaction) (err error)
 	DeleteByIDAndUserID(ctx context.Context, dbTrx TrxObj, id int64, userID int64) error
-	GetAllByUserID(ctx context.Context, userID int64) (result []*TransactionWithCategory, err error)
-	GetSummaryByCategoryAndTypeByUserID(ctx context.Context, userID int64, startDate, endDate string) (result []*TransactionSummaryByCategory, err error)
-	GetDailySummaryByUserID(ctx context.Context, userID int64, startDate, endDate string) (result []map[string]interface{}, err error)
+	GetDeletedByIDAndUserID(ctx context.Context, ID int64, userID int64) (result *entity.Transaction, err error)
+	RestoreByIDAndUserID(ctx context.Context, dbTrx TrxObj, id int64, userID int64) error
+	GetWithCategoryByIDAndUserID(ctx context.Context, ID int64, userID int64) (result *TransactionWithCategory, err error)
+	GetAllByUserID(ctx context.Context, userID int64, filter TransactionFilter, after *TransactionCursor, limit int) (result []*TransactionWithCategory, err error)
+	StreamAllByUserID(ctx context.Context, userID int64, filter TransactionFilter, fn func(row *TransactionWithCategory) error) error
+	GetSummaryByCategoryAndTypeByUserID(ctx context.Context, userID int64, startDate, endDate string, txType entity.TransactionType) (result []*TransactionSummaryByCategory, err error)
+	GetDailySummaryByUserID(ctx context.Context, userID int64, startDate, endDate string, includeAll bool) (result []*DailySummaryRow, err error)
+	GetDailyTotalsByCategoryID(ctx context.Context, userID int64, categoryID int64, currency string, startDate, endDate string) (result []*DailyTotal, err error)
+	GetMonthlySummaryByUserID(ctx context.Context, userID int64, startDate, endDate string, includeAll bool) (result []*MonthlySummaryRow, err error)
+	GetTotalsByType(ctx context.Context, userID int64, startDate, endDate string, includeAll bool) (result []*TypeTotal, err error)
+	GetBalanceBeforeDate(ctx context.Context, userID int64, currency string, date string, includeAll bool) (balance float64, err error)
+	GetDistinctTransactionDates(ctx context.Context, userID int64) (result []string, err error)
+	GetByUserIDAndDateRange(ctx context.Context, userID int64, startDate, endDate string) (result []*TransactionWithCategory, err error)
+	GetTotalsByCategoryID(ctx context.Context, userID int64, currency string, startDate, endDate string) (result []*CategoryTypeTotal, err error)
+	ListByUserID(ctx context.Context, userID int64, filter TransactionFilter, limit, offset int) (result []*TransactionWithCategory, err error)
+	CountByUserID(ctx context.Context, userID int64, filter TransactionFilter) (total int64, err error)
+	GetByUserIDAndReferences(ctx context.Context, userID int64, references []string) (result []*entity.Transaction, err error)
+	FindPossibleDuplicate(ctx context.Context, params *entity.Transaction, createdSince time.Time) (result *entity.Transaction, err error)
+	GetByDescriptionContains(ctx context.Context, userID int64, find string, caseSensitive bool) (result []*entity.Transaction, err error)
+	GetCategoryMonthTotals(ctx context.Context, userID int64, currency string, txType entity.TransactionType, startDate, endDate string) (result []*CategoryMonthTotal, err error)
+	GetTopExpenseCategories(ctx context.Context, userID int64, currency string, startDate, endDate string, limit int) (result []*CategorySpending, err error)
+	GetWithCoordinatesByUserID(ctx context.Context, userID int64, startDate, endDate string) (result []*TransactionWithCategory, err error)
+	GetTopCategoryByDescription(ctx context.Context, userID int64, description string) (result *CategoryUsage, err error)
+	GetYearsByUserID(ctx context.Context, userID int64) (result []int, err error)
+	GetDateRangeByUserID(ctx context.Context, userID int64) (result *TransactionDateRange, err error)
+	GetBoundsByUserID(ctx context.Context, userID int64) (result *TransactionBounds, err error)
+	GetTypesByUserID(ctx context.Context, userID int64) (result []entity.TransactionType, err error)
+	GetAmountBucketCounts(ctx context.Context, userID int64, currency string, txType entity.TransactionType, startDate, endDate string, bucketSize float64) (result []*AmountBucketCount, err error)
+	GetTotalByCategoryID(ctx context.Context, userID int64, categoryID int64) (result *CategoryTransactionTotal, err error)
+	ReassignCategory(ctx context.Context, dbTrx TrxObj, fromID, toID int64, userID int64) (affected int64, err error)
+	CountOrphanedTransactions(ctx context.Context, userID int64) (total int64, err error)
+	ClearOrphanedCategories(ctx context.Context, dbTrx TrxObj, userID int64) (affected int64, err error)
+	SearchByDescription(ctx context.Context, userID int64, query string, limit int) (result []*TransactionWithCategory, err error)
+	GetRecentWithDescription(ctx context.Context, userID int64, limit int) (result []*TransactionWithCategory, err error)
+	GetAmountAnomalies(ctx context.Context, userID int64, currency string, txType entity.TransactionType, startDate, endDate string, minTransactions int, stddevFactor float64) (result []*AmountAnomaly, err error)
+	BackfillCurrency(ctx context.Context, currency string) (affected int64, err error)
 }
 
 // TransactionRepository adalah implementasi repository untuk entitas Transaction.
@@ -50,33 +311,42 @@ func NewTransactionRepository(mysql *config.Mysql) *TransactionRepository {
 	return &TransactionRepository{GormTrxSupport{db: mysql.DB}}
 }
 
-
-
-// GetAllByUserID mengambil semua transaksi yang dimiliki oleh user tertentu, termasuk nama kategori.
-func (r *TransactionRepository) GetAllByUserID(ctx context.Context, userID int64) (result []*TransactionWithCategory, err error) {
+// GetAllByUserID mengambil maksimal limit transaksi yang dimiliki oleh user tertentu sesuai filter, termasuk nama kategori,
+// dengan urutan filter.Sort (default transaction_date DESC, id DESC). Urutan tanggal dan rentang StartDate/EndDate
+// mengikuti filter.DateColumn.
+// Transaksi yang sudah di-soft delete hanya ikut jika filter.IncludeDeleted true.
+// Jika after diberikan, hanya transaksi setelah posisi tersebut pada urutan yang sama yang diambil, sehingga halaman tetap stabil
+// walaupun ID tidak urut dengan transaction_date atau amount.
+func (r *TransactionRepository) GetAllByUserID(ctx context.Context, userID int64, filter TransactionFilter, after *TransactionCursor, limit int) (result []*TransactionWithCategory, err error) {
 	funcName := "TransactionRepository.GetAllByUserID"
 
 	if err := helper.CheckDeadline(ctx); err != nil {
 		return nil, errwrap.Wrap(err, funcName)
 	}
 
-	// Menggunakan Raw SQL untuk JOIN dan mengambil category_name
 	// Pastikan alias kolom `c.name` menjadi `category_name` agar cocok dengan TransactionWithCategory.
 	// Jika category_id adalah NULL, c.name juga akan NULL (LEFT JOIN).
-	query := `
-		SELECT
-			t.id, t.user_id, t.category_id, t.amount, t.type, t.description, t.transaction_date, t.created_at, t.updated_at,
-			c.name as category_name
-		FROM
-			transactions t
-		LEFT JOIN
-			categories c ON t.category_id = c.id
-		WHERE
-			t.user_id = ?
-		ORDER BY
-			t.transaction_date DESC, t.id DESC
-	`
-	err = r.db.Raw(query, userID).Scan(&result).Error
+	db := r.filterTransactions(userID, filter).
+		Select("t.id, t.user_id, t.category_id, t.amount, t.currency, t.type, t.description, t.notes, t.latitude, t.longitude, t.metadata, t.attachment_url, t.transaction_date, t.created_at, t.updated_at, t.version, t.deleted_at, c.name as category_name").
+		Joins("LEFT JOIN categories c ON t.category_id = c.id")
+	order, column, direction := filter.orderBy()
+	if after != nil {
+		var afterValue interface{} = after.TransactionDate.Format(helper.DateLayout)
+		switch column {
+		case "t.amount":
+			afterValue = after.Amount
+		case dateColumnSQL[DateColumnCreatedAt].order:
+			afterValue = after.CreatedAt
+		}
+		// column dan operator berasal dari allowlist, nilai cursor selalu lewat placeholder
+		operator := "<"
+		if direction == "ASC" {
+			operator = ">"
+		}
+		db = db.Where(column+" "+operator+" ? OR ("+column+" = ? AND t.id "+operator+" ?)", afterValue, afterValue, after.ID)
+	}
+
+	err = db.Order(order).Limit(limit).Scan(&result).Error
 	if errwrap.Is(err, gorm.ErrRecordNotFound) {
 		return []*TransactionWithCategory{}, nil // Mengembalikan slice kosong jika tidak ada record
 	}
@@ -87,9 +357,48 @@ func (r *TransactionRepository) GetAllByUserID(ctx context.Context, userID int64
 	return result, nil
 }
 
+// StreamAllByUserID membaca transaksi user sesuai filter dengan urutan yang sama dengan GetAllByUserID baris per baris
+// dan memanggil fn untuk tiap baris, sehingga memori tidak bertambah sesuai jumlah transaksi.
+// filter.DateColumn diabaikan seperti pada GetAllByUserID. Iterasi berhenti pada error pertama dari fn.
+func (r *TransactionRepository) StreamAllByUserID(ctx context.Context, userID int64, filter TransactionFilter, fn func(row *TransactionWithCategory) error) error {
+	funcName := "TransactionRepository.StreamAllByUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	filter.DateColumn = DateColumnTransactionDate
+	rows, err := r.filterTransactions(userID, filter).
+		Select("t.id, t.user_id, t.category_id, t.amount, t.currency, t.type, t.description, t.notes, t.latitude, t.longitude, t.metadata, t.attachment_url, t.transaction_date, t.created_at, t.updated_at, t.version, c.name as category_name").
+		Joins("LEFT JOIN categories c ON t.category_id = c.id").
+		Order("t.transaction_date DESC, t.id DESC").
+		Rows()
+	if err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row TransactionWithCategory
+		if err := r.db.ScanRows(rows, &row); err != nil {
+			return errwrap.Wrap(err, funcName)
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	return nil
+}
+
 // GetByIDAndUserID mengambil transaksi berdasarkan ID dan user ID-nya.
 // Ini penting untuk otorisasi agar user hanya bisa melihat/memodifikasi transaksinya sendiri.
 // Mengembalikan *entity.Transaction karena tidak selalu perlu nama kategori di sini.
+// Transaksi yang sudah di-soft delete dianggap tidak ada.
 func (r *TransactionRepository) GetByIDAndUserID(ctx context.Context, ID int64, userID int64) (result *entity.Transaction, err error) {
 	funcName := "TransactionRepository.GetByIDAndUserID"
 
@@ -98,7 +407,7 @@ func (r *TransactionRepository) GetByIDAndUserID(ctx context.Context, ID int64,
 	}
 
 	// Wajib menambahkan filter WHERE user_id = ? untuk keamanan!
-	err = r.db.Where("id = ? AND user_id = ?", ID, userID).First(&result).Error
+	err = r.db.Where("id = ? AND user_id = ? AND deleted_at IS NULL", ID, userID).First(&result).Error
 	if errwrap.Is(err, gorm.ErrRecordNotFound) {
 		return nil, apperr.ErrRecordNotFound()
 	}
@@ -109,34 +418,70 @@ func (r *TransactionRepository) GetByIDAndUserID(ctx context.Context, ID int64,
 	return result, nil
 }
 
-// GetDailySummaryByUserID contoh fungsi untuk mendapatkan ringkasan transaksi per hari untuk user tertentu.
-// Ini bisa dikembangkan lebih lanjut (misal: filter type, category, etc.)
-func (r *TransactionRepository) GetDailySummaryByUserID(ctx context.Context, userID int64, startDate, endDate string) (result []map[string]interface{}, err error) {
+// GetWithCategoryByIDAndUserID mengambil satu transaksi milik user beserta nama kategorinya.
+// Mengembalikan ErrRecordNotFound jika transaksi tidak ada atau milik user lain.
+func (r *TransactionRepository) GetWithCategoryByIDAndUserID(ctx context.Context, ID int64, userID int64) (result *TransactionWithCategory, err error) {
+	funcName := "TransactionRepository.GetWithCategoryByIDAndUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	var row TransactionWithCategory
+	err = r.db.Table("transactions t").
+		Select("t.id, t.user_id, t.category_id, t.amount, t.currency, t.type, t.description, t.notes, t.latitude, t.longitude, t.metadata, t.attachment_url, t.transaction_date, t.created_at, t.updated_at, t.version, c.name as category_name").
+		Joins("LEFT JOIN categories c ON t.category_id = c.id").
+		Where("t.id = ? AND t.user_id = ? AND t.deleted_at IS NULL", ID, userID).
+		Take(&row).Error
+	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.ErrRecordNotFound()
+	}
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return &row, nil
+}
+
+// excludedFromTotalsCondition menyaring transaksi pada kategori yang ditandai exclude_from_totals.
+// Query pemakainya harus LEFT JOIN categories c ON t.category_id = c.id; transaksi tanpa kategori tetap dihitung.
+const excludedFromTotalsCondition = ` AND COALESCE(c.exclude_from_totals, 0) = 0`
+
+// GetDailySummaryByUserID mengambil ringkasan transaksi per hari, mata uang, dan tipe untuk user tertentu.
+// Amount dengan mata uang berbeda tidak pernah dijumlahkan. Transaksi pada kategori exclude_from_totals tidak dihitung kecuali includeAll true.
+func (r *TransactionRepository) GetDailySummaryByUserID(ctx context.Context, userID int64, startDate, endDate string, includeAll bool) (result []*DailySummaryRow, err error) {
 	funcName := "TransactionRepository.GetDailySummaryByUserID"
 
 	if err := helper.CheckDeadline(ctx); err != nil {
 		return nil, errwrap.Wrap(err, funcName)
 	}
 
-	// Contoh SQL untuk ringkasan harian
+	where := `t.user_id = ? AND t.deleted_at IS NULL AND t.transaction_date BETWEEN ? AND ?`
+	if !includeAll {
+		where += excludedFromTotalsCondition
+	}
+
 	// Sum amount by transaction_date and type, grouped by user_id
 	err = r.db.Raw(`
 		SELECT
-			DATE(transaction_date) as transaction_day,
-			type,
-			SUM(amount) as total_amount
+			DATE_FORMAT(t.transaction_date, '%Y-%m-%d') as transaction_day,
+			t.currency,
+			t.type,
+			SUM(t.amount) as total_amount
 		FROM
-			transactions
+			transactions t
+		LEFT JOIN
+			categories c ON t.category_id = c.id
 		WHERE
-			user_id = ? AND transaction_date BETWEEN ? AND ?
+			`+where+`
 		GROUP BY
-			transaction_day, type
+			transaction_day, t.currency, t.type
 		ORDER BY
-			transaction_day ASC, type ASC
+			transaction_day ASC, t.currency ASC, t.type ASC
 	`, userID, startDate, endDate).Scan(&result).Error
 
 	if errwrap.Is(err, gorm.ErrRecordNotFound) {
-		return []map[string]interface{}{}, nil // Mengembalikan slice kosong jika tidak ada record
+		return []*DailySummaryRow{}, nil // Mengembalikan slice kosong jika tidak ada record
 	}
 	if err != nil {
 		return nil, errwrap.Wrap(err, funcName)
@@ -144,7 +489,8 @@ func (r *TransactionRepository) GetDailySummaryByUserID(ctx context.Context, use
 	return result, nil
 }
 
-// Create membuat transaksi baru.
+// Create membuat transaksi baru. Transaksi baru selalu dimulai dari version 1.
+// Amount harus lebih dari 0 apa pun pemanggilnya, tipe transaksi yang menentukan arah saldo.
 func (r *TransactionRepository) Create(ctx context.Context, dbTrx TrxObj, params *entity.Transaction, nonZeroVal bool) error {
 	funcName := "TransactionRepository.Create"
 
@@ -152,12 +498,20 @@ func (r *TransactionRepository) Create(ctx context.Context, dbTrx TrxObj, params
 		return errwrap.Wrap(err, funcName)
 	}
 
+	if params.Amount <= 0 {
+		return errwrap.Wrap(apperr.ErrInvalidRequest().SetDetail("Transaction amount must be greater than 0."), funcName)
+	}
+	if params.Version == 0 {
+		params.Version = 1
+	}
 	cols := helper.NonZeroCols(params, nonZeroVal)
 	return r.Trx(dbTrx).Select(cols).Create(&params).Error
 }
 
-// Update memperbarui transaksi yang ada.
-// Wajib menambahkan filter user_id untuk otorisasi.
+// Update memperbarui transaksi yang ada dengan optimistic locking: baris hanya diubah jika version-nya masih
+// sama dengan params.Version, lalu version dinaikkan satu. Jika tidak ada baris yang berubah berarti transaksi
+// sudah diubah request lain sejak dibaca, dan ErrConflict dikembalikan. params.Version 0 (version tidak ikut
+// dibaca) melewati pengecekan version. Amount yang ditulis harus lebih dari 0; pada changes, amount 0 berarti tidak diubah.
 func (r *TransactionRepository) Update(ctx context.Context, dbTrx TrxObj, params *entity.Transaction, changes *entity.Transaction) error {
 	funcName := "TransactionRepository.Update"
 
@@ -168,25 +522,36 @@ func (r *TransactionRepository) Update(ctx context.Context, dbTrx TrxObj, params
 	if params.ID == 0 || params.UserID == 0 {
 		return errwrap.Wrap(apperr.ErrInvalidRequest().SetDetail("Transaction ID or User ID is missing."), funcName)
 	}
+	if (changes == nil && params.Amount <= 0) || (changes != nil && changes.Amount < 0) {
+		return errwrap.Wrap(apperr.ErrInvalidRequest().SetDetail("Transaction amount must be greater than 0."), funcName)
+	}
 
 	db := r.Trx(dbTrx).Model(params).Where("user_id = ?", params.UserID)
+	if params.Version > 0 {
+		db = db.Where("version = ?", params.Version)
+	}
 
-	var err error
+	var values map[string]any
 	if changes != nil {
-		err = db.Updates(*changes).Error
+		values = helper.StructToMap(changes, true)
 	} else {
-		err = db.Updates(helper.StructToMap(params, false)).Error
+		values = helper.StructToMap(params, false)
 	}
+	values["Version"] = gorm.Expr("version + 1")
 
-	if err != nil {
-		return errwrap.Wrap(err, funcName)
+	result := db.Updates(values)
+	if result.Error != nil {
+		return errwrap.Wrap(result.Error, funcName)
+	}
+	if params.Version > 0 && result.RowsAffected == 0 {
+		return apperr.ErrConflict().SetDetail("Transaction was modified by another request")
 	}
 
 	return nil
 }
 
-// DeleteByIDAndUserID menghapus transaksi berdasarkan ID dan user ID-nya.
-// Wajib menambahkan filter user_id untuk otorisasi.
+// DeleteByIDAndUserID melakukan soft delete transaksi berdasarkan ID dan user ID-nya dengan mengisi deleted_at,
+// sehingga transaksi bisa dikembalikan lewat RestoreByIDAndUserID. Wajib menambahkan filter user_id untuk otorisasi.
 func (r *TransactionRepository) DeleteByIDAndUserID(ctx context.Context, dbTrx TrxObj, id int64, userID int64) error {
 	funcName := "TransactionRepository.DeleteByIDAndUserID"
 
@@ -198,7 +563,9 @@ func (r *TransactionRepository) DeleteByIDAndUserID(ctx context.Context, dbTrx T
 		return errwrap.Wrap(apperr.ErrInvalidRequest().SetDetail("User ID is missing for delete operation."), funcName)
 	}
 
-	err := r.Trx(dbTrx).Where("id = ? AND user_id = ?", id, userID).Delete(&entity.Transaction{}).Error
+	err := r.Trx(dbTrx).Model(&entity.Transaction{}).
+		Where("id = ? AND user_id = ? AND deleted_at IS NULL", id, userID).
+		Update("deleted_at", helper.DatetimeNowJakarta()).Error
 	if err != nil {
 		return errwrap.Wrap(err, funcName)
 	}
@@ -206,31 +573,84 @@ func (r *TransactionRepository) DeleteByIDAndUserID(ctx context.Context, dbTrx T
 	return nil
 }
 
+// GetDeletedByIDAndUserID mengambil transaksi milik user yang sudah di-soft delete.
+// Mengembalikan ErrRecordNotFound jika transaksi tidak ada, milik user lain, atau belum dihapus.
+func (r *TransactionRepository) GetDeletedByIDAndUserID(ctx context.Context, ID int64, userID int64) (result *entity.Transaction, err error) {
+	funcName := "TransactionRepository.GetDeletedByIDAndUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.db.Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", ID, userID).First(&result).Error
+	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.ErrRecordNotFound()
+	}
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// RestoreByIDAndUserID mengembalikan transaksi yang sudah di-soft delete dengan mengosongkan deleted_at.
+// Wajib menambahkan filter user_id untuk otorisasi.
+func (r *TransactionRepository) RestoreByIDAndUserID(ctx context.Context, dbTrx TrxObj, id int64, userID int64) error {
+	funcName := "TransactionRepository.RestoreByIDAndUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	if userID == 0 {
+		return errwrap.Wrap(apperr.ErrInvalidRequest().SetDetail("User ID is missing for restore operation."), funcName)
+	}
 
-func (r *TransactionRepository) GetSummaryByCategoryAndTypeByUserID(ctx context.Context, userID int64, startDate, endDate string) (result []*TransactionSummaryByCategory, err error) {
+	err := r.Trx(dbTrx).Model(&entity.Transaction{}).
+		Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).
+		Update("deleted_at", nil).Error
+	if err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	return nil
+}
+
+// GetSummaryByCategoryAndTypeByUserID mengambil total transaksi per kategori, mata uang, dan tipe dalam rentang tanggal.
+// txType kosong berarti income dan expense, selain itu hanya tipe tersebut yang diambil.
+func (r *TransactionRepository) GetSummaryByCategoryAndTypeByUserID(ctx context.Context, userID int64, startDate, endDate string, txType entity.TransactionType) (result []*TransactionSummaryByCategory, err error) {
 	funcName := "TransactionRepository.GetSummaryByCategoryAndTypeByUserID"
 
 	if err := helper.CheckDeadline(ctx); err != nil {
 		return nil, errwrap.Wrap(err, funcName)
 	}
 
+	where := `t.user_id = ? AND t.deleted_at IS NULL AND t.transaction_date BETWEEN ? AND ?`
+	args := []interface{}{userID, startDate, endDate}
+	if txType != "" {
+		where += ` AND t.type = ?`
+		args = append(args, txType)
+	}
+
 	query := `
 		SELECT
 			COALESCE(c.name, 'Uncategorized') as category_name, -- Gunakan COALESCE untuk kategori NULL
+			t.currency,
 			t.type,
-			SUM(t.amount) as total_amount
+			SUM(t.amount) as total_amount,
+			COUNT(t.id) as transaction_count
 		FROM
 			transactions t
 		LEFT JOIN
 			categories c ON t.category_id = c.id
 		WHERE
-			t.user_id = ? AND t.transaction_date BETWEEN ? AND ?
+			` + where + `
 		GROUP BY
-			category_name, t.type
+			category_name, t.currency, t.type
 		ORDER BY
-			category_name ASC, t.type ASC
+			category_name ASC, t.currency ASC, t.type ASC
 	`
-	err = r.db.Raw(query, userID, startDate, endDate).Scan(&result).Error
+	err = r.db.Raw(query, args...).Scan(&result).Error
 
 	if errwrap.Is(err, gorm.ErrRecordNotFound) {
 		return []*TransactionSummaryByCategory{}, nil // Mengembalikan slice kosong jika tidak ada record
@@ -239,4 +659,872 @@ func (r *TransactionRepository) GetSummaryByCategoryAndTypeByUserID(ctx context.
 		return nil, errwrap.Wrap(err, funcName)
 	}
 	return result, nil
-}
\ No newline at end of file
+}
+
+// GetDailyTotalsByCategoryID mengambil total pengeluaran (type expense) dalam satu mata uang per hari untuk satu kategori milik user tertentu.
+// Kategori tidak memiliki tipe sendiri sehingga bisa berisi income dan expense, karena itu hanya expense yang dijumlahkan.
+// Hari tanpa transaksi tidak dikembalikan, pengisian gap dilakukan di usecase.
+func (r *TransactionRepository) GetDailyTotalsByCategoryID(ctx context.Context, userID int64, categoryID int64, currency string, startDate, endDate string) (result []*DailyTotal, err error) {
+	funcName := "TransactionRepository.GetDailyTotalsByCategoryID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := `
+		SELECT
+			DATE(t.transaction_date) as transaction_day,
+			SUM(t.amount) as total_amount
+		FROM
+			transactions t
+		WHERE
+			t.user_id = ? AND t.deleted_at IS NULL AND t.category_id = ? AND t.currency = ? AND t.type = ?
+			AND DATE(t.transaction_date) BETWEEN ? AND ?
+		GROUP BY
+			transaction_day
+		ORDER BY
+			transaction_day ASC
+	`
+	err = r.db.Raw(query, userID, categoryID, currency, entity.TransactionTypeExpense, startDate, endDate).Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetBalanceBeforeDate menghitung saldo (total income dikurangi total expense) dari semua transaksi dalam satu mata uang sebelum tanggal tertentu.
+// Dipakai sebagai saldo awal untuk perhitungan saldo kumulatif. Transaksi pada kategori exclude_from_totals
+// tidak dihitung kecuali includeAll true.
+func (r *TransactionRepository) GetBalanceBeforeDate(ctx context.Context, userID int64, currency string, date string, includeAll bool) (balance float64, err error) {
+	funcName := "TransactionRepository.GetBalanceBeforeDate"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return 0, errwrap.Wrap(err, funcName)
+	}
+
+	where := `t.user_id = ? AND t.deleted_at IS NULL AND t.currency = ? AND t.transaction_date < ?`
+	if !includeAll {
+		where += excludedFromTotalsCondition
+	}
+
+	query := `
+		SELECT
+			COALESCE(SUM(CASE WHEN t.type = ? THEN t.amount ELSE -t.amount END), 0) as balance
+		FROM
+			transactions t
+		LEFT JOIN
+			categories c ON t.category_id = c.id
+		WHERE
+			` + where
+	err = r.db.Raw(query, entity.TransactionTypeIncome, userID, currency, date).Scan(&balance).Error
+	if err != nil {
+		return 0, errwrap.Wrap(err, funcName)
+	}
+
+	return balance, nil
+}
+
+// GetMonthlySummaryByUserID mengambil ringkasan transaksi per bulan, mata uang, dan tipe dalam rentang tanggal, diurutkan berdasarkan bulan, mata uang, lalu tipe.
+// Bulan tanpa transaksi tidak dikembalikan. Transaksi pada kategori exclude_from_totals tidak dihitung kecuali includeAll true.
+func (r *TransactionRepository) GetMonthlySummaryByUserID(ctx context.Context, userID int64, startDate, endDate string, includeAll bool) (result []*MonthlySummaryRow, err error) {
+	funcName := "TransactionRepository.GetMonthlySummaryByUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	where := `t.user_id = ? AND t.deleted_at IS NULL AND t.transaction_date BETWEEN ? AND ?`
+	if !includeAll {
+		where += excludedFromTotalsCondition
+	}
+
+	query := `
+		SELECT
+			DATE_FORMAT(t.transaction_date, '%Y-%m') as month,
+			t.currency,
+			t.type,
+			SUM(t.amount) as total_amount
+		FROM
+			transactions t
+		LEFT JOIN
+			categories c ON t.category_id = c.id
+		WHERE
+			` + where + `
+		GROUP BY
+			month, t.currency, t.type
+		ORDER BY
+			month ASC, t.currency ASC, t.type ASC
+	`
+	err = r.db.Raw(query, userID, startDate, endDate).Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetTotalsByType menjumlahkan amount transaksi user per mata uang dan tipe dalam rentang tanggal (inklusif).
+// Tipe tanpa transaksi tidak dikembalikan. Transaksi pada kategori exclude_from_totals tidak dihitung kecuali includeAll true.
+func (r *TransactionRepository) GetTotalsByType(ctx context.Context, userID int64, startDate, endDate string, includeAll bool) (result []*TypeTotal, err error) {
+	funcName := "TransactionRepository.GetTotalsByType"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	where := `t.user_id = ? AND t.deleted_at IS NULL AND t.transaction_date BETWEEN ? AND ?`
+	if !includeAll {
+		where += excludedFromTotalsCondition
+	}
+
+	query := `
+		SELECT
+			t.currency,
+			t.type,
+			SUM(t.amount) as total_amount
+		FROM
+			transactions t
+		LEFT JOIN
+			categories c ON t.category_id = c.id
+		WHERE
+			` + where + `
+		GROUP BY
+			t.currency, t.type
+		ORDER BY
+			t.currency ASC, t.type ASC
+	`
+	err = r.db.Raw(query, userID, startDate, endDate).Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetDistinctTransactionDates mengambil daftar tanggal unik (YYYY-MM-DD, urut naik) di mana user mencatat transaksi.
+func (r *TransactionRepository) GetDistinctTransactionDates(ctx context.Context, userID int64) (result []string, err error) {
+	funcName := "TransactionRepository.GetDistinctTransactionDates"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := `
+		SELECT DISTINCT
+			DATE_FORMAT(t.transaction_date, '%Y-%m-%d') as transaction_day
+		FROM
+			transactions t
+		WHERE
+			t.user_id = ? AND t.deleted_at IS NULL
+		ORDER BY
+			transaction_day ASC
+	`
+	err = r.db.Raw(query, userID).Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetByUserIDAndDateRange mengambil transaksi user (beserta nama kategori) dengan transaction_date di antara startDate dan endDate (inklusif).
+func (r *TransactionRepository) GetByUserIDAndDateRange(ctx context.Context, userID int64, startDate, endDate string) (result []*TransactionWithCategory, err error) {
+	funcName := "TransactionRepository.GetByUserIDAndDateRange"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := `
+		SELECT
+			t.id, t.user_id, t.category_id, t.amount, t.currency, t.type, t.description, t.notes, t.latitude, t.longitude, t.metadata, t.attachment_url, t.transaction_date, t.created_at, t.updated_at, t.version,
+			c.name as category_name
+		FROM
+			transactions t
+		LEFT JOIN
+			categories c ON t.category_id = c.id
+		WHERE
+			t.user_id = ? AND t.deleted_at IS NULL
+			AND t.transaction_date BETWEEN ? AND ?
+		ORDER BY
+			t.transaction_date ASC, t.id ASC
+	`
+	err = r.db.Raw(query, userID, startDate, endDate).Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetTotalsByCategoryID mengambil total amount per category_id dan tipe transaksi dalam satu mata uang dan rentang tanggal.
+func (r *TransactionRepository) GetTotalsByCategoryID(ctx context.Context, userID int64, currency string, startDate, endDate string) (result []*CategoryTypeTotal, err error) {
+	funcName := "TransactionRepository.GetTotalsByCategoryID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := `
+		SELECT
+			t.category_id,
+			t.type,
+			SUM(t.amount) as total_amount
+		FROM
+			transactions t
+		WHERE
+			t.user_id = ? AND t.deleted_at IS NULL AND t.currency = ? AND t.transaction_date BETWEEN ? AND ?
+		GROUP BY
+			t.category_id, t.type
+	`
+	err = r.db.Raw(query, userID, currency, startDate, endDate).Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// filterTransactions membangun FROM dan WHERE yang sama untuk ListByUserID, CountByUserID, dan GetAllByUserID,
+// sehingga total pagination selalu dihitung dari filter yang identik dengan daftar.
+func (r *TransactionRepository) filterTransactions(userID int64, filter TransactionFilter) *gorm.DB {
+	where := "t.user_id = ? AND t.deleted_at IS NULL"
+	if filter.IncludeDeleted {
+		where = "t.user_id = ?"
+	}
+	db := r.db.Table("transactions t").Where(where, userID)
+	dateColumn, _ := filter.dateColumn()
+
+	if filter.StartDate != "" {
+		db = db.Where(dateColumn+" >= ?", filter.StartDate)
+	}
+	if filter.EndDate != "" {
+		db = db.Where(dateColumn+" <= ?", filter.EndDate)
+	}
+	if filter.Type != "" {
+		db = db.Where("t.type = ?", filter.Type)
+	}
+	if filter.CategoryID != nil {
+		db = db.Where("t.category_id = ?", *filter.CategoryID)
+	}
+	// Key diurutkan agar query yang dihasilkan selalu sama untuk filter yang sama
+	keys := make([]string, 0, len(filter.Metadata))
+	for key := range filter.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		// Key disusun menjadi JSON path, jadi key yang tidak lolos validasi menggagalkan query dan tidak pernah sampai ke database
+		if err := helper.ValidateMetadataKey(key); err != nil {
+			db.AddError(apperr.ErrInvalidRequest().SetDetail("Invalid metadata filter: " + err.Error()))
+			return db
+		}
+		db = db.Where("JSON_UNQUOTE(JSON_EXTRACT(t.metadata, ?)) = ?", `$."`+key+`"`, filter.Metadata[key])
+	}
+
+	return db
+}
+
+// ListByUserID mengambil satu halaman transaksi user (beserta nama kategori) sesuai filter.
+func (r *TransactionRepository) ListByUserID(ctx context.Context, userID int64, filter TransactionFilter, limit, offset int) (result []*TransactionWithCategory, err error) {
+	funcName := "TransactionRepository.ListByUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	order, _, _ := filter.orderBy()
+
+	err = r.filterTransactions(userID, filter).
+		Select("t.id, t.user_id, t.category_id, t.amount, t.currency, t.type, t.description, t.notes, t.latitude, t.longitude, t.metadata, t.attachment_url, t.transaction_date, t.created_at, t.updated_at, t.version, t.deleted_at, c.name as category_name").
+		Joins("LEFT JOIN categories c ON t.category_id = c.id").
+		Order(order).
+		Limit(limit).
+		Offset(offset).
+		Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// CountByUserID menghitung total transaksi user sesuai filter, tanpa LIMIT/OFFSET, untuk meta pagination.
+func (r *TransactionRepository) CountByUserID(ctx context.Context, userID int64, filter TransactionFilter) (total int64, err error) {
+	funcName := "TransactionRepository.CountByUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return 0, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.filterTransactions(userID, filter).Count(&total).Error
+	if err != nil {
+		return 0, errwrap.Wrap(err, funcName)
+	}
+
+	return total, nil
+}
+
+// GetByUserIDAndReferences mengambil transaksi user yang memiliki salah satu nomor referensi yang diberikan.
+// Transaksi yang sudah di-soft delete ikut dikembalikan beserta deleted_at karena reference tetap unik per user
+// di database, pemanggil wajib memeriksa DeletedAt (sync melewatinya agar tidak membuat ulang transaksi yang sengaja dihapus user).
+func (r *TransactionRepository) GetByUserIDAndReferences(ctx context.Context, userID int64, references []string) (result []*entity.Transaction, err error) {
+	funcName := "TransactionRepository.GetByUserIDAndReferences"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.db.Where("user_id = ? AND reference IN ?", userID, references).Find(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// FindPossibleDuplicate mengambil transaksi terbaru milik params.UserID yang dibuat sejak createdSince dengan amount,
+// currency, type, kategori, dan transaction_date yang sama dengan params. Mengembalikan nil jika tidak ada.
+// Dipakai untuk mendeteksi transaksi yang tidak sengaja dikirim dua kali.
+func (r *TransactionRepository) FindPossibleDuplicate(ctx context.Context, params *entity.Transaction, createdSince time.Time) (result *entity.Transaction, err error) {
+	funcName := "TransactionRepository.FindPossibleDuplicate"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	db := r.db.Where("user_id = ? AND deleted_at IS NULL AND amount = ? AND currency = ? AND type = ? AND transaction_date = ? AND created_at >= ?",
+		params.UserID, params.Amount, params.Currency, params.Type, params.TransactionDate.Format(helper.DateLayout), createdSince)
+	if params.CategoryID.Valid {
+		db = db.Where("category_id = ?", params.CategoryID.Int64)
+	} else {
+		db = db.Where("category_id IS NULL")
+	}
+
+	var rows []*entity.Transaction
+	err = db.Order("id DESC").Limit(1).Find(&rows).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	return rows[0], nil
+}
+
+// GetByDescriptionContains mengambil transaksi user yang description-nya mengandung find, diurutkan berdasarkan ID.
+// Tanpa caseSensitive perbandingan memakai LOWER, dengan caseSensitive memakai LIKE BINARY.
+func (r *TransactionRepository) GetByDescriptionContains(ctx context.Context, userID int64, find string, caseSensitive bool) (result []*entity.Transaction, err error) {
+	funcName := "TransactionRepository.GetByDescriptionContains"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	db := r.db.Where("user_id = ? AND deleted_at IS NULL", userID)
+	if caseSensitive {
+		db = db.Where("description LIKE BINARY ?", helper.LikeContains(find))
+	} else {
+		db = db.Where("LOWER(description) LIKE ?", helper.LikeContains(strings.ToLower(find)))
+	}
+
+	err = db.Order("id ASC").Find(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetCategoryMonthTotals mengambil total amount per kategori dan bulan untuk satu mata uang dan tipe transaksi dalam rentang tanggal,
+// diurutkan berdasarkan kategori lalu bulan. Transaksi tanpa kategori dikelompokkan sebagai 'Uncategorized'.
+func (r *TransactionRepository) GetCategoryMonthTotals(ctx context.Context, userID int64, currency string, txType entity.TransactionType, startDate, endDate string) (result []*CategoryMonthTotal, err error) {
+	funcName := "TransactionRepository.GetCategoryMonthTotals"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := `
+		SELECT
+			t.category_id,
+			COALESCE(c.name, 'Uncategorized') as category_name,
+			DATE_FORMAT(t.transaction_date, '%Y-%m') as month,
+			SUM(t.amount) as total_amount
+		FROM
+			transactions t
+		LEFT JOIN
+			categories c ON t.category_id = c.id
+		WHERE
+			t.user_id = ? AND t.deleted_at IS NULL AND t.currency = ? AND t.type = ? AND t.transaction_date BETWEEN ? AND ?
+		GROUP BY
+			t.category_id, category_name, month
+		ORDER BY
+			category_name ASC, t.category_id ASC, month ASC
+	`
+	err = r.db.Raw(query, userID, currency, txType, startDate, endDate).Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetTopExpenseCategories mengambil maksimal limit kategori dengan total pengeluaran (type expense) terbesar dalam satu mata uang
+// dan rentang tanggal, diurutkan dari total terbesar. Transaksi tanpa kategori dikelompokkan sebagai 'Uncategorized'.
+func (r *TransactionRepository) GetTopExpenseCategories(ctx context.Context, userID int64, currency string, startDate, endDate string, limit int) (result []*CategorySpending, err error) {
+	funcName := "TransactionRepository.GetTopExpenseCategories"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := `
+		SELECT
+			t.category_id,
+			COALESCE(c.name, 'Uncategorized') as category_name,
+			SUM(t.amount) as total_amount,
+			COUNT(t.id) as transaction_count
+		FROM
+			transactions t
+		LEFT JOIN
+			categories c ON t.category_id = c.id
+		WHERE
+			t.user_id = ? AND t.deleted_at IS NULL AND t.currency = ? AND t.type = ? AND t.transaction_date BETWEEN ? AND ?
+		GROUP BY
+			t.category_id, category_name
+		ORDER BY
+			total_amount DESC, category_name ASC
+		LIMIT ?
+	`
+	err = r.db.Raw(query, userID, currency, entity.TransactionTypeExpense, startDate, endDate, limit).Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetWithCoordinatesByUserID mengambil transaksi user (beserta nama kategori) dalam rentang tanggal yang memiliki latitude dan longitude.
+// Transaksi tanpa koordinat tidak dikembalikan.
+func (r *TransactionRepository) GetWithCoordinatesByUserID(ctx context.Context, userID int64, startDate, endDate string) (result []*TransactionWithCategory, err error) {
+	funcName := "TransactionRepository.GetWithCoordinatesByUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := `
+		SELECT
+			t.id, t.user_id, t.category_id, t.amount, t.currency, t.type, t.description, t.notes, t.latitude, t.longitude, t.metadata, t.attachment_url, t.transaction_date, t.created_at, t.updated_at, t.version,
+			c.name as category_name
+		FROM
+			transactions t
+		LEFT JOIN
+			categories c ON t.category_id = c.id
+		WHERE
+			t.user_id = ? AND t.deleted_at IS NULL
+			AND t.transaction_date BETWEEN ? AND ?
+			AND t.latitude IS NOT NULL AND t.longitude IS NOT NULL
+		ORDER BY
+			t.transaction_date ASC, t.id ASC
+	`
+	err = r.db.Raw(query, userID, startDate, endDate).Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetTopCategoryByDescription mengambil kategori yang paling sering dipakai user untuk transaksi dengan description
+// yang mengandung teks tersebut (case-insensitive). Seri diputus oleh transaksi terbaru.
+// Mengembalikan nil jika tidak ada transaksi berkategori yang cocok.
+func (r *TransactionRepository) GetTopCategoryByDescription(ctx context.Context, userID int64, description string) (result *CategoryUsage, err error) {
+	funcName := "TransactionRepository.GetTopCategoryByDescription"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := `
+		SELECT
+			t.category_id,
+			c.name as category_name,
+			COUNT(*) as usage_count
+		FROM
+			transactions t
+		JOIN
+			categories c ON t.category_id = c.id
+		WHERE
+			t.user_id = ? AND t.deleted_at IS NULL AND LOWER(t.description) LIKE ?
+		GROUP BY
+			t.category_id, c.name
+		ORDER BY
+			usage_count DESC, MAX(t.transaction_date) DESC, t.category_id ASC
+		LIMIT 1
+	`
+	var rows []*CategoryUsage
+	err = r.db.Raw(query, userID, helper.LikeContains(strings.ToLower(description))).Scan(&rows).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	return rows[0], nil
+}
+
+// GetYearsByUserID mengambil tahun-tahun (menurun) yang memiliki transaksi milik user.
+// transaction_date adalah kolom DATE yang sudah disimpan sebagai tanggal lokal (Asia/Jakarta),
+// sehingga batas tahun mengikuti zona waktu user tanpa konversi tambahan.
+func (r *TransactionRepository) GetYearsByUserID(ctx context.Context, userID int64) (result []int, err error) {
+	funcName := "TransactionRepository.GetYearsByUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := `
+		SELECT DISTINCT
+			YEAR(t.transaction_date) as year
+		FROM
+			transactions t
+		WHERE
+			t.user_id = ? AND t.deleted_at IS NULL
+		ORDER BY
+			year DESC
+	`
+	err = r.db.Raw(query, userID).Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetDateRangeByUserID mengambil transaction_date (YYYY-MM-DD) paling awal dan paling akhir dari transaksi user.
+func (r *TransactionRepository) GetDateRangeByUserID(ctx context.Context, userID int64) (result *TransactionDateRange, err error) {
+	funcName := "TransactionRepository.GetDateRangeByUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := `
+		SELECT
+			DATE_FORMAT(MIN(t.transaction_date), '%Y-%m-%d') as earliest,
+			DATE_FORMAT(MAX(t.transaction_date), '%Y-%m-%d') as latest
+		FROM
+			transactions t
+		WHERE
+			t.user_id = ? AND t.deleted_at IS NULL
+	`
+	result = &TransactionDateRange{}
+	err = r.db.Raw(query, userID).Scan(result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetBoundsByUserID mengambil amount dan transaction_date (YYYY-MM-DD) terkecil dan terbesar dari seluruh transaksi user.
+func (r *TransactionRepository) GetBoundsByUserID(ctx context.Context, userID int64) (result *TransactionBounds, err error) {
+	funcName := "TransactionRepository.GetBoundsByUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := `
+		SELECT
+			MIN(t.amount) as min_amount,
+			MAX(t.amount) as max_amount,
+			DATE_FORMAT(MIN(t.transaction_date), '%Y-%m-%d') as min_date,
+			DATE_FORMAT(MAX(t.transaction_date), '%Y-%m-%d') as max_date
+		FROM
+			transactions t
+		WHERE
+			t.user_id = ? AND t.deleted_at IS NULL
+	`
+	result = &TransactionBounds{}
+	err = r.db.Raw(query, userID).Scan(result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetTypesByUserID mengambil tipe transaksi (urut abjad) yang pernah dicatat user.
+func (r *TransactionRepository) GetTypesByUserID(ctx context.Context, userID int64) (result []entity.TransactionType, err error) {
+	funcName := "TransactionRepository.GetTypesByUserID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := `
+		SELECT DISTINCT
+			t.type
+		FROM
+			transactions t
+		WHERE
+			t.user_id = ? AND t.deleted_at IS NULL
+		ORDER BY
+			t.type ASC
+	`
+	err = r.db.Raw(query, userID).Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetAmountBucketCounts menghitung jumlah transaksi satu mata uang dan tipe dalam rentang tanggal per bucket amount berukuran bucketSize,
+// diurutkan dari bucket terkecil. Hanya bucket yang memiliki transaksi yang dikembalikan.
+func (r *TransactionRepository) GetAmountBucketCounts(ctx context.Context, userID int64, currency string, txType entity.TransactionType, startDate, endDate string, bucketSize float64) (result []*AmountBucketCount, err error) {
+	funcName := "TransactionRepository.GetAmountBucketCounts"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := `
+		SELECT
+			FLOOR(t.amount / ?) as bucket_index,
+			COUNT(*) as transaction_count
+		FROM
+			transactions t
+		WHERE
+			t.user_id = ? AND t.deleted_at IS NULL AND t.currency = ? AND t.type = ? AND t.transaction_date BETWEEN ? AND ?
+		GROUP BY
+			bucket_index
+		ORDER BY
+			bucket_index ASC
+	`
+	err = r.db.Raw(query, bucketSize, userID, currency, txType, startDate, endDate).Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetTotalByCategoryID menghitung jumlah dan total amount seluruh transaksi user yang mereferensikan satu kategori,
+// tanpa batas tanggal dan tanpa membedakan tipe transaksi.
+func (r *TransactionRepository) GetTotalByCategoryID(ctx context.Context, userID int64, categoryID int64) (result *CategoryTransactionTotal, err error) {
+	funcName := "TransactionRepository.GetTotalByCategoryID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := `
+		SELECT
+			COUNT(*) as transaction_count,
+			COALESCE(SUM(t.amount), 0) as total_amount
+		FROM
+			transactions t
+		WHERE
+			t.user_id = ? AND t.deleted_at IS NULL AND t.category_id = ?
+	`
+	result = &CategoryTransactionTotal{}
+	err = r.db.Raw(query, userID, categoryID).Scan(result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// ReassignCategory memindahkan seluruh transaksi user dari kategori fromID ke kategori toID, termasuk transaksi
+// yang sudah di-soft delete agar tetap berkategori jika di-restore. Version setiap transaksi ikut dinaikkan.
+// Mengembalikan jumlah transaksi yang dipindahkan.
+func (r *TransactionRepository) ReassignCategory(ctx context.Context, dbTrx TrxObj, fromID, toID int64, userID int64) (affected int64, err error) {
+	funcName := "TransactionRepository.ReassignCategory"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return 0, errwrap.Wrap(err, funcName)
+	}
+
+	if userID == 0 {
+		return 0, errwrap.Wrap(apperr.ErrInvalidRequest().SetDetail("User ID is missing for reassign operation."), funcName)
+	}
+
+	result := r.Trx(dbTrx).Model(&entity.Transaction{}).
+		Where("user_id = ? AND category_id = ?", userID, fromID).
+		Updates(map[string]any{"category_id": toID, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return 0, errwrap.Wrap(result.Error, funcName)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// orphanedCategoryCondition memilih transaksi yang category_id-nya terisi tetapi kategorinya sudah tidak ada di tabel categories.
+const orphanedCategoryCondition = `user_id = ? AND category_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM categories c WHERE c.id = transactions.category_id)`
+
+// CountOrphanedTransactions menghitung transaksi user (termasuk yang sudah di-soft delete) yang category_id-nya
+// menunjuk ke kategori yang sudah tidak ada.
+func (r *TransactionRepository) CountOrphanedTransactions(ctx context.Context, userID int64) (total int64, err error) {
+	funcName := "TransactionRepository.CountOrphanedTransactions"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return 0, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.db.Model(&entity.Transaction{}).Where(orphanedCategoryCondition, userID).Count(&total).Error
+	if err != nil {
+		return 0, errwrap.Wrap(err, funcName)
+	}
+
+	return total, nil
+}
+
+// ClearOrphanedCategories mengosongkan category_id transaksi user yang kategorinya sudah tidak ada sehingga
+// transaksi tersebut menjadi tanpa kategori. Version setiap transaksi ikut dinaikkan.
+// Mengembalikan jumlah transaksi yang diperbaiki.
+func (r *TransactionRepository) ClearOrphanedCategories(ctx context.Context, dbTrx TrxObj, userID int64) (affected int64, err error) {
+	funcName := "TransactionRepository.ClearOrphanedCategories"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return 0, errwrap.Wrap(err, funcName)
+	}
+
+	if userID == 0 {
+		return 0, errwrap.Wrap(apperr.ErrInvalidRequest().SetDetail("User ID is missing for orphan cleanup."), funcName)
+	}
+
+	result := r.Trx(dbTrx).Model(&entity.Transaction{}).
+		Where(orphanedCategoryCondition, userID).
+		Updates(map[string]any{"category_id": nil, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return 0, errwrap.Wrap(result.Error, funcName)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// describedTransactions membangun FROM dan WHERE untuk transaksi user yang memiliki description,
+// beserta nama kategori, diurutkan dari yang terbaru.
+func (r *TransactionRepository) describedTransactions(userID int64) *gorm.DB {
+	return r.db.Table("transactions t").
+		Select("t.id, t.user_id, t.category_id, t.amount, t.currency, t.type, t.description, t.notes, t.latitude, t.longitude, t.metadata, t.attachment_url, t.transaction_date, t.created_at, t.updated_at, t.version, c.name as category_name").
+		Joins("LEFT JOIN categories c ON t.category_id = c.id").
+		Where("t.user_id = ? AND t.deleted_at IS NULL AND t.description IS NOT NULL AND t.description <> ''", userID).
+		Order("t.transaction_date DESC, t.id DESC")
+}
+
+// SearchByDescription mengambil maksimal limit transaksi user terbaru yang description-nya mengandung query (case-insensitive).
+func (r *TransactionRepository) SearchByDescription(ctx context.Context, userID int64, query string, limit int) (result []*TransactionWithCategory, err error) {
+	funcName := "TransactionRepository.SearchByDescription"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.describedTransactions(userID).
+		Where("LOWER(t.description) LIKE ?", helper.LikeContains(strings.ToLower(query))).
+		Limit(limit).
+		Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetRecentWithDescription mengambil maksimal limit transaksi user terbaru yang memiliki description.
+// Dipakai sebagai kandidat terbatas untuk pencocokan fuzzy di usecase agar tidak memindai seluruh tabel.
+func (r *TransactionRepository) GetRecentWithDescription(ctx context.Context, userID int64, limit int) (result []*TransactionWithCategory, err error) {
+	funcName := "TransactionRepository.GetRecentWithDescription"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	err = r.describedTransactions(userID).
+		Limit(limit).
+		Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetAmountAnomalies mengambil transaksi satu mata uang dan tipe dalam rentang tanggal yang amount-nya lebih besar dari
+// mean + stddevFactor * stddev (populasi) kategorinya pada rentang yang sama, diurutkan dari yang terbaru.
+// Kategori dengan kurang dari minTransactions transaksi dan transaksi tanpa kategori dilewati karena tidak punya baseline.
+func (r *TransactionRepository) GetAmountAnomalies(ctx context.Context, userID int64, currency string, txType entity.TransactionType, startDate, endDate string, minTransactions int, stddevFactor float64) (result []*AmountAnomaly, err error) {
+	funcName := "TransactionRepository.GetAmountAnomalies"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	query := `
+		SELECT
+			t.id, t.user_id, t.category_id, t.amount, t.currency, t.type, t.description, t.notes, t.latitude, t.longitude, t.metadata, t.attachment_url, t.transaction_date, t.created_at, t.updated_at, t.version,
+			c.name as category_name,
+			s.category_mean, s.category_stddev, s.category_transaction_count
+		FROM
+			transactions t
+		JOIN (
+			SELECT
+				category_id,
+				AVG(amount) as category_mean,
+				STDDEV_POP(amount) as category_stddev,
+				COUNT(*) as category_transaction_count
+			FROM
+				transactions
+			WHERE
+				user_id = ? AND deleted_at IS NULL AND currency = ? AND type = ? AND transaction_date BETWEEN ? AND ? AND category_id IS NOT NULL
+			GROUP BY
+				category_id
+			HAVING
+				COUNT(*) >= ?
+		) s ON t.category_id = s.category_id
+		LEFT JOIN
+			categories c ON t.category_id = c.id
+		WHERE
+			t.user_id = ? AND t.deleted_at IS NULL AND t.currency = ? AND t.type = ? AND t.transaction_date BETWEEN ? AND ?
+			AND t.amount > s.category_mean + ? * s.category_stddev
+		ORDER BY
+			t.transaction_date DESC, t.id DESC
+	`
+	err = r.db.Raw(query,
+		userID, currency, txType, startDate, endDate, minTransactions,
+		userID, currency, txType, startDate, endDate, stddevFactor,
+	).Scan(&result).Error
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// BackfillCurrency mengisi currency transaksi lama (NULL, dibuat sebelum kolom currency ada) dengan mata uang dasar.
+// Dipanggil saat API start karena mata uang dasar berasal dari konfigurasi, bukan dari migrasi.
+func (r *TransactionRepository) BackfillCurrency(ctx context.Context, currency string) (affected int64, err error) {
+	funcName := "TransactionRepository.BackfillCurrency"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return 0, errwrap.Wrap(err, funcName)
+	}
+
+	result := r.db.Model(&entity.Transaction{}).
+		Where("currency IS NULL").
+		UpdateColumn("currency", currency)
+	if result.Error != nil {
+		return 0, errwrap.Wrap(result.Error, funcName)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,955 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rakahikmah/finance-tracking/config"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	"github.com/stretchr/testify/suite"
+	gmysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type TransactionRepositoryTestSuite struct {
+	suite.Suite
+	mock   sqlmock.Sqlmock
+	db     *sql.DB
+	repo   *mysql.TransactionRepository
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func TestTransactionRepository(t *testing.T) {
+	suite.Run(t, new(TransactionRepositoryTestSuite))
+}
+
+func (s *TransactionRepositoryTestSuite) SetupTest() {
+	var err error
+	s.db, s.mock, err = sqlmock.New()
+	if err != nil {
+		s.Failf("an error '%s' was not expected when opening a stub database connection", err.Error())
+	}
+
+	dialector := gmysql.New(gmysql.Config{Conn: s.db, SkipInitializeWithVersion: true})
+	gormDB, _ := gorm.Open(dialector, &gorm.Config{})
+	s.repo = mysql.NewTransactionRepository(&config.Mysql{DB: gormDB})
+	s.ctx, s.cancel = context.WithDeadline(context.Background(), time.Now().Add(time.Hour))
+}
+
+func (s *TransactionRepositoryTestSuite) TearDownTest() {
+	s.cancel()
+	s.db.Close()
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetDailySummaryByUserID() {
+	// Driver MySQL mengembalikan DECIMAL SUM sebagai bytes, pastikan tetap ter-scan menjadi float64
+	rows := sqlmock.NewRows([]string{"transaction_day", "type", "total_amount"}).
+		AddRow([]byte("2024-01-05"), []byte("expense"), []byte("12000.50")).
+		AddRow([]byte("2024-01-05"), []byte("income"), []byte("500000.00"))
+	s.mock.ExpectQuery("SELECT(.+)FROM(.+)transactions").
+		WithArgs(int64(1), "2024-01-01", "2024-01-31").
+		WillReturnRows(rows)
+
+	result, err := s.repo.GetDailySummaryByUserID(s.ctx, 1, "2024-01-01", "2024-01-31", true)
+	s.Require().NoError(err)
+
+	s.Equal([]*mysql.DailySummaryRow{
+		{TransactionDay: "2024-01-05", Type: entity.TransactionTypeExpense, TotalAmount: 12000.5},
+		{TransactionDay: "2024-01-05", Type: entity.TransactionTypeIncome, TotalAmount: 500000},
+	}, result)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestExcludeFromTotals() {
+	s.Run("daily summary skips excluded categories by default", func() {
+		s.mock.ExpectQuery(`LEFT JOIN categories c ON t.category_id = c.id WHERE t.user_id = \? AND t.deleted_at IS NULL AND t.transaction_date BETWEEN \? AND \? AND COALESCE\(c.exclude_from_totals, 0\) = 0`).
+			WithArgs(int64(1), "2024-01-01", "2024-01-31").
+			WillReturnRows(sqlmock.NewRows([]string{"transaction_day", "type", "total_amount"}))
+
+		_, err := s.repo.GetDailySummaryByUserID(s.ctx, 1, "2024-01-01", "2024-01-31", false)
+		s.Require().NoError(err)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("balance skips excluded categories by default", func() {
+		s.mock.ExpectQuery(`WHERE t.user_id = \? AND t.deleted_at IS NULL AND t.currency = \? AND t.transaction_date < \? AND COALESCE\(c.exclude_from_totals, 0\) = 0`).
+			WithArgs(entity.TransactionTypeIncome, int64(1), "IDR", "2024-01-01").
+			WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow([]byte("1500.00")))
+
+		balance, err := s.repo.GetBalanceBeforeDate(s.ctx, 1, "IDR", "2024-01-01", false)
+		s.Require().NoError(err)
+		s.Equal(1500.0, balance)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("include all keeps every category", func() {
+		s.mock.ExpectQuery(`WHERE t.user_id = \? AND t.deleted_at IS NULL AND t.currency = \? AND t.transaction_date < \?\s*$`).
+			WithArgs(entity.TransactionTypeIncome, int64(1), "IDR", "2024-01-01").
+			WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow([]byte("2500.00")))
+
+		balance, err := s.repo.GetBalanceBeforeDate(s.ctx, 1, "IDR", "2024-01-01", true)
+		s.Require().NoError(err)
+		s.Equal(2500.0, balance)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("totals by type skip excluded categories by default", func() {
+		s.mock.ExpectQuery(`WHERE t.user_id = \? AND t.deleted_at IS NULL AND t.transaction_date BETWEEN \? AND \? AND COALESCE\(c.exclude_from_totals, 0\) = 0 GROUP BY t.currency, t.type`).
+			WithArgs(int64(1), "2024-01-01", "2024-01-31").
+			WillReturnRows(sqlmock.NewRows([]string{"currency", "type", "total_amount"}).
+				AddRow([]byte("IDR"), []byte("expense"), []byte("1250.50")).
+				AddRow([]byte("IDR"), []byte("income"), []byte("5000.00")))
+
+		result, err := s.repo.GetTotalsByType(s.ctx, 1, "2024-01-01", "2024-01-31", false)
+		s.Require().NoError(err)
+		s.Equal([]*mysql.TypeTotal{
+			{Currency: "IDR", Type: entity.TransactionTypeExpense, TotalAmount: 1250.5},
+			{Currency: "IDR", Type: entity.TransactionTypeIncome, TotalAmount: 5000},
+		}, result)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *TransactionRepositoryTestSuite) TestListAndCountUseSameFilter() {
+	categoryID := int64(7)
+
+	testCases := []struct {
+		name   string
+		filter mysql.TransactionFilter
+		where  string
+		args   []driver.Value
+		order  string
+	}{
+		{
+			name:  "no filter",
+			where: "WHERE t.user_id = ? AND t.deleted_at IS NULL",
+			args:  []driver.Value{int64(1)},
+		},
+		{
+			name:   "date range",
+			filter: mysql.TransactionFilter{StartDate: "2024-01-01", EndDate: "2024-01-31"},
+			where:  "WHERE (t.user_id = ? AND t.deleted_at IS NULL) AND t.transaction_date >= ? AND t.transaction_date <= ?",
+			args:   []driver.Value{int64(1), "2024-01-01", "2024-01-31"},
+		},
+		{
+			name:   "type and category",
+			filter: mysql.TransactionFilter{Type: entity.TransactionTypeExpense, CategoryID: &categoryID},
+			where:  "WHERE (t.user_id = ? AND t.deleted_at IS NULL) AND t.type = ? AND t.category_id = ?",
+			args:   []driver.Value{int64(1), "expense", int64(7)},
+		},
+		{
+			name:   "all filters",
+			filter: mysql.TransactionFilter{StartDate: "2024-01-01", EndDate: "2024-01-31", Type: entity.TransactionTypeIncome, CategoryID: &categoryID},
+			where:  "WHERE (t.user_id = ? AND t.deleted_at IS NULL) AND t.transaction_date >= ? AND t.transaction_date <= ? AND t.type = ? AND t.category_id = ?",
+			args:   []driver.Value{int64(1), "2024-01-01", "2024-01-31", "income", int64(7)},
+		},
+		{
+			name:   "date range by created_at",
+			filter: mysql.TransactionFilter{StartDate: "2024-01-01", EndDate: "2024-01-31", DateColumn: mysql.DateColumnCreatedAt},
+			where:  "WHERE (t.user_id = ? AND t.deleted_at IS NULL) AND DATE(t.created_at) >= ? AND DATE(t.created_at) <= ?",
+			args:   []driver.Value{int64(1), "2024-01-01", "2024-01-31"},
+			order:  "ORDER BY t.created_at DESC, t.id DESC",
+		},
+		{
+			name:   "metadata keys in sorted order",
+			filter: mysql.TransactionFilter{Type: entity.TransactionTypeExpense, Metadata: map[string]string{"trip": "bali", "project": "PRJ-7"}},
+			where:  "WHERE (t.user_id = ? AND t.deleted_at IS NULL) AND t.type = ? AND JSON_UNQUOTE(JSON_EXTRACT(t.metadata, ?)) = ? AND JSON_UNQUOTE(JSON_EXTRACT(t.metadata, ?)) = ?",
+			args:   []driver.Value{int64(1), "expense", `$."project"`, "PRJ-7", `$."trip"`, "bali"},
+		},
+		{
+			name:   "sort by amount",
+			filter: mysql.TransactionFilter{Sort: mysql.TransactionSortAmountDesc},
+			where:  "WHERE t.user_id = ? AND t.deleted_at IS NULL",
+			args:   []driver.Value{int64(1)},
+			order:  "ORDER BY t.amount DESC, t.id DESC",
+		},
+		{
+			name:   "sort injection attempt falls back to date_desc",
+			filter: mysql.TransactionFilter{Sort: mysql.TransactionSort("amount_desc, (SELECT SLEEP(5))")},
+			where:  "WHERE t.user_id = ? AND t.deleted_at IS NULL",
+			args:   []driver.Value{int64(1)},
+		},
+		{
+			name:   "unknown date column falls back to transaction_date",
+			filter: mysql.TransactionFilter{StartDate: "2024-01-01", DateColumn: mysql.DateColumn("amount; DROP TABLE transactions")},
+			where:  "WHERE (t.user_id = ? AND t.deleted_at IS NULL) AND t.transaction_date >= ?",
+			args:   []driver.Value{int64(1), "2024-01-01"},
+		},
+	}
+
+	for _, tt := range testCases {
+		s.Run(tt.name, func() {
+			where := regexp.QuoteMeta(tt.where)
+			order := tt.order
+			if order == "" {
+				order = "ORDER BY t.transaction_date DESC, t.id DESC"
+			}
+
+			s.mock.ExpectQuery(`SELECT count\(\*\) FROM transactions t ` + where + `$`).
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+			s.mock.ExpectQuery(`SELECT (.+) FROM transactions t LEFT JOIN categories c ON t.category_id = c.id ` + where + ` ` + regexp.QuoteMeta(order) + ` LIMIT \?`).
+				WithArgs(append(tt.args, 10, 20)...).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			total, err := s.repo.CountByUserID(s.ctx, 1, tt.filter)
+			s.Require().NoError(err)
+			s.Equal(int64(42), total)
+
+			_, err = s.repo.ListByUserID(s.ctx, 1, tt.filter, 10, 20)
+			s.Require().NoError(err)
+
+			s.NoError(s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetMonthlySummaryByUserID() {
+	s.mock.ExpectQuery(`SELECT DATE_FORMAT\(t.transaction_date, '%Y-%m'\) as month(.+)WHERE t.user_id = \? AND t.deleted_at IS NULL AND t.transaction_date BETWEEN \? AND \? AND COALESCE\(c.exclude_from_totals, 0\) = 0 GROUP BY month, t.currency, t.type ORDER BY month ASC, t.currency ASC, t.type ASC`).
+		WithArgs(int64(1), "2024-01-01", "2024-12-31").
+		WillReturnRows(sqlmock.NewRows([]string{"month", "currency", "type", "total_amount"}).
+			AddRow([]byte("2024-01"), []byte("IDR"), []byte("expense"), []byte("3000.00")).
+			AddRow([]byte("2024-01"), []byte("USD"), []byte("expense"), []byte("45.50")).
+			AddRow([]byte("2024-03"), []byte("IDR"), []byte("income"), []byte("5000.00")))
+
+	result, err := s.repo.GetMonthlySummaryByUserID(s.ctx, 1, "2024-01-01", "2024-12-31", false)
+	s.Require().NoError(err)
+
+	s.Equal([]*mysql.MonthlySummaryRow{
+		{Month: "2024-01", Currency: "IDR", Type: entity.TransactionTypeExpense, TotalAmount: 3000},
+		{Month: "2024-01", Currency: "USD", Type: entity.TransactionTypeExpense, TotalAmount: 45.5},
+		{Month: "2024-03", Currency: "IDR", Type: entity.TransactionTypeIncome, TotalAmount: 5000},
+	}, result)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetSummaryByCategoryAndTypeByUserID() {
+	s.Run("both types without filter", func() {
+		s.mock.ExpectQuery(`WHERE t.user_id = \? AND t.deleted_at IS NULL AND t.transaction_date BETWEEN \? AND \? GROUP BY`).
+			WithArgs(int64(1), "2024-01-01", "2024-01-31").
+			WillReturnRows(sqlmock.NewRows([]string{"category_name", "currency", "type", "total_amount", "transaction_count"}).
+				AddRow([]byte("Food"), []byte("IDR"), []byte("expense"), []byte("3000.00"), int64(2)).
+				AddRow([]byte("Salary"), []byte("IDR"), []byte("income"), []byte("5000.00"), int64(1)))
+
+		result, err := s.repo.GetSummaryByCategoryAndTypeByUserID(s.ctx, 1, "2024-01-01", "2024-01-31", "")
+		s.Require().NoError(err)
+		s.Len(result, 2)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("type filter is a bound parameter", func() {
+		s.mock.ExpectQuery(`WHERE t.user_id = \? AND t.deleted_at IS NULL AND t.transaction_date BETWEEN \? AND \? AND t.type = \? GROUP BY`).
+			WithArgs(int64(1), "2024-01-01", "2024-01-31", entity.TransactionTypeExpense).
+			WillReturnRows(sqlmock.NewRows([]string{"category_name", "currency", "type", "total_amount", "transaction_count"}).
+				AddRow([]byte("Food"), []byte("IDR"), []byte("expense"), []byte("3000.00"), int64(2)))
+
+		result, err := s.repo.GetSummaryByCategoryAndTypeByUserID(s.ctx, 1, "2024-01-01", "2024-01-31", entity.TransactionTypeExpense)
+		s.Require().NoError(err)
+		s.Require().Len(result, 1)
+		s.Equal("expense", result[0].Type)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetCategoryMonthTotals() {
+	rows := sqlmock.NewRows([]string{"category_id", "category_name", "month", "total_amount"}).
+		AddRow(int64(7), []byte("Makan"), []byte("2024-01"), []byte("150000.00")).
+		AddRow(int64(7), []byte("Makan"), []byte("2024-02"), []byte("90000.00")).
+		AddRow(nil, []byte("Uncategorized"), []byte("2024-01"), []byte("12000.00"))
+	s.mock.ExpectQuery(`SELECT(.+)DATE_FORMAT\(t.transaction_date, '%Y-%m'\) as month(.+)GROUP BY(.+)ORDER BY\s+category_name ASC, t.category_id ASC, month ASC`).
+		WithArgs(int64(1), "IDR", "expense", "2024-01-01", "2024-12-31").
+		WillReturnRows(rows)
+
+	result, err := s.repo.GetCategoryMonthTotals(s.ctx, 1, "IDR", entity.TransactionTypeExpense, "2024-01-01", "2024-12-31")
+	s.Require().NoError(err)
+
+	s.Equal([]*mysql.CategoryMonthTotal{
+		{CategoryID: sql.NullInt64{Int64: 7, Valid: true}, CategoryName: "Makan", Month: "2024-01", TotalAmount: 150000},
+		{CategoryID: sql.NullInt64{Int64: 7, Valid: true}, CategoryName: "Makan", Month: "2024-02", TotalAmount: 90000},
+		{CategoryName: "Uncategorized", Month: "2024-01", TotalAmount: 12000},
+	}, result)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetTopExpenseCategories() {
+	rows := sqlmock.NewRows([]string{"category_id", "category_name", "total_amount", "transaction_count"}).
+		AddRow(int64(7), []byte("Makan"), []byte("150000.00"), int64(12)).
+		AddRow(nil, []byte("Uncategorized"), []byte("40000.00"), int64(3))
+	s.mock.ExpectQuery(`SELECT(.+)COALESCE\(c.name, 'Uncategorized'\) as category_name(.+)t.type = \?(.+)GROUP BY\s+t.category_id, category_name\s+ORDER BY\s+total_amount DESC, category_name ASC\s+LIMIT \?`).
+		WithArgs(int64(1), "IDR", entity.TransactionTypeExpense, "2024-01-01", "2024-01-31", 5).
+		WillReturnRows(rows)
+
+	result, err := s.repo.GetTopExpenseCategories(s.ctx, 1, "IDR", "2024-01-01", "2024-01-31", 5)
+	s.Require().NoError(err)
+
+	s.Equal([]*mysql.CategorySpending{
+		{CategoryID: sql.NullInt64{Int64: 7, Valid: true}, CategoryName: "Makan", TotalAmount: 150000, TransactionCount: 12},
+		{CategoryName: "Uncategorized", TotalAmount: 40000, TransactionCount: 3},
+	}, result)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetWithCoordinatesByUserIDExcludesMissingCoordinates() {
+	s.mock.ExpectQuery(`SELECT(.+)t.latitude, t.longitude(.+)FROM(.+)WHERE(.+)AND t.latitude IS NOT NULL AND t.longitude IS NOT NULL`).
+		WithArgs(int64(1), "2024-01-01", "2024-01-31").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "latitude", "longitude", "category_name"}).
+			AddRow(int64(1), []byte("-6.208800"), []byte("106.845600"), []byte("Makan")))
+
+	result, err := s.repo.GetWithCoordinatesByUserID(s.ctx, 1, "2024-01-01", "2024-01-31")
+	s.Require().NoError(err)
+
+	s.Require().Len(result, 1)
+	s.Equal(sql.NullFloat64{Float64: -6.2088, Valid: true}, result[0].Latitude)
+	s.Equal(sql.NullFloat64{Float64: 106.8456, Valid: true}, result[0].Longitude)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetWithCategoryByIDAndUserID() {
+	s.Run("found", func() {
+		s.mock.ExpectQuery(`SELECT (.+)c.name as category_name FROM transactions t LEFT JOIN categories c ON t.category_id = c.id WHERE t.id = \? AND t.user_id = \? AND t.deleted_at IS NULL LIMIT \?`).
+			WithArgs(int64(5), int64(1), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "category_name"}).AddRow(int64(5), int64(1), []byte("Makan")))
+
+		result, err := s.repo.GetWithCategoryByIDAndUserID(s.ctx, 5, 1)
+		s.Require().NoError(err)
+		s.Equal(int64(5), result.ID)
+		s.Equal(sql.NullString{String: "Makan", Valid: true}, result.CategoryName)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("not found", func() {
+		s.mock.ExpectQuery(`FROM transactions t`).
+			WithArgs(int64(6), int64(1), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := s.repo.GetWithCategoryByIDAndUserID(s.ctx, 6, 1)
+		s.ErrorIs(err, apperr.ErrRecordNotFound())
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetAllByUserIDCursor() {
+	s.Run("first page", func() {
+		s.mock.ExpectQuery(`SELECT (.+) FROM transactions t LEFT JOIN categories c ON t.category_id = c.id WHERE t.user_id = \? AND t.deleted_at IS NULL ORDER BY t.transaction_date DESC, t.id DESC LIMIT \?`).
+			WithArgs(int64(1), 21).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(9)))
+
+		result, err := s.repo.GetAllByUserID(s.ctx, 1, mysql.TransactionFilter{}, nil, 21)
+		s.Require().NoError(err)
+		s.Len(result, 1)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("after cursor keeps transaction_date order", func() {
+		s.mock.ExpectQuery(`WHERE \(t.user_id = \? AND t.deleted_at IS NULL\) AND \(t.transaction_date < \? OR \(t.transaction_date = \? AND t.id < \?\)\) ORDER BY t.transaction_date DESC, t.id DESC LIMIT \?`).
+			WithArgs(int64(1), "2024-01-05", "2024-01-05", int64(9), 21).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		after := &mysql.TransactionCursor{TransactionDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), ID: 9}
+		_, err := s.repo.GetAllByUserID(s.ctx, 1, mysql.TransactionFilter{}, after, 21)
+		s.Require().NoError(err)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("created_at column drives range and cursor", func() {
+		createdAt := time.Date(2024, 1, 10, 8, 30, 0, 0, time.UTC)
+		s.mock.ExpectQuery(regexp.QuoteMeta("WHERE (t.user_id = ? AND t.deleted_at IS NULL) AND DATE(t.created_at) >= ? AND (t.created_at < ? OR (t.created_at = ? AND t.id < ?)) ORDER BY t.created_at DESC, t.id DESC LIMIT ?")).
+			WithArgs(int64(1), "2024-01-10", createdAt, createdAt, int64(9), 21).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		after := &mysql.TransactionCursor{TransactionDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), CreatedAt: createdAt, ID: 9}
+		filter := mysql.TransactionFilter{StartDate: "2024-01-10", DateColumn: mysql.DateColumnCreatedAt}
+		_, err := s.repo.GetAllByUserID(s.ctx, 1, filter, after, 21)
+		s.Require().NoError(err)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("include deleted drops the deleted_at condition", func() {
+		s.mock.ExpectQuery(`SELECT (.+)t.deleted_at, c.name as category_name FROM transactions t LEFT JOIN categories c ON t.category_id = c.id WHERE t.user_id = \? ORDER BY t.transaction_date DESC, t.id DESC LIMIT \?`).
+			WithArgs(int64(1), 21).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).AddRow(int64(9), time.Date(2024, time.January, 6, 0, 0, 0, 0, time.UTC)))
+
+		result, err := s.repo.GetAllByUserID(s.ctx, 1, mysql.TransactionFilter{IncludeDeleted: true}, nil, 21)
+		s.Require().NoError(err)
+		s.Require().Len(result, 1)
+		s.True(result[0].DeletedAt.Valid)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("filters with bound parameters", func() {
+		categoryID := int64(7)
+		filter := mysql.TransactionFilter{StartDate: "2024-01-01", EndDate: "2024-01-31", Type: entity.TransactionTypeExpense, CategoryID: &categoryID}
+		s.mock.ExpectQuery(regexp.QuoteMeta("WHERE (t.user_id = ? AND t.deleted_at IS NULL) AND t.transaction_date >= ? AND t.transaction_date <= ? AND t.type = ? AND t.category_id = ? ORDER BY t.transaction_date DESC, t.id DESC LIMIT ?")).
+			WithArgs(int64(1), "2024-01-01", "2024-01-31", "expense", int64(7), 21).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := s.repo.GetAllByUserID(s.ctx, 1, filter, nil, 21)
+		s.Require().NoError(err)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *TransactionRepositoryTestSuite) TestTransactionCursorToken() {
+	s.Run("round trip keeps every position value", func() {
+		cursor := mysql.TransactionCursor{
+			TransactionDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			CreatedAt:       time.Date(2024, 1, 10, 8, 30, 15, 123000000, time.UTC),
+			Amount:          15000.75,
+			ID:              9,
+		}
+
+		decoded, err := mysql.DecodeTransactionCursor(cursor.Encode())
+		s.Require().NoError(err)
+		s.True(cursor.TransactionDate.Equal(decoded.TransactionDate))
+		s.True(cursor.CreatedAt.Equal(decoded.CreatedAt))
+		s.Equal(cursor.Amount, decoded.Amount)
+		s.Equal(cursor.ID, decoded.ID)
+	})
+
+	s.Run("malformed tokens are rejected", func() {
+		for _, token := range []string{"", "9", "%%%", "bnVsbA", "eyJpIjowfQ"} {
+			_, err := mysql.DecodeTransactionCursor(token)
+			s.Error(err, token)
+		}
+	})
+}
+
+func (s *TransactionRepositoryTestSuite) TestInvalidMetadataFilterKey() {
+	filter := mysql.TransactionFilter{Metadata: map[string]string{`trip")) OR 1=1 -- `: "bali"}}
+
+	_, err := s.repo.GetAllByUserID(s.ctx, 1, filter, nil, 21)
+	var appErr apperr.CustomErrorResponse
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+
+	_, err = s.repo.ListByUserID(s.ctx, 1, filter, 20, 0)
+	s.Require().ErrorAs(err, &appErr)
+
+	_, err = s.repo.CountByUserID(s.ctx, 1, filter)
+	s.Require().ErrorAs(err, &appErr)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetAllByUserIDSort() {
+	testCases := []struct {
+		name  string
+		sort  mysql.TransactionSort
+		order string
+	}{
+		{name: "default", sort: "", order: "ORDER BY t.transaction_date DESC, t.id DESC"},
+		{name: "date ascending", sort: mysql.TransactionSortDateAsc, order: "ORDER BY t.transaction_date ASC, t.id ASC"},
+		{name: "amount descending", sort: mysql.TransactionSortAmountDesc, order: "ORDER BY t.amount DESC, t.id DESC"},
+		{name: "amount ascending", sort: mysql.TransactionSortAmountAsc, order: "ORDER BY t.amount ASC, t.id ASC"},
+		{name: "unknown sort falls back to date_desc", sort: "price_desc", order: "ORDER BY t.transaction_date DESC, t.id DESC"},
+		{name: "injection attempt is ignored", sort: "amount_desc; DROP TABLE transactions; --", order: "ORDER BY t.transaction_date DESC, t.id DESC"},
+		{name: "raw column is ignored", sort: "t.user_id DESC", order: "ORDER BY t.transaction_date DESC, t.id DESC"},
+	}
+
+	for _, tt := range testCases {
+		s.Run(tt.name, func() {
+			s.mock.ExpectQuery(`WHERE t.user_id = \? AND t.deleted_at IS NULL `+regexp.QuoteMeta(tt.order)+` LIMIT \?$`).
+				WithArgs(int64(1), 21).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			_, err := s.repo.GetAllByUserID(s.ctx, 1, mysql.TransactionFilter{Sort: tt.sort}, nil, 21)
+			s.Require().NoError(err)
+			s.NoError(s.mock.ExpectationsWereMet())
+		})
+	}
+
+	s.Run("after cursor follows amount order", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta("WHERE (t.user_id = ? AND t.deleted_at IS NULL) AND (t.amount > ? OR (t.amount = ? AND t.id > ?)) ORDER BY t.amount ASC, t.id ASC LIMIT ?")).
+			WithArgs(int64(1), 15000.0, 15000.0, int64(9), 21).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		after := &mysql.TransactionCursor{TransactionDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), Amount: 15000, ID: 9}
+		_, err := s.repo.GetAllByUserID(s.ctx, 1, mysql.TransactionFilter{Sort: mysql.TransactionSortAmountAsc}, after, 21)
+		s.Require().NoError(err)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *TransactionRepositoryTestSuite) TestBackfillCurrency() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("UPDATE `transactions` SET `currency`=\\? WHERE currency IS NULL").
+		WithArgs("IDR").
+		WillReturnResult(sqlmock.NewResult(0, 42))
+	s.mock.ExpectCommit()
+
+	affected, err := s.repo.BackfillCurrency(s.ctx, "IDR")
+	s.Require().NoError(err)
+	s.Equal(int64(42), affected)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestSoftDelete() {
+	s.Run("delete only sets deleted_at", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec("UPDATE `transactions` SET `deleted_at`=\\?,`updated_at`=\\? WHERE id = \\? AND user_id = \\? AND deleted_at IS NULL").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), int64(5), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		s.Require().NoError(s.repo.DeleteByIDAndUserID(s.ctx, nil, 5, 1))
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("restore clears deleted_at", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec("UPDATE `transactions` SET `deleted_at`=\\?,`updated_at`=\\? WHERE id = \\? AND user_id = \\? AND deleted_at IS NOT NULL").
+			WithArgs(nil, sqlmock.AnyArg(), int64(5), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		s.Require().NoError(s.repo.RestoreByIDAndUserID(s.ctx, nil, 5, 1))
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("transaction that is not deleted cannot be fetched as deleted", func() {
+		s.mock.ExpectQuery(`WHERE id = \? AND user_id = \? AND deleted_at IS NOT NULL`).
+			WithArgs(int64(6), int64(1), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := s.repo.GetDeletedByIDAndUserID(s.ctx, 6, 1)
+		s.ErrorIs(err, apperr.ErrRecordNotFound())
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("deleted rows are excluded from listing and balance", func() {
+		s.mock.ExpectQuery(`FROM transactions t LEFT JOIN categories c ON t.category_id = c.id WHERE t.user_id = \? AND t.deleted_at IS NULL ORDER BY`).
+			WithArgs(int64(1), 21).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		s.mock.ExpectQuery(`WHERE t.user_id = \? AND t.deleted_at IS NULL AND t.transaction_date BETWEEN \? AND \?\s+GROUP BY t.currency, t.type`).
+			WithArgs(int64(1), "2024-01-01", "2024-01-31").
+			WillReturnRows(sqlmock.NewRows([]string{"type", "total_amount"}))
+
+		_, err := s.repo.GetAllByUserID(s.ctx, 1, mysql.TransactionFilter{}, nil, 21)
+		s.Require().NoError(err)
+		_, err = s.repo.GetTotalsByType(s.ctx, 1, "2024-01-01", "2024-01-31", true)
+		s.Require().NoError(err)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *TransactionRepositoryTestSuite) TestUpdateOptimisticLocking() {
+	s.Run("update bumps the version it read", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec("UPDATE `transactions` SET (.+)`version`=version \\+ 1 WHERE user_id = \\? AND version = \\? AND `id` = \\?").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.Update(s.ctx, nil, &entity.Transaction{ID: 5, UserID: 1, Amount: 1000, Version: 3}, nil)
+		s.Require().NoError(err)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("stale version is rejected with conflict", func() {
+		// Request lain sudah menaikkan version, sehingga tidak ada baris yang cocok
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec("UPDATE `transactions` SET (.+) WHERE user_id = \\? AND version = \\? AND `id` = \\?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectCommit()
+
+		err := s.repo.Update(s.ctx, nil, &entity.Transaction{ID: 5, UserID: 1, Amount: 1000, Version: 3}, nil)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusConflict, appErr.HTTPCode)
+		s.Equal("Transaction was modified by another request", appErr.Detail)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("partial changes also check the version", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec("UPDATE `transactions` SET `description`=\\?,`version`=version \\+ 1,`updated_at`=\\? WHERE user_id = \\? AND version = \\? AND `id` = \\?").
+			WithArgs("new", sqlmock.AnyArg(), int64(1), 2, int64(5)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		changes := &entity.Transaction{Description: sql.NullString{String: "new", Valid: true}}
+		err := s.repo.Update(s.ctx, nil, &entity.Transaction{ID: 5, UserID: 1, Version: 2}, changes)
+		s.Require().NoError(err)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *TransactionRepositoryTestSuite) TestStreamAllByUserID() {
+	columns := []string{"id", "user_id", "category_id", "amount", "type", "description", "latitude", "longitude", "transaction_date", "created_at", "updated_at", "category_name"}
+	now := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	s.Run("scans every row in order", func() {
+		rows := sqlmock.NewRows(columns)
+		for i := 1; i <= 1000; i++ {
+			rows.AddRow(int64(i), int64(1), nil, []byte("1000.00"), []byte("expense"), nil, nil, nil, now, now, now, nil)
+		}
+		s.mock.ExpectQuery(`SELECT (.+) FROM transactions t LEFT JOIN categories c ON t.category_id = c.id WHERE t.user_id = \? AND t.deleted_at IS NULL ORDER BY t.transaction_date DESC, t.id DESC$`).
+			WithArgs(int64(1)).
+			WillReturnRows(rows)
+
+		var ids []int64
+		err := s.repo.StreamAllByUserID(s.ctx, 1, mysql.TransactionFilter{}, func(row *mysql.TransactionWithCategory) error {
+			ids = append(ids, row.ID)
+			s.Equal(float64(1000), row.Amount)
+			return nil
+		})
+		s.Require().NoError(err)
+		s.Len(ids, 1000)
+		s.Equal(int64(1), ids[0])
+		s.Equal(int64(1000), ids[999])
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("stops on callback error", func() {
+		rows := sqlmock.NewRows(columns).
+			AddRow(int64(1), int64(1), nil, []byte("1000.00"), []byte("expense"), nil, nil, nil, now, now, now, nil).
+			AddRow(int64(2), int64(1), nil, []byte("1000.00"), []byte("expense"), nil, nil, nil, now, now, now, nil)
+		s.mock.ExpectQuery("SELECT(.+)FROM(.+)transactions").
+			WithArgs(int64(1)).
+			WillReturnRows(rows)
+
+		calls := 0
+		stop := errors.New("client gone")
+		err := s.repo.StreamAllByUserID(s.ctx, 1, mysql.TransactionFilter{}, func(row *mysql.TransactionWithCategory) error {
+			calls++
+			return stop
+		})
+		s.ErrorIs(err, stop)
+		s.Equal(1, calls)
+	})
+
+	s.Run("filters by date range", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta("WHERE (t.user_id = ? AND t.deleted_at IS NULL) AND t.transaction_date >= ? AND t.transaction_date <= ? ORDER BY t.transaction_date DESC, t.id DESC")).
+			WithArgs(int64(1), "2024-01-01", "2024-01-31").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		err := s.repo.StreamAllByUserID(s.ctx, 1, mysql.TransactionFilter{StartDate: "2024-01-01", EndDate: "2024-01-31"}, func(row *mysql.TransactionWithCategory) error {
+			return nil
+		})
+		s.Require().NoError(err)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetAmountBucketCounts() {
+	rows := sqlmock.NewRows([]string{"bucket_index", "transaction_count"}).
+		AddRow(int64(0), int64(2)).
+		AddRow(int64(3), int64(1))
+	s.mock.ExpectQuery(`SELECT(.+)FLOOR\(t.amount / \?\) as bucket_index(.+)t.currency = \? AND t.type = \?(.+)BETWEEN \? AND \?(.+)GROUP BY(.+)bucket_index`).
+		WithArgs(50000.0, int64(1), "IDR", entity.TransactionTypeExpense, "2024-01-01", "2024-01-31").
+		WillReturnRows(rows)
+
+	result, err := s.repo.GetAmountBucketCounts(s.ctx, 1, "IDR", entity.TransactionTypeExpense, "2024-01-01", "2024-01-31", 50000)
+	s.Require().NoError(err)
+	s.Equal([]*mysql.AmountBucketCount{
+		{BucketIndex: 0, TransactionCount: 2},
+		{BucketIndex: 3, TransactionCount: 1},
+	}, result)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetYearsByUserID() {
+	s.Run("distinct years descending", func() {
+		// Transaksi 2023-12-31 dan 2024-01-01 berada di tahun yang berbeda meskipun hanya selisih sehari
+		rows := sqlmock.NewRows([]string{"year"}).AddRow(2024).AddRow(2023).AddRow(2021)
+		s.mock.ExpectQuery(`SELECT DISTINCT(.+)YEAR\(t.transaction_date\)(.+)FROM(.+)transactions t(.+)t.user_id = \?(.+)ORDER BY(.+)year DESC`).
+			WithArgs(int64(1)).
+			WillReturnRows(rows)
+
+		result, err := s.repo.GetYearsByUserID(s.ctx, 1)
+		s.Require().NoError(err)
+		s.Equal([]int{2024, 2023, 2021}, result)
+	})
+
+	s.Run("no transactions", func() {
+		s.mock.ExpectQuery("SELECT DISTINCT(.+)FROM(.+)transactions").
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"year"}))
+
+		result, err := s.repo.GetYearsByUserID(s.ctx, 2)
+		s.Require().NoError(err)
+		s.Empty(result)
+	})
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetDateRangeByUserID() {
+	s.Run("earliest and latest", func() {
+		rows := sqlmock.NewRows([]string{"earliest", "latest"}).AddRow("2021-03-04", "2024-01-31")
+		s.mock.ExpectQuery(`SELECT(.+)MIN\(t.transaction_date\)(.+)MAX\(t.transaction_date\)(.+)FROM(.+)transactions t(.+)t.user_id = \?`).
+			WithArgs(int64(1)).
+			WillReturnRows(rows)
+
+		result, err := s.repo.GetDateRangeByUserID(s.ctx, 1)
+		s.Require().NoError(err)
+		s.Equal("2021-03-04", result.Earliest.String)
+		s.Equal("2024-01-31", result.Latest.String)
+	})
+
+	s.Run("no transactions", func() {
+		rows := sqlmock.NewRows([]string{"earliest", "latest"}).AddRow(nil, nil)
+		s.mock.ExpectQuery("SELECT(.+)FROM(.+)transactions").
+			WithArgs(int64(2)).
+			WillReturnRows(rows)
+
+		result, err := s.repo.GetDateRangeByUserID(s.ctx, 2)
+		s.Require().NoError(err)
+		s.False(result.Earliest.Valid)
+		s.False(result.Latest.Valid)
+	})
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetTopCategoryByDescription() {
+	s.Run("majority category", func() {
+		rows := sqlmock.NewRows([]string{"category_id", "category_name", "usage_count"}).
+			AddRow(int64(3), []byte("Kopi"), int64(12))
+		s.mock.ExpectQuery(`SELECT(.+)FROM(.+)transactions t(.+)LOWER\(t.description\) LIKE \?(.+)ORDER BY(.+)usage_count DESC(.+)LIMIT 1`).
+			WithArgs(int64(1), "%starbucks%").
+			WillReturnRows(rows)
+
+		result, err := s.repo.GetTopCategoryByDescription(s.ctx, 1, "StarBucks")
+		s.Require().NoError(err)
+		s.Equal(&mysql.CategoryUsage{CategoryID: 3, CategoryName: "Kopi", UsageCount: 12}, result)
+	})
+
+	s.Run("no history", func() {
+		s.mock.ExpectQuery("SELECT(.+)FROM(.+)transactions").
+			WithArgs(int64(1), `%100\%%`).
+			WillReturnRows(sqlmock.NewRows([]string{"category_id", "category_name", "usage_count"}))
+
+		result, err := s.repo.GetTopCategoryByDescription(s.ctx, 1, "100%")
+		s.Require().NoError(err)
+		s.Nil(result)
+	})
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetTotalByCategoryID() {
+	s.mock.ExpectQuery(`SELECT COUNT\(\*\) as transaction_count, COALESCE\(SUM\(t.amount\), 0\) as total_amount FROM transactions t WHERE t.user_id = \? AND t.deleted_at IS NULL AND t.category_id = \?`).
+		WithArgs(int64(1), int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_count", "total_amount"}).AddRow(3, []byte("125000.00")))
+
+	result, err := s.repo.GetTotalByCategoryID(s.ctx, 1, 7)
+	s.Require().NoError(err)
+
+	s.Equal(&mysql.CategoryTransactionTotal{TransactionCount: 3, TotalAmount: 125000}, result)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestAmountMustBePositive() {
+	assertRejected := func(err error) {
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	}
+
+	s.Run("create with zero amount", func() {
+		err := s.repo.Create(s.ctx, nil, &entity.Transaction{UserID: 1, Amount: 0, Type: entity.TransactionTypeExpense}, false)
+		assertRejected(err)
+	})
+
+	s.Run("create with negative amount", func() {
+		err := s.repo.Create(s.ctx, nil, &entity.Transaction{UserID: 1, Amount: -5000, Type: entity.TransactionTypeExpense}, false)
+		assertRejected(err)
+	})
+
+	s.Run("full update with zero amount", func() {
+		err := s.repo.Update(s.ctx, nil, &entity.Transaction{ID: 5, UserID: 1, Amount: 0, Version: 1}, nil)
+		assertRejected(err)
+	})
+
+	s.Run("partial update with negative amount", func() {
+		err := s.repo.Update(s.ctx, nil, &entity.Transaction{ID: 5, UserID: 1, Version: 1}, &entity.Transaction{Amount: -1})
+		assertRejected(err)
+	})
+
+	// Tidak ada query yang dijalankan untuk amount yang ditolak
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestReassignCategory() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("UPDATE `transactions` SET `category_id`=\\?,`version`=version \\+ 1,`updated_at`=\\? WHERE user_id = \\? AND category_id = \\?").
+		WithArgs(int64(8), sqlmock.AnyArg(), int64(1), int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+	s.mock.ExpectCommit()
+
+	affected, err := s.repo.ReassignCategory(s.ctx, nil, 7, 8, 1)
+	s.Require().NoError(err)
+	s.Equal(int64(5), affected)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestOrphanedCategories() {
+	s.Run("count", func() {
+		s.mock.ExpectQuery("SELECT count\\(\\*\\) FROM `transactions` WHERE user_id = \\? AND category_id IS NOT NULL AND NOT EXISTS \\(SELECT 1 FROM categories c WHERE c.id = transactions.category_id\\)").
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+		total, err := s.repo.CountOrphanedTransactions(s.ctx, 1)
+		s.Require().NoError(err)
+		s.Equal(int64(4), total)
+	})
+
+	s.Run("clear", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec("UPDATE `transactions` SET `category_id`=\\?,`version`=version \\+ 1,`updated_at`=\\? WHERE user_id = \\? AND category_id IS NOT NULL AND NOT EXISTS").
+			WithArgs(nil, sqlmock.AnyArg(), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		s.mock.ExpectCommit()
+
+		affected, err := s.repo.ClearOrphanedCategories(s.ctx, nil, 1)
+		s.Require().NoError(err)
+		s.Equal(int64(4), affected)
+	})
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestSearchByDescription() {
+	s.mock.ExpectQuery(`WHERE \(t.user_id = \? AND t.deleted_at IS NULL AND t.description IS NOT NULL AND t.description <> ''\) AND LOWER\(t.description\) LIKE \? ORDER BY t.transaction_date DESC, t.id DESC LIMIT \?`).
+		WithArgs(int64(1), `%50\% off%`, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "description", "category_name"}).AddRow(3, "Diskon 50% OFF", "Belanja"))
+
+	result, err := s.repo.SearchByDescription(s.ctx, 1, "50% OFF", 20)
+	s.Require().NoError(err)
+
+	s.Require().Len(result, 1)
+	s.Equal(int64(3), result[0].ID)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetAmountAnomalies() {
+	s.mock.ExpectQuery(`STDDEV_POP\(amount\)(.+)HAVING COUNT\(\*\) >= \? \) s ON t.category_id = s.category_id(.+)AND t.amount > s.category_mean \+ \? \* s.category_stddev`).
+		WithArgs(int64(1), "IDR", entity.TransactionTypeExpense, "2024-01-01", "2024-03-31", 10,
+			int64(1), "IDR", entity.TransactionTypeExpense, "2024-01-01", "2024-03-31", float64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "category_name", "category_mean", "category_stddev", "category_transaction_count"}).
+			AddRow(42, []byte("1500000.00"), "Makan", []byte("100000.0000"), []byte("40000.5"), 30))
+
+	result, err := s.repo.GetAmountAnomalies(s.ctx, 1, "IDR", entity.TransactionTypeExpense, "2024-01-01", "2024-03-31", 10, 2)
+	s.Require().NoError(err)
+
+	s.Require().Len(result, 1)
+	s.Equal(int64(42), result[0].ID)
+	s.Equal(float64(1500000), result[0].Amount)
+	s.Equal(float64(100000), result[0].CategoryMean)
+	s.Equal(40000.5, result[0].CategoryStddev)
+	s.Equal(int64(30), result[0].CategoryTransactionCount)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetByUserIDAndReferencesIncludesDeleted() {
+	deletedAt := time.Date(2024, time.January, 6, 3, 0, 0, 0, time.UTC)
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `transactions` WHERE user_id = ? AND reference IN (?,?)")).
+		WithArgs(int64(1), "REF-1", "REF-2").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "reference", "deleted_at"}).
+			AddRow(int64(5), "REF-1", nil).
+			AddRow(int64(6), "REF-2", deletedAt))
+
+	result, err := s.repo.GetByUserIDAndReferences(s.ctx, 1, []string{"REF-1", "REF-2"})
+	s.Require().NoError(err)
+	s.Require().Len(result, 2)
+	s.False(result[0].DeletedAt.Valid)
+	s.True(result[1].DeletedAt.Valid)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestFindPossibleDuplicate() {
+	createdSince := time.Date(2024, time.January, 5, 9, 55, 0, 0, time.UTC)
+	params := &entity.Transaction{UserID: 1, Amount: 15000, Currency: "IDR", Type: entity.TransactionTypeExpense,
+		TransactionDate: time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)}
+
+	s.Run("without category matches NULL category", func() {
+		s.mock.ExpectQuery(`WHERE \(user_id = \? AND deleted_at IS NULL AND amount = \? AND currency = \? AND type = \? AND transaction_date = \? AND created_at >= \?\) AND category_id IS NULL ORDER BY id DESC LIMIT \?`).
+			WithArgs(int64(1), 15000.0, "IDR", entity.TransactionTypeExpense, "2024-01-05", createdSince, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "amount"}).AddRow(42, 1, 15000))
+
+		result, err := s.repo.FindPossibleDuplicate(s.ctx, params, createdSince)
+		s.Require().NoError(err)
+		s.Require().NotNil(result)
+		s.Equal(int64(42), result.ID)
+	})
+
+	s.Run("with category and no match", func() {
+		withCategory := *params
+		withCategory.CategoryID = sql.NullInt64{Int64: 3, Valid: true}
+		s.mock.ExpectQuery(`AND category_id = \? ORDER BY id DESC LIMIT \?`).
+			WithArgs(int64(1), 15000.0, "IDR", entity.TransactionTypeExpense, "2024-01-05", createdSince, int64(3), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		result, err := s.repo.FindPossibleDuplicate(s.ctx, &withCategory, createdSince)
+		s.Require().NoError(err)
+		s.Nil(result)
+	})
+
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetByDescriptionContains() {
+	s.Run("case insensitive lowers both sides", func() {
+		s.mock.ExpectQuery(`WHERE \(user_id = \? AND deleted_at IS NULL\) AND LOWER\(description\) LIKE \? ORDER BY id ASC`).
+			WithArgs(int64(1), "%grocries%").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "description"}).AddRow(1, "GROCRIES"))
+
+		result, err := s.repo.GetByDescriptionContains(s.ctx, 1, "GrocRies", false)
+		s.Require().NoError(err)
+		s.Require().Len(result, 1)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("case sensitive compares binary", func() {
+		s.mock.ExpectQuery(`WHERE \(user_id = \? AND deleted_at IS NULL\) AND description LIKE BINARY \? ORDER BY id ASC`).
+			WithArgs(int64(1), "%GrocRies%").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "description"}))
+
+		result, err := s.repo.GetByDescriptionContains(s.ctx, 1, "GrocRies", true)
+		s.Require().NoError(err)
+		s.Empty(result)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+}
+
+func (s *TransactionRepositoryTestSuite) TestGetBoundsByUserID() {
+	s.Run("user with transactions", func() {
+		s.mock.ExpectQuery(`SELECT MIN\(t.amount\) as min_amount, MAX\(t.amount\) as max_amount(.+)WHERE t.user_id = \?`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"min_amount", "max_amount", "min_date", "max_date"}).
+				AddRow([]byte("2500.00"), []byte("8000000.00"), []byte("2023-11-02"), []byte("2024-06-30")))
+
+		result, err := s.repo.GetBoundsByUserID(s.ctx, 1)
+		s.Require().NoError(err)
+
+		s.Equal(&mysql.TransactionBounds{
+			MinAmount: sql.NullFloat64{Float64: 2500, Valid: true},
+			MaxAmount: sql.NullFloat64{Float64: 8000000, Valid: true},
+			MinDate:   sql.NullString{String: "2023-11-02", Valid: true},
+			MaxDate:   sql.NullString{String: "2024-06-30", Valid: true},
+		}, result)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+
+	s.Run("user without transactions", func() {
+		s.mock.ExpectQuery(`SELECT MIN\(t.amount\)`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"min_amount", "max_amount", "min_date", "max_date"}).AddRow(nil, nil, nil, nil))
+
+		result, err := s.repo.GetBoundsByUserID(s.ctx, 2)
+		s.Require().NoError(err)
+
+		s.Equal(&mysql.TransactionBounds{}, result)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+}
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
 
 	errwrap "github.com/pkg/errors"
 	"github.com/rakahikmah/finance-tracking/config"
@@ -19,6 +20,10 @@ type UserRepository interface {
 	LockByID(ctx context.Context, dbTrx TrxObj, ID int64) (*entity.User, error)
 	GetByEmail(ctx context.Context, email string) (*entity.User, error)
 	GetByEmailAndRole(ctx context.Context, email string, role entity.RoleType) (*entity.User, error)
+	GetStatusByID(ctx context.Context, ID int64) (entity.UserStatus, error)
+	GetByID(ctx context.Context, ID int64) (*entity.User, error)
+	GetTimezoneByID(ctx context.Context, ID int64) (string, error)
+	UpdateTimezone(ctx context.Context, ID int64, timezone string) error
 }
 
 type User struct {
@@ -82,3 +87,73 @@ func (u *User) GetByEmailAndRole(ctx context.Context, email string, role entity.
 
 	return user, err
 }
+
+func (u *User) GetStatusByID(ctx context.Context, ID int64) (entity.UserStatus, error) {
+	funcName := "UserRepository.GetStatusByID"
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return 0, errwrap.Wrap(err, funcName)
+	}
+
+	var user *entity.User
+	err := u.db.Select("id", "status").Where("id = ?", ID).Take(&user).Error
+	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+		return 0, apperr.ErrUserNotFound()
+	}
+	if err != nil {
+		return 0, errwrap.Wrap(err, funcName)
+	}
+
+	return user.Status, nil
+}
+
+func (u *User) GetByID(ctx context.Context, ID int64) (*entity.User, error) {
+	funcName := "UserRepository.GetByID"
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	var user *entity.User
+	err := u.db.Where("id = ?", ID).Take(&user).Error
+	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.ErrUserNotFound()
+	}
+	if err != nil {
+		return nil, errwrap.Wrap(err, funcName)
+	}
+
+	return user, nil
+}
+
+// GetTimezoneByID returns the stored IANA timezone of the user, an empty string when it was never set.
+func (u *User) GetTimezoneByID(ctx context.Context, ID int64) (string, error) {
+	funcName := "UserRepository.GetTimezoneByID"
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return "", errwrap.Wrap(err, funcName)
+	}
+
+	var user *entity.User
+	err := u.db.Select("id", "timezone").Where("id = ?", ID).Take(&user).Error
+	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+		return "", apperr.ErrUserNotFound()
+	}
+	if err != nil {
+		return "", errwrap.Wrap(err, funcName)
+	}
+
+	return user.Timezone.String, nil
+}
+
+// UpdateTimezone stores the IANA timezone of the user, an empty timezone resets it to NULL.
+func (u *User) UpdateTimezone(ctx context.Context, ID int64, timezone string) error {
+	funcName := "UserRepository.UpdateTimezone"
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	value := sql.NullString{String: timezone, Valid: timezone != ""}
+	if err := u.db.Model(&entity.User{}).Where("id = ?", ID).Update("timezone", value).Error; err != nil {
+		return errwrap.Wrap(err, funcName)
+	}
+
+	return nil
+}
@@ -0,0 +1,355 @@
+package budget_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/rakahikmah/finance-tracking/config"
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
+	"github.com/rakahikmah/finance-tracking/internal/usecase/budget/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// CrudBudget adalah usecase untuk budget bulanan per kategori.
+// Budget dihitung terhadap pengeluaran dalam mata uang dasar (CURRENCY_CODE), sama seperti laporan.
+type CrudBudget struct {
+	BudgetRepo     mysql.IBudgetRepository
+	CategoryRepo   mysql.ICategoryRepository
+	CurrencyOption config.CurrencyOption
+	UserStatus     usecase.IUserStatusChecker // Menolak penulisan dari user yang tidak aktif
+}
+
+// NewCrudBudget adalah konstruktor untuk CrudBudget.
+func NewCrudBudget(
+	BudgetRepo mysql.IBudgetRepository,
+	CategoryRepo mysql.ICategoryRepository,
+	CurrencyOption config.CurrencyOption,
+	UserStatus usecase.IUserStatusChecker,
+) *CrudBudget {
+	return &CrudBudget{
+		BudgetRepo:     BudgetRepo,
+		CategoryRepo:   CategoryRepo,
+		CurrencyOption: CurrencyOption,
+		UserStatus:     UserStatus,
+	}
+}
+
+// ICrudBudget mendefinisikan interface untuk operasi CRUD dan status budget.
+type ICrudBudget interface {
+	Create(ctx context.Context, userID int64, req entity.BudgetReq) (*entity.BudgetResponse, error)
+	GetAll(ctx context.Context, userID int64, month string) ([]entity.BudgetResponse, error)
+	Update(ctx context.Context, id int64, userID int64, req entity.UpdateBudgetReq) error
+	Delete(ctx context.Context, id int64, userID int64) error
+	GetBudgetStatus(ctx context.Context, userID int64, month string) (*entity.BudgetStatusResponse, error)
+	GetOverspent(ctx context.Context, userID int64, month string) (*entity.OverspentResponse, error)
+}
+
+// Create membuat budget bulanan untuk satu kategori. Satu kategori hanya boleh punya satu budget per bulan.
+func (u *CrudBudget) Create(ctx context.Context, userID int64, req entity.BudgetReq) (*entity.BudgetResponse, error) {
+	funcName := "CrudBudget.Create"
+	logFields := generalEntity.CaptureFields{
+		"user_id":     strconv.FormatInt(userID, 10),
+		"category_id": strconv.FormatInt(req.CategoryID, 10),
+		"month":       req.Month,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if req.CategoryID <= 0 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("category_id is required")
+	}
+	if req.LimitAmount <= 0 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("limit_amount must be greater than 0")
+	}
+
+	month, err := helper.ParseMonthStrict(req.Month)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseMonthStrict", err, logFields, "Invalid month")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid month: " + err.Error())
+	}
+
+	if err := u.validateCategory(ctx, userID, req.CategoryID); err != nil {
+		helper.LogError(funcName, "validateCategory", err, logFields, "Invalid budget category")
+		return nil, err
+	}
+
+	existing, err := u.BudgetRepo.GetByUserIDCategoryAndMonth(ctx, userID, req.CategoryID, month.Format(helper.DateLayout))
+	if err != nil && !errors.Is(err, apperr.ErrRecordNotFound()) {
+		helper.LogError(funcName, "BudgetRepo.GetByUserIDCategoryAndMonth", err, logFields, "")
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperr.ErrConflict().SetDetail(fmt.Sprintf("Category %d already has a budget for %s.", req.CategoryID, req.Month))
+	}
+
+	now := helper.DatetimeNowJakarta()
+	data := &myentity.Budget{
+		UserID:      userID,
+		CategoryID:  req.CategoryID,
+		Month:       month,
+		LimitAmount: req.LimitAmount,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := u.BudgetRepo.Create(ctx, nil, data, false); err != nil {
+		helper.LogError(funcName, "BudgetRepo.Create", err, logFields, "")
+		return nil, err
+	}
+
+	result := toBudgetResponse(data)
+	return &result, nil
+}
+
+// GetAll mengambil semua budget user pada bulan tertentu (YYYY-MM).
+func (u *CrudBudget) GetAll(ctx context.Context, userID int64, month string) ([]entity.BudgetResponse, error) {
+	funcName := "CrudBudget.GetAll"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"month":   month,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	start, err := helper.ParseMonthStrict(month)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseMonthStrict", err, logFields, "Invalid month")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid month: " + err.Error())
+	}
+
+	data, err := u.BudgetRepo.GetAllByUserIDAndMonth(ctx, userID, start.Format(helper.DateLayout))
+	if err != nil {
+		helper.LogError(funcName, "BudgetRepo.GetAllByUserIDAndMonth", err, logFields, "")
+		return nil, err
+	}
+
+	result := make([]entity.BudgetResponse, 0, len(data))
+	for _, row := range data {
+		result = append(result, toBudgetResponse(row))
+	}
+
+	return result, nil
+}
+
+// Update mengubah batas budget milik user.
+func (u *CrudBudget) Update(ctx context.Context, id int64, userID int64, req entity.UpdateBudgetReq) error {
+	funcName := "CrudBudget.Update"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"id":      fmt.Sprintf("%d", id),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return err
+	}
+
+	if req.LimitAmount <= 0 {
+		return apperr.ErrInvalidRequest().SetDetail("limit_amount must be greater than 0")
+	}
+
+	// Otorisasi: budget milik user lain diperlakukan sebagai tidak ditemukan
+	oldData, err := u.BudgetRepo.GetByIDAndUserID(ctx, id, userID)
+	if err != nil {
+		helper.LogError(funcName, "BudgetRepo.GetByIDAndUserID", err, logFields, "Error getting budget for update")
+		return err
+	}
+
+	changes := &myentity.Budget{
+		LimitAmount: req.LimitAmount,
+		UpdatedAt:   helper.DatetimeNowJakarta(),
+	}
+	if err := u.BudgetRepo.Update(ctx, nil, oldData, changes); err != nil {
+		helper.LogError(funcName, "BudgetRepo.Update", err, logFields, "")
+		return err
+	}
+
+	return nil
+}
+
+// Delete menghapus budget milik user.
+func (u *CrudBudget) Delete(ctx context.Context, id int64, userID int64) error {
+	funcName := "CrudBudget.Delete"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"id":      fmt.Sprintf("%d", id),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return err
+	}
+
+	if _, err := u.BudgetRepo.GetByIDAndUserID(ctx, id, userID); err != nil {
+		helper.LogError(funcName, "BudgetRepo.GetByIDAndUserID", err, logFields, "Error getting budget for delete")
+		return err
+	}
+
+	if err := u.BudgetRepo.DeleteByIDAndUserID(ctx, nil, id, userID); err != nil {
+		helper.LogError(funcName, "BudgetRepo.DeleteByIDAndUserID", err, logFields, "")
+		return err
+	}
+
+	return nil
+}
+
+// GetBudgetStatus membandingkan setiap budget user pada bulan tertentu (YYYY-MM) dengan pengeluaran kategorinya.
+// Kategori tanpa budget tidak ikut ditampilkan, budget tanpa pengeluaran tampil dengan spent 0.
+// Over budget hanya jika spent melebihi limit, tepat sama dengan limit belum dianggap over.
+func (u *CrudBudget) GetBudgetStatus(ctx context.Context, userID int64, month string) (*entity.BudgetStatusResponse, error) {
+	funcName := "CrudBudget.GetBudgetStatus"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"month":   month,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	start, err := helper.ParseMonthStrict(month)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseMonthStrict", err, logFields, "Invalid month")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid month: " + err.Error())
+	}
+	end := start.AddDate(0, 1, -1)
+
+	currency := helper.BaseCurrency(u.CurrencyOption.Code)
+	rows, err := u.BudgetRepo.GetStatusByUserIDAndMonth(ctx, userID, currency, start.Format(helper.DateLayout), end.Format(helper.DateLayout))
+	if err != nil {
+		helper.LogError(funcName, "BudgetRepo.GetStatusByUserIDAndMonth", err, logFields, "")
+		return nil, err
+	}
+
+	result := &entity.BudgetStatusResponse{
+		Month:      start.Format(helper.MonthLayout),
+		Currency:   currency,
+		Categories: make([]entity.BudgetStatusItem, 0, len(rows)),
+	}
+	for _, row := range rows {
+		result.Categories = append(result.Categories, entity.BudgetStatusItem{
+			BudgetID:   row.BudgetID,
+			CategoryID: row.CategoryID,
+			Category:   row.CategoryName,
+			Limit:      row.LimitAmount,
+			Spent:      row.Spent,
+			Remaining:  math.Round((row.LimitAmount-row.Spent)*100) / 100,
+			OverBudget: row.Spent > row.LimitAmount,
+		})
+	}
+
+	return result, nil
+}
+
+// GetOverspent mengambil budget user pada bulan tertentu (YYYY-MM) yang pengeluarannya melebihi limit beserta selisihnya,
+// urut dari overspend terbesar. Budget yang tepat sama dengan limit tidak ikut, list kosong jika tidak ada yang terlampaui.
+func (u *CrudBudget) GetOverspent(ctx context.Context, userID int64, month string) (*entity.OverspentResponse, error) {
+	funcName := "CrudBudget.GetOverspent"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"month":   month,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	start, err := helper.ParseMonthStrict(month)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseMonthStrict", err, logFields, "Invalid month")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid month: " + err.Error())
+	}
+	end := start.AddDate(0, 1, -1)
+
+	currency := helper.BaseCurrency(u.CurrencyOption.Code)
+	rows, err := u.BudgetRepo.GetOverspentByUserIDAndMonth(ctx, userID, currency, start.Format(helper.DateLayout), end.Format(helper.DateLayout))
+	if err != nil {
+		helper.LogError(funcName, "BudgetRepo.GetOverspentByUserIDAndMonth", err, logFields, "")
+		return nil, err
+	}
+
+	result := &entity.OverspentResponse{
+		Month:      start.Format(helper.MonthLayout),
+		Currency:   currency,
+		Categories: make([]entity.OverspentItem, 0, len(rows)),
+	}
+	for _, row := range rows {
+		result.Categories = append(result.Categories, entity.OverspentItem{
+			BudgetID:   row.BudgetID,
+			CategoryID: row.CategoryID,
+			Category:   row.CategoryName,
+			Limit:      row.LimitAmount,
+			Spent:      row.Spent,
+			Overspend:  math.Round((row.Spent-row.LimitAmount)*100) / 100,
+		})
+	}
+
+	return result, nil
+}
+
+// validateCategory memastikan kategori ada, milik user, dan boleh dipakai untuk pengeluaran.
+func (u *CrudBudget) validateCategory(ctx context.Context, userID int64, categoryID int64) error {
+	category, err := u.CategoryRepo.GetByID(ctx, categoryID)
+	if err != nil && !errors.Is(err, apperr.ErrRecordNotFound()) {
+		return err
+	}
+	if category == nil {
+		return apperr.ErrInvalidRequest().SetDetail("Invalid Category ID provided.")
+	}
+	if category.CreatedBy != userID {
+		return apperr.ErrForbidden().SetDetail("You are not authorized to use this category.")
+	}
+	if category.Type == myentity.TransactionTypeIncome {
+		return apperr.ErrInvalidRequest().SetDetail("Budgets can only be set on expense categories.")
+	}
+
+	return nil
+}
+
+func toBudgetResponse(row *myentity.Budget) entity.BudgetResponse {
+	return entity.BudgetResponse{
+		ID:          row.ID,
+		CategoryID:  row.CategoryID,
+		Month:       row.Month.Format(helper.MonthLayout),
+		LimitAmount: row.LimitAmount,
+		CreatedAt:   helper.ConvertToJakartaTime(row.CreatedAt),
+		UpdatedAt:   helper.ConvertToJakartaTime(row.UpdatedAt),
+	}
+}
@@ -0,0 +1,217 @@
+package budget_usecase_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rakahikmah/finance-tracking/config"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	budget_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/budget"
+	"github.com/rakahikmah/finance-tracking/internal/usecase/budget/entity"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CrudBudgetTestSuite struct {
+	suite.Suite
+
+	budgetRepo   *mocks.IBudgetRepository
+	categoryRepo *mocks.ICategoryRepository
+	userStatus   *mocks.IUserStatusChecker
+	usecase      budget_usecase.ICrudBudget
+	ctx          context.Context
+}
+
+func (s *CrudBudgetTestSuite) SetupTest() {
+	s.budgetRepo = &mocks.IBudgetRepository{}
+	s.categoryRepo = &mocks.ICategoryRepository{}
+	s.userStatus = &mocks.IUserStatusChecker{}
+	s.userStatus.On("EnsureActive", mock.Anything, int64(1)).Return(nil).Maybe()
+	s.usecase = budget_usecase.NewCrudBudget(s.budgetRepo, s.categoryRepo, config.CurrencyOption{Code: "IDR"}, s.userStatus)
+	s.ctx = context.Background()
+}
+
+func TestCrudBudget(t *testing.T) {
+	suite.Run(t, new(CrudBudgetTestSuite))
+}
+
+func (s *CrudBudgetTestSuite) assertHTTPCode(err error, code int) {
+	var appErr apperr.CustomErrorResponse
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(code, appErr.HTTPCode)
+}
+
+func (s *CrudBudgetTestSuite) TestCreate() {
+	req := entity.BudgetReq{CategoryID: 7, Month: "2024-03", LimitAmount: 500000}
+
+	s.Run("stores budget for the first day of the month", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).
+			Return(&myentity.Category{ID: 7, CreatedBy: 1, Type: myentity.TransactionTypeExpense}, nil).Once()
+		s.budgetRepo.On("GetByUserIDCategoryAndMonth", mock.Anything, int64(1), int64(7), "2024-03-01").
+			Return(nil, apperr.ErrRecordNotFound()).Once()
+		s.budgetRepo.On("Create", mock.Anything, nil, mock.MatchedBy(func(budget *myentity.Budget) bool {
+			return budget.UserID == 1 && budget.CategoryID == 7 && budget.LimitAmount == 500000 &&
+				budget.Month.Format("2006-01-02") == "2024-03-01"
+		}), false).Return(nil).Once()
+
+		result, err := s.usecase.Create(s.ctx, 1, req)
+		s.Require().NoError(err)
+		s.Equal("2024-03", result.Month)
+		s.budgetRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("duplicate budget for the same month", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).
+			Return(&myentity.Category{ID: 7, CreatedBy: 1}, nil).Once()
+		s.budgetRepo.On("GetByUserIDCategoryAndMonth", mock.Anything, int64(1), int64(7), "2024-03-01").
+			Return(&myentity.Budget{ID: 3}, nil).Once()
+
+		_, err := s.usecase.Create(s.ctx, 1, req)
+		s.assertHTTPCode(err, http.StatusConflict)
+		s.budgetRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("category of another user", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).
+			Return(&myentity.Category{ID: 7, CreatedBy: 2}, nil).Once()
+
+		_, err := s.usecase.Create(s.ctx, 1, req)
+		s.assertHTTPCode(err, http.StatusForbidden)
+	})
+
+	s.Run("income category", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).
+			Return(&myentity.Category{ID: 7, CreatedBy: 1, Type: myentity.TransactionTypeIncome}, nil).Once()
+
+		_, err := s.usecase.Create(s.ctx, 1, req)
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+
+	s.Run("limit must be positive", func() {
+		s.SetupTest()
+		_, err := s.usecase.Create(s.ctx, 1, entity.BudgetReq{CategoryID: 7, Month: "2024-03", LimitAmount: 0})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+		s.categoryRepo.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
+	})
+
+	s.Run("invalid month", func() {
+		s.SetupTest()
+		_, err := s.usecase.Create(s.ctx, 1, entity.BudgetReq{CategoryID: 7, Month: "2024-13", LimitAmount: 1})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+}
+
+func (s *CrudBudgetTestSuite) TestUpdate() {
+	s.Run("changes only the limit", func() {
+		s.SetupTest()
+		old := &myentity.Budget{ID: 3, UserID: 1, CategoryID: 7}
+		s.budgetRepo.On("GetByIDAndUserID", mock.Anything, int64(3), int64(1)).Return(old, nil).Once()
+		s.budgetRepo.On("Update", mock.Anything, nil, old, mock.MatchedBy(func(changes *myentity.Budget) bool {
+			return changes.LimitAmount == 750000 && changes.CategoryID == 0
+		})).Return(nil).Once()
+
+		s.Require().NoError(s.usecase.Update(s.ctx, 3, 1, entity.UpdateBudgetReq{LimitAmount: 750000}))
+		s.budgetRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("negative limit", func() {
+		s.SetupTest()
+		err := s.usecase.Update(s.ctx, 3, 1, entity.UpdateBudgetReq{LimitAmount: -1})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+		s.budgetRepo.AssertNotCalled(s.T(), "GetByIDAndUserID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("budget of another user", func() {
+		s.SetupTest()
+		s.budgetRepo.On("GetByIDAndUserID", mock.Anything, int64(3), int64(1)).Return(nil, apperr.ErrRecordNotFound()).Once()
+
+		err := s.usecase.Update(s.ctx, 3, 1, entity.UpdateBudgetReq{LimitAmount: 750000})
+		s.assertHTTPCode(err, http.StatusNotFound)
+	})
+}
+
+func (s *CrudBudgetTestSuite) TestGetBudgetStatus() {
+	s.Run("over, under and unspent budgets", func() {
+		s.SetupTest()
+		s.budgetRepo.On("GetStatusByUserIDAndMonth", mock.Anything, int64(1), "IDR", "2024-02-01", "2024-02-29").
+			Return([]*mysql.BudgetStatusRow{
+				{BudgetID: 1, CategoryID: 7, CategoryName: "Food", LimitAmount: 1000000, Spent: 1250000.5},
+				{BudgetID: 2, CategoryID: 8, CategoryName: "Transport", LimitAmount: 300000, Spent: 300000},
+				{BudgetID: 3, CategoryID: 9, CategoryName: "Travel", LimitAmount: 2000000, Spent: 0},
+			}, nil).Once()
+
+		result, err := s.usecase.GetBudgetStatus(s.ctx, 1, "2024-02")
+		s.Require().NoError(err)
+		s.Equal("2024-02", result.Month)
+		s.Equal("IDR", result.Currency)
+		s.Equal([]entity.BudgetStatusItem{
+			{BudgetID: 1, CategoryID: 7, Category: "Food", Limit: 1000000, Spent: 1250000.5, Remaining: -250000.5, OverBudget: true},
+			{BudgetID: 2, CategoryID: 8, Category: "Transport", Limit: 300000, Spent: 300000, Remaining: 0, OverBudget: false},
+			{BudgetID: 3, CategoryID: 9, Category: "Travel", Limit: 2000000, Spent: 0, Remaining: 2000000, OverBudget: false},
+		}, result.Categories)
+	})
+
+	s.Run("no budgets returns an empty list", func() {
+		s.SetupTest()
+		s.budgetRepo.On("GetStatusByUserIDAndMonth", mock.Anything, int64(1), "IDR", "2024-02-01", "2024-02-29").
+			Return(nil, nil).Once()
+
+		result, err := s.usecase.GetBudgetStatus(s.ctx, 1, "2024-02")
+		s.Require().NoError(err)
+		s.NotNil(result.Categories)
+		s.Empty(result.Categories)
+	})
+
+	s.Run("invalid month", func() {
+		s.SetupTest()
+		_, err := s.usecase.GetBudgetStatus(s.ctx, 1, "Feb 2024")
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+}
+
+func (s *CrudBudgetTestSuite) TestGetOverspent() {
+	s.Run("only budgets over the limit, largest overspend first", func() {
+		s.SetupTest()
+		// Repository sudah menyaring budget yang under maupun tepat di limit
+		s.budgetRepo.On("GetOverspentByUserIDAndMonth", mock.Anything, int64(1), "IDR", "2024-02-01", "2024-02-29").
+			Return([]*mysql.BudgetStatusRow{
+				{BudgetID: 3, CategoryID: 8, CategoryName: "Transport", LimitAmount: 300000, Spent: 700000},
+				{BudgetID: 1, CategoryID: 7, CategoryName: "Food", LimitAmount: 1000000, Spent: 1250000.5},
+			}, nil).Once()
+
+		result, err := s.usecase.GetOverspent(s.ctx, 1, "2024-02")
+		s.Require().NoError(err)
+		s.Equal("2024-02", result.Month)
+		s.Equal("IDR", result.Currency)
+		s.Equal([]entity.OverspentItem{
+			{BudgetID: 3, CategoryID: 8, Category: "Transport", Limit: 300000, Spent: 700000, Overspend: 400000},
+			{BudgetID: 1, CategoryID: 7, Category: "Food", Limit: 1000000, Spent: 1250000.5, Overspend: 250000.5},
+		}, result.Categories)
+	})
+
+	s.Run("nothing over returns an empty list", func() {
+		s.SetupTest()
+		s.budgetRepo.On("GetOverspentByUserIDAndMonth", mock.Anything, int64(1), "IDR", "2024-02-01", "2024-02-29").
+			Return(nil, nil).Once()
+
+		result, err := s.usecase.GetOverspent(s.ctx, 1, "2024-02")
+		s.Require().NoError(err)
+		s.NotNil(result.Categories)
+		s.Empty(result.Categories)
+	})
+
+	s.Run("invalid month", func() {
+		s.SetupTest()
+		_, err := s.usecase.GetOverspent(s.ctx, 1, "2024-13")
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+		s.budgetRepo.AssertNotCalled(s.T(), "GetOverspentByUserIDAndMonth", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,71 @@
+package entity
+
+// BudgetReq adalah request body untuk membuat budget bulanan satu kategori.
+type BudgetReq struct {
+	UserID      int64   `json:"user_id,omitempty"`
+	CategoryID  int64   `json:"category_id" validate:"required,gt=0" name:"Kategori"`
+	Month       string  `json:"month" validate:"required" name:"Bulan"`
+	LimitAmount float64 `json:"limit_amount" validate:"required,gt=0" name:"Batas Budget"`
+}
+
+// SetUserID menyisipkan user ID dari JWT ke request.
+func (r *BudgetReq) SetUserID(userID int64) {
+	r.UserID = userID
+}
+
+// UpdateBudgetReq adalah request body untuk mengubah batas budget. Kategori dan bulan budget tidak bisa diubah.
+type UpdateBudgetReq struct {
+	UserID      int64   `json:"user_id,omitempty"`
+	LimitAmount float64 `json:"limit_amount" validate:"required,gt=0" name:"Batas Budget"`
+}
+
+// SetUserID menyisipkan user ID dari JWT ke request.
+func (r *UpdateBudgetReq) SetUserID(userID int64) {
+	r.UserID = userID
+}
+
+// BudgetResponse adalah struktur data untuk respons budget.
+type BudgetResponse struct {
+	ID          int64   `json:"id"`
+	CategoryID  int64   `json:"category_id"`
+	Month       string  `json:"month"`
+	LimitAmount float64 `json:"limit_amount"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+// BudgetStatusItem adalah posisi satu budget terhadap pengeluaran kategorinya.
+// Remaining bernilai negatif saat pengeluaran melewati batas.
+type BudgetStatusItem struct {
+	BudgetID   int64   `json:"budget_id"`
+	CategoryID int64   `json:"category_id"`
+	Category   string  `json:"category"`
+	Limit      float64 `json:"limit"`
+	Spent      float64 `json:"spent"`
+	Remaining  float64 `json:"remaining"`
+	OverBudget bool    `json:"over_budget"`
+}
+
+// BudgetStatusResponse adalah status semua budget user pada satu bulan.
+type BudgetStatusResponse struct {
+	Month      string             `json:"month"`
+	Currency   string             `json:"currency"`
+	Categories []BudgetStatusItem `json:"categories"`
+}
+
+// OverspentItem adalah satu budget yang pengeluarannya melebihi limit. Overspend adalah selisih spent dengan limit.
+type OverspentItem struct {
+	BudgetID   int64   `json:"budget_id"`
+	CategoryID int64   `json:"category_id"`
+	Category   string  `json:"category"`
+	Limit      float64 `json:"limit"`
+	Spent      float64 `json:"spent"`
+	Overspend  float64 `json:"overspend"`
+}
+
+// OverspentResponse adalah daftar budget yang terlampaui pada satu bulan, urut dari overspend terbesar.
+type OverspentResponse struct {
+	Month      string          `json:"month"`
+	Currency   string          `json:"currency"`
+	Categories []OverspentItem `json:"categories"`
+}
@@ -2,38 +2,55 @@ package category_usecase // Nama paket harus berbeda dari 'entity'
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	generalEntity "github.com/rakahikmah/finance-tracking/entity"
 	"github.com/rakahikmah/finance-tracking/internal/helper"
 	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
 	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
 	"github.com/rakahikmah/finance-tracking/internal/usecase/category/entity"
+	transactions_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/transactions"
+	transactionEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
 
 	apperr "github.com/rakahikmah/finance-tracking/error"
 )
 
 // CrudCategory adalah struct yang akan menampung dependensi repository.
 type CrudCategory struct {
-	CategoryRepo mysql.ICategoryRepository
+	CategoryRepo       mysql.ICategoryRepository
+	TransactionRepo    mysql.ITransactionRepository
+	UserStatus         usecase.IUserStatusChecker              // Menolak penulisan dari user yang tidak aktif
+	TransactionUsecase transactions_usecase.ICrudTransaction // Validasi transaksi awal dengan aturan yang sama seperti Create
 }
 
 // NewCrudCategory adalah konstruktor untuk CrudCategory.
 func NewCrudCategory(
 	CategoryRepo mysql.ICategoryRepository,
+	TransactionRepo mysql.ITransactionRepository,
+	UserStatus usecase.IUserStatusChecker,
+	TransactionUsecase transactions_usecase.ICrudTransaction,
 ) *CrudCategory {
-	return &CrudCategory{CategoryRepo: CategoryRepo}
+	return &CrudCategory{CategoryRepo: CategoryRepo, TransactionRepo: TransactionRepo, UserStatus: UserStatus, TransactionUsecase: TransactionUsecase}
 }
 
 // ICrudCategory mendefinisikan interface untuk operasi CRUD pada Category.
 type ICrudCategory interface {
 	// Ini sudah benar
 	Create(ctx context.Context, userID int64, req entity.CategoryReq) error
+	CreateCategoryWithTransaction(ctx context.Context, userID int64, catReq entity.CategoryReq, txReq transactionEntity.TransactionReq) (*entity.CategoryWithTransactionResponse, error)
 	GetAll(ctx context.Context, userID int64) ([]entity.CategoryResponse, error)
+	List(ctx context.Context, userID int64, req entity.CategoryListReq) (*entity.CategoryListResponse, error)
+	GetByID(ctx context.Context, id int64, userID int64) (*entity.CategoryResponse, error)
+	GetTree(ctx context.Context, userID int64) ([]entity.CategoryNode, error)
 	Update(ctx context.Context, id int64, userID int64, req entity.CategoryReq) error
 	Delete(ctx context.Context, id int64, userID int64) error
+	Merge(ctx context.Context, id int64, userID int64, req entity.CategoryMergeReq) (*entity.CategoryMergeResponse, error)
+	GetDeleteImpact(ctx context.Context, id int64, userID int64) (*entity.CategoryDeleteImpactResponse, error)
 }
 
 func (u *CrudCategory) Create(ctx context.Context, userID int64, req entity.CategoryReq) error {
@@ -45,38 +62,131 @@ func (u *CrudCategory) Create(ctx context.Context, userID int64, req entity.Cate
 		return apperr.ErrInvalidRequest().SetDetail("User ID is required")
 	}
 
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return err
+	}
+
+	data, logFields, err := u.buildCategory(ctx, funcName, userID, req)
+	if err != nil {
+		return err
+	}
+
+	// 3. Panggil repository untuk membuat record
+	err = u.CategoryRepo.Create(ctx, nil, data, false)
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.Create", err, logFields, "")
+		return err
+	}
+
+	return nil
+}
+
+// CreateCategoryWithTransaction membuat kategori baru beserta transaksi pertamanya dalam satu DB transaction,
+// sehingga kegagalan menyimpan transaksi tidak meninggalkan kategori tanpa transaksi.
+// category_id pada txReq diabaikan karena transaksi selalu memakai kategori yang baru dibuat.
+func (u *CrudCategory) CreateCategoryWithTransaction(ctx context.Context, userID int64, catReq entity.CategoryReq, txReq transactionEntity.TransactionReq) (*entity.CategoryWithTransactionResponse, error) {
+	funcName := "CrudCategory.CreateCategoryWithTransaction"
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, nil, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// 1. Validasi kategori dan transaksi sebelum menyimpan apa pun
+	category, logFields, err := u.buildCategory(ctx, funcName, userID, catReq)
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := u.TransactionUsecase.PrepareCreate(ctx, userID, txReq, category)
+	if err != nil {
+		helper.LogError(funcName, "TransactionUsecase.PrepareCreate", err, logFields, "Invalid initial transaction")
+		return nil, err
+	}
+
+	// 2. Simpan kategori lalu transaksi yang merujuk ke kategori tersebut, keduanya di-rollback jika salah satu gagal
+	err = mysql.DBTransaction(u.CategoryRepo, func(trx mysql.TrxObj) error {
+		if err := u.CategoryRepo.Create(ctx, trx, category, false); err != nil {
+			helper.LogError(funcName, "CategoryRepo.Create", err, logFields, "")
+			return err
+		}
+
+		transaction.CategoryID = sql.NullInt64{Int64: category.ID, Valid: true}
+		if err := u.TransactionRepo.Create(ctx, trx, transaction, false); err != nil {
+			helper.LogError(funcName, "TransactionRepo.Create", err, logFields, "")
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.CategoryWithTransactionResponse{
+		CategoryID:    category.ID,
+		TransactionID: transaction.ID,
+	}, nil
+}
+
+// buildCategory memvalidasi request kategori baru (tipe dan duplikasi nama) dan mengembalikan kategori yang siap disimpan.
+func (u *CrudCategory) buildCategory(ctx context.Context, funcName string, userID int64, req entity.CategoryReq) (*myentity.Category, generalEntity.CaptureFields, error) {
+	req.Name = helper.NormalizeCategoryName(req.Name)
 	logFields := generalEntity.CaptureFields{
 		"user_id": strconv.FormatInt(userID, 10), // Sekarang `userID` di sini merujuk ke parameter
 		"name":    req.Name,
 	}
 
+	// Kategori baru wajib memiliki tipe agar tidak bisa dipakai untuk transaksi dengan tipe yang berbeda
+	if req.Type == "" {
+		return nil, logFields, apperr.ErrInvalidRequest().SetDetail("type is required")
+	}
+	if !isValidCategoryType(req.Type) {
+		return nil, logFields, apperr.ErrInvalidRequest().SetDetail("type must be either 'income' or 'expense'")
+	}
+
+	if err := validateCategoryAppearance(req); err != nil {
+		return nil, logFields, err
+	}
+
 	// 1. Cek duplikasi nama kategori untuk user yang sama
-	existingCategory, err := u.CategoryRepo.GetByUserIDAndName(ctx, userID, req.Name) // Menggunakan parameter `userID`
+	existingCategory, err := u.CategoryRepo.GetByUserIDAndName(ctx, userID, req.Name, 0) // Menggunakan parameter `userID`
 	if err != nil && !errors.Is(err, apperr.ErrRecordNotFound()) {
 		helper.LogError(funcName, "GetByUserIDAndName", err, logFields, "Error checking for existing category name")
-		return err
+		return nil, logFields, err
 	}
 	if existingCategory != nil {
 		helper.LogError(funcName, "GetByUserIDAndName", errors.New("category name already exists for this user"), logFields, "")
-		return apperr.ErrConflict().SetDetail(fmt.Sprintf("Category with name '%s' already exists for this user.", req.Name))
+		return nil, logFields, apperr.ErrConflict().SetDetail(fmt.Sprintf("Category with name '%s' already exists for this user.", req.Name))
 	}
 
-	// 2. Siapkan data untuk disimpan ke database
-	data := &myentity.Category{
-		Name:      req.Name,
-		CreatedAt: helper.DatetimeNowJakarta(),
-		UpdatedAt: helper.DatetimeNowJakarta(),
-		CreatedBy: userID, // Menggunakan parameter `userID`
-	}
-
-	// 3. Panggil repository untuk membuat record
-	err = u.CategoryRepo.Create(ctx, nil, data, false)
-	if err != nil {
-		helper.LogError(funcName, "CategoryRepo.Create", err, logFields, "")
-		return err
+	// 2. Validasi kategori induk jika diisi, 0 sama dengan tanpa induk
+	var parentID sql.NullInt64
+	if req.ParentID != nil && *req.ParentID != 0 {
+		if err := u.validateParent(ctx, funcName, logFields, userID, 0, *req.ParentID, myentity.TransactionType(req.Type)); err != nil {
+			return nil, logFields, err
+		}
+		parentID = sql.NullInt64{Int64: *req.ParentID, Valid: true}
 	}
 
-	return nil
+	// 3. Siapkan data untuk disimpan ke database
+	return &myentity.Category{
+		Name:              req.Name,
+		ParentID:          parentID,
+		Type:              myentity.TransactionType(req.Type),
+		ExcludeFromTotals: req.ExcludeFromTotals != nil && *req.ExcludeFromTotals,
+		Icon:              stringValue(req.Icon),
+		Color:             stringValue(req.Color),
+		CreatedAt:         helper.DatetimeNowJakarta(),
+		UpdatedAt:         helper.DatetimeNowJakarta(),
+		CreatedBy:         userID, // Menggunakan parameter `userID`
+	}, logFields, nil
 }
 
 // // GetAll mengambil semua kategori untuk user tertentu.
@@ -104,18 +214,159 @@ func (u *CrudCategory) GetAll(ctx context.Context, userID int64) ([]entity.Categ
 	// Mapping ke response DTO
 	var result []entity.CategoryResponse
 	for _, row := range data {
-		result = append(result, entity.CategoryResponse{
-			ID:        row.ID,
-			Name:      row.Name,
-			CreatedBy: row.CreatedBy,
-			CreatedAt: helper.ConvertToJakartaTime(row.CreatedAt), // Konversi time.Time ke string
-			UpdatedAt: helper.ConvertToJakartaTime(row.UpdatedAt), // Konversi time.Time ke string
-		})
+		result = append(result, toCategoryResponse(row))
 	}
 
 	return result, nil
 }
 
+// Batas jumlah kategori per halaman untuk List.
+const (
+	defaultCategoryLimit = 50
+	maxCategoryLimit     = 200
+)
+
+// List mengambil kategori user per halaman dengan limit dan offset, opsional difilter nama (req.Query),
+// diurutkan berdasarkan nama. Dipakai oleh akun dengan banyak kategori; GetAll tetap mengembalikan semua kategori.
+func (u *CrudCategory) List(ctx context.Context, userID int64, req entity.CategoryListReq) (*entity.CategoryListResponse, error) {
+	funcName := "CrudCategory.List"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"limit":   strconv.Itoa(req.Limit),
+		"offset":  strconv.Itoa(req.Offset),
+		"q":       req.Query,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if req.Limit == 0 {
+		req.Limit = defaultCategoryLimit
+	}
+	if req.Limit < 1 || req.Limit > maxCategoryLimit {
+		return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("limit must be between 1 and %d", maxCategoryLimit))
+	}
+	if req.Offset < 0 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("offset must not be negative")
+	}
+
+	filter := mysql.CategoryFilter{Query: strings.TrimSpace(req.Query)}
+
+	total, err := u.CategoryRepo.CountByUserID(ctx, userID, filter)
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.CountByUserID", err, logFields, "")
+		return nil, err
+	}
+
+	data, err := u.CategoryRepo.ListByUserID(ctx, userID, filter, req.Limit, req.Offset)
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.ListByUserID", err, logFields, "")
+		return nil, err
+	}
+
+	result := &entity.CategoryListResponse{
+		Data: make([]entity.CategoryResponse, 0, len(data)),
+		Meta: generalEntity.PaginationMeta{
+			Page:       req.Offset/req.Limit + 1,
+			PerPage:    req.Limit,
+			Total:      total,
+			TotalPages: int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+			HasNext:    int64(req.Offset+req.Limit) < total,
+		},
+	}
+	for _, row := range data {
+		result.Data = append(result.Data, toCategoryResponse(row))
+	}
+
+	return result, nil
+}
+
+// GetByID mengambil detail satu kategori milik user. Repo GetByID tidak memfilter pemilik,
+// sehingga kepemilikan diperiksa di sini: kategori user lain ditolak dengan ErrForbidden.
+func (u *CrudCategory) GetByID(ctx context.Context, id int64, userID int64) (*entity.CategoryResponse, error) {
+	funcName := "CrudCategory.GetByID"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"id":      fmt.Sprintf("%d", id),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	category, err := u.CategoryRepo.GetByID(ctx, id)
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.GetByID", err, logFields, "")
+		return nil, err
+	}
+
+	if category.CreatedBy != userID {
+		helper.LogError(funcName, "Authorization", errors.New("unauthorized access to category"), logFields, "User tried to get category not owned by them")
+		return nil, apperr.ErrForbidden().SetDetail("You are not authorized to access this category.")
+	}
+
+	result := toCategoryResponse(category)
+	return &result, nil
+}
+
+// GetTree mengembalikan kategori user sebagai pohon: kategori level teratas beserta subkategorinya secara bertingkat.
+// Urutan saudara mengikuti urutan GetAll. Kategori yang induknya tidak ditemukan ditampilkan di level teratas.
+func (u *CrudCategory) GetTree(ctx context.Context, userID int64) ([]entity.CategoryNode, error) {
+	funcName := "CrudCategory.GetTree"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"layer":   "usecase",
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	data, err := u.CategoryRepo.GetAll(ctx, userID)
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.GetAll", err, logFields, "")
+		return nil, err
+	}
+
+	exists := make(map[int64]bool, len(data))
+	for _, row := range data {
+		exists[row.ID] = true
+	}
+	children := make(map[int64][]*myentity.Category)
+	roots := make([]*myentity.Category, 0)
+	for _, row := range data {
+		if row.ParentID.Valid && exists[row.ParentID.Int64] && row.ParentID.Int64 != row.ID {
+			children[row.ParentID.Int64] = append(children[row.ParentID.Int64], row)
+			continue
+		}
+		roots = append(roots, row)
+	}
+
+	// visited mencegah loop tak berujung jika data lama ternyata membentuk siklus
+	visited := make(map[int64]bool, len(data))
+	var build func(rows []*myentity.Category) []entity.CategoryNode
+	build = func(rows []*myentity.Category) []entity.CategoryNode {
+		nodes := make([]entity.CategoryNode, 0, len(rows))
+		for _, row := range rows {
+			if visited[row.ID] {
+				continue
+			}
+			visited[row.ID] = true
+			nodes = append(nodes, entity.CategoryNode{CategoryResponse: toCategoryResponse(row), Children: build(children[row.ID])})
+		}
+		return nodes
+	}
+
+	return build(roots), nil
+}
+
 // // Update memperbarui kategori berdasarkan ID dan memastikan milik user yang benar.
 func (u *CrudCategory) Update(ctx context.Context, id int64, userID int64, req entity.CategoryReq) error {
 	funcName := "CrudCategory.Update"
@@ -131,6 +382,18 @@ func (u *CrudCategory) Update(ctx context.Context, id int64, userID int64, req e
 		return apperr.ErrInvalidRequest().SetDetail("User ID is required")
 	}
 
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return err
+	}
+
+	if req.Type != "" && !isValidCategoryType(req.Type) {
+		return apperr.ErrInvalidRequest().SetDetail("type must be either 'income' or 'expense'")
+	}
+	if err := validateCategoryAppearance(req); err != nil {
+		return err
+	}
+
 	// 1. Ambil data lama dari database
 	oldData, err := u.CategoryRepo.GetByID(ctx, id)
 	if err != nil {
@@ -141,30 +404,57 @@ func (u *CrudCategory) Update(ctx context.Context, id int64, userID int64, req e
 	// 2. Otorisasi: Pastikan kategori yang akan diupdate adalah milik user yang sedang login
 	if oldData.CreatedBy != userID {
 		helper.LogError(funcName, "Authorization", errors.New("unauthorized access to category"), logFields, "User tried to update category not owned by them")
-		return apperr.ErrUnauthorized().SetDetail("You are not authorized to update this category.")
+		return apperr.ErrForbidden().SetDetail("You are not authorized to update this category.")
 	}
 
-	// 3. (Opsional) Cek duplikasi nama jika nama diubah
-	if oldData.Name != req.Name { // Jika nama kategori diubah
-		existingCategory, err := u.CategoryRepo.GetByUserIDAndName(ctx, userID, req.Name)
+	// 3. (Opsional) Cek duplikasi nama jika nama diubah, nama kosong berarti hanya field lain yang diubah
+	req.Name = helper.NormalizeCategoryName(req.Name)
+	if req.Name == "" {
+		req.Name = oldData.Name
+	}
+	if oldData.Name != req.Name { // Jika nama kategori diubah, termasuk hanya huruf besar/kecilnya
+		// Kategori ini sendiri dikecualikan sehingga "food" -> "Food" tidak dianggap konflik
+		existingCategory, err := u.CategoryRepo.GetByUserIDAndName(ctx, userID, req.Name, id)
 		if err != nil && !errors.Is(err, apperr.ErrRecordNotFound()) {
 			helper.LogError(funcName, "GetByUserIDAndName", err, logFields, "Error checking for existing category name on update")
 			return err
 		}
-		if existingCategory != nil && existingCategory.ID != id { // Jika nama baru sudah ada di kategori lain milik user ini
+		if existingCategory != nil { // Jika nama baru sudah ada di kategori lain milik user ini
 			helper.LogError(funcName, "GetByUserIDAndName", errors.New("category name already exists for this user"), logFields, "")
 			return apperr.ErrConflict().SetDetail(fmt.Sprintf("Category with name '%s' already exists for this user.", req.Name))
 		}
 	}
 
 	// 4. Siapkan perubahan data
-	changes := &myentity.Category{
-		Name:      req.Name,
-		UpdatedAt: helper.DatetimeNowJakarta(), // Update UpdatedAt
+	updated := *oldData
+	updated.Name = req.Name
+	updated.UpdatedAt = helper.DatetimeNowJakarta() // Update UpdatedAt
+	if req.ExcludeFromTotals != nil {
+		updated.ExcludeFromTotals = *req.ExcludeFromTotals
+	}
+	// Mengisi tipe juga menjadi cara memigrasi kategori lama yang belum bertipe
+	if req.Type != "" {
+		updated.Type = myentity.TransactionType(req.Type)
+	}
+	if req.Icon != nil {
+		updated.Icon = *req.Icon
+	}
+	if req.Color != nil {
+		updated.Color = *req.Color
+	}
+	if req.ParentID != nil {
+		updated.ParentID = sql.NullInt64{}
+		if *req.ParentID != 0 {
+			if err := u.validateParent(ctx, funcName, logFields, userID, id, *req.ParentID, updated.Type); err != nil {
+				return err
+			}
+			updated.ParentID = sql.NullInt64{Int64: *req.ParentID, Valid: true}
+		}
 	}
 
 	// 5. Panggil repository untuk update
-	err = u.CategoryRepo.Update(ctx, nil, oldData, changes)
+	// changes nil agar exclude_from_totals yang diubah menjadi false ikut tersimpan
+	err = u.CategoryRepo.Update(ctx, nil, &updated, nil)
 	if err != nil {
 		helper.LogError(funcName, "CategoryRepo.Update", err, logFields, "")
 		return err
@@ -188,6 +478,11 @@ func (u *CrudCategory) Delete(ctx context.Context, id int64, userID int64) error
 		return apperr.ErrInvalidRequest().SetDetail("User ID is required")
 	}
 
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return err
+	}
+
 	// 1. Validasi apakah data dengan ID tersebut ada dan milik user yang benar
 	oldData, err := u.CategoryRepo.GetByID(ctx, id)
 	if err != nil {
@@ -198,10 +493,20 @@ func (u *CrudCategory) Delete(ctx context.Context, id int64, userID int64) error
 	// 2. Otorisasi: Pastikan kategori yang akan dihapus adalah milik user yang sedang login
 	if oldData.CreatedBy != userID {
 		helper.LogError(funcName, "Authorization", errors.New("unauthorized access to category"), logFields, "User tried to delete category not owned by them")
-		return apperr.ErrUnauthorized().SetDetail("You are not authorized to delete this category.")
+		return apperr.ErrForbidden().SetDetail("You are not authorized to delete this category.")
 	}
 
-	// 3. Lakukan delete
+	// 3. Kategori induk tidak boleh dihapus selama masih memiliki subkategori
+	childCount, err := u.CategoryRepo.CountChildren(ctx, id)
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.CountChildren", err, logFields, "")
+		return err
+	}
+	if childCount > 0 {
+		return apperr.ErrConflict().SetDetail(fmt.Sprintf("Category still has %d subcategories. Move or delete them first.", childCount))
+	}
+
+	// 4. Lakukan delete
 	err = u.CategoryRepo.DeleteByID(ctx, nil, id)
 	if err != nil {
 		helper.LogError(funcName, "CategoryRepo.DeleteByID", err, logFields, "")
@@ -210,3 +515,247 @@ func (u *CrudCategory) Delete(ctx context.Context, id int64, userID int64) error
 
 	return nil
 }
+
+// Merge menggabungkan kategori id ke kategori req.TargetID, misalnya untuk duplikat "Food" dan "food":
+// seluruh transaksi kategori sumber dipindahkan ke target lalu kategori sumber dihapus dalam satu DB transaction.
+// Kedua kategori harus milik user, dan target harus boleh dipakai tipe transaksi kategori sumber.
+// Seperti Delete, kategori sumber yang masih memiliki subkategori ditolak, dan budget kategori sumber ikut terhapus.
+func (u *CrudCategory) Merge(ctx context.Context, id int64, userID int64, req entity.CategoryMergeReq) (*entity.CategoryMergeResponse, error) {
+	funcName := "CrudCategory.Merge"
+	logFields := generalEntity.CaptureFields{
+		"user_id":   strconv.FormatInt(userID, 10),
+		"id":        fmt.Sprintf("%d", id),
+		"target_id": fmt.Sprintf("%d", req.TargetID),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if req.TargetID <= 0 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("target_id must be greater than 0")
+	}
+	if req.TargetID == id {
+		return nil, apperr.ErrInvalidRequest().SetDetail("Category cannot be merged into itself")
+	}
+
+	// 1. Kedua kategori harus ada dan milik user yang sedang login
+	source, err := u.CategoryRepo.GetByID(ctx, id)
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.GetByID", err, logFields, "Error getting source category for merge")
+		return nil, err
+	}
+	if source.CreatedBy != userID {
+		helper.LogError(funcName, "Authorization", errors.New("unauthorized access to category"), logFields, "User tried to merge category not owned by them")
+		return nil, apperr.ErrForbidden().SetDetail("You are not authorized to merge this category.")
+	}
+
+	target, err := u.CategoryRepo.GetByID(ctx, req.TargetID)
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.GetByID", err, logFields, "Error getting target category for merge")
+		if errors.Is(err, apperr.ErrRecordNotFound()) {
+			return nil, apperr.ErrInvalidRequest().SetDetail("Target category not found")
+		}
+		return nil, err
+	}
+	if target.CreatedBy != userID {
+		helper.LogError(funcName, "Authorization", errors.New("unauthorized access to category"), logFields, "User tried to merge into category not owned by them")
+		return nil, apperr.ErrForbidden().SetDetail("You are not authorized to use the target category.")
+	}
+
+	// 2. Transaksi sumber harus tetap valid di target: kategori tanpa tipe menerima income maupun expense
+	if target.Type != "" && source.Type != target.Type {
+		return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Target category is for %s, it can only receive transactions of the same type.", target.Type))
+	}
+
+	// 3. Sama seperti Delete, kategori induk tidak boleh dihapus selama masih memiliki subkategori
+	childCount, err := u.CategoryRepo.CountChildren(ctx, id)
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.CountChildren", err, logFields, "")
+		return nil, err
+	}
+	if childCount > 0 {
+		return nil, apperr.ErrConflict().SetDetail(fmt.Sprintf("Category still has %d subcategories. Move or delete them first.", childCount))
+	}
+
+	// 4. Pindahkan transaksi lalu hapus kategori sumber, keduanya di-rollback jika salah satu gagal
+	var reassigned int64
+	err = mysql.DBTransaction(u.CategoryRepo, func(trx mysql.TrxObj) error {
+		reassigned, err = u.TransactionRepo.ReassignCategory(ctx, trx, id, req.TargetID, userID)
+		if err != nil {
+			helper.LogError(funcName, "TransactionRepo.ReassignCategory", err, logFields, "")
+			return err
+		}
+
+		if err := u.CategoryRepo.DeleteByID(ctx, trx, id); err != nil {
+			helper.LogError(funcName, "CategoryRepo.DeleteByID", err, logFields, "")
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.CategoryMergeResponse{
+		SourceID:               id,
+		TargetID:               req.TargetID,
+		ReassignedTransactions: reassigned,
+	}, nil
+}
+
+// GetDeleteImpact mengembalikan pratinjau dampak penghapusan kategori: jumlah dan total amount transaksi
+// yang mereferensikannya. Hanya membaca data, tidak ada yang dihapus.
+func (u *CrudCategory) GetDeleteImpact(ctx context.Context, id int64, userID int64) (*entity.CategoryDeleteImpactResponse, error) {
+	funcName := "CrudCategory.GetDeleteImpact"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"id":      fmt.Sprintf("%d", id),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	category, err := u.CategoryRepo.GetByID(ctx, id)
+	if err != nil {
+		helper.LogError(funcName, "GetByID", err, logFields, "Error getting category for delete impact")
+		return nil, err
+	}
+
+	// Otorisasi sama seperti Delete: kategori harus milik user yang sedang login
+	if category.CreatedBy != userID {
+		helper.LogError(funcName, "Authorization", errors.New("unauthorized access to category"), logFields, "User tried to preview delete of category not owned by them")
+		return nil, apperr.ErrForbidden().SetDetail("You are not authorized to delete this category.")
+	}
+
+	total, err := u.TransactionRepo.GetTotalByCategoryID(ctx, userID, id)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetTotalByCategoryID", err, logFields, "")
+		return nil, err
+	}
+	childCount, err := u.CategoryRepo.CountChildren(ctx, id)
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.CountChildren", err, logFields, "")
+		return nil, err
+	}
+
+	return &entity.CategoryDeleteImpactResponse{
+		CategoryID:       id,
+		TransactionCount: total.TransactionCount,
+		TotalAmount:      total.TotalAmount,
+		HasChildren:      childCount > 0,
+	}, nil
+}
+
+// validateParent memastikan parentID adalah kategori milik user dengan tipe yang sama, dan untuk kategori yang sudah ada
+// (categoryID bukan 0) bahwa categoryID bukan leluhur dari parentID, sehingga hierarki tidak membentuk siklus.
+func (u *CrudCategory) validateParent(ctx context.Context, funcName string, logFields generalEntity.CaptureFields, userID, categoryID, parentID int64, categoryType myentity.TransactionType) error {
+	if parentID < 0 {
+		return apperr.ErrInvalidRequest().SetDetail("parent_id must be greater than 0")
+	}
+	if parentID == categoryID {
+		return apperr.ErrInvalidRequest().SetDetail("A category cannot be its own parent.")
+	}
+
+	parent, err := u.CategoryRepo.GetByID(ctx, parentID)
+	if errors.Is(err, apperr.ErrRecordNotFound()) {
+		return apperr.ErrInvalidRequest().SetDetail("Parent category not found.")
+	}
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.GetByID", err, logFields, "Error getting parent category")
+		return err
+	}
+	if parent.CreatedBy != userID {
+		helper.LogError(funcName, "Authorization", errors.New("unauthorized access to parent category"), logFields, "User tried to use parent category not owned by them")
+		return apperr.ErrForbidden().SetDetail("You are not authorized to use this parent category.")
+	}
+	if categoryType != "" && parent.Type != "" && parent.Type != categoryType {
+		return apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Parent category is for %s, subcategory must have the same type.", parent.Type))
+	}
+
+	// Kategori baru belum memiliki turunan sehingga tidak mungkin membentuk siklus
+	if categoryID == 0 || !parent.ParentID.Valid {
+		return nil
+	}
+
+	all, err := u.CategoryRepo.GetAll(ctx, userID)
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.GetAll", err, logFields, "")
+		return err
+	}
+	byID := make(map[int64]*myentity.Category, len(all))
+	for _, category := range all {
+		byID[category.ID] = category
+	}
+
+	// Telusuri leluhur parent, dibatasi jumlah kategori agar data lama yang sudah bersiklus tidak membuat loop tak berujung
+	current := parent
+	for i := 0; i <= len(all) && current != nil && current.ParentID.Valid; i++ {
+		if current.ParentID.Int64 == categoryID {
+			return apperr.ErrInvalidRequest().SetDetail("A category cannot be moved under its own subcategory.")
+		}
+		current = byID[current.ParentID.Int64]
+	}
+
+	return nil
+}
+
+// toCategoryResponse memetakan kategori ke response DTO.
+func toCategoryResponse(row *myentity.Category) entity.CategoryResponse {
+	var parentID *int64
+	if row.ParentID.Valid {
+		parentID = &row.ParentID.Int64
+	}
+
+	return entity.CategoryResponse{
+		ID:                row.ID,
+		ParentID:          parentID,
+		Name:              row.Name,
+		Type:              string(row.Type),
+		Icon:              row.Icon,
+		Color:             row.Color,
+		CreatedBy:         row.CreatedBy,
+		ExcludeFromTotals: row.ExcludeFromTotals,
+		CreatedAt:         helper.ConvertToJakartaTime(row.CreatedAt), // Konversi time.Time ke string
+		UpdatedAt:         helper.ConvertToJakartaTime(row.UpdatedAt), // Konversi time.Time ke string
+	}
+}
+
+// validateCategoryAppearance memeriksa icon dan color kategori jika diberikan.
+func validateCategoryAppearance(req entity.CategoryReq) error {
+	if req.Icon != nil {
+		if err := helper.ValidateCategoryIcon(*req.Icon); err != nil {
+			return apperr.ErrInvalidRequest().SetDetail("Invalid icon: " + err.Error())
+		}
+	}
+	if req.Color != nil {
+		if err := helper.ValidateCategoryColor(*req.Color); err != nil {
+			return apperr.ErrInvalidRequest().SetDetail("Invalid color: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// stringValue mengembalikan isi pointer string, atau string kosong jika nil.
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// isValidCategoryType memeriksa apakah tipe kategori adalah income atau expense.
+func isValidCategoryType(categoryType string) bool {
+	return categoryType == string(myentity.TransactionTypeIncome) || categoryType == string(myentity.TransactionTypeExpense)
+}
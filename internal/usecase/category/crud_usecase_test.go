@@ -0,0 +1,607 @@
+package category_usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	category_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/category"
+	"github.com/rakahikmah/finance-tracking/internal/usecase/category/entity"
+	transactionEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CrudCategoryTestSuite struct {
+	suite.Suite
+
+	categoryRepo    *mocks.ICategoryRepository
+	transactionRepo *mocks.ITransactionRepository
+	userStatus      *mocks.IUserStatusChecker
+	transactions    *mocks.ICrudTransaction
+	usecase         category_usecase.ICrudCategory
+	ctx             context.Context
+}
+
+func (s *CrudCategoryTestSuite) SetupTest() {
+	s.categoryRepo = &mocks.ICategoryRepository{}
+	s.transactionRepo = &mocks.ITransactionRepository{}
+	s.userStatus = &mocks.IUserStatusChecker{}
+	s.userStatus.On("EnsureActive", mock.Anything, int64(1)).Return(nil).Maybe()
+	s.transactions = &mocks.ICrudTransaction{}
+	s.ctx = context.Background()
+
+	s.usecase = category_usecase.NewCrudCategory(s.categoryRepo, s.transactionRepo, s.userStatus, s.transactions)
+}
+
+func TestCrudCategory(t *testing.T) {
+	suite.Run(t, new(CrudCategoryTestSuite))
+}
+
+func (s *CrudCategoryTestSuite) assertHTTPCode(err error, code int) {
+	var appErr apperr.CustomErrorResponse
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(code, appErr.HTTPCode)
+}
+
+func (s *CrudCategoryTestSuite) TestGetByID() {
+	s.Run("own category", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).
+			Return(&myentity.Category{ID: 7, CreatedBy: 1, Name: "Makan", Type: myentity.TransactionTypeExpense, Icon: "utensils", Color: "#FF8800"}, nil).Once()
+
+		result, err := s.usecase.GetByID(s.ctx, 7, 1)
+		s.Require().NoError(err)
+
+		s.Equal(int64(7), result.ID)
+		s.Equal("Makan", result.Name)
+		s.Equal("utensils", result.Icon)
+		s.Equal("#FF8800", result.Color)
+	})
+
+	s.Run("missing category", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(8)).Return(nil, apperr.ErrRecordNotFound()).Once()
+
+		_, err := s.usecase.GetByID(s.ctx, 8, 1)
+		s.assertHTTPCode(err, http.StatusNotFound)
+	})
+
+	s.Run("category of another user", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(9)).Return(&myentity.Category{ID: 9, CreatedBy: 2, Name: "Gaji"}, nil).Once()
+
+		result, err := s.usecase.GetByID(s.ctx, 9, 1)
+		s.assertHTTPCode(err, http.StatusForbidden)
+		s.Nil(result)
+	})
+}
+
+func (s *CrudCategoryTestSuite) TestGetDeleteImpact() {
+	s.Run("category with transactions", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).Return(&myentity.Category{ID: 7, CreatedBy: 1, Name: "Makan"}, nil).Once()
+		s.transactionRepo.On("GetTotalByCategoryID", mock.Anything, int64(1), int64(7)).
+			Return(&mysql.CategoryTransactionTotal{TransactionCount: 3, TotalAmount: 125000}, nil).Once()
+		s.categoryRepo.On("CountChildren", mock.Anything, int64(7)).Return(int64(0), nil).Once()
+
+		result, err := s.usecase.GetDeleteImpact(s.ctx, 7, 1)
+		s.Require().NoError(err)
+
+		s.Equal(&entity.CategoryDeleteImpactResponse{CategoryID: 7, TransactionCount: 3, TotalAmount: 125000, HasChildren: false}, result)
+		s.categoryRepo.AssertNotCalled(s.T(), "DeleteByID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("empty category", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(8)).Return(&myentity.Category{ID: 8, CreatedBy: 1, Name: "Lainnya"}, nil).Once()
+		s.transactionRepo.On("GetTotalByCategoryID", mock.Anything, int64(1), int64(8)).
+			Return(&mysql.CategoryTransactionTotal{}, nil).Once()
+		s.categoryRepo.On("CountChildren", mock.Anything, int64(8)).Return(int64(0), nil).Once()
+
+		result, err := s.usecase.GetDeleteImpact(s.ctx, 8, 1)
+		s.Require().NoError(err)
+
+		s.Equal(int64(0), result.TransactionCount)
+		s.Equal(float64(0), result.TotalAmount)
+		s.False(result.HasChildren)
+	})
+
+	s.Run("parent category reports its children", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(11)).Return(&myentity.Category{ID: 11, CreatedBy: 1, Name: "Makan"}, nil).Once()
+		s.transactionRepo.On("GetTotalByCategoryID", mock.Anything, int64(1), int64(11)).
+			Return(&mysql.CategoryTransactionTotal{}, nil).Once()
+		s.categoryRepo.On("CountChildren", mock.Anything, int64(11)).Return(int64(2), nil).Once()
+
+		result, err := s.usecase.GetDeleteImpact(s.ctx, 11, 1)
+		s.Require().NoError(err)
+		s.True(result.HasChildren)
+	})
+
+	s.Run("category of another user", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(9)).Return(&myentity.Category{ID: 9, CreatedBy: 2}, nil).Once()
+
+		_, err := s.usecase.GetDeleteImpact(s.ctx, 9, 1)
+		s.assertHTTPCode(err, http.StatusForbidden)
+		s.transactionRepo.AssertNotCalled(s.T(), "GetTotalByCategoryID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("category not found", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(10)).Return(nil, apperr.ErrRecordNotFound()).Once()
+
+		_, err := s.usecase.GetDeleteImpact(s.ctx, 10, 1)
+		s.assertHTTPCode(err, http.StatusNotFound)
+	})
+}
+
+func (s *CrudCategoryTestSuite) TestHierarchy() {
+	parentID := func(id int64) sql.NullInt64 {
+		return sql.NullInt64{Int64: id, Valid: true}
+	}
+	// Food > Groceries > Sayur, semuanya expense milik user 1
+	food := &myentity.Category{ID: 1, CreatedBy: 1, Name: "Food", Type: myentity.TransactionTypeExpense}
+	groceries := &myentity.Category{ID: 2, CreatedBy: 1, Name: "Groceries", Type: myentity.TransactionTypeExpense, ParentID: parentID(1)}
+	vegetables := &myentity.Category{ID: 3, CreatedBy: 1, Name: "Sayur", Type: myentity.TransactionTypeExpense, ParentID: parentID(2)}
+	salary := &myentity.Category{ID: 4, CreatedBy: 1, Name: "Gaji", Type: myentity.TransactionTypeIncome}
+
+	s.Run("create under a parent", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByUserIDAndName", mock.Anything, int64(1), "Buah", int64(0)).Return(nil, apperr.ErrRecordNotFound()).Once()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(2)).Return(groceries, nil).Once()
+		s.categoryRepo.On("Create", mock.Anything, nil, mock.MatchedBy(func(c *myentity.Category) bool {
+			return c.ParentID == parentID(2)
+		}), false).Return(nil).Once()
+
+		err := s.usecase.Create(s.ctx, 1, entity.CategoryReq{Name: "Buah", Type: "expense", ParentID: int64Ptr(2)})
+		s.Require().NoError(err)
+		// Kategori baru belum punya turunan, tidak perlu memuat semua kategori untuk cek siklus
+		s.categoryRepo.AssertNotCalled(s.T(), "GetAll", mock.Anything, mock.Anything)
+	})
+
+	s.Run("parent of another user", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByUserIDAndName", mock.Anything, int64(1), "Buah", int64(0)).Return(nil, apperr.ErrRecordNotFound()).Once()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(9)).Return(&myentity.Category{ID: 9, CreatedBy: 2}, nil).Once()
+
+		err := s.usecase.Create(s.ctx, 1, entity.CategoryReq{Name: "Buah", Type: "expense", ParentID: int64Ptr(9)})
+		s.assertHTTPCode(err, http.StatusForbidden)
+		s.categoryRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("parent not found", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByUserIDAndName", mock.Anything, int64(1), "Buah", int64(0)).Return(nil, apperr.ErrRecordNotFound()).Once()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(99)).Return(nil, apperr.ErrRecordNotFound()).Once()
+
+		err := s.usecase.Create(s.ctx, 1, entity.CategoryReq{Name: "Buah", Type: "expense", ParentID: int64Ptr(99)})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+
+	s.Run("parent with a different type", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByUserIDAndName", mock.Anything, int64(1), "Bonus", int64(0)).Return(nil, apperr.ErrRecordNotFound()).Once()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(1)).Return(food, nil).Once()
+
+		err := s.usecase.Create(s.ctx, 1, entity.CategoryReq{Name: "Bonus", Type: "income", ParentID: int64Ptr(1)})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+
+	s.Run("category cannot be its own parent", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(2)).Return(groceries, nil).Once()
+
+		err := s.usecase.Update(s.ctx, 2, 1, entity.CategoryReq{ParentID: int64Ptr(2)})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+		s.categoryRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("moving a category under its descendant is a cycle", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(1)).Return(food, nil).Once()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(3)).Return(vegetables, nil).Once()
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return([]*myentity.Category{food, groceries, vegetables}, nil).Once()
+
+		err := s.usecase.Update(s.ctx, 1, 1, entity.CategoryReq{ParentID: int64Ptr(3)})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+		s.categoryRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("parent_id 0 moves the category to the top level", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(3)).Return(vegetables, nil).Once()
+		s.categoryRepo.On("Update", mock.Anything, nil, mock.MatchedBy(func(c *myentity.Category) bool {
+			return !c.ParentID.Valid
+		}), (*myentity.Category)(nil)).Return(nil).Once()
+
+		err := s.usecase.Update(s.ctx, 3, 1, entity.CategoryReq{ParentID: int64Ptr(0)})
+		s.Require().NoError(err)
+	})
+
+	s.Run("deleting a parent with children is a conflict", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(1)).Return(food, nil).Once()
+		s.categoryRepo.On("CountChildren", mock.Anything, int64(1)).Return(int64(1), nil).Once()
+
+		err := s.usecase.Delete(s.ctx, 1, 1)
+		s.assertHTTPCode(err, http.StatusConflict)
+		s.categoryRepo.AssertNotCalled(s.T(), "DeleteByID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("deleting a leaf category", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(3)).Return(vegetables, nil).Once()
+		s.categoryRepo.On("CountChildren", mock.Anything, int64(3)).Return(int64(0), nil).Once()
+		s.categoryRepo.On("DeleteByID", mock.Anything, nil, int64(3)).Return(nil).Once()
+
+		s.Require().NoError(s.usecase.Delete(s.ctx, 3, 1))
+	})
+
+	s.Run("tree nests subcategories", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return([]*myentity.Category{food, groceries, vegetables, salary}, nil).Once()
+
+		tree, err := s.usecase.GetTree(s.ctx, 1)
+		s.Require().NoError(err)
+
+		s.Require().Len(tree, 2)
+		s.Equal("Food", tree[0].Name)
+		s.Require().Len(tree[0].Children, 1)
+		s.Equal("Groceries", tree[0].Children[0].Name)
+		s.Require().Len(tree[0].Children[0].Children, 1)
+		s.Equal("Sayur", tree[0].Children[0].Children[0].Name)
+		s.Equal(int64(2), *tree[0].Children[0].Children[0].ParentID)
+		s.Equal("Gaji", tree[1].Name)
+		s.Empty(tree[1].Children)
+	})
+}
+
+func (s *CrudCategoryTestSuite) TestCategoryType() {
+	s.Run("create requires a type", func() {
+		s.SetupTest()
+
+		err := s.usecase.Create(s.ctx, 1, entity.CategoryReq{Name: "Gaji"})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+		s.categoryRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("create rejects an unknown type", func() {
+		s.SetupTest()
+
+		err := s.usecase.Create(s.ctx, 1, entity.CategoryReq{Name: "Gaji", Type: "transfer"})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+
+	s.Run("create stores the type", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByUserIDAndName", mock.Anything, int64(1), "Gaji", int64(0)).Return(nil, apperr.ErrRecordNotFound()).Once()
+		s.categoryRepo.On("Create", mock.Anything, nil, mock.MatchedBy(func(c *myentity.Category) bool {
+			return c.Name == "Gaji" && c.Type == myentity.TransactionTypeIncome
+		}), false).Return(nil).Once()
+
+		s.Require().NoError(s.usecase.Create(s.ctx, 1, entity.CategoryReq{Name: "Gaji", Type: "income"}))
+		s.categoryRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("update without type keeps an untyped category untyped", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).Return(&myentity.Category{ID: 7, CreatedBy: 1, Name: "Lainnya"}, nil).Once()
+		s.categoryRepo.On("Update", mock.Anything, nil, mock.MatchedBy(func(c *myentity.Category) bool {
+			return c.Type == ""
+		}), (*myentity.Category)(nil)).Return(nil).Once()
+
+		s.Require().NoError(s.usecase.Update(s.ctx, 7, 1, entity.CategoryReq{Name: "Lainnya"}))
+		s.categoryRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("update migrates an untyped category", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).Return(&myentity.Category{ID: 7, CreatedBy: 1, Name: "Lainnya"}, nil).Once()
+		s.categoryRepo.On("Update", mock.Anything, nil, mock.MatchedBy(func(c *myentity.Category) bool {
+			return c.Type == myentity.TransactionTypeExpense
+		}), (*myentity.Category)(nil)).Return(nil).Once()
+
+		s.Require().NoError(s.usecase.Update(s.ctx, 7, 1, entity.CategoryReq{Name: "Lainnya", Type: "expense"}))
+		s.categoryRepo.AssertExpectations(s.T())
+	})
+}
+
+func (s *CrudCategoryTestSuite) TestUpdateNameCase() {
+	s.Run("renaming to a different case of the same category succeeds", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).Return(&myentity.Category{ID: 7, CreatedBy: 1, Name: "food"}, nil).Once()
+		s.categoryRepo.On("GetByUserIDAndName", mock.Anything, int64(1), "Food", int64(7)).Return(nil, apperr.ErrRecordNotFound()).Once()
+		s.categoryRepo.On("Update", mock.Anything, nil, mock.MatchedBy(func(c *myentity.Category) bool {
+			return c.ID == 7 && c.Name == "Food"
+		}), (*myentity.Category)(nil)).Return(nil).Once()
+
+		s.Require().NoError(s.usecase.Update(s.ctx, 7, 1, entity.CategoryReq{Name: "Food"}))
+		s.categoryRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("renaming to another category's name in a different case conflicts", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).Return(&myentity.Category{ID: 7, CreatedBy: 1, Name: "Snack"}, nil).Once()
+		s.categoryRepo.On("GetByUserIDAndName", mock.Anything, int64(1), "FOOD", int64(7)).
+			Return(&myentity.Category{ID: 8, CreatedBy: 1, Name: "food"}, nil).Once()
+
+		err := s.usecase.Update(s.ctx, 7, 1, entity.CategoryReq{Name: "FOOD"})
+		s.assertHTTPCode(err, http.StatusConflict)
+		s.categoryRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CrudCategoryTestSuite) TestIconAndColor() {
+	str := func(value string) *string { return &value }
+
+	s.Run("create stores icon and color", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByUserIDAndName", mock.Anything, int64(1), "Makan", int64(0)).Return(nil, apperr.ErrRecordNotFound()).Once()
+		s.categoryRepo.On("Create", mock.Anything, nil, mock.MatchedBy(func(c *myentity.Category) bool {
+			return c.Icon == "food" && c.Color == "#FF8800"
+		}), false).Return(nil).Once()
+
+		s.Require().NoError(s.usecase.Create(s.ctx, 1, entity.CategoryReq{Name: "Makan", Type: "expense", Icon: str("food"), Color: str("#FF8800")}))
+		s.categoryRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("create rejects unknown icon and invalid color", func() {
+		s.SetupTest()
+
+		err := s.usecase.Create(s.ctx, 1, entity.CategoryReq{Name: "Makan", Type: "expense", Icon: str("rocket")})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+		err = s.usecase.Create(s.ctx, 1, entity.CategoryReq{Name: "Makan", Type: "expense", Color: str("orange")})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+		s.categoryRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("update changes color without a name", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).
+			Return(&myentity.Category{ID: 7, CreatedBy: 1, Name: "Makan", Icon: "food", Color: "#FF8800"}, nil).Once()
+		s.categoryRepo.On("Update", mock.Anything, nil, mock.MatchedBy(func(c *myentity.Category) bool {
+			return c.Name == "Makan" && c.Icon == "food" && c.Color == "#00AA55"
+		}), (*myentity.Category)(nil)).Return(nil).Once()
+
+		s.Require().NoError(s.usecase.Update(s.ctx, 7, 1, entity.CategoryReq{Color: str("#00AA55")}))
+		s.categoryRepo.AssertNotCalled(s.T(), "GetByUserIDAndName", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		s.categoryRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("update with empty icon clears it", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).
+			Return(&myentity.Category{ID: 7, CreatedBy: 1, Name: "Makan", Icon: "food"}, nil).Once()
+		s.categoryRepo.On("Update", mock.Anything, nil, mock.MatchedBy(func(c *myentity.Category) bool {
+			return c.Icon == ""
+		}), (*myentity.Category)(nil)).Return(nil).Once()
+
+		s.Require().NoError(s.usecase.Update(s.ctx, 7, 1, entity.CategoryReq{Icon: str("")}))
+		s.categoryRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("existing categories return empty strings", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return([]*myentity.Category{{ID: 7, CreatedBy: 1, Name: "Lainnya"}}, nil).Once()
+
+		result, err := s.usecase.GetAll(s.ctx, 1)
+		s.Require().NoError(err)
+		s.Require().Len(result, 1)
+		s.Equal("", result[0].Icon)
+		s.Equal("", result[0].Color)
+	})
+}
+
+func (s *CrudCategoryTestSuite) TestCreateCategoryWithTransaction() {
+	catReq := entity.CategoryReq{Name: "Langganan", Type: "expense"}
+	amount := 50000.0
+	txType := transactionEntity.TransactionTypeExpenseStr
+	txReq := transactionEntity.TransactionReq{Amount: &amount, Type: &txType, TransactionDate: "2024-01-05"}
+
+	s.Run("both rows are committed together", func() {
+		s.SetupTest()
+		trx := &mocks.TrxObj{}
+		s.categoryRepo.On("GetByUserIDAndName", mock.Anything, int64(1), "Langganan", int64(0)).Return(nil, apperr.ErrRecordNotFound()).Once()
+		s.transactions.On("PrepareCreate", mock.Anything, int64(1), txReq, mock.AnythingOfType("*entity.Category")).
+			Return(&myentity.Transaction{UserID: 1, Amount: amount, Type: myentity.TransactionTypeExpense}, nil).Once()
+		s.categoryRepo.On("Begin").Return(trx, nil).Once()
+		s.categoryRepo.On("Create", mock.Anything, trx, mock.Anything, false).
+			Run(func(args mock.Arguments) { args.Get(2).(*myentity.Category).ID = 12 }).Return(nil).Once()
+		s.transactionRepo.On("Create", mock.Anything, trx, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return t.CategoryID.Valid && t.CategoryID.Int64 == 12
+		}), false).Run(func(args mock.Arguments) { args.Get(2).(*myentity.Transaction).ID = 30 }).Return(nil).Once()
+		trx.On("Commit").Return(nil).Once()
+
+		result, err := s.usecase.CreateCategoryWithTransaction(s.ctx, 1, catReq, txReq)
+		s.Require().NoError(err)
+		s.Equal(&entity.CategoryWithTransactionResponse{CategoryID: 12, TransactionID: 30}, result)
+		trx.AssertExpectations(s.T())
+	})
+
+	s.Run("failed transaction insert rolls back the category", func() {
+		s.SetupTest()
+		trx := &mocks.TrxObj{}
+		s.categoryRepo.On("GetByUserIDAndName", mock.Anything, int64(1), "Langganan", int64(0)).Return(nil, apperr.ErrRecordNotFound()).Once()
+		s.transactions.On("PrepareCreate", mock.Anything, int64(1), txReq, mock.Anything).
+			Return(&myentity.Transaction{UserID: 1, Amount: amount, Type: myentity.TransactionTypeExpense}, nil).Once()
+		s.categoryRepo.On("Begin").Return(trx, nil).Once()
+		s.categoryRepo.On("Create", mock.Anything, trx, mock.Anything, false).Return(nil).Once()
+		s.transactionRepo.On("Create", mock.Anything, trx, mock.Anything, false).Return(errors.New("insert failed")).Once()
+		trx.On("Rollback").Return(nil).Once()
+
+		_, err := s.usecase.CreateCategoryWithTransaction(s.ctx, 1, catReq, txReq)
+		s.Require().Error(err)
+		trx.AssertExpectations(s.T())
+		trx.AssertNotCalled(s.T(), "Commit")
+	})
+
+	s.Run("invalid transaction is rejected before writing", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByUserIDAndName", mock.Anything, int64(1), "Langganan", int64(0)).Return(nil, apperr.ErrRecordNotFound()).Once()
+		s.transactions.On("PrepareCreate", mock.Anything, int64(1), txReq, mock.Anything).
+			Return(nil, apperr.ErrInvalidRequest().SetDetail("Transaction type does not match the category type.")).Once()
+
+		_, err := s.usecase.CreateCategoryWithTransaction(s.ctx, 1, catReq, txReq)
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+		s.categoryRepo.AssertNotCalled(s.T(), "Begin")
+	})
+}
+
+// int64Ptr mengembalikan pointer ke v untuk field opsional CategoryReq.
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func (s *CrudCategoryTestSuite) TestMerge() {
+	source := func() *myentity.Category {
+		return &myentity.Category{ID: 7, CreatedBy: 1, Name: "food", Type: myentity.TransactionTypeExpense}
+	}
+	target := func() *myentity.Category {
+		return &myentity.Category{ID: 8, CreatedBy: 1, Name: "Food", Type: myentity.TransactionTypeExpense}
+	}
+
+	s.Run("transactions move to the target and the source is deleted", func() {
+		s.SetupTest()
+		trx := &mocks.TrxObj{}
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).Return(source(), nil).Once()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(8)).Return(target(), nil).Once()
+		s.categoryRepo.On("CountChildren", mock.Anything, int64(7)).Return(int64(0), nil).Once()
+		s.categoryRepo.On("Begin").Return(trx, nil).Once()
+		s.transactionRepo.On("ReassignCategory", mock.Anything, trx, int64(7), int64(8), int64(1)).Return(int64(5), nil).Once()
+		s.categoryRepo.On("DeleteByID", mock.Anything, trx, int64(7)).Return(nil).Once()
+		trx.On("Commit").Return(nil).Once()
+
+		result, err := s.usecase.Merge(s.ctx, 7, 1, entity.CategoryMergeReq{TargetID: 8})
+		s.Require().NoError(err)
+
+		s.Equal(&entity.CategoryMergeResponse{SourceID: 7, TargetID: 8, ReassignedTransactions: 5}, result)
+		trx.AssertExpectations(s.T())
+	})
+
+	s.Run("failed delete rolls back the reassignment", func() {
+		s.SetupTest()
+		trx := &mocks.TrxObj{}
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).Return(source(), nil).Once()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(8)).Return(target(), nil).Once()
+		s.categoryRepo.On("CountChildren", mock.Anything, int64(7)).Return(int64(0), nil).Once()
+		s.categoryRepo.On("Begin").Return(trx, nil).Once()
+		s.transactionRepo.On("ReassignCategory", mock.Anything, trx, int64(7), int64(8), int64(1)).Return(int64(5), nil).Once()
+		s.categoryRepo.On("DeleteByID", mock.Anything, trx, int64(7)).Return(errors.New("delete failed")).Once()
+		trx.On("Rollback").Return(nil).Once()
+
+		_, err := s.usecase.Merge(s.ctx, 7, 1, entity.CategoryMergeReq{TargetID: 8})
+		s.Require().Error(err)
+		trx.AssertExpectations(s.T())
+		trx.AssertNotCalled(s.T(), "Commit")
+	})
+
+	s.Run("target of another user", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).Return(source(), nil).Once()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(9)).Return(&myentity.Category{ID: 9, CreatedBy: 2, Name: "Food"}, nil).Once()
+
+		_, err := s.usecase.Merge(s.ctx, 7, 1, entity.CategoryMergeReq{TargetID: 9})
+		s.assertHTTPCode(err, http.StatusForbidden)
+		s.transactionRepo.AssertNotCalled(s.T(), "ReassignCategory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("source of another user", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(9)).Return(&myentity.Category{ID: 9, CreatedBy: 2, Name: "Food"}, nil).Once()
+
+		_, err := s.usecase.Merge(s.ctx, 9, 1, entity.CategoryMergeReq{TargetID: 8})
+		s.assertHTTPCode(err, http.StatusForbidden)
+	})
+
+	s.Run("target with another type", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).Return(source(), nil).Once()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(10)).
+			Return(&myentity.Category{ID: 10, CreatedBy: 1, Name: "Gaji", Type: myentity.TransactionTypeIncome}, nil).Once()
+
+		_, err := s.usecase.Merge(s.ctx, 7, 1, entity.CategoryMergeReq{TargetID: 10})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+
+	s.Run("missing target", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).Return(source(), nil).Once()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(99)).Return(nil, apperr.ErrRecordNotFound()).Once()
+
+		_, err := s.usecase.Merge(s.ctx, 7, 1, entity.CategoryMergeReq{TargetID: 99})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+
+	s.Run("merge into itself", func() {
+		s.SetupTest()
+
+		_, err := s.usecase.Merge(s.ctx, 7, 1, entity.CategoryMergeReq{TargetID: 7})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+		s.categoryRepo.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
+	})
+
+	s.Run("source with subcategories", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).Return(source(), nil).Once()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(8)).Return(target(), nil).Once()
+		s.categoryRepo.On("CountChildren", mock.Anything, int64(7)).Return(int64(2), nil).Once()
+
+		_, err := s.usecase.Merge(s.ctx, 7, 1, entity.CategoryMergeReq{TargetID: 8})
+		s.assertHTTPCode(err, http.StatusConflict)
+		s.categoryRepo.AssertNotCalled(s.T(), "Begin")
+	})
+}
+
+func (s *CrudCategoryTestSuite) TestList() {
+	s.Run("page with search and total", func() {
+		s.SetupTest()
+		filter := mysql.CategoryFilter{Query: "food"}
+		s.categoryRepo.On("CountByUserID", mock.Anything, int64(1), filter).Return(int64(45), nil).Once()
+		s.categoryRepo.On("ListByUserID", mock.Anything, int64(1), filter, 20, 20).
+			Return([]*myentity.Category{{ID: 3, CreatedBy: 1, Name: "Food"}, {ID: 4, CreatedBy: 1, Name: "Fast food"}}, nil).Once()
+
+		result, err := s.usecase.List(s.ctx, 1, entity.CategoryListReq{Limit: 20, Offset: 20, Query: " food "})
+		s.Require().NoError(err)
+
+		s.Len(result.Data, 2)
+		s.Equal("Food", result.Data[0].Name)
+		s.Equal(int64(45), result.Meta.Total)
+		s.Equal(2, result.Meta.Page)
+		s.Equal(20, result.Meta.PerPage)
+		s.Equal(3, result.Meta.TotalPages)
+		s.True(result.Meta.HasNext)
+	})
+
+	s.Run("default limit and empty result", func() {
+		s.SetupTest()
+		s.categoryRepo.On("CountByUserID", mock.Anything, int64(1), mysql.CategoryFilter{}).Return(int64(0), nil).Once()
+		s.categoryRepo.On("ListByUserID", mock.Anything, int64(1), mysql.CategoryFilter{}, 50, 0).Return([]*myentity.Category{}, nil).Once()
+
+		result, err := s.usecase.List(s.ctx, 1, entity.CategoryListReq{})
+		s.Require().NoError(err)
+
+		s.NotNil(result.Data)
+		s.Empty(result.Data)
+		s.Equal(0, result.Meta.TotalPages)
+		s.False(result.Meta.HasNext)
+	})
+
+	s.Run("invalid limit or offset", func() {
+		s.SetupTest()
+
+		_, err := s.usecase.List(s.ctx, 1, entity.CategoryListReq{Limit: 201})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+
+		_, err = s.usecase.List(s.ctx, 1, entity.CategoryListReq{Limit: 10, Offset: -1})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+		s.categoryRepo.AssertNotCalled(s.T(), "ListByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -1,21 +1,97 @@
 package entity
 
+import (
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	transactionEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
+)
 
 type CategoryReq struct {
-	Name      string `json:"name" validate:"required" name:"Nama Kategori"`
-	userID int64  `validate:"required" name:"ID Pembuat"`
+	// Name wajib diisi saat create, kosong saat update berarti tidak diubah
+	Name string `json:"name" validate:"required" name:"Nama Kategori"`
+	// Type wajib diisi saat create, kosong saat update berarti tidak diubah
+	Type string `json:"type" validate:"omitempty,oneof=income expense" name:"Tipe Kategori"`
+	// ExcludeFromTotals nil berarti tidak diubah saat update
+	ExcludeFromTotals *bool `json:"exclude_from_totals"`
+	// Icon salah satu helper.CategoryIcons dan Color berformat #RRGGBB. Nil berarti tidak diubah saat update, string kosong menghapusnya
+	Icon  *string `json:"icon"`
+	Color *string `json:"color"`
+	// ParentID adalah kategori induk milik user yang sama. Nil berarti tidak diubah saat update, 0 menjadikannya kategori level teratas
+	ParentID *int64 `json:"parent_id"`
+	userID   int64  `validate:"required" name:"ID Pembuat"`
 }
 
 type CategoryResponse struct {
-	ID        int64  `json:"id"`
-	Name      string `json:"name"`
-	CreatedBy int64  `json:"created_by"`
-	CreatedAt string `json:"created_at"` // Biasanya diubah ke string untuk format JSON
-	UpdatedAt string `json:"updated_at"` // Biasanya diubah ke string untuk format JSON
+	ID                int64  `json:"id"`
+	ParentID          *int64 `json:"parent_id"` // null untuk kategori level teratas
+	Name              string `json:"name"`
+	Type              string `json:"type"` // Kosong untuk kategori lama yang bisa dipakai income maupun expense
+	Icon              string `json:"icon"`
+	Color             string `json:"color"`
+	CreatedBy         int64  `json:"created_by"`
+	ExcludeFromTotals bool   `json:"exclude_from_totals"`
+	CreatedAt         string `json:"created_at"` // Biasanya diubah ke string untuk format JSON
+	UpdatedAt         string `json:"updated_at"` // Biasanya diubah ke string untuk format JSON
 }
 
+// CategoryNode adalah satu kategori pada pohon kategori beserta subkategorinya.
+type CategoryNode struct {
+	CategoryResponse
+	Children []CategoryNode `json:"children"`
+}
+
+// CategoryDeleteImpactResponse adalah pratinjau dampak penghapusan kategori sebelum dikonfirmasi.
+type CategoryDeleteImpactResponse struct {
+	CategoryID       int64   `json:"category_id"`
+	TransactionCount int64   `json:"transaction_count"`
+	TotalAmount      float64 `json:"total_amount"`
+	// HasChildren true jika kategori masih memiliki subkategori, penghapusan akan ditolak sampai subkategori dipindah atau dihapus
+	HasChildren bool `json:"has_children"`
+}
+
+// CategoryListReq adalah parameter daftar kategori ber-halaman. Limit 0 berarti memakai limit default,
+// Query mencari kategori yang namanya mengandung teks tersebut (tidak membedakan huruf besar/kecil).
+type CategoryListReq struct {
+	Limit  int
+	Offset int
+	Query  string
+}
+
+// CategoryListResponse adalah satu halaman kategori beserta informasi pagination.
+// Page dihitung dari offset dan limit (offset / limit + 1).
+type CategoryListResponse struct {
+	Data []CategoryResponse           `json:"data"`
+	Meta generalEntity.PaginationMeta `json:"meta"`
+}
+
+// CategoryMergeReq adalah body request untuk menggabungkan kategori ke kategori target.
+type CategoryMergeReq struct {
+	TargetID int64 `json:"target_id" validate:"required,gt=0" name:"Kategori Target"`
+}
+
+// CategoryMergeResponse adalah hasil penggabungan kategori: kategori sumber sudah dihapus
+// dan transaksinya dipindahkan ke kategori target.
+type CategoryMergeResponse struct {
+	SourceID               int64 `json:"source_id"`
+	TargetID               int64 `json:"target_id"`
+	ReassignedTransactions int64 `json:"reassigned_transactions"`
+}
 
 func (r *CategoryReq) SetUserID(userID int64) {
 	r.userID = userID
 }
 
+// CategoryWithTransactionReq adalah request membuat kategori baru sekaligus transaksi pertamanya.
+type CategoryWithTransactionReq struct {
+	Category    CategoryReq                      `json:"category"`
+	Transaction transactionEntity.TransactionReq `json:"transaction"`
+}
+
+func (r *CategoryWithTransactionReq) SetUserID(userID int64) {
+	r.Category.SetUserID(userID)
+}
+
+// CategoryWithTransactionResponse berisi ID kategori dan transaksi yang dibuat bersamaan.
+type CategoryWithTransactionResponse struct {
+	CategoryID    int64 `json:"category_id"`
+	TransactionID int64 `json:"transaction_id"`
+}
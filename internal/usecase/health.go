@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+)
+
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+)
+
+// HealthChecker is implemented by repositories that can report whether their backing store is reachable.
+type HealthChecker interface {
+	Ping(ctx context.Context) error
+}
+
+// HealthDependency is one named dependency checked by Health, e.g. "mysql" or "mongodb".
+type HealthDependency struct {
+	Name    string
+	Checker HealthChecker
+}
+
+// HealthResult is the readiness of the service. Dependencies maps every dependency name to ok or unavailable,
+// the error itself is only logged so the unauthenticated endpoint does not leak connection details.
+type HealthResult struct {
+	Status       string            `json:"status"`
+	Dependencies map[string]string `json:"dependencies,omitempty"`
+}
+
+// Healthy returns true when every dependency is reachable.
+func (r HealthResult) Healthy() bool {
+	return r.Status == HealthStatusOK
+}
+
+// Health checks the dependencies needed to serve requests, used as readiness probe.
+type Health struct {
+	dependencies []HealthDependency
+	timeout      time.Duration
+}
+
+type HealthUsecase interface {
+	Check(ctx context.Context) HealthResult
+}
+
+// NewHealth creates a Health usecase. Each dependency is pinged with its own timeout so one slow store
+// does not hide the status of the others.
+func NewHealth(timeout time.Duration, dependencies ...HealthDependency) *Health {
+	return &Health{dependencies: dependencies, timeout: timeout}
+}
+
+// Check pings every dependency and returns ok only when all of them respond.
+func (h *Health) Check(ctx context.Context) HealthResult {
+	funcName := "Health.Check"
+
+	result := HealthResult{Status: HealthStatusOK, Dependencies: make(map[string]string, len(h.dependencies))}
+	for _, dependency := range h.dependencies {
+		pingCtx, cancel := context.WithTimeout(ctx, h.timeout)
+		err := dependency.Checker.Ping(pingCtx)
+		cancel()
+
+		if err != nil {
+			helper.LogError(funcName, dependency.Name+".Ping", err, entity.CaptureFields{"dependency": dependency.Name}, "")
+			result.Status = HealthStatusUnavailable
+			result.Dependencies[dependency.Name] = HealthStatusUnavailable
+			continue
+		}
+		result.Dependencies[dependency.Name] = HealthStatusOK
+	}
+
+	return result
+}
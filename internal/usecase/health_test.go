@@ -0,0 +1,73 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthTestSuite struct {
+	suite.Suite
+
+	mysql   *mocks.HealthChecker
+	mongodb *mocks.HealthChecker
+	health  *usecase.Health
+	ctx     context.Context
+}
+
+func (s *HealthTestSuite) SetupTest() {
+	s.mysql = &mocks.HealthChecker{}
+	s.mongodb = &mocks.HealthChecker{}
+	s.health = usecase.NewHealth(time.Second,
+		usecase.HealthDependency{Name: "mysql", Checker: s.mysql},
+		usecase.HealthDependency{Name: "mongodb", Checker: s.mongodb},
+	)
+	s.ctx = context.Background()
+}
+
+func TestHealth(t *testing.T) {
+	suite.Run(t, new(HealthTestSuite))
+}
+
+func (s *HealthTestSuite) TestCheck() {
+	s.Run("all dependencies reachable", func() {
+		s.SetupTest()
+		s.mysql.On("Ping", mock.Anything).Return(nil).Once()
+		s.mongodb.On("Ping", mock.Anything).Return(nil).Once()
+
+		result := s.health.Check(s.ctx)
+
+		s.True(result.Healthy())
+		s.Equal(usecase.HealthResult{Status: "ok", Dependencies: map[string]string{"mysql": "ok", "mongodb": "ok"}}, result)
+	})
+
+	s.Run("failed dependency is reported and the others are still checked", func() {
+		s.SetupTest()
+		s.mysql.On("Ping", mock.Anything).Return(errors.New("dial tcp: connection refused")).Once()
+		s.mongodb.On("Ping", mock.Anything).Return(nil).Once()
+
+		result := s.health.Check(s.ctx)
+
+		s.False(result.Healthy())
+		s.Equal(usecase.HealthResult{Status: "unavailable", Dependencies: map[string]string{"mysql": "unavailable", "mongodb": "ok"}}, result)
+		s.mongodb.AssertExpectations(s.T())
+	})
+
+	s.Run("each ping gets a deadline", func() {
+		s.SetupTest()
+		s.mysql.On("Ping", mock.MatchedBy(func(ctx context.Context) bool {
+			_, ok := ctx.Deadline()
+			return ok
+		})).Return(nil).Once()
+		s.mongodb.On("Ping", mock.Anything).Return(nil).Once()
+
+		s.True(s.health.Check(s.ctx).Healthy())
+		s.mysql.AssertExpectations(s.T())
+	})
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/queue"
+	notification_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/notification"
+	notificationEntity "github.com/rakahikmah/finance-tracking/internal/usecase/notification/entity"
+)
+
+// MonthlyRecap is a usecase for enqueueing monthly recap email jobs to Queue (Message Broker)
+type MonthlyRecap struct {
+	queue             queue.Queue
+	preferenceUsecase notification_usecase.INotificationPreference
+}
+
+func NewMonthlyRecapUsecase(
+	queue queue.Queue,
+	preferenceUsecase notification_usecase.INotificationPreference,
+) *MonthlyRecap {
+	return &MonthlyRecap{queue, preferenceUsecase}
+}
+
+type MonthlyRecapUsecase interface {
+	EnqueueMonthlyRecaps(ctx context.Context, now time.Time) (int, error)
+}
+
+// MonthlyRecapPayload is the message published to queue.ProcessMonthlyRecap, one per user.
+type MonthlyRecapPayload struct {
+	UserID int64  `json:"user_id"`
+	Month  string `json:"month"`
+}
+
+// EnqueueMonthlyRecaps publishes a recap job for the month before now to every user that enabled the
+// monthly recap email. A failed publish does not stop the remaining users; the number of published jobs
+// is returned together with the joined publish errors.
+func (m *MonthlyRecap) EnqueueMonthlyRecaps(ctx context.Context, now time.Time) (int, error) {
+	funcName := "MonthlyRecapUsecase.EnqueueMonthlyRecaps"
+
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -1, 0).Format(helper.MonthLayout)
+
+	userIDs, err := m.preferenceUsecase.GetSubscribedUserIDs(ctx, notificationEntity.ChannelMonthlyRecapEmail)
+	if err != nil {
+		helper.LogError("preferenceUsecase.GetSubscribedUserIDs", funcName, err, entity.CaptureFields{}, "")
+
+		return 0, err
+	}
+
+	var errs []error
+	published := 0
+	for _, userID := range userIDs {
+		payload, _ := helper.Serialize(MonthlyRecapPayload{UserID: userID, Month: month})
+		if err := m.queue.Publish(queue.ProcessMonthlyRecap, payload, 1); err != nil {
+			helper.LogError("queue.Publish", funcName, err, entity.CaptureFields{"user_id": helper.ToString(userID)}, "")
+			errs = append(errs, err)
+			continue
+		}
+		published++
+	}
+
+	return published, errors.Join(errs...)
+}
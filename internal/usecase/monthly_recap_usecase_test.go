@@ -0,0 +1,67 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/internal/queue"
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
+	notificationEntity "github.com/rakahikmah/finance-tracking/internal/usecase/notification/entity"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MonthlyRecapUsecaseTestSuite struct {
+	suite.Suite
+
+	queue      *mocks.Queue
+	preference *mocks.INotificationPreference
+	usecase    usecase.MonthlyRecapUsecase
+}
+
+func (s *MonthlyRecapUsecaseTestSuite) SetupTest() {
+	s.queue = &mocks.Queue{}
+	s.preference = &mocks.INotificationPreference{}
+	s.usecase = usecase.NewMonthlyRecapUsecase(s.queue, s.preference)
+}
+
+func TestMonthlyRecapUsecase(t *testing.T) {
+	suite.Run(t, new(MonthlyRecapUsecaseTestSuite))
+}
+
+func (s *MonthlyRecapUsecaseTestSuite) TestEnqueueMonthlyRecaps() {
+	now := time.Date(2024, time.March, 1, 7, 0, 0, 0, time.UTC)
+
+	s.Run("one job per subscribed user for the previous month", func() {
+		s.SetupTest()
+		s.preference.On("GetSubscribedUserIDs", mock.Anything, notificationEntity.ChannelMonthlyRecapEmail).Return([]int64{1, 2}, nil).Once()
+
+		var published []usecase.MonthlyRecapPayload
+		s.queue.On("Publish", queue.ProcessMonthlyRecap, mock.Anything, int32(1)).Run(func(args mock.Arguments) {
+			var payload usecase.MonthlyRecapPayload
+			s.Require().NoError(json.Unmarshal(args.Get(1).([]byte), &payload))
+			published = append(published, payload)
+		}).Return(nil).Twice()
+
+		count, err := s.usecase.EnqueueMonthlyRecaps(context.Background(), now)
+		s.Require().NoError(err)
+
+		s.Equal(2, count)
+		s.Equal([]usecase.MonthlyRecapPayload{{UserID: 1, Month: "2024-02"}, {UserID: 2, Month: "2024-02"}}, published)
+	})
+
+	s.Run("publish failure does not stop other users", func() {
+		s.SetupTest()
+		s.preference.On("GetSubscribedUserIDs", mock.Anything, notificationEntity.ChannelMonthlyRecapEmail).Return([]int64{1, 2}, nil).Once()
+		s.queue.On("Publish", queue.ProcessMonthlyRecap, mock.Anything, int32(1)).Return(errors.New("channel closed")).Once()
+		s.queue.On("Publish", queue.ProcessMonthlyRecap, mock.Anything, int32(1)).Return(nil).Once()
+
+		count, err := s.usecase.EnqueueMonthlyRecaps(context.Background(), now)
+		s.Error(err)
+		s.Equal(1, count)
+	})
+}
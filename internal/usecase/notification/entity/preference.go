@@ -0,0 +1,32 @@
+package entity
+
+// Channel notifikasi yang bisa diatur user.
+const (
+	ChannelLargeTransactionEmail = "large_transaction_email" // Email saat ada transaksi di atas threshold
+	ChannelBudgetWebhook         = "budget_webhook"          // Webhook saat pengeluaran melewati threshold budget
+	ChannelMonthlyRecapEmail     = "monthly_recap_email"     // Email rekap bulan sebelumnya di awal bulan
+)
+
+// Channels adalah allowlist channel notifikasi, urutannya dipakai pada response.
+var Channels = []string{
+	ChannelLargeTransactionEmail,
+	ChannelBudgetWebhook,
+	ChannelMonthlyRecapEmail,
+}
+
+// PreferenceItem adalah status dan threshold satu channel notifikasi.
+type PreferenceItem struct {
+	Channel   string  `json:"channel"`
+	Enabled   bool    `json:"enabled"`
+	Threshold float64 `json:"threshold"`
+}
+
+// UpdatePreferencesReq adalah request body untuk memperbarui preferensi notifikasi.
+type UpdatePreferencesReq struct {
+	Preferences []PreferenceItem `json:"preferences"`
+}
+
+// PreferencesResponse berisi preferensi semua channel, channel yang belum pernah diatur bernilai nonaktif.
+type PreferencesResponse struct {
+	Preferences []PreferenceItem `json:"preferences"`
+}
@@ -0,0 +1,176 @@
+package notification_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	"github.com/rakahikmah/finance-tracking/internal/usecase/notification/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// NotificationPreference adalah usecase untuk preferensi notifikasi user.
+type NotificationPreference struct {
+	PreferenceRepo mysql.INotificationPreferenceRepository
+}
+
+// NewNotificationPreference adalah konstruktor untuk NotificationPreference.
+func NewNotificationPreference(PreferenceRepo mysql.INotificationPreferenceRepository) *NotificationPreference {
+	return &NotificationPreference{PreferenceRepo: PreferenceRepo}
+}
+
+// INotificationPreference mendefinisikan interface usecase preferensi notifikasi.
+type INotificationPreference interface {
+	GetPreferences(ctx context.Context, userID int64) (*entity.PreferencesResponse, error)
+	UpdatePreferences(ctx context.Context, userID int64, req entity.UpdatePreferencesReq) (*entity.PreferencesResponse, error)
+	ShouldNotify(ctx context.Context, userID int64, channel string, amount float64) (bool, error)
+	GetSubscribedUserIDs(ctx context.Context, channel string) ([]int64, error)
+}
+
+// GetPreferences mengambil preferensi semua channel. Channel yang belum pernah diatur dianggap nonaktif.
+func (u *NotificationPreference) GetPreferences(ctx context.Context, userID int64) (*entity.PreferencesResponse, error) {
+	funcName := "NotificationPreference.GetPreferences"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	stored, err := u.PreferenceRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		helper.LogError(funcName, "PreferenceRepo.GetByUserID", err, logFields, "")
+		return nil, err
+	}
+
+	byChannel := make(map[string]*myentity.NotificationPreference, len(stored))
+	for _, pref := range stored {
+		byChannel[pref.Channel] = pref
+	}
+
+	result := &entity.PreferencesResponse{Preferences: make([]entity.PreferenceItem, 0, len(entity.Channels))}
+	for _, channel := range entity.Channels {
+		item := entity.PreferenceItem{Channel: channel}
+		if pref, ok := byChannel[channel]; ok {
+			item.Enabled = pref.Enabled
+			item.Threshold = pref.Threshold
+		}
+		result.Preferences = append(result.Preferences, item)
+	}
+
+	return result, nil
+}
+
+// UpdatePreferences menyimpan preferensi channel yang dikirim, channel lain tidak berubah.
+func (u *NotificationPreference) UpdatePreferences(ctx context.Context, userID int64, req entity.UpdatePreferencesReq) (*entity.PreferencesResponse, error) {
+	funcName := "NotificationPreference.UpdatePreferences"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if len(req.Preferences) == 0 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("preferences must not be empty")
+	}
+
+	seen := map[string]bool{}
+	for _, item := range req.Preferences {
+		if !isAllowedChannel(item.Channel) {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Unknown notification channel '%s'.", item.Channel))
+		}
+		if item.Threshold < 0 {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Threshold for '%s' must not be negative.", item.Channel))
+		}
+		if seen[item.Channel] {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Channel '%s' is listed more than once.", item.Channel))
+		}
+		seen[item.Channel] = true
+	}
+
+	err := mysql.DBTransaction(u.PreferenceRepo, func(trx mysql.TrxObj) error {
+		for _, item := range req.Preferences {
+			err := u.PreferenceRepo.Upsert(ctx, trx, &myentity.NotificationPreference{
+				UserID:    userID,
+				Channel:   item.Channel,
+				Enabled:   item.Enabled,
+				Threshold: item.Threshold,
+				CreatedAt: helper.DatetimeNowJakarta(),
+				UpdatedAt: helper.DatetimeNowJakarta(),
+			})
+			if err != nil {
+				helper.LogError(funcName, "PreferenceRepo.Upsert", err, logFields, "")
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetPreferences(ctx, userID)
+}
+
+// ShouldNotify menentukan apakah notifikasi channel perlu dikirim untuk amount tertentu.
+// Notifikasi hanya dikirim jika channel aktif dan amount mencapai threshold.
+func (u *NotificationPreference) ShouldNotify(ctx context.Context, userID int64, channel string, amount float64) (bool, error) {
+	funcName := "NotificationPreference.ShouldNotify"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"channel": channel,
+	}
+
+	pref, err := u.PreferenceRepo.GetByUserIDAndChannel(ctx, userID, channel)
+	if errors.Is(err, apperr.ErrRecordNotFound()) {
+		return false, nil
+	}
+	if err != nil {
+		helper.LogError(funcName, "PreferenceRepo.GetByUserIDAndChannel", err, logFields, "")
+		return false, err
+	}
+
+	return pref.Enabled && amount >= pref.Threshold, nil
+}
+
+func isAllowedChannel(channel string) bool {
+	for _, allowed := range entity.Channels {
+		if channel == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSubscribedUserIDs mengambil ID user yang mengaktifkan channel tertentu, dipakai untuk notifikasi terjadwal.
+func (u *NotificationPreference) GetSubscribedUserIDs(ctx context.Context, channel string) ([]int64, error) {
+	funcName := "NotificationPreference.GetSubscribedUserIDs"
+	logFields := generalEntity.CaptureFields{
+		"channel": channel,
+	}
+
+	if !isAllowedChannel(channel) {
+		return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Unknown notification channel '%s'.", channel))
+	}
+
+	userIDs, err := u.PreferenceRepo.GetUserIDsByEnabledChannel(ctx, channel)
+	if err != nil {
+		helper.LogError(funcName, "PreferenceRepo.GetUserIDsByEnabledChannel", err, logFields, "")
+		return nil, err
+	}
+
+	return userIDs, nil
+}
@@ -0,0 +1,128 @@
+package notification_usecase_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	notification_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/notification"
+	"github.com/rakahikmah/finance-tracking/internal/usecase/notification/entity"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type NotificationPreferenceTestSuite struct {
+	suite.Suite
+
+	preferenceRepo *mocks.INotificationPreferenceRepository
+	usecase        notification_usecase.INotificationPreference
+	ctx            context.Context
+
+	// stored mensimulasikan tabel notification_preferences untuk user 1
+	stored map[string]*myentity.NotificationPreference
+}
+
+func (s *NotificationPreferenceTestSuite) SetupTest() {
+	s.preferenceRepo = &mocks.INotificationPreferenceRepository{}
+	s.ctx = context.Background()
+	s.stored = map[string]*myentity.NotificationPreference{}
+
+	s.preferenceRepo.On("GetByUserID", mock.Anything, int64(1)).Return(func(context.Context, int64) ([]*myentity.NotificationPreference, error) {
+		var result []*myentity.NotificationPreference
+		for _, pref := range s.stored {
+			result = append(result, pref)
+		}
+		return result, nil
+	}).Maybe()
+	s.preferenceRepo.On("GetByUserIDAndChannel", mock.Anything, int64(1), mock.Anything).Return(func(_ context.Context, _ int64, channel string) (*myentity.NotificationPreference, error) {
+		if pref, ok := s.stored[channel]; ok {
+			return pref, nil
+		}
+		return nil, apperr.ErrRecordNotFound()
+	}).Maybe()
+
+	s.usecase = notification_usecase.NewNotificationPreference(s.preferenceRepo)
+}
+
+func TestNotificationPreference(t *testing.T) {
+	suite.Run(t, new(NotificationPreferenceTestSuite))
+}
+
+func (s *NotificationPreferenceTestSuite) expectUpsert() {
+	trx := &mocks.TrxObj{}
+	trx.On("Commit").Return(nil).Once()
+	s.preferenceRepo.On("Begin").Return(trx, nil).Once()
+	s.preferenceRepo.On("Upsert", mock.Anything, trx, mock.Anything).Run(func(args mock.Arguments) {
+		pref := args.Get(2).(*myentity.NotificationPreference)
+		s.stored[pref.Channel] = pref
+	}).Return(nil)
+}
+
+func (s *NotificationPreferenceTestSuite) TestDefaultsToAllOff() {
+	result, err := s.usecase.GetPreferences(s.ctx, 1)
+	s.Require().NoError(err)
+
+	s.Equal([]entity.PreferenceItem{
+		{Channel: entity.ChannelLargeTransactionEmail},
+		{Channel: entity.ChannelBudgetWebhook},
+		{Channel: entity.ChannelMonthlyRecapEmail},
+	}, result.Preferences)
+}
+
+func (s *NotificationPreferenceTestSuite) TestRoundTrip() {
+	s.expectUpsert()
+
+	updated, err := s.usecase.UpdatePreferences(s.ctx, 1, entity.UpdatePreferencesReq{
+		Preferences: []entity.PreferenceItem{
+			{Channel: entity.ChannelLargeTransactionEmail, Enabled: true, Threshold: 1000000},
+		},
+	})
+	s.Require().NoError(err)
+
+	fetched, err := s.usecase.GetPreferences(s.ctx, 1)
+	s.Require().NoError(err)
+
+	want := []entity.PreferenceItem{
+		{Channel: entity.ChannelLargeTransactionEmail, Enabled: true, Threshold: 1000000},
+		{Channel: entity.ChannelBudgetWebhook},
+		{Channel: entity.ChannelMonthlyRecapEmail},
+	}
+	s.Equal(want, updated.Preferences)
+	s.Equal(want, fetched.Preferences)
+
+	notify, err := s.usecase.ShouldNotify(s.ctx, 1, entity.ChannelLargeTransactionEmail, 1500000)
+	s.Require().NoError(err)
+	s.True(notify)
+
+	notify, err = s.usecase.ShouldNotify(s.ctx, 1, entity.ChannelLargeTransactionEmail, 500000)
+	s.Require().NoError(err)
+	s.False(notify, "amount below threshold")
+
+	notify, err = s.usecase.ShouldNotify(s.ctx, 1, entity.ChannelBudgetWebhook, 5000000)
+	s.Require().NoError(err)
+	s.False(notify, "channel never enabled")
+}
+
+func (s *NotificationPreferenceTestSuite) TestUpdateValidation() {
+	testCases := []struct {
+		name string
+		item entity.PreferenceItem
+	}{
+		{name: "unknown channel", item: entity.PreferenceItem{Channel: "sms", Enabled: true}},
+		{name: "negative threshold", item: entity.PreferenceItem{Channel: entity.ChannelBudgetWebhook, Threshold: -1}},
+	}
+
+	for _, tt := range testCases {
+		s.Run(tt.name, func() {
+			_, err := s.usecase.UpdatePreferences(s.ctx, 1, entity.UpdatePreferencesReq{Preferences: []entity.PreferenceItem{tt.item}})
+
+			var appErr apperr.CustomErrorResponse
+			s.Require().ErrorAs(err, &appErr)
+			s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		})
+	}
+	s.preferenceRepo.AssertNotCalled(s.T(), "Upsert", mock.Anything, mock.Anything, mock.Anything)
+}
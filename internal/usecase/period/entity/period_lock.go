@@ -0,0 +1,11 @@
+package entity
+
+// LockPeriodReq adalah request body untuk mengunci periode sampai tanggal tertentu (inklusif, YYYY-MM-DD).
+type LockPeriodReq struct {
+	LockedThroughDate string `json:"locked_through_date" validate:"required"`
+}
+
+// PeriodLockResponse adalah kunci periode user yang berlaku.
+type PeriodLockResponse struct {
+	LockedThroughDate string `json:"locked_through_date"`
+}
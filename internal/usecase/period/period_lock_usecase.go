@@ -0,0 +1,136 @@
+package period_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
+	"github.com/rakahikmah/finance-tracking/internal/usecase/period/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// PeriodLock adalah usecase untuk mengunci periode transaksi yang sudah direkonsiliasi (closing the books).
+type PeriodLock struct {
+	PeriodLockRepo mysql.IPeriodLockRepository
+	UserStatus     usecase.IUserStatusChecker
+}
+
+// NewPeriodLock adalah konstruktor untuk PeriodLock.
+func NewPeriodLock(
+	PeriodLockRepo mysql.IPeriodLockRepository,
+	UserStatus usecase.IUserStatusChecker,
+) *PeriodLock {
+	return &PeriodLock{
+		PeriodLockRepo: PeriodLockRepo,
+		UserStatus:     UserStatus,
+	}
+}
+
+// IPeriodLockChecker dipakai usecase lain untuk menolak perubahan transaksi di dalam periode yang terkunci.
+type IPeriodLockChecker interface {
+	EnsureUnlocked(ctx context.Context, userID int64, date time.Time) error
+}
+
+// IPeriodLock mendefinisikan interface usecase kunci periode.
+type IPeriodLock interface {
+	IPeriodLockChecker
+	Lock(ctx context.Context, userID int64, req entity.LockPeriodReq) (*entity.PeriodLockResponse, error)
+	Unlock(ctx context.Context, userID int64) error
+}
+
+// Lock mengunci semua transaksi user yang bertanggal pada atau sebelum LockedThroughDate.
+// Mengunci ulang mengganti tanggal kunci sebelumnya.
+func (u *PeriodLock) Lock(ctx context.Context, userID int64, req entity.LockPeriodReq) (*entity.PeriodLockResponse, error) {
+	funcName := "PeriodLock.Lock"
+	logFields := generalEntity.CaptureFields{
+		"user_id":             strconv.FormatInt(userID, 10),
+		"locked_through_date": req.LockedThroughDate,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	lockedThrough, err := helper.ParseDateStrict(req.LockedThroughDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid locked_through_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid locked_through_date: " + err.Error())
+	}
+
+	now := helper.DatetimeNowJakarta()
+	err = u.PeriodLockRepo.Upsert(ctx, nil, &myentity.PeriodLock{
+		UserID:            userID,
+		LockedThroughDate: lockedThrough,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	})
+	if err != nil {
+		helper.LogError(funcName, "PeriodLockRepo.Upsert", err, logFields, "")
+		return nil, err
+	}
+
+	return &entity.PeriodLockResponse{LockedThroughDate: lockedThrough.Format(helper.DateLayout)}, nil
+}
+
+// Unlock menghapus kunci periode user sehingga semua transaksi bisa diubah kembali.
+func (u *PeriodLock) Unlock(ctx context.Context, userID int64) error {
+	funcName := "PeriodLock.Unlock"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := u.PeriodLockRepo.DeleteByUserID(ctx, nil, userID); err != nil {
+		helper.LogError(funcName, "PeriodLockRepo.DeleteByUserID", err, logFields, "")
+		return err
+	}
+
+	return nil
+}
+
+// EnsureUnlocked mengembalikan apperr.ErrConflict jika tanggal berada pada atau sebelum tanggal kunci user.
+// User tanpa kunci periode selalu lolos.
+func (u *PeriodLock) EnsureUnlocked(ctx context.Context, userID int64, date time.Time) error {
+	funcName := "PeriodLock.EnsureUnlocked"
+
+	lock, err := u.PeriodLockRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, apperr.ErrRecordNotFound()) {
+		return nil
+	}
+	if err != nil {
+		helper.LogError(funcName, "PeriodLockRepo.GetByUserID", err, generalEntity.CaptureFields{"user_id": strconv.FormatInt(userID, 10)}, "")
+		return err
+	}
+
+	// Bandingkan sebagai YYYY-MM-DD agar tidak terpengaruh zona waktu hasil scan kolom DATE
+	lockedThrough := lock.LockedThroughDate.Format(helper.DateLayout)
+	if date.Format(helper.DateLayout) <= lockedThrough {
+		return apperr.ErrConflict().SetDetail(fmt.Sprintf("Transactions dated on or before %s are locked. Unlock the period to change them.", lockedThrough))
+	}
+
+	return nil
+}
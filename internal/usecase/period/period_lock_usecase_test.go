@@ -0,0 +1,96 @@
+package period_usecase_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	period_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/period"
+	"github.com/rakahikmah/finance-tracking/internal/usecase/period/entity"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PeriodLockTestSuite struct {
+	suite.Suite
+
+	lockRepo   *mocks.IPeriodLockRepository
+	userStatus *mocks.IUserStatusChecker
+	usecase    period_usecase.IPeriodLock
+	ctx        context.Context
+}
+
+func (s *PeriodLockTestSuite) SetupTest() {
+	s.lockRepo = &mocks.IPeriodLockRepository{}
+	s.userStatus = &mocks.IUserStatusChecker{}
+	s.userStatus.On("EnsureActive", mock.Anything, int64(1)).Return(nil).Maybe()
+	s.usecase = period_usecase.NewPeriodLock(s.lockRepo, s.userStatus)
+	s.ctx = context.Background()
+}
+
+func TestPeriodLock(t *testing.T) {
+	suite.Run(t, new(PeriodLockTestSuite))
+}
+
+func (s *PeriodLockTestSuite) assertHTTPCode(err error, code int) {
+	var appErr apperr.CustomErrorResponse
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(code, appErr.HTTPCode)
+}
+
+func (s *PeriodLockTestSuite) TestLock() {
+	s.Run("stores locked through date", func() {
+		s.lockRepo.On("Upsert", mock.Anything, nil, mock.MatchedBy(func(lock *myentity.PeriodLock) bool {
+			return lock.UserID == 1 && lock.LockedThroughDate.Format("2006-01-02") == "2024-01-31"
+		})).Return(nil).Once()
+
+		result, err := s.usecase.Lock(s.ctx, 1, entity.LockPeriodReq{LockedThroughDate: "2024-01-31"})
+		s.Require().NoError(err)
+		s.Equal("2024-01-31", result.LockedThroughDate)
+	})
+
+	s.Run("invalid date", func() {
+		_, err := s.usecase.Lock(s.ctx, 1, entity.LockPeriodReq{LockedThroughDate: "2024-02-30"})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+
+	s.lockRepo.AssertExpectations(s.T())
+}
+
+func (s *PeriodLockTestSuite) TestUnlock() {
+	s.lockRepo.On("DeleteByUserID", mock.Anything, nil, int64(1)).Return(nil).Once()
+
+	s.Require().NoError(s.usecase.Unlock(s.ctx, 1))
+	s.lockRepo.AssertExpectations(s.T())
+}
+
+func (s *PeriodLockTestSuite) TestEnsureUnlocked() {
+	date := func(value string) time.Time {
+		parsed, _ := time.Parse("2006-01-02", value)
+		return parsed
+	}
+	s.lockRepo.On("GetByUserID", mock.Anything, int64(1)).
+		Return(&myentity.PeriodLock{UserID: 1, LockedThroughDate: date("2024-01-31")}, nil)
+
+	s.Run("before lock date", func() {
+		s.assertHTTPCode(s.usecase.EnsureUnlocked(s.ctx, 1, date("2024-01-15")), http.StatusConflict)
+	})
+
+	s.Run("on lock date", func() {
+		s.assertHTTPCode(s.usecase.EnsureUnlocked(s.ctx, 1, date("2024-01-31")), http.StatusConflict)
+	})
+
+	s.Run("after lock date", func() {
+		s.NoError(s.usecase.EnsureUnlocked(s.ctx, 1, date("2024-02-01")))
+	})
+
+	s.Run("user without lock", func() {
+		s.lockRepo.On("GetByUserID", mock.Anything, int64(2)).Return(nil, apperr.ErrRecordNotFound()).Once()
+
+		s.NoError(s.usecase.EnsureUnlocked(s.ctx, 2, date("2000-01-01")))
+	})
+}
@@ -0,0 +1,18 @@
+package entity
+
+// PreviewRecurringReq adalah definisi aturan transaksi berulang yang ingin dilihat jadwalnya tanpa disimpan.
+type PreviewRecurringReq struct {
+	// Frequency adalah interval pengulangan: daily, weekly, monthly, atau yearly
+	Frequency string `json:"frequency"`
+	// StartDate adalah kejadian pertama (YYYY-MM-DD)
+	StartDate string `json:"start_date"`
+	// EndDate opsional, kejadian setelah tanggal ini (inklusif) tidak ikut
+	EndDate string `json:"end_date"`
+	// Count adalah jumlah kejadian yang ditampilkan, 0 berarti default
+	Count int `json:"count"`
+}
+
+// PreviewRecurringResponse adalah tanggal kejadian aturan berulang, urut dari yang paling awal.
+type PreviewRecurringResponse struct {
+	Occurrences []string `json:"occurrences"`
+}
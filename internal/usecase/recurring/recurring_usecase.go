@@ -0,0 +1,83 @@
+package recurring_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/usecase/recurring/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// Batas jumlah kejadian pada preview aturan berulang.
+const (
+	defaultPreviewCount = 5
+	maxPreviewCount     = 100
+)
+
+// Recurring adalah usecase untuk aturan transaksi berulang.
+type Recurring struct{}
+
+// NewRecurring adalah konstruktor untuk Recurring.
+func NewRecurring() *Recurring {
+	return &Recurring{}
+}
+
+// IRecurring mendefinisikan interface usecase aturan transaksi berulang.
+type IRecurring interface {
+	Preview(ctx context.Context, userID int64, req entity.PreviewRecurringReq) (*entity.PreviewRecurringResponse, error)
+}
+
+// Preview memvalidasi aturan berulang dan mengembalikan maksimal Count tanggal kejadian pertamanya tanpa menyimpan apa pun.
+// Tanggal dihitung dengan helper.Occurrences, logika yang sama untuk generator transaksi berulang.
+func (u *Recurring) Preview(ctx context.Context, userID int64, req entity.PreviewRecurringReq) (*entity.PreviewRecurringResponse, error) {
+	funcName := "Recurring.Preview"
+	logFields := generalEntity.CaptureFields{
+		"user_id":   strconv.FormatInt(userID, 10),
+		"frequency": req.Frequency,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	frequency := helper.Frequency(req.Frequency)
+	if !helper.IsValidFrequency(frequency) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("frequency must be daily, weekly, monthly, or yearly")
+	}
+	start, err := helper.ParseDateStrict(req.StartDate)
+	if err != nil {
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid start_date: " + err.Error())
+	}
+	var end *time.Time
+	if req.EndDate != "" {
+		parsed, err := helper.ParseDateStrict(req.EndDate)
+		if err != nil {
+			return nil, apperr.ErrInvalidRequest().SetDetail("Invalid end_date: " + err.Error())
+		}
+		if parsed.Before(start) {
+			return nil, apperr.ErrInvalidRequest().SetDetail("end_date must not be before start_date")
+		}
+		end = &parsed
+	}
+	if req.Count == 0 {
+		req.Count = defaultPreviewCount
+	}
+	if req.Count < 1 || req.Count > maxPreviewCount {
+		return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("count must be between 1 and %d", maxPreviewCount))
+	}
+
+	result := &entity.PreviewRecurringResponse{Occurrences: []string{}}
+	for _, occurrence := range helper.Occurrences(start, end, frequency, req.Count) {
+		result.Occurrences = append(result.Occurrences, occurrence.Format(helper.DateLayout))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,99 @@
+package recurring_usecase_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	recurring_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/recurring"
+	"github.com/rakahikmah/finance-tracking/internal/usecase/recurring/entity"
+	"github.com/stretchr/testify/suite"
+)
+
+type RecurringTestSuite struct {
+	suite.Suite
+
+	usecase recurring_usecase.IRecurring
+	ctx     context.Context
+}
+
+func (s *RecurringTestSuite) SetupTest() {
+	s.usecase = recurring_usecase.NewRecurring()
+	s.ctx = context.Background()
+}
+
+func TestRecurring(t *testing.T) {
+	suite.Run(t, new(RecurringTestSuite))
+}
+
+func (s *RecurringTestSuite) TestPreview() {
+	testCases := []struct {
+		name string
+		req  entity.PreviewRecurringReq
+		want []string
+	}{
+		{
+			name: "daily",
+			req:  entity.PreviewRecurringReq{Frequency: "daily", StartDate: "2024-02-28", Count: 3},
+			want: []string{"2024-02-28", "2024-02-29", "2024-03-01"},
+		},
+		{
+			name: "weekly",
+			req:  entity.PreviewRecurringReq{Frequency: "weekly", StartDate: "2024-01-29", Count: 3},
+			want: []string{"2024-01-29", "2024-02-05", "2024-02-12"},
+		},
+		{
+			name: "monthly on the 31st",
+			req:  entity.PreviewRecurringReq{Frequency: "monthly", StartDate: "2024-01-31", Count: 4},
+			want: []string{"2024-01-31", "2024-02-29", "2024-03-31", "2024-04-30"},
+		},
+		{
+			name: "end date stops before count",
+			req:  entity.PreviewRecurringReq{Frequency: "monthly", StartDate: "2024-01-15", EndDate: "2024-03-14", Count: 10},
+			want: []string{"2024-01-15", "2024-02-15"},
+		},
+		{
+			name: "default count",
+			req:  entity.PreviewRecurringReq{Frequency: "yearly", StartDate: "2024-02-29"},
+			want: []string{"2024-02-29", "2025-02-28", "2026-02-28", "2027-02-28", "2028-02-29"},
+		},
+	}
+
+	for _, tt := range testCases {
+		s.Run(tt.name, func() {
+			result, err := s.usecase.Preview(s.ctx, 1, tt.req)
+			s.Require().NoError(err)
+			s.Equal(tt.want, result.Occurrences)
+		})
+	}
+}
+
+func (s *RecurringTestSuite) TestPreviewValidation() {
+	testCases := []struct {
+		name string
+		req  entity.PreviewRecurringReq
+	}{
+		{name: "unknown frequency", req: entity.PreviewRecurringReq{Frequency: "hourly", StartDate: "2024-01-01"}},
+		{name: "missing start_date", req: entity.PreviewRecurringReq{Frequency: "daily"}},
+		{name: "invalid end_date", req: entity.PreviewRecurringReq{Frequency: "daily", StartDate: "2024-01-01", EndDate: "2024-02-30"}},
+		{name: "end_date before start_date", req: entity.PreviewRecurringReq{Frequency: "daily", StartDate: "2024-01-10", EndDate: "2024-01-09"}},
+		{name: "count too large", req: entity.PreviewRecurringReq{Frequency: "daily", StartDate: "2024-01-01", Count: 101}},
+		{name: "negative count", req: entity.PreviewRecurringReq{Frequency: "daily", StartDate: "2024-01-01", Count: -1}},
+	}
+
+	for _, tt := range testCases {
+		s.Run(tt.name, func() {
+			_, err := s.usecase.Preview(s.ctx, 1, tt.req)
+
+			var appErr apperr.CustomErrorResponse
+			s.Require().ErrorAs(err, &appErr)
+			s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		})
+	}
+
+	s.Run("missing user", func() {
+		_, err := s.usecase.Preview(s.ctx, 0, entity.PreviewRecurringReq{Frequency: "daily", StartDate: "2024-01-01"})
+		s.Error(err)
+	})
+}
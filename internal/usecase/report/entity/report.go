@@ -0,0 +1,297 @@
+package entity
+
+// TrendPoint adalah total amount dalam satu periode (bucket) time series.
+type TrendPoint struct {
+	Period      string  `json:"period"`
+	TotalAmount float64 `json:"total_amount"`
+}
+
+// CategoryTrendResponse adalah struktur data untuk respons time series total per kategori.
+type CategoryTrendResponse struct {
+	CategoryID   int64        `json:"category_id"`
+	CategoryName string       `json:"category_name"`
+	Currency     string       `json:"currency"`
+	Granularity  string       `json:"granularity"`
+	Points       []TrendPoint `json:"points"`
+}
+
+// HeatmapDay adalah total amount dalam satu hari untuk heatmap.
+type HeatmapDay struct {
+	Date        string  `json:"date"`
+	TotalAmount float64 `json:"total_amount"`
+}
+
+// HeatmapResponse adalah struktur data untuk respons heatmap harian selama satu tahun.
+type HeatmapResponse struct {
+	Year     int          `json:"year"`
+	Type     string       `json:"type"`
+	Currency string       `json:"currency"`
+	Days     []HeatmapDay `json:"days"`
+}
+
+// NetWorthPoint adalah net (income - expense) satu periode dan saldo kumulatif di akhir periode tersebut.
+type NetWorthPoint struct {
+	Period  string  `json:"period"`
+	Net     float64 `json:"net"`
+	Balance float64 `json:"balance"`
+}
+
+// NetWorthResponse adalah struktur data untuk respons saldo kumulatif (net worth) dari waktu ke waktu.
+type NetWorthResponse struct {
+	Granularity    string          `json:"granularity"`
+	Currency       string          `json:"currency"`
+	OpeningBalance float64         `json:"opening_balance"`
+	Points         []NetWorthPoint `json:"points"`
+}
+
+// ActivityResponse adalah statistik keaktifan user mencatat transaksi.
+type ActivityResponse struct {
+	CurrentStreak   int     `json:"current_streak"`
+	LongestStreak   int     `json:"longest_streak"`
+	TotalActiveDays int     `json:"total_active_days"`
+	LastActiveDate  *string `json:"last_active_date"`
+}
+
+// BudgetOverride adalah budget hipotetis untuk satu kategori pada simulasi what-if.
+type BudgetOverride struct {
+	CategoryID int64   `json:"category_id"`
+	Amount     float64 `json:"amount"`
+}
+
+// WhatIfReq adalah request body simulasi what-if.
+type WhatIfReq struct {
+	Budgets []BudgetOverride `json:"budgets"`
+}
+
+// WhatIfTotals adalah proyeksi posisi akhir bulan.
+type WhatIfTotals struct {
+	ProjectedIncome  float64 `json:"projected_income"`
+	ProjectedExpense float64 `json:"projected_expense"`
+	ProjectedNet     float64 `json:"projected_net"`
+}
+
+// WhatIfCategory adalah proyeksi pengeluaran satu kategori, CategoryID nil untuk transaksi tanpa kategori.
+type WhatIfCategory struct {
+	CategoryID         *int64   `json:"category_id"`
+	CategoryName       string   `json:"category_name"`
+	Actual             float64  `json:"actual"`
+	Budget             *float64 `json:"budget"`
+	BaselineProjection float64  `json:"baseline_projection"`
+	ScenarioProjection float64  `json:"scenario_projection"`
+}
+
+// WhatIfResponse adalah hasil simulasi what-if. Simulation selalu true karena tidak ada data yang disimpan.
+type WhatIfResponse struct {
+	Simulation bool             `json:"simulation"`
+	Month      string           `json:"month"`
+	AsOf       string           `json:"as_of"`
+	Currency   string           `json:"currency"`
+	Baseline   WhatIfTotals     `json:"baseline"`
+	Scenario   WhatIfTotals     `json:"scenario"`
+	Difference float64          `json:"difference"`
+	Categories []WhatIfCategory `json:"categories"`
+}
+
+// CategoryMonthRow adalah total amount satu kategori dalam satu bulan, CategoryID nil untuk transaksi tanpa kategori.
+type CategoryMonthRow struct {
+	CategoryID   *int64  `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	Month        string  `json:"month"`
+	TotalAmount  float64 `json:"total_amount"`
+}
+
+// CategoryMonthResponse adalah struktur data untuk respons total per kategori dan bulan dalam bentuk baris datar.
+type CategoryMonthResponse struct {
+	Year     int                `json:"year"`
+	Type     string             `json:"type"`
+	Currency string             `json:"currency"`
+	Rows     []CategoryMonthRow `json:"rows"`
+}
+
+// MapPoint adalah satu transaksi berkoordinat untuk ditampilkan di peta pengeluaran.
+type MapPoint struct {
+	TransactionID   int64   `json:"transaction_id"`
+	TransactionDate string  `json:"transaction_date"`
+	Type            string  `json:"type"`
+	Amount          float64 `json:"amount"`
+	Currency        string  `json:"currency"`
+	CategoryName    *string `json:"category_name"`
+	Description     *string `json:"description"`
+	Latitude        float64 `json:"latitude"`
+	Longitude       float64 `json:"longitude"`
+}
+
+// MapResponse adalah struktur data untuk respons peta pengeluaran dalam rentang tanggal.
+type MapResponse struct {
+	StartDate string     `json:"start_date"`
+	EndDate   string     `json:"end_date"`
+	Points    []MapPoint `json:"points"`
+}
+
+// RecapCategory adalah total pengeluaran satu kategori pada rekap bulanan.
+type RecapCategory struct {
+	CategoryName string  `json:"category_name"`
+	TotalAmount  float64 `json:"total_amount"`
+}
+
+// MonthlyRecapResponse adalah rekap satu bulan beserta perbandingan pengeluaran dengan bulan sebelumnya.
+// ExpenseChangePercent nil jika bulan sebelumnya tidak memiliki pengeluaran.
+type MonthlyRecapResponse struct {
+	Month                string          `json:"month"`
+	Currency             string          `json:"currency"`
+	TotalIncome          float64         `json:"total_income"`
+	TotalExpense         float64         `json:"total_expense"`
+	Net                  float64         `json:"net"`
+	PreviousMonth        string          `json:"previous_month"`
+	PreviousTotalExpense float64         `json:"previous_total_expense"`
+	ExpenseChange        float64         `json:"expense_change"`
+	ExpenseChangePercent *float64        `json:"expense_change_percent"`
+	TopCategories        []RecapCategory `json:"top_categories"`
+}
+
+// DiffReq adalah dua rentang tanggal (inklusif) yang dibandingkan pada laporan diff.
+type DiffReq struct {
+	AStart string
+	AEnd   string
+	BStart string
+	BEnd   string
+}
+
+// DiffPeriod adalah total satu periode pada laporan diff.
+type DiffPeriod struct {
+	StartDate    string  `json:"start_date"`
+	EndDate      string  `json:"end_date"`
+	TotalIncome  float64 `json:"total_income"`
+	TotalExpense float64 `json:"total_expense"`
+	Net          float64 `json:"net"`
+}
+
+// DiffTypeDelta adalah selisih total satu tipe transaksi (B dikurangi A).
+type DiffTypeDelta struct {
+	Type    string  `json:"type"`
+	AAmount float64 `json:"a_amount"`
+	BAmount float64 `json:"b_amount"`
+	Delta   float64 `json:"delta"`
+}
+
+// DiffCategoryDelta adalah selisih total satu kategori dan tipe (B dikurangi A).
+// Kategori yang hanya ada di satu periode bernilai 0 pada periode lainnya.
+type DiffCategoryDelta struct {
+	CategoryName string  `json:"category_name"`
+	Type         string  `json:"type"`
+	AAmount      float64 `json:"a_amount"`
+	BAmount      float64 `json:"b_amount"`
+	Delta        float64 `json:"delta"`
+}
+
+// DiffResponse adalah perbandingan dua periode arbitrer beserta selisih per tipe dan per kategori.
+type DiffResponse struct {
+	Currency   string              `json:"currency"`
+	A          DiffPeriod          `json:"a"`
+	B          DiffPeriod          `json:"b"`
+	Types      []DiffTypeDelta     `json:"types"`
+	Categories []DiffCategoryDelta `json:"categories"`
+}
+
+// CategoryAverage adalah total, jumlah transaksi, dan rata-rata nominal transaksi satu kategori.
+type CategoryAverage struct {
+	CategoryName     string  `json:"category_name"`
+	TotalAmount      float64 `json:"total_amount"`
+	TransactionCount int64   `json:"transaction_count"`
+	AverageAmount    float64 `json:"average_amount"`
+}
+
+// CategoryAveragesResponse adalah rata-rata nominal transaksi per kategori dalam rentang tanggal, urut rata-rata terbesar.
+type CategoryAveragesResponse struct {
+	StartDate string            `json:"start_date"`
+	EndDate   string            `json:"end_date"`
+	Type      string            `json:"type"`
+	Currency  string            `json:"currency"`
+	Rows      []CategoryAverage `json:"rows"`
+}
+
+// EnvelopeAllocation adalah persentase dari total bulanan yang dialokasikan ke satu kategori.
+type EnvelopeAllocation struct {
+	CategoryID int64   `json:"category_id"`
+	Percentage float64 `json:"percentage"`
+}
+
+// EnvelopeReq adalah request body envelope budgeting. Jumlah seluruh Percentage harus 100.
+type EnvelopeReq struct {
+	Total       float64              `json:"total"`
+	Allocations []EnvelopeAllocation `json:"allocations"`
+}
+
+// EnvelopeCategory adalah alokasi dan realisasi pengeluaran satu kategori.
+// Variance adalah Allocated dikurangi Actual, negatif berarti alokasinya terlampaui.
+type EnvelopeCategory struct {
+	CategoryID   int64   `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	Percentage   float64 `json:"percentage"`
+	Allocated    float64 `json:"allocated"`
+	Actual       float64 `json:"actual"`
+	Variance     float64 `json:"variance"`
+}
+
+// EnvelopeResponse adalah perbandingan alokasi envelope dengan pengeluaran bulan berjalan. Tidak ada data yang disimpan.
+type EnvelopeResponse struct {
+	Month      string             `json:"month"`
+	Currency   string             `json:"currency"`
+	Total      float64            `json:"total"`
+	Actual     float64            `json:"actual"`
+	Variance   float64            `json:"variance"`
+	Categories []EnvelopeCategory `json:"categories"`
+}
+
+// AmountBucket adalah jumlah transaksi dengan amount di rentang [Start, End).
+type AmountBucket struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Count int64   `json:"count"`
+}
+
+// AmountHistogramResponse adalah distribusi jumlah transaksi per bucket amount, dari 0 sampai bucket amount terbesar.
+type AmountHistogramResponse struct {
+	StartDate  string         `json:"start_date"`
+	EndDate    string         `json:"end_date"`
+	Type       string         `json:"type"`
+	Currency   string         `json:"currency"`
+	BucketSize float64        `json:"bucket_size"`
+	Buckets    []AmountBucket `json:"buckets"`
+}
+
+// AnomalyTransaction adalah transaksi yang amount-nya jauh di atas baseline kategorinya.
+// Threshold adalah CategoryMean + 2 * CategoryStddev, transaksi dianggap anomali jika Amount melebihinya.
+type AnomalyTransaction struct {
+	TransactionID            int64   `json:"transaction_id"`
+	TransactionDate          string  `json:"transaction_date"`
+	Amount                   float64 `json:"amount"`
+	Description              *string `json:"description"`
+	CategoryID               int64   `json:"category_id"`
+	CategoryName             string  `json:"category_name"`
+	CategoryMean             float64 `json:"category_mean"`
+	CategoryStddev           float64 `json:"category_stddev"`
+	CategoryTransactionCount int64   `json:"category_transaction_count"`
+	Threshold                float64 `json:"threshold"`
+}
+
+// AnomaliesResponse adalah daftar transaksi tidak biasa dalam rentang tanggal, dari yang terbaru.
+type AnomaliesResponse struct {
+	StartDate    string               `json:"start_date"`
+	EndDate      string               `json:"end_date"`
+	Type         string               `json:"type"`
+	Currency     string               `json:"currency"`
+	Transactions []AnomalyTransaction `json:"transactions"`
+}
+
+// YTDResponse adalah total income, expense, dan net dari StartDate (1 Januari) sampai EndDate.
+// EndDate adalah hari ini untuk tahun berjalan dan 31 Desember untuk tahun yang sudah lewat.
+type YTDResponse struct {
+	Year         int     `json:"year"`
+	StartDate    string  `json:"start_date"`
+	EndDate      string  `json:"end_date"`
+	Currency     string  `json:"currency"`
+	TotalIncome  float64 `json:"total_income"`
+	TotalExpense float64 `json:"total_expense"`
+	Net          float64 `json:"net"`
+}
@@ -0,0 +1,1245 @@
+package report_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/config"
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/report/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// Report adalah usecase untuk laporan dan chart yang dibangun di atas data transaksi.
+// Nominal beda mata uang tidak bisa dijumlahkan, sehingga setiap laporan hanya menghitung transaksi
+// dalam mata uang dasar (CURRENCY_CODE) dan menyebutkan mata uang tersebut pada field currency respons.
+type Report struct {
+	TransactionRepo mysql.ITransactionRepository
+	CategoryRepo    mysql.ICategoryRepository
+	CurrencyOption  config.CurrencyOption // Mata uang dasar yang dihitung laporan
+}
+
+// NewReport adalah konstruktor untuk Report.
+func NewReport(
+	TransactionRepo mysql.ITransactionRepository,
+	CategoryRepo mysql.ICategoryRepository,
+	CurrencyOption config.CurrencyOption,
+) *Report {
+	return &Report{
+		TransactionRepo: TransactionRepo,
+		CategoryRepo:    CategoryRepo,
+		CurrencyOption:  CurrencyOption,
+	}
+}
+
+// baseCurrency mengembalikan mata uang dasar yang dipakai semua laporan.
+func (u *Report) baseCurrency() string {
+	return helper.BaseCurrency(u.CurrencyOption.Code)
+}
+
+// summaryInCurrency mengambil baris ringkasan per kategori yang mata uangnya sama dengan currency.
+func summaryInCurrency(rows []*mysql.TransactionSummaryByCategory, currency string) []*mysql.TransactionSummaryByCategory {
+	result := make([]*mysql.TransactionSummaryByCategory, 0, len(rows))
+	for _, row := range rows {
+		if row.Currency == currency {
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
+// IReport mendefinisikan interface untuk usecase laporan.
+type IReport interface {
+	GetCategoryTrend(ctx context.Context, userID int64, categoryID int64, startDate, endDate string, granularity helper.Granularity) (*usecaseEntity.CategoryTrendResponse, error)
+	GetHeatmap(ctx context.Context, userID int64, year int, txType string) (*usecaseEntity.HeatmapResponse, error)
+	GetNetWorth(ctx context.Context, userID int64, startDate, endDate string, granularity helper.Granularity, includeAll bool) (*usecaseEntity.NetWorthResponse, error)
+	GetActivity(ctx context.Context, userID int64, today time.Time) (*usecaseEntity.ActivityResponse, error)
+	SimulateWhatIf(ctx context.Context, userID int64, req usecaseEntity.WhatIfReq, today time.Time) (*usecaseEntity.WhatIfResponse, error)
+	GetCategoryMonth(ctx context.Context, userID int64, year int, txType string) (*usecaseEntity.CategoryMonthResponse, error)
+	GetMap(ctx context.Context, userID int64, startDate, endDate string) (*usecaseEntity.MapResponse, error)
+	GetMonthlyRecap(ctx context.Context, userID int64, month string) (*usecaseEntity.MonthlyRecapResponse, error)
+	GetDiff(ctx context.Context, userID int64, req usecaseEntity.DiffReq) (*usecaseEntity.DiffResponse, error)
+	GetCategoryAverages(ctx context.Context, userID int64, startDate, endDate string, txType string) (*usecaseEntity.CategoryAveragesResponse, error)
+	GetEnvelope(ctx context.Context, userID int64, req usecaseEntity.EnvelopeReq, today time.Time) (*usecaseEntity.EnvelopeResponse, error)
+	GetAmountHistogram(ctx context.Context, userID int64, startDate, endDate string, txType string, bucketSize float64) (*usecaseEntity.AmountHistogramResponse, error)
+	GetAnomalies(ctx context.Context, userID int64, startDate, endDate string, txType string) (*usecaseEntity.AnomaliesResponse, error)
+	GetYTD(ctx context.Context, userID int64, year int, today time.Time, includeAll bool) (*usecaseEntity.YTDResponse, error)
+}
+
+// GetCategoryTrend mengambil time series total pengeluaran satu kategori, bucket kosong diisi 0.
+func (u *Report) GetCategoryTrend(ctx context.Context, userID int64, categoryID int64, startDate, endDate string, granularity helper.Granularity) (*usecaseEntity.CategoryTrendResponse, error) {
+	funcName := "Report.GetCategoryTrend"
+	logFields := generalEntity.CaptureFields{
+		"user_id":     strconv.FormatInt(userID, 10),
+		"category_id": strconv.FormatInt(categoryID, 10),
+		"start_date":  startDate,
+		"end_date":    endDate,
+		"granularity": string(granularity),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if granularity == "" {
+		granularity = helper.GranularityMonth
+	}
+	if !helper.IsValidGranularity(granularity) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid granularity. Use day, week, or month.")
+	}
+
+	start, err := helper.ParseDateStrict(startDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid start_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid start_date: " + err.Error())
+	}
+	end, err := helper.ParseDateStrict(endDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid end_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid end_date: " + err.Error())
+	}
+	if end.Before(start) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("end_date must be on or after start_date.")
+	}
+	if helper.CountBuckets(start, end, granularity) > helper.MaxBuckets {
+		return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Date range is too large for %s granularity, at most %d buckets are allowed.", granularity, helper.MaxBuckets))
+	}
+
+	// Otorisasi: kategori harus milik user yang sedang login
+	category, err := u.CategoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.GetByID", err, logFields, "Error getting category for trend")
+		return nil, err
+	}
+	// Kategori milik user lain diperlakukan sebagai tidak ditemukan agar keberadaannya tidak bocor
+	if category.CreatedBy != userID {
+		helper.LogError(funcName, "Authorization", errors.New("access to category not owned by user"), logFields, "User tried to read trend of category not owned by them")
+		return nil, apperr.ErrRecordNotFound().SetDetail("Category not found.")
+	}
+
+	currency := u.baseCurrency()
+	data, err := u.TransactionRepo.GetDailyTotalsByCategoryID(ctx, userID, categoryID, currency, startDate, endDate)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetDailyTotalsByCategoryID", err, logFields, "")
+		return nil, err
+	}
+
+	// Kelompokkan total harian ke dalam bucket, lalu isi bucket yang kosong dengan 0
+	totals := make(map[string]float64)
+	for _, row := range data {
+		label := helper.BucketLabel(helper.BucketStart(row.TransactionDay, granularity), granularity)
+		totals[label] += row.TotalAmount
+	}
+
+	points := []usecaseEntity.TrendPoint{}
+	for _, bucket := range helper.GenerateBuckets(start, end, granularity) {
+		label := helper.BucketLabel(bucket, granularity)
+		points = append(points, usecaseEntity.TrendPoint{
+			Period:      label,
+			TotalAmount: totals[label],
+		})
+	}
+
+	return &usecaseEntity.CategoryTrendResponse{
+		CategoryID:   category.ID,
+		CategoryName: category.Name,
+		Currency:     currency,
+		Granularity:  string(granularity),
+		Points:       points,
+	}, nil
+}
+
+// GetHeatmap mengambil total per hari selama satu tahun untuk satu tipe transaksi, hari tanpa transaksi diisi 0.
+// transaction_date disimpan sebagai DATE (tanggal kalender user), sehingga hari dibangun dari kalender UTC
+// tanpa konversi zona waktu agar tidak bergeser satu hari.
+func (u *Report) GetHeatmap(ctx context.Context, userID int64, year int, txType string) (*usecaseEntity.HeatmapResponse, error) {
+	funcName := "Report.GetHeatmap"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"year":    strconv.Itoa(year),
+		"type":    txType,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if year < 1900 || year > 9999 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid year.")
+	}
+
+	if txType == "" {
+		txType = string(myentity.TransactionTypeExpense)
+	}
+	if txType != string(myentity.TransactionTypeIncome) && txType != string(myentity.TransactionTypeExpense) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid type. Use income or expense.")
+	}
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	data, err := u.TransactionRepo.GetDailySummaryByUserID(ctx, userID, start.Format(helper.DateLayout), end.Format(helper.DateLayout), true)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetDailySummaryByUserID", err, logFields, "")
+		return nil, err
+	}
+
+	currency := u.baseCurrency()
+	totals := make(map[string]float64)
+	for _, row := range data {
+		if string(row.Type) != txType || row.Currency != currency {
+			continue
+		}
+		totals[row.TransactionDay] += row.TotalAmount
+	}
+
+	days := make([]usecaseEntity.HeatmapDay, 0, 366)
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format(helper.DateLayout)
+		days = append(days, usecaseEntity.HeatmapDay{
+			Date:        date,
+			TotalAmount: totals[date],
+		})
+	}
+
+	return &usecaseEntity.HeatmapResponse{
+		Year:     year,
+		Type:     txType,
+		Currency: currency,
+		Days:     days,
+	}, nil
+}
+
+// GetNetWorth menghitung saldo kumulatif di akhir setiap bucket dalam rentang tanggal.
+// Saldo awal diambil dari semua transaksi sebelum start_date, lalu net tiap bucket ditambahkan berurutan.
+// Bucket tanpa transaksi tetap muncul dengan net 0 dan saldo yang sama dengan bucket sebelumnya.
+// Transaksi pada kategori exclude_from_totals tidak dihitung kecuali includeAll true.
+func (u *Report) GetNetWorth(ctx context.Context, userID int64, startDate, endDate string, granularity helper.Granularity, includeAll bool) (*usecaseEntity.NetWorthResponse, error) {
+	funcName := "Report.GetNetWorth"
+	logFields := generalEntity.CaptureFields{
+		"user_id":     strconv.FormatInt(userID, 10),
+		"start_date":  startDate,
+		"end_date":    endDate,
+		"granularity": string(granularity),
+		"include_all": strconv.FormatBool(includeAll),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if granularity == "" {
+		granularity = helper.GranularityMonth
+	}
+	if !helper.IsValidGranularity(granularity) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid granularity. Use day, week, or month.")
+	}
+
+	start, err := helper.ParseDateStrict(startDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid start_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid start_date: " + err.Error())
+	}
+	end, err := helper.ParseDateStrict(endDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid end_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid end_date: " + err.Error())
+	}
+	if end.Before(start) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("end_date must be on or after start_date.")
+	}
+	if helper.CountBuckets(start, end, granularity) > helper.MaxBuckets {
+		return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Date range is too large for %s granularity, at most %d buckets are allowed.", granularity, helper.MaxBuckets))
+	}
+
+	currency := u.baseCurrency()
+	openingBalance, err := u.TransactionRepo.GetBalanceBeforeDate(ctx, userID, currency, startDate, includeAll)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetBalanceBeforeDate", err, logFields, "")
+		return nil, err
+	}
+
+	data, err := u.TransactionRepo.GetDailySummaryByUserID(ctx, userID, startDate, endDate, includeAll)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetDailySummaryByUserID", err, logFields, "")
+		return nil, err
+	}
+
+	nets := make(map[string]float64)
+	for _, row := range data {
+		if row.Currency != currency {
+			continue
+		}
+
+		day, err := helper.ParseDateStrict(row.TransactionDay)
+		if err != nil {
+			helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid transaction_day from daily summary")
+			return nil, err
+		}
+
+		amount := row.TotalAmount
+		if row.Type == myentity.TransactionTypeExpense {
+			amount = -amount
+		}
+
+		nets[helper.BucketLabel(helper.BucketStart(day, granularity), granularity)] += amount
+	}
+
+	balance := openingBalance
+	points := []usecaseEntity.NetWorthPoint{}
+	for _, bucket := range helper.GenerateBuckets(start, end, granularity) {
+		label := helper.BucketLabel(bucket, granularity)
+		balance += nets[label]
+		points = append(points, usecaseEntity.NetWorthPoint{
+			Period:  label,
+			Net:     nets[label],
+			Balance: balance,
+		})
+	}
+
+	return &usecaseEntity.NetWorthResponse{
+		Granularity:    string(granularity),
+		Currency:       currency,
+		OpeningBalance: openingBalance,
+		Points:         points,
+	}, nil
+}
+
+// GetActivity menghitung streak hari berturut-turut user mencatat transaksi.
+// today adalah tanggal hari ini di zona waktu user. Streak saat ini tetap dihitung jika hari terakhir
+// aktif adalah kemarin (user belum mencatat hari ini), dan menjadi 0 jika ada hari yang terlewat.
+func (u *Report) GetActivity(ctx context.Context, userID int64, today time.Time) (*usecaseEntity.ActivityResponse, error) {
+	funcName := "Report.GetActivity"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	dates, err := u.TransactionRepo.GetDistinctTransactionDates(ctx, userID)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetDistinctTransactionDates", err, logFields, "")
+		return nil, err
+	}
+
+	result := &usecaseEntity.ActivityResponse{TotalActiveDays: len(dates)}
+	if len(dates) == 0 {
+		return result, nil
+	}
+
+	streak := 0
+	var previous time.Time
+	for i, value := range dates {
+		day, err := helper.ParseDateStrict(value)
+		if err != nil {
+			helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid transaction date")
+			return nil, err
+		}
+
+		if i > 0 && day.Equal(previous.AddDate(0, 0, 1)) {
+			streak++
+		} else {
+			streak = 1
+		}
+		if streak > result.LongestStreak {
+			result.LongestStreak = streak
+		}
+		previous = day
+	}
+
+	todayDate, _ := helper.ParseDateStrict(today.Format(helper.DateLayout))
+	if !previous.Before(todayDate.AddDate(0, 0, -1)) {
+		result.CurrentStreak = streak
+	}
+
+	lastActive := previous.Format(helper.DateLayout)
+	result.LastActiveDate = &lastActive
+
+	return result, nil
+}
+
+// SimulateWhatIf memproyeksikan posisi akhir bulan berjalan dengan budget kategori hipotetis, tanpa menyimpan apa pun.
+// Baseline memproyeksikan pengeluaran tiap kategori dari laju harian sejauh ini (actual / hari berjalan * jumlah hari).
+// Pada skenario, kategori yang diberi budget diproyeksikan sebesar budget tersebut, atau actual jika sudah melewatinya.
+// Pemasukan tidak diekstrapolasi karena biasanya datang sekaligus (misalnya gaji).
+func (u *Report) SimulateWhatIf(ctx context.Context, userID int64, req usecaseEntity.WhatIfReq, today time.Time) (*usecaseEntity.WhatIfResponse, error) {
+	funcName := "Report.SimulateWhatIf"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if len(req.Budgets) == 0 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("budgets must not be empty")
+	}
+
+	categories, err := u.CategoryRepo.GetAll(ctx, userID)
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.GetAll", err, logFields, "")
+		return nil, err
+	}
+
+	names := make(map[int64]string, len(categories))
+	for _, category := range categories {
+		names[category.ID] = category.Name
+	}
+
+	budgets := make(map[int64]float64, len(req.Budgets))
+	for _, budget := range req.Budgets {
+		if _, ok := names[budget.CategoryID]; !ok {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Category %d not found.", budget.CategoryID))
+		}
+		if budget.Amount < 0 {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Budget for category %d must not be negative.", budget.CategoryID))
+		}
+		if _, ok := budgets[budget.CategoryID]; ok {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Category %d is listed more than once.", budget.CategoryID))
+		}
+		budgets[budget.CategoryID] = budget.Amount
+	}
+
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	start := today.AddDate(0, 0, 1-today.Day())
+	end := start.AddDate(0, 1, -1)
+	elapsed := float64(today.Day())
+	daysInMonth := float64(end.Day())
+
+	currency := u.baseCurrency()
+	totals, err := u.TransactionRepo.GetTotalsByCategoryID(ctx, userID, currency, start.Format(helper.DateLayout), today.Format(helper.DateLayout))
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetTotalsByCategoryID", err, logFields, "")
+		return nil, err
+	}
+
+	result := &usecaseEntity.WhatIfResponse{
+		Simulation: true,
+		Month:      start.Format(helper.MonthLayout),
+		AsOf:       today.Format(helper.DateLayout),
+		Currency:   currency,
+		Categories: []usecaseEntity.WhatIfCategory{},
+	}
+
+	actuals := map[int64]float64{}
+	var order []int64
+	for _, row := range totals {
+		if row.Type == myentity.TransactionTypeIncome {
+			result.Baseline.ProjectedIncome += row.TotalAmount
+			continue
+		}
+
+		id := int64(0)
+		if row.CategoryID.Valid {
+			id = row.CategoryID.Int64
+		}
+		if _, ok := actuals[id]; !ok {
+			order = append(order, id)
+		}
+		actuals[id] += row.TotalAmount
+	}
+
+	// Kategori yang diberi budget tetap masuk proyeksi walaupun belum ada pengeluaran
+	for _, budget := range req.Budgets {
+		if _, ok := actuals[budget.CategoryID]; !ok {
+			actuals[budget.CategoryID] = 0
+			order = append(order, budget.CategoryID)
+		}
+	}
+
+	for _, id := range order {
+		actual := actuals[id]
+		item := usecaseEntity.WhatIfCategory{
+			CategoryName:       "Uncategorized",
+			Actual:             actual,
+			BaselineProjection: actual / elapsed * daysInMonth,
+		}
+		if id != 0 {
+			categoryID := id
+			item.CategoryID = &categoryID
+			item.CategoryName = names[id]
+		}
+
+		item.ScenarioProjection = item.BaselineProjection
+		if budget, ok := budgets[id]; ok {
+			item.Budget = &budget
+			item.ScenarioProjection = math.Max(actual, budget)
+		}
+
+		result.Baseline.ProjectedExpense += item.BaselineProjection
+		result.Scenario.ProjectedExpense += item.ScenarioProjection
+		result.Categories = append(result.Categories, item)
+	}
+
+	result.Scenario.ProjectedIncome = result.Baseline.ProjectedIncome
+	result.Baseline.ProjectedNet = result.Baseline.ProjectedIncome - result.Baseline.ProjectedExpense
+	result.Scenario.ProjectedNet = result.Scenario.ProjectedIncome - result.Scenario.ProjectedExpense
+	result.Difference = result.Scenario.ProjectedNet - result.Baseline.ProjectedNet
+
+	return result, nil
+}
+
+// GetCategoryMonth mengambil total per kategori dan bulan selama satu tahun sebagai baris datar.
+// Hanya kombinasi kategori-bulan yang memiliki transaksi yang dikembalikan, pivot dilakukan oleh client.
+func (u *Report) GetCategoryMonth(ctx context.Context, userID int64, year int, txType string) (*usecaseEntity.CategoryMonthResponse, error) {
+	funcName := "Report.GetCategoryMonth"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"year":    strconv.Itoa(year),
+		"type":    txType,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if year < 1900 || year > 9999 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid year.")
+	}
+
+	if txType == "" {
+		txType = string(myentity.TransactionTypeExpense)
+	}
+	if txType != string(myentity.TransactionTypeIncome) && txType != string(myentity.TransactionTypeExpense) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid type. Use income or expense.")
+	}
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	currency := u.baseCurrency()
+	data, err := u.TransactionRepo.GetCategoryMonthTotals(ctx, userID, currency, myentity.TransactionType(txType), start.Format(helper.DateLayout), end.Format(helper.DateLayout))
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetCategoryMonthTotals", err, logFields, "")
+		return nil, err
+	}
+
+	rows := make([]usecaseEntity.CategoryMonthRow, 0, len(data))
+	for _, row := range data {
+		var categoryID *int64
+		if row.CategoryID.Valid {
+			id := row.CategoryID.Int64
+			categoryID = &id
+		}
+		rows = append(rows, usecaseEntity.CategoryMonthRow{
+			CategoryID:   categoryID,
+			CategoryName: row.CategoryName,
+			Month:        row.Month,
+			TotalAmount:  row.TotalAmount,
+		})
+	}
+
+	return &usecaseEntity.CategoryMonthResponse{
+		Year:     year,
+		Type:     txType,
+		Currency: currency,
+		Rows:     rows,
+	}, nil
+}
+
+// GetMap mengambil transaksi yang memiliki koordinat dalam rentang tanggal untuk ditampilkan di peta.
+// Transaksi tanpa koordinat tidak ikut dikembalikan.
+func (u *Report) GetMap(ctx context.Context, userID int64, startDate, endDate string) (*usecaseEntity.MapResponse, error) {
+	funcName := "Report.GetMap"
+	logFields := generalEntity.CaptureFields{
+		"user_id":    strconv.FormatInt(userID, 10),
+		"start_date": startDate,
+		"end_date":   endDate,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	start, err := helper.ParseDateStrict(startDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid start_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid start_date: " + err.Error())
+	}
+	end, err := helper.ParseDateStrict(endDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid end_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid end_date: " + err.Error())
+	}
+	if end.Before(start) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("end_date must be on or after start_date.")
+	}
+
+	rows, err := u.TransactionRepo.GetWithCoordinatesByUserID(ctx, userID, startDate, endDate)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetWithCoordinatesByUserID", err, logFields, "")
+		return nil, err
+	}
+
+	points := make([]usecaseEntity.MapPoint, 0, len(rows))
+	for _, row := range rows {
+		if !row.Latitude.Valid || !row.Longitude.Valid {
+			continue
+		}
+
+		var categoryName, description *string
+		if row.CategoryName.Valid {
+			categoryName = &row.CategoryName.String
+		}
+		if row.Description.Valid {
+			description = &row.Description.String
+		}
+
+		points = append(points, usecaseEntity.MapPoint{
+			TransactionID:   row.ID,
+			TransactionDate: row.TransactionDate.Format(helper.DateLayout),
+			Type:            string(row.Type),
+			Amount:          row.Amount,
+			Currency:        row.Currency,
+			CategoryName:    categoryName,
+			Description:     description,
+			Latitude:        row.Latitude.Float64,
+			Longitude:       row.Longitude.Float64,
+		})
+	}
+
+	return &usecaseEntity.MapResponse{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Points:    points,
+	}, nil
+}
+
+// topRecapCategories adalah jumlah kategori pengeluaran terbesar yang ditampilkan pada rekap bulanan.
+const topRecapCategories = 3
+
+// GetMonthlyRecap menyusun rekap satu bulan (YYYY-MM): total income/expense, kategori pengeluaran terbesar,
+// dan perubahan pengeluaran dibanding bulan sebelumnya.
+func (u *Report) GetMonthlyRecap(ctx context.Context, userID int64, month string) (*usecaseEntity.MonthlyRecapResponse, error) {
+	funcName := "Report.GetMonthlyRecap"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"month":   month,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	start, err := helper.ParseMonthStrict(month)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseMonthStrict", err, logFields, "Invalid month")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid month: " + err.Error())
+	}
+	previous := start.AddDate(0, -1, 0)
+
+	current, err := u.TransactionRepo.GetSummaryByCategoryAndTypeByUserID(ctx, userID, start.Format(helper.DateLayout), start.AddDate(0, 1, -1).Format(helper.DateLayout), "")
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetSummaryByCategoryAndTypeByUserID", err, logFields, "current month")
+		return nil, err
+	}
+	before, err := u.TransactionRepo.GetSummaryByCategoryAndTypeByUserID(ctx, userID, previous.Format(helper.DateLayout), start.AddDate(0, 0, -1).Format(helper.DateLayout), "")
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetSummaryByCategoryAndTypeByUserID", err, logFields, "previous month")
+		return nil, err
+	}
+
+	currency := u.baseCurrency()
+	current, before = summaryInCurrency(current, currency), summaryInCurrency(before, currency)
+
+	result := &usecaseEntity.MonthlyRecapResponse{
+		Month:         start.Format(helper.MonthLayout),
+		Currency:      currency,
+		PreviousMonth: previous.Format(helper.MonthLayout),
+		TopCategories: []usecaseEntity.RecapCategory{},
+	}
+
+	for _, row := range current {
+		switch myentity.TransactionType(row.Type) {
+		case myentity.TransactionTypeIncome:
+			result.TotalIncome += row.TotalAmount
+		case myentity.TransactionTypeExpense:
+			result.TotalExpense += row.TotalAmount
+			result.TopCategories = append(result.TopCategories, usecaseEntity.RecapCategory{
+				CategoryName: row.CategoryName.String,
+				TotalAmount:  row.TotalAmount,
+			})
+		}
+	}
+	for _, row := range before {
+		if myentity.TransactionType(row.Type) == myentity.TransactionTypeExpense {
+			result.PreviousTotalExpense += row.TotalAmount
+		}
+	}
+
+	result.Net = result.TotalIncome - result.TotalExpense
+	result.ExpenseChange = result.TotalExpense - result.PreviousTotalExpense
+	if result.PreviousTotalExpense > 0 {
+		percent := math.Round(result.ExpenseChange/result.PreviousTotalExpense*10000) / 100
+		result.ExpenseChangePercent = &percent
+	}
+
+	sort.SliceStable(result.TopCategories, func(i, j int) bool {
+		return result.TopCategories[i].TotalAmount > result.TopCategories[j].TotalAmount
+	})
+	if len(result.TopCategories) > topRecapCategories {
+		result.TopCategories = result.TopCategories[:topRecapCategories]
+	}
+
+	return result, nil
+}
+
+// GetDiff membandingkan dua periode arbitrer (A dan B): total tiap periode, selisih per tipe, dan selisih per kategori.
+// Delta selalu B dikurangi A. Periode boleh saling tumpang tindih.
+func (u *Report) GetDiff(ctx context.Context, userID int64, req usecaseEntity.DiffReq) (*usecaseEntity.DiffResponse, error) {
+	funcName := "Report.GetDiff"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"a_start": req.AStart,
+		"a_end":   req.AEnd,
+		"b_start": req.BStart,
+		"b_end":   req.BEnd,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if err := validateDiffRange("a", req.AStart, req.AEnd); err != nil {
+		helper.LogError(funcName, "validateDiffRange", err, logFields, "Invalid period A")
+		return nil, err
+	}
+	if err := validateDiffRange("b", req.BStart, req.BEnd); err != nil {
+		helper.LogError(funcName, "validateDiffRange", err, logFields, "Invalid period B")
+		return nil, err
+	}
+
+	rowsA, err := u.TransactionRepo.GetSummaryByCategoryAndTypeByUserID(ctx, userID, req.AStart, req.AEnd, "")
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetSummaryByCategoryAndTypeByUserID", err, logFields, "period A")
+		return nil, err
+	}
+	rowsB, err := u.TransactionRepo.GetSummaryByCategoryAndTypeByUserID(ctx, userID, req.BStart, req.BEnd, "")
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetSummaryByCategoryAndTypeByUserID", err, logFields, "period B")
+		return nil, err
+	}
+
+	currency := u.baseCurrency()
+	rowsA, rowsB = summaryInCurrency(rowsA, currency), summaryInCurrency(rowsB, currency)
+
+	result := &usecaseEntity.DiffResponse{
+		Currency:   currency,
+		A:          diffPeriod(req.AStart, req.AEnd, rowsA),
+		B:          diffPeriod(req.BStart, req.BEnd, rowsB),
+		Categories: []usecaseEntity.DiffCategoryDelta{},
+	}
+	result.Types = []usecaseEntity.DiffTypeDelta{
+		{
+			Type:    string(myentity.TransactionTypeIncome),
+			AAmount: result.A.TotalIncome,
+			BAmount: result.B.TotalIncome,
+			Delta:   result.B.TotalIncome - result.A.TotalIncome,
+		},
+		{
+			Type:    string(myentity.TransactionTypeExpense),
+			AAmount: result.A.TotalExpense,
+			BAmount: result.B.TotalExpense,
+			Delta:   result.B.TotalExpense - result.A.TotalExpense,
+		},
+	}
+
+	// Gabungkan kategori dari kedua periode berdasarkan nama dan tipe, kategori satu sisi bernilai 0 di sisi lain
+	type categoryKey struct {
+		name    string
+		txnType string
+	}
+	index := make(map[categoryKey]int)
+	deltaFor := func(row *mysql.TransactionSummaryByCategory) *usecaseEntity.DiffCategoryDelta {
+		key := categoryKey{name: row.CategoryName.String, txnType: row.Type}
+		i, ok := index[key]
+		if !ok {
+			i = len(result.Categories)
+			index[key] = i
+			result.Categories = append(result.Categories, usecaseEntity.DiffCategoryDelta{
+				CategoryName: key.name,
+				Type:         key.txnType,
+			})
+		}
+		return &result.Categories[i]
+	}
+	for _, row := range rowsA {
+		deltaFor(row).AAmount += row.TotalAmount
+	}
+	for _, row := range rowsB {
+		deltaFor(row).BAmount += row.TotalAmount
+	}
+	for i := range result.Categories {
+		result.Categories[i].Delta = result.Categories[i].BAmount - result.Categories[i].AAmount
+	}
+
+	sort.SliceStable(result.Categories, func(i, j int) bool {
+		if result.Categories[i].Type != result.Categories[j].Type {
+			return result.Categories[i].Type < result.Categories[j].Type
+		}
+		return result.Categories[i].CategoryName < result.Categories[j].CategoryName
+	})
+
+	return result, nil
+}
+
+// validateDiffRange memvalidasi satu rentang periode diff, prefix adalah "a" atau "b" sesuai nama query.
+func validateDiffRange(prefix, startDate, endDate string) error {
+	start, err := helper.ParseDateStrict(startDate)
+	if err != nil {
+		return apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Invalid %s_start: %s", prefix, err.Error()))
+	}
+	end, err := helper.ParseDateStrict(endDate)
+	if err != nil {
+		return apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Invalid %s_end: %s", prefix, err.Error()))
+	}
+	if end.Before(start) {
+		return apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("%s_end must be on or after %s_start.", prefix, prefix))
+	}
+	return nil
+}
+
+// diffPeriod menjumlahkan total income dan expense satu periode dari ringkasan per kategori.
+func diffPeriod(startDate, endDate string, rows []*mysql.TransactionSummaryByCategory) usecaseEntity.DiffPeriod {
+	period := usecaseEntity.DiffPeriod{StartDate: startDate, EndDate: endDate}
+	for _, row := range rows {
+		switch myentity.TransactionType(row.Type) {
+		case myentity.TransactionTypeIncome:
+			period.TotalIncome += row.TotalAmount
+		case myentity.TransactionTypeExpense:
+			period.TotalExpense += row.TotalAmount
+		}
+	}
+	period.Net = period.TotalIncome - period.TotalExpense
+	return period
+}
+
+// GetCategoryAverages menghitung total, jumlah transaksi, dan rata-rata nominal per kategori (termasuk Uncategorized)
+// untuk satu tipe transaksi. Type kosong berarti expense. Diurutkan dari rata-rata terbesar.
+func (u *Report) GetCategoryAverages(ctx context.Context, userID int64, startDate, endDate string, txType string) (*usecaseEntity.CategoryAveragesResponse, error) {
+	funcName := "Report.GetCategoryAverages"
+	logFields := generalEntity.CaptureFields{
+		"user_id":    strconv.FormatInt(userID, 10),
+		"start_date": startDate,
+		"end_date":   endDate,
+		"type":       txType,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if txType == "" {
+		txType = string(myentity.TransactionTypeExpense)
+	}
+	if txType != string(myentity.TransactionTypeIncome) && txType != string(myentity.TransactionTypeExpense) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid type. Use income or expense.")
+	}
+
+	start, err := helper.ParseDateStrict(startDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid start_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid start_date: " + err.Error())
+	}
+	end, err := helper.ParseDateStrict(endDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid end_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid end_date: " + err.Error())
+	}
+	if end.Before(start) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("end_date must be on or after start_date.")
+	}
+
+	summary, err := u.TransactionRepo.GetSummaryByCategoryAndTypeByUserID(ctx, userID, startDate, endDate, "")
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetSummaryByCategoryAndTypeByUserID", err, logFields, "")
+		return nil, err
+	}
+
+	currency := u.baseCurrency()
+	rows := []usecaseEntity.CategoryAverage{}
+	for _, row := range summaryInCurrency(summary, currency) {
+		if row.Type != txType {
+			continue
+		}
+
+		average := 0.0
+		if row.TransactionCount > 0 {
+			average = math.Round(row.TotalAmount/float64(row.TransactionCount)*100) / 100
+		}
+		rows = append(rows, usecaseEntity.CategoryAverage{
+			CategoryName:     row.CategoryName.String,
+			TotalAmount:      row.TotalAmount,
+			TransactionCount: row.TransactionCount,
+			AverageAmount:    average,
+		})
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].AverageAmount != rows[j].AverageAmount {
+			return rows[i].AverageAmount > rows[j].AverageAmount
+		}
+		return rows[i].CategoryName < rows[j].CategoryName
+	})
+
+	return &usecaseEntity.CategoryAveragesResponse{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Type:      txType,
+		Currency:  currency,
+		Rows:      rows,
+	}, nil
+}
+
+// envelopePercentageTolerance adalah toleransi pembulatan jumlah persentase envelope terhadap 100.
+const envelopePercentageTolerance = 0.01
+
+// GetEnvelope membagi total bulanan ke kategori sesuai persentase lalu membandingkannya dengan pengeluaran
+// kategori tersebut pada bulan berjalan. Hasil dihitung saja, tidak disimpan.
+func (u *Report) GetEnvelope(ctx context.Context, userID int64, req usecaseEntity.EnvelopeReq, today time.Time) (*usecaseEntity.EnvelopeResponse, error) {
+	funcName := "Report.GetEnvelope"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if req.Total <= 0 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("total must be greater than 0")
+	}
+	if len(req.Allocations) == 0 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("allocations must not be empty")
+	}
+
+	categories, err := u.CategoryRepo.GetAll(ctx, userID)
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.GetAll", err, logFields, "")
+		return nil, err
+	}
+
+	names := make(map[int64]string, len(categories))
+	for _, category := range categories {
+		names[category.ID] = category.Name
+	}
+
+	seen := make(map[int64]bool, len(req.Allocations))
+	sum := 0.0
+	for _, allocation := range req.Allocations {
+		if _, ok := names[allocation.CategoryID]; !ok {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Category %d not found.", allocation.CategoryID))
+		}
+		if allocation.Percentage <= 0 || allocation.Percentage > 100 {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Percentage for category %d must be greater than 0 and at most 100.", allocation.CategoryID))
+		}
+		if seen[allocation.CategoryID] {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Category %d is listed more than once.", allocation.CategoryID))
+		}
+		seen[allocation.CategoryID] = true
+		sum += allocation.Percentage
+	}
+	if math.Abs(sum-100) > envelopePercentageTolerance {
+		return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Percentages must sum to 100, got %s.", strconv.FormatFloat(sum, 'f', -1, 64)))
+	}
+
+	start := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, -1)
+
+	currency := u.baseCurrency()
+	totals, err := u.TransactionRepo.GetTotalsByCategoryID(ctx, userID, currency, start.Format(helper.DateLayout), end.Format(helper.DateLayout))
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetTotalsByCategoryID", err, logFields, "")
+		return nil, err
+	}
+
+	actuals := map[int64]float64{}
+	for _, row := range totals {
+		if row.Type == myentity.TransactionTypeExpense && row.CategoryID.Valid {
+			actuals[row.CategoryID.Int64] += row.TotalAmount
+		}
+	}
+
+	result := &usecaseEntity.EnvelopeResponse{
+		Month:      start.Format(helper.MonthLayout),
+		Currency:   currency,
+		Total:      req.Total,
+		Categories: make([]usecaseEntity.EnvelopeCategory, 0, len(req.Allocations)),
+	}
+	for _, allocation := range req.Allocations {
+		item := usecaseEntity.EnvelopeCategory{
+			CategoryID:   allocation.CategoryID,
+			CategoryName: names[allocation.CategoryID],
+			Percentage:   allocation.Percentage,
+			Allocated:    math.Round(req.Total*allocation.Percentage) / 100,
+			Actual:       actuals[allocation.CategoryID],
+		}
+		item.Variance = math.Round((item.Allocated-item.Actual)*100) / 100
+
+		result.Actual += item.Actual
+		result.Categories = append(result.Categories, item)
+	}
+	result.Variance = math.Round((result.Total-result.Actual)*100) / 100
+
+	return result, nil
+}
+
+// maxHistogramBuckets membatasi jumlah bucket histogram agar bucket_size yang terlalu kecil tidak menghasilkan respons raksasa.
+const maxHistogramBuckets = 1000
+
+// GetAmountHistogram menghitung distribusi jumlah transaksi per bucket amount berukuran bucketSize.
+// Bucket diurutkan dari 0 sampai bucket amount terbesar, termasuk bucket kosong di antaranya. Type default expense.
+func (u *Report) GetAmountHistogram(ctx context.Context, userID int64, startDate, endDate string, txType string, bucketSize float64) (*usecaseEntity.AmountHistogramResponse, error) {
+	funcName := "Report.GetAmountHistogram"
+	logFields := generalEntity.CaptureFields{
+		"user_id":     strconv.FormatInt(userID, 10),
+		"start_date":  startDate,
+		"end_date":    endDate,
+		"type":        txType,
+		"bucket_size": strconv.FormatFloat(bucketSize, 'f', -1, 64),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if txType == "" {
+		txType = string(myentity.TransactionTypeExpense)
+	}
+	if txType != string(myentity.TransactionTypeIncome) && txType != string(myentity.TransactionTypeExpense) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid type. Use income or expense.")
+	}
+	if bucketSize <= 0 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("bucket_size must be greater than 0.")
+	}
+
+	start, err := helper.ParseDateStrict(startDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid start_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid start_date: " + err.Error())
+	}
+	end, err := helper.ParseDateStrict(endDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid end_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid end_date: " + err.Error())
+	}
+	if end.Before(start) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("end_date must be on or after start_date.")
+	}
+
+	currency := u.baseCurrency()
+	counts, err := u.TransactionRepo.GetAmountBucketCounts(ctx, userID, currency, myentity.TransactionType(txType), startDate, endDate, bucketSize)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetAmountBucketCounts", err, logFields, "")
+		return nil, err
+	}
+
+	result := &usecaseEntity.AmountHistogramResponse{
+		StartDate:  startDate,
+		EndDate:    endDate,
+		Type:       txType,
+		Currency:   currency,
+		BucketSize: bucketSize,
+		Buckets:    []usecaseEntity.AmountBucket{},
+	}
+	if len(counts) == 0 {
+		return result, nil
+	}
+
+	// Hasil repository terurut naik, jadi bucket terakhir adalah bucket amount terbesar
+	last := counts[len(counts)-1].BucketIndex
+	if last >= maxHistogramBuckets {
+		return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("bucket_size is too small, it produces more than %d buckets.", maxHistogramBuckets))
+	}
+
+	byIndex := make(map[int64]int64, len(counts))
+	for _, row := range counts {
+		byIndex[row.BucketIndex] = row.TransactionCount
+	}
+	for i := int64(0); i <= last; i++ {
+		result.Buckets = append(result.Buckets, usecaseEntity.AmountBucket{
+			Start: float64(i) * bucketSize,
+			End:   float64(i+1) * bucketSize,
+			Count: byIndex[i],
+		})
+	}
+
+	return result, nil
+}
+
+const (
+	// anomalyStddevFactor adalah kelipatan standar deviasi di atas mean kategori yang dianggap tidak biasa
+	anomalyStddevFactor = 2
+	// anomalyMinTransactions adalah jumlah transaksi minimum per kategori agar mean dan stddev-nya bermakna
+	anomalyMinTransactions = 10
+)
+
+// GetAnomalies mengambil transaksi dalam rentang tanggal yang amount-nya melebihi mean + 2 * stddev kategorinya
+// pada rentang yang sama, beserta baseline kategori sebagai konteks. Kategori dengan kurang dari
+// anomalyMinTransactions transaksi dilewati. Type default expense.
+func (u *Report) GetAnomalies(ctx context.Context, userID int64, startDate, endDate string, txType string) (*usecaseEntity.AnomaliesResponse, error) {
+	funcName := "Report.GetAnomalies"
+	logFields := generalEntity.CaptureFields{
+		"user_id":    strconv.FormatInt(userID, 10),
+		"start_date": startDate,
+		"end_date":   endDate,
+		"type":       txType,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if txType == "" {
+		txType = string(myentity.TransactionTypeExpense)
+	}
+	if txType != string(myentity.TransactionTypeIncome) && txType != string(myentity.TransactionTypeExpense) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid type. Use income or expense.")
+	}
+
+	start, err := helper.ParseDateStrict(startDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid start_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid start_date: " + err.Error())
+	}
+	end, err := helper.ParseDateStrict(endDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid end_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid end_date: " + err.Error())
+	}
+	if end.Before(start) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("end_date must be on or after start_date.")
+	}
+
+	currency := u.baseCurrency()
+	rows, err := u.TransactionRepo.GetAmountAnomalies(ctx, userID, currency, myentity.TransactionType(txType), startDate, endDate, anomalyMinTransactions, anomalyStddevFactor)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetAmountAnomalies", err, logFields, "")
+		return nil, err
+	}
+
+	transactions := make([]usecaseEntity.AnomalyTransaction, 0, len(rows))
+	for _, row := range rows {
+		var description *string
+		if row.Description.Valid {
+			description = &row.Description.String
+		}
+
+		transactions = append(transactions, usecaseEntity.AnomalyTransaction{
+			TransactionID:            row.ID,
+			TransactionDate:          row.TransactionDate.Format(helper.DateLayout),
+			Amount:                   row.Amount,
+			Description:              description,
+			CategoryID:               row.CategoryID.Int64,
+			CategoryName:             row.CategoryName.String,
+			CategoryMean:             math.Round(row.CategoryMean*100) / 100,
+			CategoryStddev:           math.Round(row.CategoryStddev*100) / 100,
+			CategoryTransactionCount: row.CategoryTransactionCount,
+			Threshold:                math.Round((row.CategoryMean+anomalyStddevFactor*row.CategoryStddev)*100) / 100,
+		})
+	}
+
+	return &usecaseEntity.AnomaliesResponse{
+		StartDate:    startDate,
+		EndDate:      endDate,
+		Type:         txType,
+		Currency:     currency,
+		Transactions: transactions,
+	}, nil
+}
+
+// GetYTD menghitung total income, expense, dan net dari 1 Januari sampai today (tanggal hari ini di zona waktu user).
+// year 0 berarti tahun berjalan, year yang sudah lewat dihitung penuh sampai 31 Desember.
+// Transaksi pada kategori exclude_from_totals tidak dihitung kecuali includeAll true.
+func (u *Report) GetYTD(ctx context.Context, userID int64, year int, today time.Time, includeAll bool) (*usecaseEntity.YTDResponse, error) {
+	funcName := "Report.GetYTD"
+	logFields := generalEntity.CaptureFields{
+		"user_id":     strconv.FormatInt(userID, 10),
+		"year":        strconv.Itoa(year),
+		"include_all": strconv.FormatBool(includeAll),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if year == 0 {
+		year = today.Year()
+	}
+	if year < 1900 || year > today.Year() {
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid year. Use the current year or a past year.")
+	}
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	if year == today.Year() {
+		end = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	startDate, endDate := start.Format(helper.DateLayout), end.Format(helper.DateLayout)
+
+	data, err := u.TransactionRepo.GetDailySummaryByUserID(ctx, userID, startDate, endDate, includeAll)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetDailySummaryByUserID", err, logFields, "")
+		return nil, err
+	}
+
+	currency := u.baseCurrency()
+	result := &usecaseEntity.YTDResponse{Year: year, StartDate: startDate, EndDate: endDate, Currency: currency}
+	for _, row := range data {
+		if row.Currency != currency {
+			continue
+		}
+
+		switch row.Type {
+		case myentity.TransactionTypeIncome:
+			result.TotalIncome += row.TotalAmount
+		case myentity.TransactionTypeExpense:
+			result.TotalExpense += row.TotalAmount
+		}
+	}
+	result.TotalIncome = math.Round(result.TotalIncome*100) / 100
+	result.TotalExpense = math.Round(result.TotalExpense*100) / 100
+	result.Net = math.Round((result.TotalIncome-result.TotalExpense)*100) / 100
+
+	return result, nil
+}
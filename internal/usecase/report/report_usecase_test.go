@@ -0,0 +1,811 @@
+package report_usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/config"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	report_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/report"
+	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/report/entity"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReportUsecaseTestSuite struct {
+	suite.Suite
+
+	transactionRepo *mocks.ITransactionRepository
+	categoryRepo    *mocks.ICategoryRepository
+	usecase         report_usecase.IReport
+	ctx             context.Context
+}
+
+func (s *ReportUsecaseTestSuite) SetupTest() {
+	s.transactionRepo = &mocks.ITransactionRepository{}
+	s.categoryRepo = &mocks.ICategoryRepository{}
+	s.ctx = context.Background()
+
+	s.usecase = report_usecase.NewReport(s.transactionRepo, s.categoryRepo, config.CurrencyOption{Code: "IDR"})
+}
+
+func TestReportUsecase(t *testing.T) {
+	suite.Run(t, new(ReportUsecaseTestSuite))
+}
+
+func date(value string) time.Time {
+	t, _ := time.Parse("2006-01-02", value)
+	return t
+}
+
+func (s *ReportUsecaseTestSuite) assertHTTPCode(err error, code int) {
+	var appErr apperr.CustomErrorResponse
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(code, appErr.HTTPCode)
+}
+
+func (s *ReportUsecaseTestSuite) TestGetCategoryTrend() {
+	category := &myentity.Category{ID: 7, CreatedBy: 1, Name: "Makan"}
+
+	s.Run("sparse activity across several months", func() {
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).Return(category, nil).Once()
+		s.transactionRepo.On("GetDailyTotalsByCategoryID", mock.Anything, int64(1), int64(7), "IDR", "2024-01-01", "2024-05-31").
+			Return([]*mysql.DailyTotal{
+				{TransactionDay: date("2024-01-05"), TotalAmount: 10000},
+				{TransactionDay: date("2024-01-20"), TotalAmount: 5000},
+				{TransactionDay: date("2024-04-02"), TotalAmount: 25000},
+			}, nil).Once()
+
+		result, err := s.usecase.GetCategoryTrend(s.ctx, 1, 7, "2024-01-01", "2024-05-31", helper.GranularityMonth)
+		s.Require().NoError(err)
+
+		s.Equal("month", result.Granularity)
+		s.Require().Len(result.Points, 5)
+
+		expected := map[string]float64{
+			"2024-01": 15000,
+			"2024-02": 0,
+			"2024-03": 0,
+			"2024-04": 25000,
+			"2024-05": 0,
+		}
+		for _, point := range result.Points {
+			s.Equal(expected[point.Period], point.TotalAmount, point.Period)
+		}
+	})
+
+	s.Run("category owned by another user", func() {
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).Return(category, nil).Once()
+
+		_, err := s.usecase.GetCategoryTrend(s.ctx, 2, 7, "2024-01-01", "2024-05-31", helper.GranularityMonth)
+		s.assertHTTPCode(err, http.StatusNotFound)
+	})
+
+	s.Run("category not found", func() {
+		s.categoryRepo.On("GetByID", mock.Anything, int64(99)).Return(nil, apperr.ErrRecordNotFound()).Once()
+
+		_, err := s.usecase.GetCategoryTrend(s.ctx, 1, 99, "2024-01-01", "2024-05-31", helper.GranularityMonth)
+		s.assertHTTPCode(err, http.StatusNotFound)
+	})
+
+	s.Run("end date before start date", func() {
+		_, err := s.usecase.GetCategoryTrend(s.ctx, 1, 7, "2024-05-31", "2024-01-01", helper.GranularityMonth)
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+
+	s.Run("invalid granularity", func() {
+		_, err := s.usecase.GetCategoryTrend(s.ctx, 1, 7, "2024-01-01", "2024-05-31", helper.Granularity("year"))
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+
+	s.Run("too many buckets", func() {
+		_, err := s.usecase.GetCategoryTrend(s.ctx, 1, 7, "2000-01-01", "2024-12-31", helper.GranularityDay)
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+}
+
+func (s *ReportUsecaseTestSuite) TestGetHeatmap() {
+	s.Run("leap year with sparse activity", func() {
+		s.transactionRepo.On("GetDailySummaryByUserID", mock.Anything, int64(1), "2024-01-01", "2024-12-31", true).
+			Return([]*mysql.DailySummaryRow{
+				{TransactionDay: "2024-02-29", Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 12000},
+				{TransactionDay: "2024-02-29", Currency: "IDR", Type: myentity.TransactionTypeIncome, TotalAmount: 500000},
+				{TransactionDay: "2024-12-31", Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 7500},
+			}, nil).Once()
+
+		result, err := s.usecase.GetHeatmap(s.ctx, 1, 2024, "expense")
+		s.Require().NoError(err)
+
+		s.Require().Len(result.Days, 366)
+		s.Equal("2024-01-01", result.Days[0].Date)
+		s.Equal(float64(0), result.Days[0].TotalAmount)
+		s.Equal("2024-02-29", result.Days[59].Date)
+		s.Equal(float64(12000), result.Days[59].TotalAmount)
+		s.Equal("2024-12-31", result.Days[365].Date)
+		s.Equal(float64(7500), result.Days[365].TotalAmount)
+	})
+
+	s.Run("non leap year without activity", func() {
+		s.transactionRepo.On("GetDailySummaryByUserID", mock.Anything, int64(1), "2023-01-01", "2023-12-31", true).
+			Return([]*mysql.DailySummaryRow{}, nil).Once()
+
+		result, err := s.usecase.GetHeatmap(s.ctx, 1, 2023, "")
+		s.Require().NoError(err)
+
+		s.Equal("expense", result.Type)
+		s.Require().Len(result.Days, 365)
+		for _, day := range result.Days {
+			s.Equal(float64(0), day.TotalAmount, day.Date)
+		}
+	})
+
+	s.Run("invalid type", func() {
+		_, err := s.usecase.GetHeatmap(s.ctx, 1, 2024, "transfer")
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+}
+
+func (s *ReportUsecaseTestSuite) TestGetNetWorth() {
+	s.Run("cumulative balance from opening balance", func() {
+		s.transactionRepo.On("GetBalanceBeforeDate", mock.Anything, int64(1), "IDR", "2024-01-01", false).Return(float64(1000000), nil).Once()
+		s.transactionRepo.On("GetDailySummaryByUserID", mock.Anything, int64(1), "2024-01-01", "2024-04-30", false).
+			Return([]*mysql.DailySummaryRow{
+				{TransactionDay: "2024-01-10", Currency: "IDR", Type: myentity.TransactionTypeIncome, TotalAmount: 500000},
+				{TransactionDay: "2024-01-20", Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 200000},
+				{TransactionDay: "2024-03-05", Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 450000},
+				{TransactionDay: "2024-04-30", Currency: "IDR", Type: myentity.TransactionTypeIncome, TotalAmount: 100000},
+			}, nil).Once()
+
+		result, err := s.usecase.GetNetWorth(s.ctx, 1, "2024-01-01", "2024-04-30", helper.GranularityMonth, false)
+		s.Require().NoError(err)
+
+		s.Equal(float64(1000000), result.OpeningBalance)
+		s.Equal([]usecaseEntity.NetWorthPoint{
+			{Period: "2024-01", Net: 300000, Balance: 1300000},
+			{Period: "2024-02", Net: 0, Balance: 1300000},
+			{Period: "2024-03", Net: -450000, Balance: 850000},
+			{Period: "2024-04", Net: 100000, Balance: 950000},
+		}, result.Points)
+	})
+
+	s.Run("include all is passed to balance queries", func() {
+		s.transactionRepo.On("GetBalanceBeforeDate", mock.Anything, int64(1), "IDR", "2024-05-01", true).Return(float64(250000), nil).Once()
+		s.transactionRepo.On("GetDailySummaryByUserID", mock.Anything, int64(1), "2024-05-01", "2024-05-31", true).
+			Return([]*mysql.DailySummaryRow{
+				{TransactionDay: "2024-05-02", Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 50000},
+			}, nil).Once()
+
+		result, err := s.usecase.GetNetWorth(s.ctx, 1, "2024-05-01", "2024-05-31", helper.GranularityMonth, true)
+		s.Require().NoError(err)
+
+		s.Equal([]usecaseEntity.NetWorthPoint{{Period: "2024-05", Net: -50000, Balance: 200000}}, result.Points)
+	})
+
+	s.Run("end date before start date", func() {
+		_, err := s.usecase.GetNetWorth(s.ctx, 1, "2024-05-01", "2024-01-01", helper.GranularityMonth, false)
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+
+	s.Run("too many buckets", func() {
+		_, err := s.usecase.GetNetWorth(s.ctx, 1, "2000-01-01", "2024-12-31", helper.GranularityDay, false)
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+}
+
+func (s *ReportUsecaseTestSuite) TestGetActivity() {
+	today := date("2024-03-10")
+
+	testCases := []struct {
+		name    string
+		dates   []string
+		current int
+		longest int
+		total   int
+	}{
+		{
+			name:    "contiguous until today",
+			dates:   []string{"2024-03-07", "2024-03-08", "2024-03-09", "2024-03-10"},
+			current: 4, longest: 4, total: 4,
+		},
+		{
+			name:    "contiguous until yesterday",
+			dates:   []string{"2024-03-08", "2024-03-09"},
+			current: 2, longest: 2, total: 2,
+		},
+		{
+			name:    "broken sequence keeps longest",
+			dates:   []string{"2024-02-27", "2024-02-28", "2024-02-29", "2024-03-01", "2024-03-05", "2024-03-09", "2024-03-10"},
+			current: 2, longest: 4, total: 7,
+		},
+		{
+			name:    "gap before today resets current streak",
+			dates:   []string{"2024-03-01", "2024-03-02", "2024-03-07"},
+			current: 0, longest: 2, total: 3,
+		},
+		{
+			name:    "single day history",
+			dates:   []string{"2024-03-10"},
+			current: 1, longest: 1, total: 1,
+		},
+		{
+			name:    "no history",
+			dates:   []string{},
+			current: 0, longest: 0, total: 0,
+		},
+	}
+
+	for _, tt := range testCases {
+		s.Run(tt.name, func() {
+			s.transactionRepo.On("GetDistinctTransactionDates", mock.Anything, int64(1)).Return(tt.dates, nil).Once()
+
+			result, err := s.usecase.GetActivity(s.ctx, 1, today)
+			s.Require().NoError(err)
+
+			s.Equal(tt.current, result.CurrentStreak, "current streak")
+			s.Equal(tt.longest, result.LongestStreak, "longest streak")
+			s.Equal(tt.total, result.TotalActiveDays, "total active days")
+		})
+	}
+}
+
+func (s *ReportUsecaseTestSuite) TestSimulateWhatIf() {
+	categories := []*myentity.Category{
+		{ID: 1, Name: "Makan", CreatedBy: 1},
+		{ID: 2, Name: "Transport", CreatedBy: 1},
+		{ID: 3, Name: "Hiburan", CreatedBy: 1},
+	}
+	totals := []*mysql.CategoryTypeTotal{
+		{Type: myentity.TransactionTypeIncome, TotalAmount: 10000000},
+		{CategoryID: sql.NullInt64{Int64: 1, Valid: true}, Type: myentity.TransactionTypeExpense, TotalAmount: 1000000},
+		{CategoryID: sql.NullInt64{Int64: 2, Valid: true}, Type: myentity.TransactionTypeExpense, TotalAmount: 200000},
+		{Type: myentity.TransactionTypeExpense, TotalAmount: 100000},
+	}
+
+	s.Run("baseline vs modified scenario", func() {
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return(categories, nil).Once()
+		s.transactionRepo.On("GetTotalsByCategoryID", mock.Anything, int64(1), "IDR", "2024-04-01", "2024-04-10").Return(totals, nil).Once()
+
+		// April punya 30 hari dan 10 hari sudah berjalan, sehingga baseline = actual * 3
+		result, err := s.usecase.SimulateWhatIf(s.ctx, 1, usecaseEntity.WhatIfReq{
+			Budgets: []usecaseEntity.BudgetOverride{
+				{CategoryID: 1, Amount: 2000000}, // di bawah laju saat ini
+				{CategoryID: 2, Amount: 100000},  // sudah terlewati, tetap actual
+				{CategoryID: 3, Amount: 500000},  // belum ada pengeluaran
+			},
+		}, date("2024-04-10"))
+		s.Require().NoError(err)
+
+		s.True(result.Simulation)
+		s.Equal("2024-04", result.Month)
+
+		s.Equal(10000000.0, result.Baseline.ProjectedIncome)
+		s.InDelta(3900000, result.Baseline.ProjectedExpense, 0.001)
+		s.InDelta(6100000, result.Baseline.ProjectedNet, 0.001)
+
+		s.InDelta(3000000, result.Scenario.ProjectedExpense, 0.001)
+		s.InDelta(7000000, result.Scenario.ProjectedNet, 0.001)
+		s.InDelta(900000, result.Difference, 0.001)
+
+		byName := map[string]usecaseEntity.WhatIfCategory{}
+		for _, c := range result.Categories {
+			byName[c.CategoryName] = c
+		}
+		s.InDelta(3000000, byName["Makan"].BaselineProjection, 0.001)
+		s.InDelta(2000000, byName["Makan"].ScenarioProjection, 0.001)
+		s.InDelta(200000, byName["Transport"].ScenarioProjection, 0.001)
+		s.InDelta(500000, byName["Hiburan"].ScenarioProjection, 0.001)
+		s.InDelta(300000, byName["Uncategorized"].ScenarioProjection, 0.001)
+	})
+
+	s.Run("category of another user", func() {
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return(categories, nil).Once()
+
+		_, err := s.usecase.SimulateWhatIf(s.ctx, 1, usecaseEntity.WhatIfReq{
+			Budgets: []usecaseEntity.BudgetOverride{{CategoryID: 99, Amount: 1000}},
+		}, date("2024-04-10"))
+
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+
+	s.Run("negative budget", func() {
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return(categories, nil).Once()
+
+		_, err := s.usecase.SimulateWhatIf(s.ctx, 1, usecaseEntity.WhatIfReq{
+			Budgets: []usecaseEntity.BudgetOverride{{CategoryID: 1, Amount: -1}},
+		}, date("2024-04-10"))
+
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+}
+
+func (s *ReportUsecaseTestSuite) TestGetCategoryMonth() {
+	s.Run("multiple categories across several months", func() {
+		s.transactionRepo.On("GetCategoryMonthTotals", mock.Anything, int64(1), "IDR", myentity.TransactionTypeExpense, "2024-01-01", "2024-12-31").
+			Return([]*mysql.CategoryMonthTotal{
+				{CategoryID: sql.NullInt64{Int64: 7, Valid: true}, CategoryName: "Makan", Month: "2024-01", TotalAmount: 150000},
+				{CategoryID: sql.NullInt64{Int64: 7, Valid: true}, CategoryName: "Makan", Month: "2024-03", TotalAmount: 90000},
+				{CategoryID: sql.NullInt64{Int64: 9, Valid: true}, CategoryName: "Transport", Month: "2024-01", TotalAmount: 40000},
+				{CategoryName: "Uncategorized", Month: "2024-02", TotalAmount: 12000},
+			}, nil).Once()
+
+		result, err := s.usecase.GetCategoryMonth(s.ctx, 1, 2024, "")
+		s.Require().NoError(err)
+
+		s.Equal(2024, result.Year)
+		s.Equal("expense", result.Type)
+		s.Require().Len(result.Rows, 4)
+
+		s.Equal(int64(7), *result.Rows[0].CategoryID)
+		s.Equal("2024-01", result.Rows[0].Month)
+		s.Equal(float64(150000), result.Rows[0].TotalAmount)
+		s.Equal("2024-03", result.Rows[1].Month)
+		s.Equal(int64(9), *result.Rows[2].CategoryID)
+		s.Equal("Transport", result.Rows[2].CategoryName)
+
+		s.Nil(result.Rows[3].CategoryID)
+		s.Equal("Uncategorized", result.Rows[3].CategoryName)
+		s.Equal(float64(12000), result.Rows[3].TotalAmount)
+	})
+
+	s.Run("no transactions returns empty rows", func() {
+		s.transactionRepo.On("GetCategoryMonthTotals", mock.Anything, int64(1), "IDR", myentity.TransactionTypeIncome, "2023-01-01", "2023-12-31").
+			Return([]*mysql.CategoryMonthTotal{}, nil).Once()
+
+		result, err := s.usecase.GetCategoryMonth(s.ctx, 1, 2023, "income")
+		s.Require().NoError(err)
+
+		s.NotNil(result.Rows)
+		s.Empty(result.Rows)
+	})
+
+	s.Run("invalid type", func() {
+		_, err := s.usecase.GetCategoryMonth(s.ctx, 1, 2024, "transfer")
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+}
+
+func (s *ReportUsecaseTestSuite) TestGetMap() {
+	s.Run("only transactions with coordinates are plotted", func() {
+		s.transactionRepo.On("GetWithCoordinatesByUserID", mock.Anything, int64(1), "2024-01-01", "2024-01-31").
+			Return([]*mysql.TransactionWithCategory{
+				{
+					Transaction: myentity.Transaction{
+						ID: 1, Amount: 25000, Type: myentity.TransactionTypeExpense, TransactionDate: date("2024-01-05"),
+						Latitude: sql.NullFloat64{Float64: -6.2088, Valid: true}, Longitude: sql.NullFloat64{Float64: 106.8456, Valid: true},
+					},
+					CategoryName: sql.NullString{String: "Makan", Valid: true},
+				},
+				{
+					Transaction: myentity.Transaction{ID: 2, Amount: 10000, Type: myentity.TransactionTypeExpense, TransactionDate: date("2024-01-06")},
+				},
+			}, nil).Once()
+
+		result, err := s.usecase.GetMap(s.ctx, 1, "2024-01-01", "2024-01-31")
+		s.Require().NoError(err)
+
+		s.Require().Len(result.Points, 1)
+		s.Equal(int64(1), result.Points[0].TransactionID)
+		s.Equal("2024-01-05", result.Points[0].TransactionDate)
+		s.Equal(-6.2088, result.Points[0].Latitude)
+		s.Equal(106.8456, result.Points[0].Longitude)
+		s.Equal("Makan", *result.Points[0].CategoryName)
+	})
+
+	s.Run("end before start", func() {
+		_, err := s.usecase.GetMap(s.ctx, 1, "2024-02-01", "2024-01-01")
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+}
+
+func (s *ReportUsecaseTestSuite) TestGetMonthlyRecap() {
+	s.Run("totals, top categories and change vs previous month", func() {
+		s.transactionRepo.On("GetSummaryByCategoryAndTypeByUserID", mock.Anything, int64(1), "2024-03-01", "2024-03-31", myentity.TransactionType("")).
+			Return([]*mysql.TransactionSummaryByCategory{
+				{CategoryName: sql.NullString{String: "Gaji", Valid: true}, Currency: "IDR", Type: "income", TotalAmount: 10000000},
+				{CategoryName: sql.NullString{String: "Hiburan", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 300000},
+				{CategoryName: sql.NullString{String: "Makan", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 1500000},
+				{CategoryName: sql.NullString{String: "Transport", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 700000},
+				{CategoryName: sql.NullString{String: "Uncategorized", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 500000},
+				{CategoryName: sql.NullString{String: "Liburan", Valid: true}, Currency: "USD", Type: "expense", TotalAmount: 900},
+			}, nil).Once()
+		s.transactionRepo.On("GetSummaryByCategoryAndTypeByUserID", mock.Anything, int64(1), "2024-02-01", "2024-02-29", myentity.TransactionType("")).
+			Return([]*mysql.TransactionSummaryByCategory{
+				{CategoryName: sql.NullString{String: "Gaji", Valid: true}, Currency: "IDR", Type: "income", TotalAmount: 10000000},
+				{CategoryName: sql.NullString{String: "Makan", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 2500000},
+			}, nil).Once()
+
+		result, err := s.usecase.GetMonthlyRecap(s.ctx, 1, "2024-03")
+		s.Require().NoError(err)
+
+		s.Equal("2024-03", result.Month)
+		s.Equal("IDR", result.Currency)
+		s.Equal("2024-02", result.PreviousMonth)
+		s.Equal(float64(10000000), result.TotalIncome)
+		s.Equal(float64(3000000), result.TotalExpense)
+		s.Equal(float64(7000000), result.Net)
+		s.Equal(float64(2500000), result.PreviousTotalExpense)
+		s.Equal(float64(500000), result.ExpenseChange)
+		s.Require().NotNil(result.ExpenseChangePercent)
+		s.Equal(20.0, *result.ExpenseChangePercent)
+
+		s.Equal([]usecaseEntity.RecapCategory{
+			{CategoryName: "Makan", TotalAmount: 1500000},
+			{CategoryName: "Transport", TotalAmount: 700000},
+			{CategoryName: "Uncategorized", TotalAmount: 500000},
+		}, result.TopCategories)
+	})
+
+	s.Run("no previous expenses", func() {
+		s.transactionRepo.On("GetSummaryByCategoryAndTypeByUserID", mock.Anything, int64(1), "2024-01-01", "2024-01-31", myentity.TransactionType("")).
+			Return([]*mysql.TransactionSummaryByCategory{
+				{CategoryName: sql.NullString{String: "Makan", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 100000},
+			}, nil).Once()
+		s.transactionRepo.On("GetSummaryByCategoryAndTypeByUserID", mock.Anything, int64(1), "2023-12-01", "2023-12-31", myentity.TransactionType("")).
+			Return([]*mysql.TransactionSummaryByCategory{}, nil).Once()
+
+		result, err := s.usecase.GetMonthlyRecap(s.ctx, 1, "2024-01")
+		s.Require().NoError(err)
+
+		s.Equal("2023-12", result.PreviousMonth)
+		s.Nil(result.ExpenseChangePercent)
+		s.Equal(float64(100000), result.ExpenseChange)
+	})
+
+	s.Run("invalid month", func() {
+		_, err := s.usecase.GetMonthlyRecap(s.ctx, 1, "2024-13")
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+}
+
+func (s *ReportUsecaseTestSuite) TestGetDiff() {
+	s.Run("disjoint periods with one-sided categories", func() {
+		s.transactionRepo.On("GetSummaryByCategoryAndTypeByUserID", mock.Anything, int64(1), "2024-01-01", "2024-01-31", myentity.TransactionType("")).
+			Return([]*mysql.TransactionSummaryByCategory{
+				{CategoryName: sql.NullString{String: "Gaji", Valid: true}, Currency: "IDR", Type: "income", TotalAmount: 8000000},
+				{CategoryName: sql.NullString{String: "Makan", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 1000000},
+				{CategoryName: sql.NullString{String: "Liburan", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 2000000},
+			}, nil).Once()
+		s.transactionRepo.On("GetSummaryByCategoryAndTypeByUserID", mock.Anything, int64(1), "2024-03-01", "2024-03-31", myentity.TransactionType("")).
+			Return([]*mysql.TransactionSummaryByCategory{
+				{CategoryName: sql.NullString{String: "Gaji", Valid: true}, Currency: "IDR", Type: "income", TotalAmount: 9000000},
+				{CategoryName: sql.NullString{String: "Makan", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 1200000},
+				{CategoryName: sql.NullString{String: "Transport", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 300000},
+			}, nil).Once()
+
+		result, err := s.usecase.GetDiff(s.ctx, 1, usecaseEntity.DiffReq{
+			AStart: "2024-01-01", AEnd: "2024-01-31",
+			BStart: "2024-03-01", BEnd: "2024-03-31",
+		})
+		s.Require().NoError(err)
+
+		s.Equal(usecaseEntity.DiffPeriod{StartDate: "2024-01-01", EndDate: "2024-01-31", TotalIncome: 8000000, TotalExpense: 3000000, Net: 5000000}, result.A)
+		s.Equal(usecaseEntity.DiffPeriod{StartDate: "2024-03-01", EndDate: "2024-03-31", TotalIncome: 9000000, TotalExpense: 1500000, Net: 7500000}, result.B)
+		s.Equal([]usecaseEntity.DiffTypeDelta{
+			{Type: "income", AAmount: 8000000, BAmount: 9000000, Delta: 1000000},
+			{Type: "expense", AAmount: 3000000, BAmount: 1500000, Delta: -1500000},
+		}, result.Types)
+		s.Equal([]usecaseEntity.DiffCategoryDelta{
+			{CategoryName: "Liburan", Type: "expense", AAmount: 2000000, BAmount: 0, Delta: -2000000},
+			{CategoryName: "Makan", Type: "expense", AAmount: 1000000, BAmount: 1200000, Delta: 200000},
+			{CategoryName: "Transport", Type: "expense", AAmount: 0, BAmount: 300000, Delta: 300000},
+			{CategoryName: "Gaji", Type: "income", AAmount: 8000000, BAmount: 9000000, Delta: 1000000},
+		}, result.Categories)
+	})
+
+	s.Run("overlapping periods", func() {
+		s.transactionRepo.On("GetSummaryByCategoryAndTypeByUserID", mock.Anything, int64(1), "2024-05-01", "2024-05-20", myentity.TransactionType("")).
+			Return([]*mysql.TransactionSummaryByCategory{
+				{CategoryName: sql.NullString{String: "Makan", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 500000},
+			}, nil).Once()
+		s.transactionRepo.On("GetSummaryByCategoryAndTypeByUserID", mock.Anything, int64(1), "2024-05-10", "2024-05-31", myentity.TransactionType("")).
+			Return([]*mysql.TransactionSummaryByCategory{
+				{CategoryName: sql.NullString{String: "Makan", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 500000},
+			}, nil).Once()
+
+		result, err := s.usecase.GetDiff(s.ctx, 1, usecaseEntity.DiffReq{
+			AStart: "2024-05-01", AEnd: "2024-05-20",
+			BStart: "2024-05-10", BEnd: "2024-05-31",
+		})
+		s.Require().NoError(err)
+
+		s.Equal([]usecaseEntity.DiffCategoryDelta{
+			{CategoryName: "Makan", Type: "expense", AAmount: 500000, BAmount: 500000, Delta: 0},
+		}, result.Categories)
+		s.Equal(float64(0), result.Types[1].Delta)
+	})
+
+	s.Run("invalid range", func() {
+		_, err := s.usecase.GetDiff(s.ctx, 1, usecaseEntity.DiffReq{
+			AStart: "2024-01-01", AEnd: "2024-01-31",
+			BStart: "2024-03-31", BEnd: "2024-03-01",
+		})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+
+		_, err = s.usecase.GetDiff(s.ctx, 1, usecaseEntity.DiffReq{
+			AStart: "2024-02-30", AEnd: "2024-03-01",
+			BStart: "2024-03-01", BEnd: "2024-03-31",
+		})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+}
+
+func (s *ReportUsecaseTestSuite) TestGetCategoryAverages() {
+	s.Run("averages for varying transaction counts", func() {
+		s.transactionRepo.On("GetSummaryByCategoryAndTypeByUserID", mock.Anything, int64(1), "2024-01-01", "2024-01-31", myentity.TransactionType("")).
+			Return([]*mysql.TransactionSummaryByCategory{
+				{CategoryName: sql.NullString{String: "Gaji", Valid: true}, Currency: "IDR", Type: "income", TotalAmount: 9000000, TransactionCount: 1},
+				{CategoryName: sql.NullString{String: "Kopi", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 300000, TransactionCount: 12},
+				{CategoryName: sql.NullString{String: "Listrik", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 450000, TransactionCount: 1},
+				{CategoryName: sql.NullString{String: "Makan", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 100000, TransactionCount: 3},
+				{CategoryName: sql.NullString{String: "Uncategorized", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 50000, TransactionCount: 2},
+			}, nil).Once()
+
+		result, err := s.usecase.GetCategoryAverages(s.ctx, 1, "2024-01-01", "2024-01-31", "")
+		s.Require().NoError(err)
+
+		s.Equal("expense", result.Type)
+		s.Equal([]usecaseEntity.CategoryAverage{
+			{CategoryName: "Listrik", TotalAmount: 450000, TransactionCount: 1, AverageAmount: 450000},
+			{CategoryName: "Makan", TotalAmount: 100000, TransactionCount: 3, AverageAmount: 33333.33},
+			{CategoryName: "Kopi", TotalAmount: 300000, TransactionCount: 12, AverageAmount: 25000},
+			{CategoryName: "Uncategorized", TotalAmount: 50000, TransactionCount: 2, AverageAmount: 25000},
+		}, result.Rows)
+	})
+
+	s.Run("zero count does not divide", func() {
+		s.transactionRepo.On("GetSummaryByCategoryAndTypeByUserID", mock.Anything, int64(1), "2024-02-01", "2024-02-29", myentity.TransactionType("")).
+			Return([]*mysql.TransactionSummaryByCategory{
+				{CategoryName: sql.NullString{String: "Gaji", Valid: true}, Currency: "IDR", Type: "income", TotalAmount: 0, TransactionCount: 0},
+			}, nil).Once()
+
+		result, err := s.usecase.GetCategoryAverages(s.ctx, 1, "2024-02-01", "2024-02-29", "income")
+		s.Require().NoError(err)
+		s.Equal([]usecaseEntity.CategoryAverage{{CategoryName: "Gaji"}}, result.Rows)
+	})
+
+	s.Run("invalid type", func() {
+		_, err := s.usecase.GetCategoryAverages(s.ctx, 1, "2024-01-01", "2024-01-31", "transfer")
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+}
+
+func (s *ReportUsecaseTestSuite) TestGetEnvelope() {
+	categories := []*myentity.Category{
+		{ID: 1, Name: "Makan", CreatedBy: 1},
+		{ID: 2, Name: "Transport", CreatedBy: 1},
+		{ID: 3, Name: "Hiburan", CreatedBy: 1},
+	}
+
+	s.Run("allocation vs actual for the current month", func() {
+		totals := []*mysql.CategoryTypeTotal{
+			{Type: myentity.TransactionTypeIncome, TotalAmount: 10000000},
+			{CategoryID: sql.NullInt64{Int64: 1, Valid: true}, Type: myentity.TransactionTypeExpense, TotalAmount: 1750000},
+			{CategoryID: sql.NullInt64{Int64: 2, Valid: true}, Type: myentity.TransactionTypeExpense, TotalAmount: 300000},
+			{Type: myentity.TransactionTypeExpense, TotalAmount: 100000},
+		}
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return(categories, nil).Once()
+		s.transactionRepo.On("GetTotalsByCategoryID", mock.Anything, int64(1), "IDR", "2024-04-01", "2024-04-30").Return(totals, nil).Once()
+
+		result, err := s.usecase.GetEnvelope(s.ctx, 1, usecaseEntity.EnvelopeReq{
+			Total: 3000000,
+			Allocations: []usecaseEntity.EnvelopeAllocation{
+				{CategoryID: 1, Percentage: 50},
+				{CategoryID: 2, Percentage: 33.33},
+				{CategoryID: 3, Percentage: 16.67},
+			},
+		}, date("2024-04-10"))
+		s.Require().NoError(err)
+
+		s.Equal("2024-04", result.Month)
+		s.Equal([]usecaseEntity.EnvelopeCategory{
+			{CategoryID: 1, CategoryName: "Makan", Percentage: 50, Allocated: 1500000, Actual: 1750000, Variance: -250000},
+			{CategoryID: 2, CategoryName: "Transport", Percentage: 33.33, Allocated: 999900, Actual: 300000, Variance: 699900},
+			{CategoryID: 3, CategoryName: "Hiburan", Percentage: 16.67, Allocated: 500100, Actual: 0, Variance: 500100},
+		}, result.Categories)
+		s.Equal(3000000.0, result.Total)
+		s.Equal(2050000.0, result.Actual)
+		s.Equal(950000.0, result.Variance)
+	})
+
+	s.Run("percentages must sum to 100", func() {
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return(categories, nil).Once()
+
+		_, err := s.usecase.GetEnvelope(s.ctx, 1, usecaseEntity.EnvelopeReq{
+			Total: 3000000,
+			Allocations: []usecaseEntity.EnvelopeAllocation{
+				{CategoryID: 1, Percentage: 50},
+				{CategoryID: 2, Percentage: 40},
+			},
+		}, date("2024-04-10"))
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+
+	s.Run("category of another user", func() {
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return(categories, nil).Once()
+
+		_, err := s.usecase.GetEnvelope(s.ctx, 1, usecaseEntity.EnvelopeReq{
+			Total:       3000000,
+			Allocations: []usecaseEntity.EnvelopeAllocation{{CategoryID: 99, Percentage: 100}},
+		}, date("2024-04-10"))
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+
+	s.Run("duplicate category", func() {
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return(categories, nil).Once()
+
+		_, err := s.usecase.GetEnvelope(s.ctx, 1, usecaseEntity.EnvelopeReq{
+			Total: 3000000,
+			Allocations: []usecaseEntity.EnvelopeAllocation{
+				{CategoryID: 1, Percentage: 50},
+				{CategoryID: 1, Percentage: 50},
+			},
+		}, date("2024-04-10"))
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+}
+
+func (s *ReportUsecaseTestSuite) TestGetAmountHistogram() {
+	s.Run("buckets in order including empty ones", func() {
+		// Amount 10.000, 45.000, 60.000, 99.999 dan 160.000 dengan bucket 50.000
+		counts := []*mysql.AmountBucketCount{
+			{BucketIndex: 0, TransactionCount: 2},
+			{BucketIndex: 1, TransactionCount: 2},
+			{BucketIndex: 3, TransactionCount: 1},
+		}
+		s.transactionRepo.On("GetAmountBucketCounts", mock.Anything, int64(1), "IDR", myentity.TransactionTypeExpense, "2024-01-01", "2024-01-31", 50000.0).
+			Return(counts, nil).Once()
+
+		result, err := s.usecase.GetAmountHistogram(s.ctx, 1, "2024-01-01", "2024-01-31", "", 50000)
+		s.Require().NoError(err)
+
+		s.Equal("expense", result.Type)
+		s.Equal([]usecaseEntity.AmountBucket{
+			{Start: 0, End: 50000, Count: 2},
+			{Start: 50000, End: 100000, Count: 2},
+			{Start: 100000, End: 150000, Count: 0},
+			{Start: 150000, End: 200000, Count: 1},
+		}, result.Buckets)
+	})
+
+	s.Run("no transactions", func() {
+		s.transactionRepo.On("GetAmountBucketCounts", mock.Anything, int64(1), "IDR", myentity.TransactionTypeIncome, "2024-01-01", "2024-01-31", 50000.0).
+			Return([]*mysql.AmountBucketCount{}, nil).Once()
+
+		result, err := s.usecase.GetAmountHistogram(s.ctx, 1, "2024-01-01", "2024-01-31", "income", 50000)
+		s.Require().NoError(err)
+		s.Empty(result.Buckets)
+		s.NotNil(result.Buckets)
+	})
+
+	s.Run("bucket_size must be positive", func() {
+		_, err := s.usecase.GetAmountHistogram(s.ctx, 1, "2024-01-01", "2024-01-31", "", 0)
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+
+		_, err = s.usecase.GetAmountHistogram(s.ctx, 1, "2024-01-01", "2024-01-31", "", -100)
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+
+	s.Run("bucket_size too small for the amounts", func() {
+		s.transactionRepo.On("GetAmountBucketCounts", mock.Anything, int64(1), "IDR", myentity.TransactionTypeExpense, "2024-01-01", "2024-01-31", 1.0).
+			Return([]*mysql.AmountBucketCount{{BucketIndex: 160000, TransactionCount: 1}}, nil).Once()
+
+		_, err := s.usecase.GetAmountHistogram(s.ctx, 1, "2024-01-01", "2024-01-31", "", 1)
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+}
+
+func (s *ReportUsecaseTestSuite) TestGetAnomalies() {
+	s.Run("clear outlier with category baseline", func() {
+		s.transactionRepo.On("GetAmountAnomalies", mock.Anything, int64(1), "IDR", myentity.TransactionTypeExpense, "2024-01-01", "2024-03-31", 10, float64(2)).
+			Return([]*mysql.AmountAnomaly{
+				{
+					TransactionWithCategory: mysql.TransactionWithCategory{
+						Transaction: myentity.Transaction{
+							ID: 42, UserID: 1, Amount: 1500000, Type: myentity.TransactionTypeExpense,
+							CategoryID:      sql.NullInt64{Int64: 3, Valid: true},
+							Description:     sql.NullString{String: "Makan malam kantor", Valid: true},
+							TransactionDate: date("2024-02-14"),
+						},
+						CategoryName: sql.NullString{String: "Makan", Valid: true},
+					},
+					CategoryMean:             100000,
+					CategoryStddev:           40000.456,
+					CategoryTransactionCount: 30,
+				},
+			}, nil).Once()
+
+		result, err := s.usecase.GetAnomalies(s.ctx, 1, "2024-01-01", "2024-03-31", "")
+		s.Require().NoError(err)
+
+		s.Equal("expense", result.Type)
+		description := "Makan malam kantor"
+		s.Equal([]usecaseEntity.AnomalyTransaction{{
+			TransactionID:            42,
+			TransactionDate:          "2024-02-14",
+			Amount:                   1500000,
+			Description:              &description,
+			CategoryID:               3,
+			CategoryName:             "Makan",
+			CategoryMean:             100000,
+			CategoryStddev:           40000.46,
+			CategoryTransactionCount: 30,
+			Threshold:                180000.91,
+		}}, result.Transactions)
+	})
+
+	s.Run("normal spread returns empty list", func() {
+		s.transactionRepo.On("GetAmountAnomalies", mock.Anything, int64(1), "IDR", myentity.TransactionTypeIncome, "2024-04-01", "2024-04-30", 10, float64(2)).
+			Return(nil, nil).Once()
+
+		result, err := s.usecase.GetAnomalies(s.ctx, 1, "2024-04-01", "2024-04-30", "income")
+		s.Require().NoError(err)
+
+		s.NotNil(result.Transactions)
+		s.Empty(result.Transactions)
+	})
+
+	s.Run("invalid type", func() {
+		_, err := s.usecase.GetAnomalies(s.ctx, 1, "2024-01-01", "2024-01-31", "transfer")
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+
+	s.Run("end date before start date", func() {
+		_, err := s.usecase.GetAnomalies(s.ctx, 1, "2024-02-01", "2024-01-01", "")
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+}
+
+func (s *ReportUsecaseTestSuite) TestGetYTD() {
+	today := time.Date(2024, time.August, 15, 21, 0, 0, 0, time.UTC)
+
+	s.Run("current year is partial up to today", func() {
+		s.transactionRepo.On("GetDailySummaryByUserID", mock.Anything, int64(1), "2024-01-01", "2024-08-15", false).
+			Return([]*mysql.DailySummaryRow{
+				// User baru bergabung bulan Juni, hanya transaksi yang ada yang dihitung
+				{TransactionDay: "2024-06-03", Currency: "IDR", Type: myentity.TransactionTypeIncome, TotalAmount: 7000000},
+				{TransactionDay: "2024-06-04", Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 1250000.25},
+				{TransactionDay: "2024-08-15", Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 300000},
+				// Transaksi mata uang lain tidak dijumlahkan ke total mata uang dasar
+				{TransactionDay: "2024-08-15", Currency: "USD", Type: myentity.TransactionTypeExpense, TotalAmount: 45.5},
+			}, nil).Once()
+
+		result, err := s.usecase.GetYTD(s.ctx, 1, 0, today, false)
+		s.Require().NoError(err)
+
+		s.Equal(&usecaseEntity.YTDResponse{
+			Year:         2024,
+			StartDate:    "2024-01-01",
+			EndDate:      "2024-08-15",
+			Currency:     "IDR",
+			TotalIncome:  7000000,
+			TotalExpense: 1550000.25,
+			Net:          5449999.75,
+		}, result)
+	})
+
+	s.Run("past year is a full year", func() {
+		s.transactionRepo.On("GetDailySummaryByUserID", mock.Anything, int64(1), "2023-01-01", "2023-12-31", true).
+			Return([]*mysql.DailySummaryRow{
+				{TransactionDay: "2023-03-01", Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 500000},
+			}, nil).Once()
+
+		result, err := s.usecase.GetYTD(s.ctx, 1, 2023, today, true)
+		s.Require().NoError(err)
+
+		s.Equal("2023-12-31", result.EndDate)
+		s.Equal(float64(0), result.TotalIncome)
+		s.Equal(float64(-500000), result.Net)
+	})
+
+	s.Run("future year", func() {
+		_, err := s.usecase.GetYTD(s.ctx, 1, 2025, today, false)
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+	})
+}
@@ -0,0 +1,322 @@
+package template_usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
+	"github.com/rakahikmah/finance-tracking/internal/usecase/template/entity"
+	transactions_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/transactions"
+	transactionEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// CrudTransactionTemplate adalah usecase untuk template transaksi (quick-add).
+type CrudTransactionTemplate struct {
+	TemplateRepo       mysql.ITransactionTemplateRepository
+	CategoryRepo       mysql.ICategoryRepository
+	TransactionUsecase transactions_usecase.ICrudTransaction // Membuat transaksi dari template dengan validasi yang sama seperti Create
+	UserStatus         usecase.IUserStatusChecker            // Menolak penulisan dari user yang tidak aktif
+}
+
+// NewCrudTransactionTemplate adalah konstruktor untuk CrudTransactionTemplate.
+func NewCrudTransactionTemplate(
+	TemplateRepo mysql.ITransactionTemplateRepository,
+	CategoryRepo mysql.ICategoryRepository,
+	TransactionUsecase transactions_usecase.ICrudTransaction,
+	UserStatus usecase.IUserStatusChecker,
+) *CrudTransactionTemplate {
+	return &CrudTransactionTemplate{
+		TemplateRepo:       TemplateRepo,
+		CategoryRepo:       CategoryRepo,
+		TransactionUsecase: TransactionUsecase,
+		UserStatus:         UserStatus,
+	}
+}
+
+// ICrudTransactionTemplate mendefinisikan interface untuk operasi CRUD pada template transaksi.
+type ICrudTransactionTemplate interface {
+	Create(ctx context.Context, userID int64, req entity.TemplateReq) (*entity.TemplateResponse, error)
+	GetAll(ctx context.Context, userID int64) ([]entity.TemplateResponse, error)
+	Update(ctx context.Context, id int64, userID int64, req entity.TemplateReq) error
+	Delete(ctx context.Context, id int64, userID int64) error
+	Use(ctx context.Context, id int64, userID int64, today time.Time) error
+}
+
+// errTemplateCategoryGone dikembalikan saat kategori template sudah dihapus atau bukan lagi milik user.
+func errTemplateCategoryGone() error {
+	return apperr.ErrInvalidRequest().SetDetail("The category of this template no longer exists. Update the template before using it.")
+}
+
+// Create membuat template baru untuk user tertentu.
+func (u *CrudTransactionTemplate) Create(ctx context.Context, userID int64, req entity.TemplateReq) (*entity.TemplateResponse, error) {
+	funcName := "CrudTransactionTemplate.Create"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"name":    req.Name,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	categoryID, err := u.validateCategory(ctx, userID, req.CategoryID)
+	if err != nil {
+		helper.LogError(funcName, "validateCategory", err, logFields, "Invalid template category")
+		return nil, err
+	}
+
+	now := helper.DatetimeNowJakarta()
+	data := &myentity.TransactionTemplate{
+		UserID:      userID,
+		Name:        req.Name,
+		CategoryID:  categoryID,
+		Amount:      req.Amount,
+		Type:        myentity.TransactionType(req.Type),
+		Description: toNullString(req.Description),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := u.TemplateRepo.Create(ctx, nil, data, false); err != nil {
+		helper.LogError(funcName, "TemplateRepo.Create", err, logFields, "")
+		return nil, err
+	}
+
+	result := toTemplateResponse(data)
+	return &result, nil
+}
+
+// GetAll mengambil semua template milik user.
+func (u *CrudTransactionTemplate) GetAll(ctx context.Context, userID int64) ([]entity.TemplateResponse, error) {
+	funcName := "CrudTransactionTemplate.GetAll"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	data, err := u.TemplateRepo.GetAllByUserID(ctx, userID)
+	if err != nil {
+		helper.LogError(funcName, "TemplateRepo.GetAllByUserID", err, logFields, "")
+		return nil, err
+	}
+
+	result := make([]entity.TemplateResponse, 0, len(data))
+	for _, row := range data {
+		result = append(result, toTemplateResponse(row))
+	}
+
+	return result, nil
+}
+
+// Update memperbarui seluruh isi template milik user, category_id dan description boleh dikosongkan.
+func (u *CrudTransactionTemplate) Update(ctx context.Context, id int64, userID int64, req entity.TemplateReq) error {
+	funcName := "CrudTransactionTemplate.Update"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"id":      fmt.Sprintf("%d", id),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return err
+	}
+
+	// Otorisasi: template milik user lain diperlakukan sebagai tidak ditemukan
+	oldData, err := u.TemplateRepo.GetByIDAndUserID(ctx, id, userID)
+	if err != nil {
+		helper.LogError(funcName, "TemplateRepo.GetByIDAndUserID", err, logFields, "Error getting template for update")
+		return err
+	}
+
+	categoryID, err := u.validateCategory(ctx, userID, req.CategoryID)
+	if err != nil {
+		helper.LogError(funcName, "validateCategory", err, logFields, "Invalid template category")
+		return err
+	}
+
+	updated := *oldData
+	updated.Name = req.Name
+	updated.CategoryID = categoryID
+	updated.Amount = req.Amount
+	updated.Type = myentity.TransactionType(req.Type)
+	updated.Description = toNullString(req.Description)
+	updated.UpdatedAt = helper.DatetimeNowJakarta()
+
+	// changes nil agar category_id dan description yang dikosongkan ikut tersimpan sebagai NULL
+	if err := u.TemplateRepo.Update(ctx, nil, &updated, nil); err != nil {
+		helper.LogError(funcName, "TemplateRepo.Update", err, logFields, "")
+		return err
+	}
+
+	return nil
+}
+
+// Delete menghapus template milik user.
+func (u *CrudTransactionTemplate) Delete(ctx context.Context, id int64, userID int64) error {
+	funcName := "CrudTransactionTemplate.Delete"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"id":      fmt.Sprintf("%d", id),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return err
+	}
+
+	if _, err := u.TemplateRepo.GetByIDAndUserID(ctx, id, userID); err != nil {
+		helper.LogError(funcName, "TemplateRepo.GetByIDAndUserID", err, logFields, "Error getting template for delete")
+		return err
+	}
+
+	if err := u.TemplateRepo.DeleteByIDAndUserID(ctx, nil, id, userID); err != nil {
+		helper.LogError(funcName, "TemplateRepo.DeleteByIDAndUserID", err, logFields, "")
+		return err
+	}
+
+	return nil
+}
+
+// Use membuat transaksi baru dari template dengan tanggal hari ini.
+// Kategori template diperiksa ulang karena bisa sudah dihapus sejak template disimpan; dalam kasus itu
+// transaksi tidak dibuat dan user diminta memperbarui template, bukan dicatat tanpa kategori diam-diam.
+func (u *CrudTransactionTemplate) Use(ctx context.Context, id int64, userID int64, today time.Time) error {
+	funcName := "CrudTransactionTemplate.Use"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"id":      fmt.Sprintf("%d", id),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	template, err := u.TemplateRepo.GetByIDAndUserID(ctx, id, userID)
+	if err != nil {
+		helper.LogError(funcName, "TemplateRepo.GetByIDAndUserID", err, logFields, "Error getting template to use")
+		return err
+	}
+
+	var categoryID *int64
+	if template.CategoryID.Valid {
+		category, err := u.CategoryRepo.GetByID(ctx, template.CategoryID.Int64)
+		if err != nil && !errors.Is(err, apperr.ErrRecordNotFound()) {
+			helper.LogError(funcName, "CategoryRepo.GetByID", err, logFields, "Error getting template category")
+			return err
+		}
+		if category == nil || category.CreatedBy != userID {
+			helper.LogError(funcName, "CategoryRepo.GetByID", errors.New("template category no longer available"), logFields, "")
+			return errTemplateCategoryGone()
+		}
+		categoryID = &category.ID
+	}
+
+	var description *string
+	if template.Description.Valid {
+		description = &template.Description.String
+	}
+
+	amount := template.Amount
+	txType := transactionEntity.TransactionTypeString(template.Type)
+	req := transactionEntity.TransactionReq{
+		UserID:          userID,
+		CategoryID:      categoryID,
+		Amount:          &amount,
+		Type:            &txType,
+		Description:     description,
+		TransactionDate: today.Format(helper.DateLayout),
+		// Template memang dipakai untuk transaksi yang berulang, jadi tidak dicek sebagai duplikat
+		Force: true,
+	}
+
+	if err := u.TransactionUsecase.Create(ctx, userID, req); err != nil {
+		helper.LogError(funcName, "TransactionUsecase.Create", err, logFields, "")
+		return err
+	}
+
+	return nil
+}
+
+// validateCategory memastikan category_id (jika diberikan) ada dan milik user.
+func (u *CrudTransactionTemplate) validateCategory(ctx context.Context, userID int64, categoryID *int64) (sql.NullInt64, error) {
+	if categoryID == nil || *categoryID <= 0 {
+		return sql.NullInt64{}, nil
+	}
+
+	category, err := u.CategoryRepo.GetByID(ctx, *categoryID)
+	if err != nil && !errors.Is(err, apperr.ErrRecordNotFound()) {
+		return sql.NullInt64{}, err
+	}
+	if category == nil || category.CreatedBy != userID {
+		return sql.NullInt64{}, apperr.ErrInvalidRequest().SetDetail("Invalid Category ID provided.")
+	}
+
+	return sql.NullInt64{Int64: category.ID, Valid: true}, nil
+}
+
+func toNullString(value *string) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *value, Valid: true}
+}
+
+func toTemplateResponse(row *myentity.TransactionTemplate) entity.TemplateResponse {
+	var categoryID *int64
+	if row.CategoryID.Valid {
+		id := row.CategoryID.Int64
+		categoryID = &id
+	}
+	var description *string
+	if row.Description.Valid {
+		value := row.Description.String
+		description = &value
+	}
+
+	return entity.TemplateResponse{
+		ID:          row.ID,
+		Name:        row.Name,
+		CategoryID:  categoryID,
+		Amount:      row.Amount,
+		Type:        string(row.Type),
+		Description: description,
+		CreatedAt:   helper.ConvertToJakartaTime(row.CreatedAt),
+		UpdatedAt:   helper.ConvertToJakartaTime(row.UpdatedAt),
+	}
+}
@@ -0,0 +1,141 @@
+package template_usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	template_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/template"
+	"github.com/rakahikmah/finance-tracking/internal/usecase/template/entity"
+	transactionEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CrudTransactionTemplateTestSuite struct {
+	suite.Suite
+
+	templateRepo       *mocks.ITransactionTemplateRepository
+	categoryRepo       *mocks.ICategoryRepository
+	transactionUsecase *mocks.ICrudTransaction
+	userStatus         *mocks.IUserStatusChecker
+	usecase            template_usecase.ICrudTransactionTemplate
+	ctx                context.Context
+	today              time.Time
+}
+
+func (s *CrudTransactionTemplateTestSuite) SetupTest() {
+	s.templateRepo = &mocks.ITransactionTemplateRepository{}
+	s.categoryRepo = &mocks.ICategoryRepository{}
+	s.transactionUsecase = &mocks.ICrudTransaction{}
+	s.userStatus = &mocks.IUserStatusChecker{}
+	s.userStatus.On("EnsureActive", mock.Anything, int64(1)).Return(nil).Maybe()
+	s.ctx = context.Background()
+	s.today = time.Date(2024, time.March, 10, 8, 30, 0, 0, time.UTC)
+
+	s.usecase = template_usecase.NewCrudTransactionTemplate(s.templateRepo, s.categoryRepo, s.transactionUsecase, s.userStatus)
+}
+
+func TestCrudTransactionTemplate(t *testing.T) {
+	suite.Run(t, new(CrudTransactionTemplateTestSuite))
+}
+
+func (s *CrudTransactionTemplateTestSuite) assertHTTPCode(err error, code int) {
+	var appErr apperr.CustomErrorResponse
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(code, appErr.HTTPCode)
+}
+
+func (s *CrudTransactionTemplateTestSuite) TestCreate() {
+	s.Run("category owned by another user is rejected", func() {
+		s.SetupTest()
+		categoryID := int64(7)
+		s.categoryRepo.On("GetByID", mock.Anything, categoryID).Return(&myentity.Category{ID: 7, CreatedBy: 2}, nil).Once()
+
+		_, err := s.usecase.Create(s.ctx, 1, entity.TemplateReq{Name: "Kopi", CategoryID: &categoryID, Amount: 25000, Type: "expense"})
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+		s.templateRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("valid template is stored", func() {
+		s.SetupTest()
+		categoryID := int64(7)
+		description := "Kopi pagi"
+		s.categoryRepo.On("GetByID", mock.Anything, categoryID).Return(&myentity.Category{ID: 7, CreatedBy: 1}, nil).Once()
+		s.templateRepo.On("Create", mock.Anything, nil, mock.MatchedBy(func(t *myentity.TransactionTemplate) bool {
+			return t.UserID == 1 && t.CategoryID.Int64 == 7 && t.Description.String == description
+		}), false).Return(nil).Once()
+
+		result, err := s.usecase.Create(s.ctx, 1, entity.TemplateReq{Name: "Kopi", CategoryID: &categoryID, Amount: 25000, Type: "expense", Description: &description})
+		s.Require().NoError(err)
+		s.Equal("Kopi", result.Name)
+		s.Equal(int64(7), *result.CategoryID)
+	})
+}
+
+func (s *CrudTransactionTemplateTestSuite) TestUse() {
+	template := &myentity.TransactionTemplate{
+		ID:          3,
+		UserID:      1,
+		Name:        "Kopi",
+		CategoryID:  sql.NullInt64{Int64: 7, Valid: true},
+		Amount:      25000,
+		Type:        myentity.TransactionTypeExpense,
+		Description: sql.NullString{String: "Kopi pagi", Valid: true},
+	}
+
+	s.Run("creates a transaction dated today", func() {
+		s.SetupTest()
+		s.templateRepo.On("GetByIDAndUserID", mock.Anything, int64(3), int64(1)).Return(template, nil).Once()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).Return(&myentity.Category{ID: 7, CreatedBy: 1}, nil).Once()
+		s.transactionUsecase.On("Create", mock.Anything, int64(1), mock.MatchedBy(func(req transactionEntity.TransactionReq) bool {
+			return req.TransactionDate == "2024-03-10" && *req.CategoryID == 7 && *req.Amount == 25000 &&
+				*req.Type == transactionEntity.TransactionTypeExpenseStr && *req.Description == "Kopi pagi"
+		})).Return(nil).Once()
+
+		s.NoError(s.usecase.Use(s.ctx, 3, 1, s.today))
+		s.transactionUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("category deleted since the template was saved", func() {
+		s.SetupTest()
+		s.templateRepo.On("GetByIDAndUserID", mock.Anything, int64(3), int64(1)).Return(template, nil).Once()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(7)).Return(nil, apperr.ErrRecordNotFound()).Once()
+
+		err := s.usecase.Use(s.ctx, 3, 1, s.today)
+		s.assertHTTPCode(err, http.StatusUnprocessableEntity)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Contains(appErr.Detail, "no longer exists")
+		s.transactionUsecase.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("template without category", func() {
+		s.SetupTest()
+		uncategorized := *template
+		uncategorized.CategoryID = sql.NullInt64{}
+		uncategorized.Description = sql.NullString{}
+		s.templateRepo.On("GetByIDAndUserID", mock.Anything, int64(3), int64(1)).Return(&uncategorized, nil).Once()
+		s.transactionUsecase.On("Create", mock.Anything, int64(1), mock.MatchedBy(func(req transactionEntity.TransactionReq) bool {
+			return req.CategoryID == nil && req.Description == nil
+		})).Return(nil).Once()
+
+		s.NoError(s.usecase.Use(s.ctx, 3, 1, s.today))
+		s.categoryRepo.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
+	})
+
+	s.Run("template owned by another user", func() {
+		s.SetupTest()
+		s.templateRepo.On("GetByIDAndUserID", mock.Anything, int64(3), int64(1)).Return(nil, apperr.ErrRecordNotFound()).Once()
+
+		err := s.usecase.Use(s.ctx, 3, 1, s.today)
+		s.assertHTTPCode(err, http.StatusNotFound)
+		s.transactionUsecase.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
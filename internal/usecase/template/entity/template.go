@@ -0,0 +1,28 @@
+package entity
+
+// TemplateReq adalah request body untuk membuat atau memperbarui template transaksi.
+type TemplateReq struct {
+	UserID      int64   `json:"user_id,omitempty"`
+	Name        string  `json:"name" validate:"required,max=100" name:"Nama Template"`
+	CategoryID  *int64  `json:"category_id"`
+	Amount      float64 `json:"amount" validate:"required,gt=0" name:"Jumlah Transaksi"`
+	Type        string  `json:"type" validate:"required,oneof=income expense" name:"Tipe Transaksi"`
+	Description *string `json:"description"`
+}
+
+// SetUserID menyisipkan user ID dari JWT ke request.
+func (r *TemplateReq) SetUserID(userID int64) {
+	r.UserID = userID
+}
+
+// TemplateResponse adalah struktur data untuk respons template transaksi.
+type TemplateResponse struct {
+	ID          int64   `json:"id"`
+	Name        string  `json:"name"`
+	CategoryID  *int64  `json:"category_id"`
+	Amount      float64 `json:"amount"`
+	Type        string  `json:"type"`
+	Description *string `json:"description"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
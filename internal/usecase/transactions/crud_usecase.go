@@ -3,14 +3,22 @@ package transactions_usecase // Nama paket
 import (
 	"context"
 	"database/sql" // Untuk sql.NullInt64 dan sql.NullString
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"strconv"
-	"time" // Untuk time.Time, time.Parse, dan DatetimeNowJakarta
+	"strings"
+	"time"
 
+	"github.com/rakahikmah/finance-tracking/config"
 	generalEntity "github.com/rakahikmah/finance-tracking/entity" // Asumsi ini entity dasar seperti CaptureFields
 	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/queue"
 	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
+	period_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/period"
 	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity" // Model GORM Transaction
 	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity" // DTO TransactionReq/Response
 
@@ -19,32 +27,84 @@ import (
 
 // CrudTransaction adalah struct yang akan menampung dependensi repository.
 type CrudTransaction struct {
-	TransactionRepo mysql.ITransactionRepository // Menggunakan interface repository Transaction
-	CategoryRepo    mysql.ICategoryRepository    // Perlu untuk validasi category_id
+	TransactionRepo mysql.ITransactionRepository      // Menggunakan interface repository Transaction
+	CategoryRepo    mysql.ICategoryRepository         // Perlu untuk validasi category_id
+	SummaryOption   config.SummaryOption              // Threshold downsampling ringkasan harian
+	CurrencyOption  config.CurrencyOption             // Mata uang untuk validasi jumlah desimal amount
+	ResponseOption  config.ResponseOption             // Default representasi field NULL pada respons
+	SearchOption    config.SearchOption               // Panjang minimal query pencarian
+	SpendingCap     config.SpendingCapOption          // Batas pengeluaran bulanan, melewatinya mengirim event alert
+	UserStatus      usecase.IUserStatusChecker        // Menolak penulisan dari user yang tidak aktif
+	UserTimezone    usecase.IUserTimezone             // Zona waktu user untuk created_at/updated_at
+	PeriodLock      period_usecase.IPeriodLockChecker // Menolak perubahan transaksi di periode yang terkunci
+	Publisher       queue.Publisher                   // Mengirim event transaksi baru ke consumer (non-blocking)
 }
 
 // NewCrudTransaction adalah konstruktor untuk CrudTransaction.
 func NewCrudTransaction(
 	TransactionRepo mysql.ITransactionRepository,
 	CategoryRepo mysql.ICategoryRepository, // Tambahkan CategoryRepo
+	SummaryOption config.SummaryOption,
+	CurrencyOption config.CurrencyOption,
+	ResponseOption config.ResponseOption,
+	SearchOption config.SearchOption,
+	SpendingCap config.SpendingCapOption,
+	UserStatus usecase.IUserStatusChecker,
+	UserTimezone usecase.IUserTimezone,
+	PeriodLock period_usecase.IPeriodLockChecker,
+	Publisher queue.Publisher,
 ) *CrudTransaction {
 	return &CrudTransaction{
 		TransactionRepo: TransactionRepo,
 		CategoryRepo:    CategoryRepo,
+		SummaryOption:   SummaryOption,
+		CurrencyOption:  CurrencyOption,
+		ResponseOption:  ResponseOption,
+		SearchOption:    SearchOption,
+		SpendingCap:     SpendingCap,
+		UserStatus:      UserStatus,
+		UserTimezone:    UserTimezone,
+		PeriodLock:      PeriodLock,
+		Publisher:       Publisher,
 	}
 }
 
 // ICrudTransaction mendefinisikan interface untuk operasi CRUD pada Transaction.
 type ICrudTransaction interface {
 	Create(ctx context.Context, userID int64, req usecaseEntity.TransactionReq) error
-	GetAll(ctx context.Context, userID int64) ([]usecaseEntity.TransactionResponse, error)
+	PrepareCreate(ctx context.Context, userID int64, req usecaseEntity.TransactionReq, category *myentity.Category) (*myentity.Transaction, error)
+	GetByID(ctx context.Context, id int64, userID int64) (*usecaseEntity.TransactionResponse, error)
+	GetMonthlySummary(ctx context.Context, userID int64, year int, includeAll bool) ([]usecaseEntity.MonthlySummaryRow, error)
+	GetYearlyReport(ctx context.Context, userID int64, year int) (*usecaseEntity.YearlyReportResponse, error)
+	GetBalance(ctx context.Context, userID int64, startDate, endDate string, includeAll bool) ([]usecaseEntity.BalanceResponse, error)
+	GetBalanceTimeline(ctx context.Context, userID int64, startDate, endDate string) (*usecaseEntity.BalanceTimelineResponse, error)
+	GetSpendingStats(ctx context.Context, userID int64, startDate, endDate string) (*usecaseEntity.SpendingStatsResponse, error)
+	GetAll(ctx context.Context, userID int64, req usecaseEntity.TransactionCursorReq) (*usecaseEntity.TransactionCursorResponse, error)
+	StreamAll(ctx context.Context, userID int64, req usecaseEntity.TransactionExportReq, fn func(item usecaseEntity.TransactionResponse) error) error
+	SuggestCategory(ctx context.Context, userID int64, description string) (*usecaseEntity.CategorySuggestionResponse, error)
+	GetYears(ctx context.Context, userID int64) ([]int, error)
+	GetDateRange(ctx context.Context, userID int64) (*usecaseEntity.DateRangeResponse, error)
+	GetFilterOptions(ctx context.Context, userID int64) (*usecaseEntity.FilterOptionsResponse, error)
+	List(ctx context.Context, userID int64, req usecaseEntity.TransactionListReq) (*usecaseEntity.TransactionListResponse, error)
+	Search(ctx context.Context, userID int64, req usecaseEntity.TransactionSearchReq) ([]usecaseEntity.TransactionResponse, error)
 	Update(ctx context.Context, id int64, userID int64, req usecaseEntity.TransactionReq) error
-	Delete(ctx context.Context, id int64, userID int64) error
-	GetDailySummary(ctx context.Context, userID int64, startDate, endDate string) ([]map[string]interface{}, error) // Contoh API tambahan
-	GetSummaryByCategoryAndType(ctx context.Context, userID int64, startDate, endDate string) ([]usecaseEntity.TransactionSummaryResponse, error)
+	Delete(ctx context.Context, id int64, userID int64, overridePeriodLock bool) error
+	Restore(ctx context.Context, id int64, userID int64) error
+	GetDailySummary(ctx context.Context, userID int64, startDate, endDate string, granularity helper.Granularity, includeAll bool) (*usecaseEntity.DailySummaryResponse, error)
+	GetSummaryByCategoryAndType(ctx context.Context, userID int64, startDate, endDate string, txType usecaseEntity.TransactionTypeString) ([]usecaseEntity.TransactionSummaryResponse, error)
+	GetTopCategories(ctx context.Context, userID int64, startDate, endDate string, limit int) (*usecaseEntity.TopCategoriesResponse, error)
+	GetCalendar(ctx context.Context, userID int64, month string, format usecaseEntity.ResponseFormatReq) (*usecaseEntity.CalendarResponse, error)
+	Import(ctx context.Context, userID int64, req usecaseEntity.ImportTransactionReq, createCategories bool) (*usecaseEntity.ImportTransactionResponse, error)
+	ImportCSV(ctx context.Context, userID int64, file io.Reader, createMissing bool) (*usecaseEntity.ImportCSVResponse, error)
+	Sync(ctx context.Context, userID int64, req usecaseEntity.SyncTransactionReq) (*usecaseEntity.SyncTransactionResponse, error)
+	ReplaceDescription(ctx context.Context, userID int64, req usecaseEntity.ReplaceDescriptionReq) (*usecaseEntity.ReplaceDescriptionResponse, error)
+	CleanupOrphanedCategories(ctx context.Context, userID int64, dryRun bool) (*usecaseEntity.OrphanCleanupResponse, error)
 }
 
 
+// duplicateTransactionWindow adalah rentang waktu Create menganggap transaksi yang sama sebagai kemungkinan duplikat.
+const duplicateTransactionWindow = 5 * time.Minute
+
 // Create membuat transaksi baru untuk user tertentu.
 func (u *CrudTransaction) Create(ctx context.Context, userID int64, req usecaseEntity.TransactionReq) error {
 	funcName := "CrudTransaction.Create"
@@ -55,66 +115,1118 @@ func (u *CrudTransaction) Create(ctx context.Context, userID int64, req usecaseE
 		return apperr.ErrInvalidRequest().SetDetail("User ID is required")
 	}
 
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return err
+	}
+
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+	}
+
+	// Validasi CategoryID jika diberikan
+	var category *myentity.Category
+	if req.CategoryID != nil && *req.CategoryID > 0 {
+		// Periksa apakah category_id yang diberikan valid dan milik user yang sama
+		var err error
+		category, err = u.CategoryRepo.GetByID(ctx, *req.CategoryID)
+		if err != nil {
+			helper.LogError(funcName, "CategoryRepo.GetByID", err, logFields, "Error getting category for transaction")
+			return apperr.ErrInvalidRequest().SetDetail("Invalid Category ID provided.")
+		}
+		// Pastikan kategori yang dipilih milik user yang sedang login
+		if category.CreatedBy != userID {
+			helper.LogError(funcName, "CategoryRepo.GetByID", errors.New("unauthorized category access"), logFields, "User tried to use category not owned by them")
+			return apperr.ErrForbidden().SetDetail("You are not authorized to use this category.")
+		}
+	}
+
+	data, err := u.buildTransaction(ctx, funcName, userID, req, category, logFields)
+	if err != nil {
+		return err
+	}
+	if category != nil {
+		data.CategoryID = sql.NullInt64{Int64: category.ID, Valid: true}
+	}
+
+	// Tolak transaksi yang sama persis dengan transaksi beberapa menit terakhir kecuali req.Force
+	if !req.Force {
+		duplicate, err := u.TransactionRepo.FindPossibleDuplicate(ctx, data, data.CreatedAt.Add(-duplicateTransactionWindow))
+		if err != nil {
+			helper.LogError(funcName, "TransactionRepo.FindPossibleDuplicate", err, logFields, "")
+			return err
+		}
+		if duplicate != nil {
+			return apperr.ErrConflict().SetDetail(fmt.Sprintf("A transaction with the same amount, type, category and date was just created (id %d). Send force=true to save it anyway.", duplicate.ID))
+		}
+	}
+
+	// Panggil repository untuk membuat record
+	err = u.TransactionRepo.Create(ctx, nil, data, false)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.Create", err, logFields, "")
+		return err
+	}
+
+	// Gagal publish hanya dicatat, transaksi sudah tersimpan sehingga request tetap berhasil
+	if err := u.Publisher.Publish(ctx, queue.ProcessTransactionCreated, transactionCreatedEvent(data)); err != nil {
+		helper.LogError(funcName, "Publisher.Publish", err, logFields, "Failed to publish transaction created event")
+	}
+	u.publishSpendingCapExceeded(ctx, data, category, logFields)
+
+	return nil
+}
+
+// publishSpendingCapExceeded mengirim event queue.EventSpendingCapExceeded jika expense data membuat total pengeluaran
+// bulan transaksi (mata uang dasar) melewati SpendingCap.MonthlyCap. Event hanya dikirim oleh transaksi yang membuat
+// total pertama kali melewati batas, bukan oleh setiap transaksi sesudahnya. Semua error hanya dicatat karena
+// transaksi sudah tersimpan.
+func (u *CrudTransaction) publishSpendingCapExceeded(ctx context.Context, data *myentity.Transaction, category *myentity.Category, logFields generalEntity.CaptureFields) {
+	funcName := "CrudTransaction.publishSpendingCapExceeded"
+
+	monthlyCap := u.SpendingCap.MonthlyCap
+	if monthlyCap <= 0 || data.Type != myentity.TransactionTypeExpense || data.Currency != u.CurrencyOption.Code {
+		return
+	}
+	// Kategori exclude_from_totals (misalnya transfer) tidak dihitung sebagai pengeluaran
+	if category != nil && category.ExcludeFromTotals {
+		return
+	}
+
+	date := data.TransactionDate
+	monthStart := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	monthEnd := monthStart.AddDate(0, 1, -1)
+	totals, err := u.TransactionRepo.GetTotalsByType(ctx, data.UserID, monthStart.Format(helper.DateLayout), monthEnd.Format(helper.DateLayout), false)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetTotalsByType", err, logFields, "Failed to check monthly spending cap")
+		return
+	}
+
+	var monthTotal float64
+	for _, total := range totals {
+		if total.Currency == u.CurrencyOption.Code && total.Type == myentity.TransactionTypeExpense {
+			monthTotal = total.TotalAmount
+		}
+	}
+	if monthTotal <= monthlyCap || monthTotal-data.Amount > monthlyCap {
+		return
+	}
+
+	event := map[string]interface{}{
+		"event_type":     queue.EventSpendingCapExceeded,
+		"user_id":        data.UserID,
+		"transaction_id": data.ID,
+		"month":          monthStart.Format("2006-01"),
+		"currency":       data.Currency,
+		"cap":            monthlyCap,
+		"total_expense":  monthTotal,
+	}
+	if err := u.Publisher.Publish(ctx, queue.ProcessTransactionCreated, event); err != nil {
+		helper.LogError(funcName, "Publisher.Publish", err, logFields, "Failed to publish spending cap event")
+	}
+}
+
+// transactionCreatedEvent adalah payload event queue.ProcessTransactionCreated.
+func transactionCreatedEvent(data *myentity.Transaction) map[string]interface{} {
+	var categoryID interface{}
+	if data.CategoryID.Valid {
+		categoryID = data.CategoryID.Int64
+	}
+
+	return map[string]interface{}{
+		"event_type":       queue.EventTransactionCreated,
+		"transaction_id":   data.ID,
+		"user_id":          data.UserID,
+		"category_id":      categoryID,
+		"amount":           data.Amount,
+		"currency":         data.Currency,
+		"type":             string(data.Type),
+		"transaction_date": data.TransactionDate.Format(helper.DateLayout),
+	}
+}
+
+// PrepareCreate memvalidasi req seperti Create dan mengembalikan transaksi yang siap disimpan tanpa menyimpannya.
+// category adalah kategori yang akan dipakai (boleh belum tersimpan), dipakai untuk validasi tipe saja,
+// sehingga CategoryID harus diisi sendiri oleh pemanggil setelah kategori tersimpan. req.CategoryID diabaikan.
+func (u *CrudTransaction) PrepareCreate(ctx context.Context, userID int64, req usecaseEntity.TransactionReq, category *myentity.Category) (*myentity.Transaction, error) {
+	funcName := "CrudTransaction.PrepareCreate"
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, nil, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+	}
+
+	return u.buildTransaction(ctx, funcName, userID, req, category, logFields)
+}
+
+// buildTransaction memvalidasi field transaksi baru (amount, type, currency, koordinat, metadata, tanggal, period lock)
+// dan kecocokan tipenya dengan category jika ada. CategoryID pada hasil selalu kosong.
+func (u *CrudTransaction) buildTransaction(ctx context.Context, funcName string, userID int64, req usecaseEntity.TransactionReq, category *myentity.Category, logFields generalEntity.CaptureFields) (*myentity.Transaction, error) {
+	if err := validateTransactionReq(req); err != nil {
+		helper.LogError(funcName, "validateTransactionReq", err, logFields, "Invalid transaction payload")
+		return nil, err
+	}
+	if req.Amount == nil {
+		return nil, apperr.ErrInvalidRequest().SetDetail("amount is required")
+	}
+	if req.Type == nil {
+		return nil, apperr.ErrInvalidRequest().SetDetail("type is required")
+	}
+	logFields["type"] = string(*req.Type)
+	logFields["amount"] = fmt.Sprintf("%.2f", *req.Amount)
+
+	currency, err := resolveCurrency(req.Currency, u.CurrencyOption.Code)
+	if err != nil {
+		helper.LogError(funcName, "resolveCurrency", err, logFields, "Invalid currency")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid currency: " + err.Error())
+	}
+
+	if err := u.validateAmountAndType(req.Amount, req.Type, currency); err != nil {
+		helper.LogError(funcName, "validateAmountAndType", err, logFields, "Invalid amount or type")
+		return nil, err
+	}
+
+	// Kolom description varchar(255), tolak lebih awal agar tidak gagal di database dengan error yang tidak jelas
+	if err := helper.ValidateDescription(req.Description); err != nil {
+		helper.LogError(funcName, "helper.ValidateDescription", err, logFields, "Invalid description")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid description: " + err.Error())
+	}
+	if err := helper.ValidateNotes(req.Notes); err != nil {
+		helper.LogError(funcName, "helper.ValidateNotes", err, logFields, "Invalid notes")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid notes: " + err.Error())
+	}
+
+	// Koordinat opsional, tapi jika diberikan harus lengkap dan dalam rentang yang valid
+	if err := helper.ValidateCoordinates(req.Latitude, req.Longitude); err != nil {
+		helper.LogError(funcName, "helper.ValidateCoordinates", err, logFields, "Invalid coordinates")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid coordinates: " + err.Error())
+	}
+
+	metadata, err := nullableMetadata(req.Metadata)
+	if err != nil {
+		helper.LogError(funcName, "nullableMetadata", err, logFields, "Invalid metadata")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid metadata: " + err.Error())
+	}
+
+	attachmentURL, err := nullableAttachmentURL(req.AttachmentURL)
+	if err != nil {
+		helper.LogError(funcName, "nullableAttachmentURL", err, logFields, "Invalid attachment_url")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid attachment_url: " + err.Error())
+	}
+
+	if category != nil && !categoryAllowsType(category, myentity.TransactionType(*req.Type)) {
+		return nil, errCategoryTypeMismatch()
+	}
+
+	// Parse TransactionDate
+	parsedDate, err := helper.ParseDateStrict(req.TransactionDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid Transaction Date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid transaction_date: " + err.Error())
+	}
+	if err := validateTransactionDateNotFuture(parsedDate); err != nil {
+		helper.LogError(funcName, "validateTransactionDateNotFuture", err, logFields, "Transaction date too far in the future")
+		return nil, err
+	}
+
+	// Tolak transaksi baru di periode yang sudah dikunci, kecuali override admin
+	if !req.OverridePeriodLock {
+		if err := u.PeriodLock.EnsureUnlocked(ctx, userID, parsedDate); err != nil {
+			return nil, err
+		}
+	}
+
+	return &myentity.Transaction{
+		UserID:          userID, // Diisi dari parameter yang aman
+		Amount:          *req.Amount,
+		Currency:        currency,
+		Type:            myentity.TransactionType(*req.Type), // Konversi ke tipe ENUM Go
+		Description:     nullableDescription(req.Description), // Handle nil pointer for description
+		Notes:           nullableNotes(req.Notes),
+		Latitude:        nullableCoordinate(req.Latitude),
+		Longitude:       nullableCoordinate(req.Longitude),
+		Metadata:        metadata,
+		AttachmentURL:   attachmentURL,
+		TransactionDate: parsedDate,
+		CreatedAt:       helper.DatetimeNowJakarta(), // Menggunakan helper
+		UpdatedAt:       helper.DatetimeNowJakarta(), // Menggunakan helper
+	}, nil
+}
+
+// GetByID mengambil satu transaksi milik user beserta nama kategorinya.
+// Mengembalikan ErrRecordNotFound (404) jika transaksi tidak ada atau milik user lain.
+func (u *CrudTransaction) GetByID(ctx context.Context, id int64, userID int64) (*usecaseEntity.TransactionResponse, error) {
+	funcName := "CrudTransaction.GetByID"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"id":      strconv.FormatInt(id, 10),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	responseFormat, err := u.responseFormat(ctx, userID, usecaseEntity.ResponseFormatReq{})
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := u.TransactionRepo.GetWithCategoryByIDAndUserID(ctx, id, userID)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetWithCategoryByIDAndUserID", err, logFields, "")
+		return nil, err
+	}
+
+	result := toTransactionResponse(row, responseFormat)
+	return &result, nil
+}
+
+// GetAll mengambil satu halaman transaksi user sesuai filter opsional (type, start_date, end_date, category_id)
+// dengan urutan transaction_date DESC, id DESC. Tanpa filter seluruh transaksi user dihitung.
+// Limit default defaultPerPage dan dibatasi maxPerPage. Cursor adalah next_cursor dari halaman sebelumnya, berisi posisi
+// transaksi terakhir (tanggal, created_at, amount, ID) sehingga tidak perlu membaca ulang transaksi tersebut.
+func (u *CrudTransaction) GetAll(ctx context.Context, userID int64, req usecaseEntity.TransactionCursorReq) (*usecaseEntity.TransactionCursorResponse, error) {
+	funcName := "CrudTransaction.GetAll"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"layer":   "usecase",
+		"cursor":  req.Cursor,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if req.Limit < 0 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("limit must be greater than 0")
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultPerPage
+	}
+	if req.Limit > maxPerPage {
+		req.Limit = maxPerPage
+	}
+	var after *mysql.TransactionCursor
+	if req.Cursor != "" {
+		cursor, err := mysql.DecodeTransactionCursor(req.Cursor)
+		if err != nil {
+			return nil, apperr.ErrInvalidRequest().SetDetail("Invalid cursor.")
+		}
+		after = cursor
+	}
+	if err := validateTransactionFilter(req.Type, req.StartDate, req.EndDate); err != nil {
+		return nil, err
+	}
+	if req.By != "" && !mysql.IsValidDateColumn(mysql.DateColumn(req.By)) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("by must be transaction_date or created_at")
+	}
+	if err := validateMetadataFilter(req.Metadata); err != nil {
+		return nil, err
+	}
+
+	responseFormat, err := u.responseFormat(ctx, userID, req.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ambil satu baris lebih dari limit untuk mengetahui apakah masih ada halaman berikutnya
+	filter := mysql.TransactionFilter{
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+		Type:       myentity.TransactionType(req.Type),
+		CategoryID: req.CategoryID,
+		Metadata:   req.Metadata,
+		DateColumn: mysql.DateColumn(req.By),
+		// Sort di luar allowlist diabaikan repository dan jatuh ke date_desc
+		Sort:           mysql.TransactionSort(req.Sort),
+		IncludeDeleted: req.IncludeDeleted,
+	}
+	data, err := u.TransactionRepo.GetAllByUserID(ctx, userID, filter, after, req.Limit+1)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetAllByUserID", err, logFields, "")
+		return nil, err
+	}
+
+	// Total memakai filter yang sama tanpa cursor sehingga sama di setiap halaman
+	total, err := u.TransactionRepo.CountByUserID(ctx, userID, filter)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.CountByUserID", err, logFields, "")
+		return nil, err
+	}
+
+	result := &usecaseEntity.TransactionCursorResponse{Data: []usecaseEntity.TransactionResponse{}, Total: total}
+	if len(data) > req.Limit {
+		data = data[:req.Limit]
+		last := data[len(data)-1]
+		nextCursor := mysql.TransactionCursor{TransactionDate: last.TransactionDate, CreatedAt: last.CreatedAt, Amount: last.Amount, ID: last.ID}.Encode()
+		result.NextCursor = &nextCursor
+	}
+
+	// Mapping ke response DTO
+	for _, row := range data {
+		result.Data = append(result.Data, toTransactionResponse(row, responseFormat))
+	}
+
+	return result, nil
+}
+
+// StreamAll mengirim semua transaksi user dalam rentang tanggal req satu per satu ke fn tanpa menampung seluruh hasil di memori.
+// Urutan, filter tanggal, dan representasi sama dengan GetAll, dipakai untuk export riwayat lengkap (JSON maupun CSV).
+func (u *CrudTransaction) StreamAll(ctx context.Context, userID int64, req usecaseEntity.TransactionExportReq, fn func(item usecaseEntity.TransactionResponse) error) error {
+	funcName := "CrudTransaction.StreamAll"
+	logFields := generalEntity.CaptureFields{
+		"user_id":    strconv.FormatInt(userID, 10),
+		"start_date": req.StartDate,
+		"end_date":   req.EndDate,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if err := validateTransactionFilter("", req.StartDate, req.EndDate); err != nil {
+		helper.LogError(funcName, "validateTransactionFilter", err, logFields, "Invalid date range")
+		return err
+	}
+
+	responseFormat, err := u.responseFormat(ctx, userID, req.Format)
+	if err != nil {
+		return err
+	}
+
+	filter := mysql.TransactionFilter{StartDate: req.StartDate, EndDate: req.EndDate}
+	err = u.TransactionRepo.StreamAllByUserID(ctx, userID, filter, func(row *mysql.TransactionWithCategory) error {
+		return fn(toTransactionResponse(row, responseFormat))
+	})
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.StreamAllByUserID", err, logFields, "")
+		return err
+	}
+
+	return nil
+}
+
+// SuggestCategory menyarankan kategori untuk description berdasarkan kategori yang paling sering dipakai
+// pada transaksi user dengan description serupa. Response kosong jika tidak ada riwayat yang cocok.
+func (u *CrudTransaction) SuggestCategory(ctx context.Context, userID int64, description string) (*usecaseEntity.CategorySuggestionResponse, error) {
+	funcName := "CrudTransaction.SuggestCategory"
+	logFields := generalEntity.CaptureFields{
+		"user_id":     strconv.FormatInt(userID, 10),
+		"description": description,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	description = strings.Join(strings.Fields(description), " ")
+	if description == "" {
+		return nil, apperr.ErrInvalidRequest().SetDetail("description is required")
+	}
+
+	usage, err := u.TransactionRepo.GetTopCategoryByDescription(ctx, userID, description)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetTopCategoryByDescription", err, logFields, "")
+		return nil, err
+	}
+
+	result := &usecaseEntity.CategorySuggestionResponse{}
+	if usage != nil {
+		result.CategoryID = &usage.CategoryID
+		result.CategoryName = &usage.CategoryName
+		result.UsageCount = usage.UsageCount
+	}
+
+	return result, nil
+}
+
+// GetYears mengambil tahun-tahun (menurun) yang memiliki transaksi milik user, untuk navigasi pemilih tahun.
+// Mengembalikan slice kosong (bukan nil) jika user belum memiliki transaksi.
+func (u *CrudTransaction) GetYears(ctx context.Context, userID int64) ([]int, error) {
+	funcName := "CrudTransaction.GetYears"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	years, err := u.TransactionRepo.GetYearsByUserID(ctx, userID)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetYearsByUserID", err, logFields, "")
+		return nil, err
+	}
+	if years == nil {
+		years = []int{}
+	}
+
+	return years, nil
+}
+
+// GetDateRange mengambil transaction_date paling awal dan paling akhir milik user, untuk default pemilih tanggal.
+// Earliest dan Latest nil (bukan tanggal kosong) jika user belum memiliki transaksi.
+func (u *CrudTransaction) GetDateRange(ctx context.Context, userID int64) (*usecaseEntity.DateRangeResponse, error) {
+	funcName := "CrudTransaction.GetDateRange"
 	logFields := generalEntity.CaptureFields{
 		"user_id": strconv.FormatInt(userID, 10),
-		"type":    string(req.Type),
-		"amount":  fmt.Sprintf("%.2f", req.Amount),
 	}
 
-	// Validasi CategoryID jika diberikan
-	var categoryID sql.NullInt64
-	if req.CategoryID != nil {
-		if *req.CategoryID > 0 {
-			// Periksa apakah category_id yang diberikan valid dan milik user yang sama
-			category, err := u.CategoryRepo.GetByID(ctx, *req.CategoryID)
-			if err != nil {
-				helper.LogError(funcName, "CategoryRepo.GetByID", err, logFields, "Error getting category for transaction")
-				return apperr.ErrInvalidRequest().SetDetail("Invalid Category ID provided.")
-			}
-			// Pastikan kategori yang dipilih milik user yang sedang login
-			if category.CreatedBy != userID {
-				helper.LogError(funcName, "CategoryRepo.GetByID", errors.New("unauthorized category access"), logFields, "User tried to use category not owned by them")
-				return apperr.ErrUnauthorized().SetDetail("You are not authorized to use this category.")
-			}
-			categoryID.Int64 = *req.CategoryID
-			categoryID.Valid = true
-		}
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	dateRange, err := u.TransactionRepo.GetDateRangeByUserID(ctx, userID)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetDateRangeByUserID", err, logFields, "")
+		return nil, err
+	}
+
+	result := &usecaseEntity.DateRangeResponse{}
+	if dateRange != nil && dateRange.Earliest.Valid && dateRange.Latest.Valid {
+		result.Earliest = &dateRange.Earliest.String
+		result.Latest = &dateRange.Latest.String
+	}
+
+	return result, nil
+}
+
+// validateTransactionFilter memvalidasi filter opsional type dan rentang tanggal (YYYY-MM-DD) daftar transaksi.
+func validateTransactionFilter(txType usecaseEntity.TransactionTypeString, startDate, endDate string) error {
+	if txType != "" && txType != usecaseEntity.TransactionTypeIncomeStr && txType != usecaseEntity.TransactionTypeExpenseStr {
+		return apperr.ErrInvalidRequest().SetDetail("type must be income or expense")
+	}
+	if startDate != "" {
+		if _, err := helper.ParseDateStrict(startDate); err != nil {
+			return apperr.ErrInvalidRequest().SetDetail("Invalid start_date: " + err.Error())
+		}
+	}
+	if endDate != "" {
+		if _, err := helper.ParseDateStrict(endDate); err != nil {
+			return apperr.ErrInvalidRequest().SetDetail("Invalid end_date: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// validateMetadataFilter memvalidasi key filter meta.<key> sebelum query dijalankan. Repository tetap menolak key
+// yang tidak valid, validasi di sini agar request ditolak sebelum ada query apa pun.
+func validateMetadataFilter(metadata map[string]string) error {
+	for key := range metadata {
+		if err := helper.ValidateMetadataKey(key); err != nil {
+			return apperr.ErrInvalidRequest().SetDetail("Invalid metadata filter: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// Batas jumlah item per halaman untuk List dan GetAll.
+const (
+	defaultPerPage = 20
+	maxPerPage     = 100
+)
+
+// List mengambil satu halaman transaksi user sesuai filter, beserta total seluruh transaksi yang cocok untuk meta pagination.
+func (u *CrudTransaction) List(ctx context.Context, userID int64, req usecaseEntity.TransactionListReq) (*usecaseEntity.TransactionListResponse, error) {
+	funcName := "CrudTransaction.List"
+	logFields := generalEntity.CaptureFields{
+		"user_id":  strconv.FormatInt(userID, 10),
+		"page":     strconv.Itoa(req.Page),
+		"per_page": strconv.Itoa(req.PerPage),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if req.Page < 1 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("page must be greater than 0")
+	}
+	if req.PerPage == 0 {
+		req.PerPage = defaultPerPage
+	}
+	if req.PerPage < 1 || req.PerPage > maxPerPage {
+		return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("per_page must be between 1 and %d", maxPerPage))
+	}
+	if err := validateTransactionFilter(req.Type, req.StartDate, req.EndDate); err != nil {
+		return nil, err
+	}
+	if req.By != "" && !mysql.IsValidDateColumn(mysql.DateColumn(req.By)) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("by must be transaction_date or created_at")
+	}
+	responseFormat, err := u.responseFormat(ctx, userID, req.Format)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMetadataFilter(req.Metadata); err != nil {
+		return nil, err
+	}
+
+	filter := mysql.TransactionFilter{
+		StartDate:      req.StartDate,
+		EndDate:        req.EndDate,
+		Type:           myentity.TransactionType(req.Type),
+		CategoryID:     req.CategoryID,
+		Metadata:       req.Metadata,
+		DateColumn:     mysql.DateColumn(req.By),
+		Sort:           mysql.TransactionSort(req.Sort),
+		IncludeDeleted: req.IncludeDeleted,
+	}
+
+	total, err := u.TransactionRepo.CountByUserID(ctx, userID, filter)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.CountByUserID", err, logFields, "")
+		return nil, err
+	}
+
+	data, err := u.TransactionRepo.ListByUserID(ctx, userID, filter, req.PerPage, (req.Page-1)*req.PerPage)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.ListByUserID", err, logFields, "")
+		return nil, err
+	}
+
+	result := &usecaseEntity.TransactionListResponse{
+		Data: make([]usecaseEntity.TransactionResponse, 0, len(data)),
+		Meta: generalEntity.PaginationMeta{
+			Page:       req.Page,
+			PerPage:    req.PerPage,
+			Total:      total,
+			TotalPages: int((total + int64(req.PerPage) - 1) / int64(req.PerPage)),
+			HasNext:    int64(req.Page*req.PerPage) < total,
+		},
+	}
+	for _, row := range data {
+		result.Data = append(result.Data, toTransactionResponse(row, responseFormat))
+	}
+
+	return result, nil
+}
+
+// validateAmountAndType memvalidasi amount (lebih dari 0, muat di decimal(15,2), dan sesuai skala mata uang transaksi)
+// dan type jika diberikan.
+func (u *CrudTransaction) validateAmountAndType(amount *float64, txType *usecaseEntity.TransactionTypeString, currency string) error {
+	if amount != nil {
+		if *amount <= 0 {
+			return apperr.ErrInvalidRequest().SetDetail("amount must be greater than 0")
+		}
+		// Amount harus muat di kolom decimal(15,2), dicek sebelum skala mata uang agar desimal ke-3 tidak dibulatkan diam-diam
+		if err := helper.ValidateStoredAmount(*amount); err != nil {
+			return apperr.ErrInvalidRequest().SetDetail("Invalid amount: " + err.Error())
+		}
+		// Validasi jumlah desimal amount sesuai mata uang
+		if err := helper.ValidateAmountScale(*amount, currency); err != nil {
+			return apperr.ErrInvalidRequest().SetDetail("Invalid amount: " + err.Error())
+		}
+	}
+	if txType != nil && *txType != usecaseEntity.TransactionTypeIncomeStr && *txType != usecaseEntity.TransactionTypeExpenseStr {
+		return apperr.ErrInvalidRequest().SetDetail("type must be income or expense")
+	}
+	return nil
+}
+
+// validateTransactionReq menjalankan tag validate pada req. Tag hanya memeriksa field yang diisi,
+// sehingga dipakai bersama oleh create dan partial update. Error tetap apperr.ErrInvalidRequest agar
+// pemanggil seperti import dan sync bisa menambahkan nomor baris ke detail.
+func validateTransactionReq(req usecaseEntity.TransactionReq) error {
+	failed := usecase.ValidateStructProcess(req)
+	if len(failed) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(failed))
+	for _, f := range failed {
+		messages = append(messages, f.Message)
+	}
+	return apperr.ErrInvalidRequest().SetDetail(strings.Join(messages, "; "))
+}
+
+// resolveCurrency mengembalikan mata uang transaksi: currency dari request jika diisi, selain itu mata uang dasar.
+func resolveCurrency(currency *string, baseCode string) (string, error) {
+	code := helper.BaseCurrency(baseCode)
+	if currency != nil && *currency != "" {
+		code = *currency
+	}
+	if err := helper.ValidateCurrencyCode(code); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// nullableDescription mengonversi description opsional ke sql.NullString tanpa dereference pointer nil.
+func nullableDescription(description *string) sql.NullString {
+	if description == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *description, Valid: true}
+}
+
+// nullableNotes mengonversi notes opsional ke sql.NullString. Nil atau string kosong disimpan sebagai NULL.
+func nullableNotes(notes *string) sql.NullString {
+	if notes == nil || *notes == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *notes, Valid: true}
+}
+
+// nullableCoordinate mengonversi koordinat opsional ke sql.NullFloat64.
+func nullableCoordinate(value *float64) sql.NullFloat64 {
+	if value == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *value, Valid: true}
+}
+
+// nullableAttachmentURL memvalidasi URL lampiran opsional. Nil atau string kosong disimpan sebagai NULL.
+func nullableAttachmentURL(value *string) (sql.NullString, error) {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return sql.NullString{}, nil
+	}
+	attachmentURL := strings.TrimSpace(*value)
+	if err := helper.ValidateAttachmentURL(attachmentURL); err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: attachmentURL, Valid: true}, nil
+}
+
+// nullableMetadata memvalidasi metadata dan meng-encode-nya ke JSON object.
+// Map nil atau kosong disimpan sebagai NULL.
+func nullableMetadata(metadata map[string]string) (sql.NullString, error) {
+	if len(metadata) == 0 {
+		return sql.NullString{}, nil
+	}
+	if err := helper.ValidateMetadata(metadata); err != nil {
+		return sql.NullString{}, err
+	}
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(encoded), Valid: true}, nil
+}
+
+// responseFormat adalah opsi representasi response yang sudah divalidasi dan digabung dengan default konfigurasi.
+type responseFormat struct {
+	nullAsEmpty bool           // field NULL dipetakan ke string kosong
+	dateLayout  string         // layout Go dari allowlist helper.DateFormat
+	location    *time.Location // zona waktu user untuk created_at dan updated_at
+}
+
+// responseFormat memvalidasi opsi representasi per request. null_as_empty memakai override per request jika ada,
+// selain itu mengikuti default di ResponseOption. date_format harus ada di allowlist helper.DateFormat.
+// Zona waktu diambil dari pengaturan user, zona kosong atau tidak valid jatuh ke Asia/Jakarta.
+func (u *CrudTransaction) responseFormat(ctx context.Context, userID int64, req usecaseEntity.ResponseFormatReq) (responseFormat, error) {
+	result := responseFormat{nullAsEmpty: u.ResponseOption.NullAsEmpty}
+	if req.NullAsEmpty != nil {
+		result.nullAsEmpty = *req.NullAsEmpty
+	}
+
+	layout, ok := helper.DateFormatLayout(helper.DateFormat(req.DateFormat))
+	if !ok {
+		return responseFormat{}, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Invalid date_format. Use %s, %s, %s, or %s.",
+			helper.DateFormatISO, helper.DateFormatDMYSlash, helper.DateFormatMDYSlash, helper.DateFormatDMYDash))
+	}
+	result.dateLayout = layout
+
+	location, err := u.UserTimezone.Location(ctx, userID)
+	if err != nil {
+		helper.LogError("CrudTransaction.responseFormat", "UserTimezone.Location", err, generalEntity.CaptureFields{"user_id": strconv.FormatInt(userID, 10)}, "")
+		return responseFormat{}, err
+	}
+	result.location = location
+
+	return result, nil
+}
+
+// toTransactionResponse memetakan baris transaksi (beserta nama kategori) ke response DTO.
+// Jika format.nullAsEmpty true, description dan category_name yang NULL dikembalikan sebagai string kosong, bukan null.
+// Tanggal diformat dengan format.dateLayout, created_at dan updated_at dalam zona waktu user (format.location).
+func toTransactionResponse(row *mysql.TransactionWithCategory, format responseFormat) usecaseEntity.TransactionResponse {
+	// Konversi sql.NullInt64/NullString ke pointer atau nilai default
+	var categoryID *int64
+	if row.CategoryID.Valid {
+		categoryID = &row.CategoryID.Int64
+	}
+	var description *string
+	if row.Description.Valid {
+		description = &row.Description.String
+	}
+	var notes *string
+	if row.Notes.Valid {
+		notes = &row.Notes.String
+	}
+	var categoryName *string // Handle CategoryName dari TransactionWithCategory
+	if row.CategoryName.Valid {
+		categoryName = &row.CategoryName.String
+	}
+	var latitude, longitude *float64
+	if row.Latitude.Valid && row.Longitude.Valid {
+		latitude = &row.Latitude.Float64
+		longitude = &row.Longitude.Float64
+	}
+	var metadata map[string]string
+	if row.Metadata.Valid {
+		// Metadata selalu ditulis lewat nullableMetadata, value yang gagal di-decode dikembalikan sebagai null
+		_ = json.Unmarshal([]byte(row.Metadata.String), &metadata)
+	}
+	var attachmentURL *string
+	if row.AttachmentURL.Valid {
+		attachmentURL = &row.AttachmentURL.String
+	}
+	var deletedAt *string
+	if row.DeletedAt.Valid {
+		formatted := helper.FormatDatetimeIn(row.DeletedAt.Time, format.location, format.dateLayout)
+		deletedAt = &formatted
+	}
+	if format.nullAsEmpty {
+		empty := ""
+		if description == nil {
+			description = &empty
+		}
+		if categoryName == nil {
+			categoryName = &empty
+		}
+	}
+
+	return usecaseEntity.TransactionResponse{
+		ID:              row.ID,
+		UserID:          row.UserID,
+		CategoryID:      categoryID,
+		CategoryName:    categoryName,
+		Amount:          row.Amount,
+		Currency:        row.Currency,
+		Type:            usecaseEntity.TransactionTypeString(row.Type),
+		Description:     description,
+		Notes:           notes,
+		Latitude:        latitude,
+		Longitude:       longitude,
+		Metadata:        metadata,
+		AttachmentURL:   attachmentURL,
+		TransactionDate: row.TransactionDate.Format(format.dateLayout),
+		CreatedAt:       helper.FormatDatetimeIn(row.CreatedAt, format.location, format.dateLayout),
+		UpdatedAt:       helper.FormatDatetimeIn(row.UpdatedAt, format.location, format.dateLayout),
+		DeletedAt:       deletedAt,
+		Version:         row.Version,
+	}
+}
+
+// Update memperbarui transaksi berdasarkan ID dan memastikan milik user yang benar.
+// Jika req.Version diisi, update ditolak dengan ErrConflict saat transaksi sudah diubah request lain sejak
+// version tersebut dibaca. Tanpa req.Version, version yang dibaca di awal update tetap dipakai sebagai acuan.
+func (u *CrudTransaction) Update(ctx context.Context, id int64, userID int64, req usecaseEntity.TransactionReq) error {
+	funcName := "CrudTransaction.Update"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"id":      fmt.Sprintf("%d", id),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if err := validateTransactionReq(req); err != nil {
+		helper.LogError(funcName, "validateTransactionReq", err, logFields, "Invalid transaction payload for update")
+		return err
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return err
+	}
+
+	// 1. Ambil data lama dari database (melibatkan otorisasi user_id)
+	oldData, err := u.TransactionRepo.GetByIDAndUserID(ctx, id, userID)
+	if err != nil {
+		helper.LogError(funcName, "GetByIDAndUserID", err, logFields, "Error getting existing transaction for update")
+		return err // Error akan berupa ErrRecordNotFound atau error lain dari repo
+	}
+
+	// Hanya field yang ada di body yang diubah, sisanya tetap seperti oldData
+	updated := *oldData
+	if req.Version != nil {
+		if *req.Version < 1 {
+			return apperr.ErrInvalidRequest().SetDetail("version must be a positive number")
+		}
+		updated.Version = *req.Version
+	}
+
+	amount := req.Amount
+	if req.Currency != nil {
+		if err := helper.ValidateCurrencyCode(*req.Currency); err != nil {
+			helper.LogError(funcName, "helper.ValidateCurrencyCode", err, logFields, "Invalid currency for update")
+			return apperr.ErrInvalidRequest().SetDetail("Invalid currency: " + err.Error())
+		}
+		updated.Currency = *req.Currency
+		// Amount lama tetap harus sesuai skala mata uang yang baru
+		if amount == nil {
+			amount = &oldData.Amount
+		}
+	}
+
+	if err := u.validateAmountAndType(amount, req.Type, updated.Currency); err != nil {
+		helper.LogError(funcName, "validateAmountAndType", err, logFields, "Invalid amount or type for update")
+		return err
+	}
+	if req.Amount != nil {
+		updated.Amount = *req.Amount
+	}
+	if req.Type != nil {
+		updated.Type = myentity.TransactionType(*req.Type)
+	}
+	if err := helper.ValidateDescription(req.Description); err != nil {
+		helper.LogError(funcName, "helper.ValidateDescription", err, logFields, "Invalid description for update")
+		return apperr.ErrInvalidRequest().SetDetail("Invalid description: " + err.Error())
+	}
+	if req.Description != nil {
+		updated.Description = nullableDescription(req.Description)
+	}
+
+	// String kosong menghapus catatan, nil berarti tidak diubah
+	if err := helper.ValidateNotes(req.Notes); err != nil {
+		helper.LogError(funcName, "helper.ValidateNotes", err, logFields, "Invalid notes for update")
+		return apperr.ErrInvalidRequest().SetDetail("Invalid notes: " + err.Error())
+	}
+	if req.Notes != nil {
+		updated.Notes = nullableNotes(req.Notes)
+	}
+
+	if err := helper.ValidateCoordinates(req.Latitude, req.Longitude); err != nil {
+		helper.LogError(funcName, "helper.ValidateCoordinates", err, logFields, "Invalid coordinates for update")
+		return apperr.ErrInvalidRequest().SetDetail("Invalid coordinates: " + err.Error())
+	}
+	if req.Latitude != nil {
+		updated.Latitude = nullableCoordinate(req.Latitude)
+		updated.Longitude = nullableCoordinate(req.Longitude)
+	}
+
+	// Map kosong menghapus metadata, nil berarti tidak diubah
+	if req.Metadata != nil {
+		metadata, err := nullableMetadata(req.Metadata)
+		if err != nil {
+			helper.LogError(funcName, "nullableMetadata", err, logFields, "Invalid metadata for update")
+			return apperr.ErrInvalidRequest().SetDetail("Invalid metadata: " + err.Error())
+		}
+		updated.Metadata = metadata
+	}
+
+	// String kosong menghapus lampiran, nil berarti tidak diubah
+	if req.AttachmentURL != nil {
+		updated.AttachmentURL, err = nullableAttachmentURL(req.AttachmentURL)
+		if err != nil {
+			helper.LogError(funcName, "nullableAttachmentURL", err, logFields, "Invalid attachment_url for update")
+			return apperr.ErrInvalidRequest().SetDetail("Invalid attachment_url: " + err.Error())
+		}
+	}
+
+	// 2. Validasi CategoryID jika diubah, category_id 0 menghapus kategori
+	var category *myentity.Category
+	if req.CategoryID != nil {
+		var newCategoryID sql.NullInt64
+		if *req.CategoryID > 0 {
+			category, err = u.CategoryRepo.GetByID(ctx, *req.CategoryID)
+			if err != nil {
+				helper.LogError(funcName, "CategoryRepo.GetByID", err, logFields, "Invalid Category ID provided for update.")
+				return apperr.ErrInvalidRequest().SetDetail("Invalid Category ID provided for update.")
+			}
+			if category.CreatedBy != userID {
+				helper.LogError(funcName, "CategoryRepo.GetByID", errors.New("unauthorized category access"), logFields, "User tried to use category not owned by them for update")
+				return apperr.ErrForbidden().SetDetail("You are not authorized to use this category for update.")
+			}
+			newCategoryID.Int64 = *req.CategoryID
+			newCategoryID.Valid = true
+		}
+		updated.CategoryID = newCategoryID
+	}
+
+	// Tipe yang diubah tetap harus cocok dengan kategori lama jika kategorinya tidak ikut diganti
+	if category == nil && req.Type != nil && updated.CategoryID.Valid {
+		category, err = u.CategoryRepo.GetByID(ctx, updated.CategoryID.Int64)
+		if err != nil {
+			helper.LogError(funcName, "CategoryRepo.GetByID", err, logFields, "Error getting current category for update")
+			return err
+		}
+	}
+	if category != nil && !categoryAllowsType(category, updated.Type) {
+		return errCategoryTypeMismatch()
+	}
+
+	// Parse TransactionDate jika diubah, jika tidak pertahankan yang lama dari oldData
+	if req.TransactionDate != "" {
+		updated.TransactionDate, err = helper.ParseDateStrict(req.TransactionDate)
+		if err != nil {
+			helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid Transaction Date for update")
+			return apperr.ErrInvalidRequest().SetDetail("Invalid transaction_date: " + err.Error())
+		}
+		if err := validateTransactionDateNotFuture(updated.TransactionDate); err != nil {
+			helper.LogError(funcName, "validateTransactionDateNotFuture", err, logFields, "Transaction date too far in the future for update")
+			return err
+		}
+	}
+
+	// Transaksi tidak boleh diubah dari maupun dipindah ke periode yang terkunci, kecuali override admin
+	if !req.OverridePeriodLock {
+		if err := u.PeriodLock.EnsureUnlocked(ctx, userID, oldData.TransactionDate); err != nil {
+			return err
+		}
+		if err := u.PeriodLock.EnsureUnlocked(ctx, userID, updated.TransactionDate); err != nil {
+			return err
+		}
+	}
+
+	// Update seluruh kolom agar category_id yang dikosongkan ikut tersimpan sebagai NULL
+	updated.UpdatedAt = helper.DatetimeNowJakarta()
+	err = u.TransactionRepo.Update(ctx, nil, &updated, nil)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.Update", err, logFields, "")
+		return err
+	}
+
+	return nil
+}
+
+// maxFutureTransactionDays adalah jumlah hari setelah hari ini (WIB) yang masih boleh dipakai sebagai transaction_date.
+const maxFutureTransactionDays = 1
+
+// validateTransactionDateNotFuture menolak transaction_date yang lebih dari maxFutureTransactionDays setelah hari ini,
+// misalnya salah ketik tahun 2202 yang akan mendominasi semua ringkasan.
+func validateTransactionDateNotFuture(date time.Time) error {
+	today, _ := helper.ParseDate(helper.DateNowJakarta())
+	limit := today.AddDate(0, 0, maxFutureTransactionDays)
+	if date.After(limit) {
+		return apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("transaction_date cannot be later than %s", limit.Format(helper.DateLayout)))
+	}
+
+	return nil
+}
+
+// categoryAllowsType memeriksa apakah transaksi bertipe txType boleh memakai kategori tersebut.
+// Kategori lama tanpa tipe boleh dipakai untuk income maupun expense.
+func categoryAllowsType(category *myentity.Category, txType myentity.TransactionType) bool {
+	return category.Type == "" || category.Type == txType
+}
+
+func errCategoryTypeMismatch() error {
+	return apperr.ErrInvalidRequest().SetDetail("Transaction type does not match the category type.")
+}
+
+// Delete menghapus (soft delete) transaksi berdasarkan ID dan memastikan milik user yang benar.
+// Transaksi yang dihapus tidak lagi muncul di daftar maupun ringkasan dan bisa dikembalikan lewat Restore.
+// overridePeriodLock mengizinkan penghapusan di periode terkunci dan hanya boleh diisi untuk admin.
+func (u *CrudTransaction) Delete(ctx context.Context, id int64, userID int64, overridePeriodLock bool) error {
+	funcName := "CrudTransaction.Delete"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"id":      fmt.Sprintf("%d", id),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return err
+	}
+
+	// Validasi apakah data dengan ID tersebut ada dan milik user yang benar
+	// Menggunakan GetByIDAndUserID untuk memastikan otorisasi di lapisan usecase
+	existing, err := u.TransactionRepo.GetByIDAndUserID(ctx, id, userID)
+	if err != nil {
+		helper.LogError(funcName, "GetByIDAndUserID", err, logFields, "Error getting transaction for delete (authorization check)")
+		return err // Error akan berupa ErrRecordNotFound atau error lain dari repo
+	}
+
+	if !overridePeriodLock {
+		if err := u.PeriodLock.EnsureUnlocked(ctx, userID, existing.TransactionDate); err != nil {
+			return err
+		}
+	}
+
+	// Lakukan delete (repository sudah memfilter berdasarkan user_id)
+	err = u.TransactionRepo.DeleteByIDAndUserID(ctx, nil, id, userID)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.DeleteByIDAndUserID", err, logFields, "")
+		return err
+	}
+
+	return nil
+}
+
+// Restore mengembalikan transaksi milik user yang sudah di-soft delete.
+// Transaksi yang tidak ada atau belum dihapus menghasilkan ErrRecordNotFound, periode terkunci tetap ditolak.
+func (u *CrudTransaction) Restore(ctx context.Context, id int64, userID int64) error {
+	funcName := "CrudTransaction.Restore"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"id":      fmt.Sprintf("%d", id),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return err
 	}
 
-	// Parse TransactionDate
-	parsedDate, err := time.Parse("2006-01-02", req.TransactionDate)
+	deleted, err := u.TransactionRepo.GetDeletedByIDAndUserID(ctx, id, userID)
 	if err != nil {
-		helper.LogError(funcName, "time.Parse", err, logFields, "Invalid Transaction Date format")
-		return apperr.ErrInvalidRequest().SetDetail("Invalid transaction_date format. Use YYYY-MM-DD.")
+		helper.LogError(funcName, "GetDeletedByIDAndUserID", err, logFields, "Error getting deleted transaction for restore")
+		return err
 	}
 
-	data := &myentity.Transaction{
-		UserID:          userID, // Diisi dari parameter yang aman
-		CategoryID:      categoryID,
-		Amount:          req.Amount,
-		Type:            myentity.TransactionType(req.Type), // Konversi ke tipe ENUM Go
-		Description:     sql.NullString{String: *req.Description, Valid: req.Description != nil}, // Handle nil pointer for description
-		TransactionDate: parsedDate,
-		CreatedAt:       helper.DatetimeNowJakarta(), // Menggunakan helper
-		UpdatedAt:       helper.DatetimeNowJakarta(), // Menggunakan helper
+	if err := u.PeriodLock.EnsureUnlocked(ctx, userID, deleted.TransactionDate); err != nil {
+		return err
 	}
 
-	// Panggil repository untuk membuat record
-	err = u.TransactionRepo.Create(ctx, nil, data, false)
+	err = u.TransactionRepo.RestoreByIDAndUserID(ctx, nil, id, userID)
 	if err != nil {
-		helper.LogError(funcName, "TransactionRepo.Create", err, logFields, "")
+		helper.LogError(funcName, "TransactionRepo.RestoreByIDAndUserID", err, logFields, "")
 		return err
 	}
 
 	return nil
 }
 
-// GetAll mengambil semua transaksi untuk user tertentu.
-func (u *CrudTransaction) GetAll(ctx context.Context, userID int64) ([]usecaseEntity.TransactionResponse, error) {
-	funcName := "CrudTransaction.GetAll"
+// GetMonthlySummary mengambil total amount per bulan, mata uang, dan tipe transaksi untuk satu tahun, urut bulan, mata uang, lalu tipe.
+// Bulan (atau tipe dalam satu bulan) tanpa transaksi tidak dikembalikan, frontend perlu mengisi nol sendiri.
+// Transaksi pada kategori exclude_from_totals tidak dihitung kecuali includeAll true.
+func (u *CrudTransaction) GetMonthlySummary(ctx context.Context, userID int64, year int, includeAll bool) ([]usecaseEntity.MonthlySummaryRow, error) {
+	funcName := "CrudTransaction.GetMonthlySummary"
 	logFields := generalEntity.CaptureFields{
-		"user_id": strconv.FormatInt(userID, 10),
-		"layer":   "usecase",
+		"user_id":     strconv.FormatInt(userID, 10),
+		"year":        strconv.Itoa(year),
+		"include_all": strconv.FormatBool(includeAll),
 	}
 
 	if userID == 0 {
@@ -123,159 +1235,268 @@ func (u *CrudTransaction) GetAll(ctx context.Context, userID int64) ([]usecaseEn
 		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
 	}
 
-	// Ambil data dari repository, yang sekarang mengembalikan TransactionWithCategory
-	data, err := u.TransactionRepo.GetAllByUserID(ctx, userID) // Ini akan mengembalikan []*mysql.TransactionWithCategory
+	if year < 1900 || year > 9999 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid year.")
+	}
+
+	startDate := fmt.Sprintf("%04d-01-01", year)
+	endDate := fmt.Sprintf("%04d-12-31", year)
+
+	data, err := u.TransactionRepo.GetMonthlySummaryByUserID(ctx, userID, startDate, endDate, includeAll)
 	if err != nil {
-		helper.LogError(funcName, "TransactionRepo.GetAllByUserID", err, logFields, "")
+		helper.LogError(funcName, "TransactionRepo.GetMonthlySummaryByUserID", err, logFields, "")
 		return nil, err
 	}
 
-	// Mapping ke response DTO
-	var result []usecaseEntity.TransactionResponse
-	for _, row := range data { // `row` sekarang adalah *mysql.TransactionWithCategory
-		// Konversi sql.NullInt64/NullString ke pointer atau nilai default
-		var categoryID *int64
-		if row.CategoryID.Valid {
-			categoryID = &row.CategoryID.Int64
-		}
-		var description *string
-		if row.Description.Valid {
-			description = &row.Description.String
-		}
-		var categoryName *string // Handle CategoryName dari TransactionWithCategory
-		if row.CategoryName.Valid {
-			categoryName = &row.CategoryName.String
-		}
-
-		result = append(result, usecaseEntity.TransactionResponse{
-			ID:              row.ID,
-			UserID:          row.UserID,
-			CategoryID:      categoryID,
-			CategoryName:    categoryName, // MAP FIELD BARU INI
-			Amount:          row.Amount,
-			Type:            usecaseEntity.TransactionTypeString(row.Type),
-			Description:     description,
-			TransactionDate: row.TransactionDate.Format("2006-01-02"),       // Format ke YYYY-MM-DD
-			CreatedAt:       helper.ConvertToJakartaTime(row.CreatedAt), // Menggunakan helper
-			UpdatedAt:       helper.ConvertToJakartaTime(row.UpdatedAt), // Menggunakan helper
+	result := make([]usecaseEntity.MonthlySummaryRow, 0, len(data))
+	for _, row := range data {
+		result = append(result, usecaseEntity.MonthlySummaryRow{
+			Month:       row.Month,
+			Currency:    row.Currency,
+			Type:        usecaseEntity.TransactionTypeString(row.Type),
+			TotalAmount: row.TotalAmount,
 		})
 	}
 
 	return result, nil
 }
 
-// Update memperbarui transaksi berdasarkan ID dan memastikan milik user yang benar.
-func (u *CrudTransaction) Update(ctx context.Context, id int64, userID int64, req usecaseEntity.TransactionReq) error {
-	funcName := "CrudTransaction.Update"
+// minReportYear adalah tahun paling awal yang diterima laporan tahunan.
+const minReportYear = 1970
+
+// GetYearlyReport menghitung total income dan expense per bulan dalam satu tahun beserta total tahunan.
+// Bulan tanpa transaksi tetap ada dengan nilai 0 sehingga response selalu berisi 12 bulan.
+// Hanya transaksi dalam mata uang dasar yang dihitung, kategori exclude_from_totals tidak ikut.
+func (u *CrudTransaction) GetYearlyReport(ctx context.Context, userID int64, year int) (*usecaseEntity.YearlyReportResponse, error) {
+	funcName := "CrudTransaction.GetYearlyReport"
 	logFields := generalEntity.CaptureFields{
 		"user_id": strconv.FormatInt(userID, 10),
-		"id":      fmt.Sprintf("%d", id),
+		"year":    strconv.Itoa(year),
 	}
 
 	if userID == 0 {
 		err := errors.New("user ID tidak ditemukan di konteks request")
 		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
-		return apperr.ErrInvalidRequest().SetDetail("User ID is required")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
 	}
 
-	// 1. Ambil data lama dari database (melibatkan otorisasi user_id)
-	oldData, err := u.TransactionRepo.GetByIDAndUserID(ctx, id, userID)
+	maxYear := helper.DatetimeNowJakarta().Year() + 1
+	if year < minReportYear || year > maxYear {
+		return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("year must be between %d and %d", minReportYear, maxYear))
+	}
+
+	data, err := u.TransactionRepo.GetMonthlySummaryByUserID(ctx, userID, fmt.Sprintf("%04d-01-01", year), fmt.Sprintf("%04d-12-31", year), false)
 	if err != nil {
-		helper.LogError(funcName, "GetByIDAndUserID", err, logFields, "Error getting existing transaction for update")
-		return err // Error akan berupa ErrRecordNotFound atau error lain dari repo
+		helper.LogError(funcName, "TransactionRepo.GetMonthlySummaryByUserID", err, logFields, "")
+		return nil, err
 	}
 
-	// 2. Validasi CategoryID jika diubah
-	var newCategoryID sql.NullInt64
-	if req.CategoryID != nil {
-		if *req.CategoryID > 0 {
-			category, err := u.CategoryRepo.GetByID(ctx, *req.CategoryID)
-			if err != nil {
-				helper.LogError(funcName, "CategoryRepo.GetByID", err, logFields, "Invalid Category ID provided for update.")
-				return apperr.ErrInvalidRequest().SetDetail("Invalid Category ID provided for update.")
-			}
-			if category.CreatedBy != userID {
-				helper.LogError(funcName, "CategoryRepo.GetByID", errors.New("unauthorized category access"), logFields, "User tried to use category not owned by them for update")
-				return apperr.ErrUnauthorized().SetDetail("You are not authorized to use this category for update.")
-			}
-			newCategoryID.Int64 = *req.CategoryID
-			newCategoryID.Valid = true
+	currency := helper.BaseCurrency(u.CurrencyOption.Code)
+	result := &usecaseEntity.YearlyReportResponse{Year: year, Currency: currency, Months: make([]usecaseEntity.YearlyReportMonth, 12)}
+	for i := range result.Months {
+		result.Months[i].Month = fmt.Sprintf("%04d-%02d", year, i+1)
+	}
+
+	for _, row := range data {
+		if row.Currency != currency {
+			continue
+		}
+		month, err := time.Parse(helper.MonthLayout, row.Month)
+		if err != nil || month.Year() != year {
+			continue
+		}
+
+		item := &result.Months[month.Month()-1]
+		switch row.Type {
+		case myentity.TransactionTypeIncome:
+			item.Income += row.TotalAmount
+			result.TotalIncome += row.TotalAmount
+		case myentity.TransactionTypeExpense:
+			item.Expense += row.TotalAmount
+			result.TotalExpense += row.TotalAmount
 		}
-	} else { // Jika CategoryID di request adalah nil, set menjadi NULL di DB
-		newCategoryID.Valid = false
 	}
 
+	// Pembulatan 2 desimal agar penjumlahan float tidak menghasilkan ekor seperti 0.30000000000000004
+	for i := range result.Months {
+		item := &result.Months[i]
+		item.Income = math.Round(item.Income*100) / 100
+		item.Expense = math.Round(item.Expense*100) / 100
+		item.Net = math.Round((item.Income-item.Expense)*100) / 100
+	}
+	result.TotalIncome = math.Round(result.TotalIncome*100) / 100
+	result.TotalExpense = math.Round(result.TotalExpense*100) / 100
+	result.Net = math.Round((result.TotalIncome-result.TotalExpense)*100) / 100
 
-	// Parse TransactionDate jika diubah
-	var parsedDate time.Time
-	if req.TransactionDate != "" {
-		parsedDate, err = time.Parse("2006-01-02", req.TransactionDate)
-		if err != nil {
-			helper.LogError(funcName, "time.Parse", err, logFields, "Invalid Transaction Date format for update")
-			return apperr.ErrInvalidRequest().SetDetail("Invalid transaction_date format. Use YYYY-MM-DD.")
-		}
-	} else {
-        // Jika transaction_date tidak diubah, pertahankan yang lama dari oldData
-        parsedDate = oldData.TransactionDate
-    }
-
-	// Siapkan perubahan data (hanya field yang diubah)
-	changes := &myentity.Transaction{
-		// ID dan UserID jangan diubah di sini, tapi di GORM Update call akan difilter berdasarkan oldData
-		Amount:          req.Amount,
-		Type:            myentity.TransactionType(req.Type),
-		TransactionDate: parsedDate,
-		UpdatedAt:       helper.DatetimeNowJakarta(), // Menggunakan helper
-		// Handle Description dan CategoryID menggunakan sql.NullXXX
-		Description:     sql.NullString{String: *req.Description, Valid: req.Description != nil},
-		CategoryID:      newCategoryID,
+	return result, nil
+}
+
+// GetBalance menghitung total income, total expense, dan net per mata uang dalam rentang tanggal, urut kode mata uang.
+// Amount dengan mata uang berbeda tidak dijumlahkan. Periode tanpa transaksi menghasilkan nol dalam mata uang dasar.
+// Transaksi pada kategori exclude_from_totals tidak dihitung kecuali includeAll true.
+func (u *CrudTransaction) GetBalance(ctx context.Context, userID int64, startDate, endDate string, includeAll bool) ([]usecaseEntity.BalanceResponse, error) {
+	funcName := "CrudTransaction.GetBalance"
+	logFields := generalEntity.CaptureFields{
+		"user_id":     strconv.FormatInt(userID, 10),
+		"start_date":  startDate,
+		"end_date":    endDate,
+		"include_all": strconv.FormatBool(includeAll),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Validasi tanggal
+	if _, err := helper.ParseDateStrict(startDate); err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid start_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid start_date: " + err.Error())
+	}
+	if _, err := helper.ParseDateStrict(endDate); err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid end_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid end_date: " + err.Error())
 	}
 
-	// Panggil repository untuk update (oldData digunakan GORM untuk WHERE, changes adalah nilai baru)
-	err = u.TransactionRepo.Update(ctx, nil, oldData, changes) // oldData untuk menemukan record, changes untuk data yang diubah
+	totals, err := u.TransactionRepo.GetTotalsByType(ctx, userID, startDate, endDate, includeAll)
 	if err != nil {
-		helper.LogError(funcName, "TransactionRepo.Update", err, logFields, "")
-		return err
+		helper.LogError(funcName, "TransactionRepo.GetTotalsByType", err, logFields, "")
+		return nil, err
 	}
 
-	return nil
+	// Baris repository sudah urut mata uang lalu tipe
+	result := []usecaseEntity.BalanceResponse{}
+	index := make(map[string]int)
+	for _, row := range totals {
+		i, ok := index[row.Currency]
+		if !ok {
+			i = len(result)
+			index[row.Currency] = i
+			result = append(result, usecaseEntity.BalanceResponse{Currency: row.Currency})
+		}
+		switch row.Type {
+		case myentity.TransactionTypeIncome:
+			result[i].TotalIncome = row.TotalAmount
+		case myentity.TransactionTypeExpense:
+			result[i].TotalExpense = row.TotalAmount
+		}
+	}
+	if len(result) == 0 {
+		result = append(result, usecaseEntity.BalanceResponse{Currency: helper.BaseCurrency(u.CurrencyOption.Code)})
+	}
+	for i := range result {
+		result[i].Net = math.Round((result[i].TotalIncome-result[i].TotalExpense)*100) / 100
+	}
+
+	return result, nil
 }
 
-// Delete menghapus transaksi berdasarkan ID dan memastikan milik user yang benar.
-func (u *CrudTransaction) Delete(ctx context.Context, id int64, userID int64) error {
-	funcName := "CrudTransaction.Delete"
+// GetBalanceTimeline menghitung income, expense, dan saldo berjalan per hari dalam rentang tanggal.
+// Saldo berjalan dimulai dari 0 pada start_date dan dijumlahkan di Go dari total harian yang sudah urut tanggal,
+// sehingga tidak bergantung pada window function database. Hari tanpa transaksi yang berada di antara hari aktif
+// tetap muncul dengan nilai 0. Hanya transaksi dalam mata uang dasar yang dihitung, kategori exclude_from_totals tidak ikut.
+func (u *CrudTransaction) GetBalanceTimeline(ctx context.Context, userID int64, startDate, endDate string) (*usecaseEntity.BalanceTimelineResponse, error) {
+	funcName := "CrudTransaction.GetBalanceTimeline"
 	logFields := generalEntity.CaptureFields{
-		"user_id": strconv.FormatInt(userID, 10),
-		"id":      fmt.Sprintf("%d", id),
+		"user_id":    strconv.FormatInt(userID, 10),
+		"start_date": startDate,
+		"end_date":   endDate,
 	}
 
 	if userID == 0 {
 		err := errors.New("user ID tidak ditemukan di konteks request")
 		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
-		return apperr.ErrInvalidRequest().SetDetail("User ID is required")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
 	}
 
-	// Validasi apakah data dengan ID tersebut ada dan milik user yang benar
-	// Menggunakan GetByIDAndUserID untuk memastikan otorisasi di lapisan usecase
-	_, err := u.TransactionRepo.GetByIDAndUserID(ctx, id, userID)
+	// Validasi tanggal
+	start, err := helper.ParseDateStrict(startDate)
 	if err != nil {
-		helper.LogError(funcName, "GetByIDAndUserID", err, logFields, "Error getting transaction for delete (authorization check)")
-		return err // Error akan berupa ErrRecordNotFound atau error lain dari repo
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid start_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid start_date: " + err.Error())
+	}
+	end, err := helper.ParseDateStrict(endDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid end_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid end_date: " + err.Error())
+	}
+	if end.Before(start) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("end_date must not be before start_date")
 	}
 
-	// Lakukan delete (repository sudah memfilter berdasarkan user_id)
-	err = u.TransactionRepo.DeleteByIDAndUserID(ctx, nil, id, userID)
+	rows, err := u.TransactionRepo.GetDailySummaryByUserID(ctx, userID, startDate, endDate, false)
 	if err != nil {
-		helper.LogError(funcName, "TransactionRepo.DeleteByIDAndUserID", err, logFields, "")
-		return err
+		helper.LogError(funcName, "TransactionRepo.GetDailySummaryByUserID", err, logFields, "")
+		return nil, err
 	}
 
-	return nil
+	currency := helper.BaseCurrency(u.CurrencyOption.Code)
+	result := &usecaseEntity.BalanceTimelineResponse{Currency: currency, Days: []usecaseEntity.BalanceTimelineDay{}}
+
+	// Total per hari, baris repository sudah urut tanggal sehingga hari pertama dan terakhir langsung diketahui
+	totals := make(map[string]*usecaseEntity.BalanceTimelineDay)
+	var first, last string
+	for _, row := range rows {
+		if row.Currency != currency {
+			continue
+		}
+		day, ok := totals[row.TransactionDay]
+		if !ok {
+			day = &usecaseEntity.BalanceTimelineDay{Date: row.TransactionDay}
+			totals[row.TransactionDay] = day
+			if first == "" {
+				first = row.TransactionDay
+			}
+			last = row.TransactionDay
+		}
+		switch row.Type {
+		case myentity.TransactionTypeIncome:
+			day.Income += row.TotalAmount
+		case myentity.TransactionTypeExpense:
+			day.Expense += row.TotalAmount
+		}
+	}
+	if first == "" {
+		return result, nil
+	}
+
+	from, err := helper.ParseDateStrict(first)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid transaction_day from daily summary")
+		return nil, err
+	}
+	to, err := helper.ParseDateStrict(last)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid transaction_day from daily summary")
+		return nil, err
+	}
+
+	var running float64
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		date := d.Format(helper.DateLayout)
+		day := usecaseEntity.BalanceTimelineDay{Date: date}
+		if total, ok := totals[date]; ok {
+			day = *total
+		}
+
+		// Pembulatan 2 desimal agar penjumlahan float tidak menghasilkan ekor seperti 0.30000000000000004
+		running += day.Income - day.Expense
+		day.Income = math.Round(day.Income*100) / 100
+		day.Expense = math.Round(day.Expense*100) / 100
+		day.RunningBalance = math.Round(running*100) / 100
+		result.Days = append(result.Days, day)
+	}
+
+	return result, nil
 }
 
-// GetDailySummary mengambil ringkasan transaksi harian untuk user tertentu.
-func (u *CrudTransaction) GetDailySummary(ctx context.Context, userID int64, startDate, endDate string) ([]map[string]interface{}, error) {
-	funcName := "CrudTransaction.GetDailySummary"
+// GetSpendingStats menghitung total expense, jumlah hari, rata-rata per hari dan per minggu, serta hari dengan expense
+// terbesar dan terkecil dalam rentang tanggal. day_count dihitung dari rentang tanggal (inklusif), bukan dari hari aktif,
+// sehingga hari tanpa expense ikut menurunkan rata-rata. max_day dan min_day hanya dari hari yang memiliki expense
+// dan bernilai null jika tidak ada expense. Hanya mata uang dasar yang dihitung, kategori exclude_from_totals tidak ikut.
+func (u *CrudTransaction) GetSpendingStats(ctx context.Context, userID int64, startDate, endDate string) (*usecaseEntity.SpendingStatsResponse, error) {
+	funcName := "CrudTransaction.GetSpendingStats"
 	logFields := generalEntity.CaptureFields{
 		"user_id":    strconv.FormatInt(userID, 10),
 		"start_date": startDate,
@@ -289,33 +1510,141 @@ func (u *CrudTransaction) GetDailySummary(ctx context.Context, userID int64, sta
 	}
 
 	// Validasi tanggal
-	_, err := time.Parse("2006-01-02", startDate)
+	start, err := helper.ParseDateStrict(startDate)
 	if err != nil {
-		helper.LogError(funcName, "time.Parse", err, logFields, "Invalid start_date format")
-		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid start_date format. Use YYYY-MM-DD.")
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid start_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid start_date: " + err.Error())
 	}
-	_, err = time.Parse("2006-01-02", endDate)
+	end, err := helper.ParseDateStrict(endDate)
 	if err != nil {
-		helper.LogError(funcName, "time.Parse", err, logFields, "Invalid end_date format")
-		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid end_date format. Use YYYY-MM-DD.")
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid end_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid end_date: " + err.Error())
+	}
+	if end.Before(start) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("end_date must not be before start_date")
 	}
 
-	result, err := u.TransactionRepo.GetDailySummaryByUserID(ctx, userID, startDate, endDate)
+	rows, err := u.TransactionRepo.GetDailySummaryByUserID(ctx, userID, startDate, endDate, false)
 	if err != nil {
 		helper.LogError(funcName, "TransactionRepo.GetDailySummaryByUserID", err, logFields, "")
 		return nil, err
 	}
 
+	currency := helper.BaseCurrency(u.CurrencyOption.Code)
+	result := &usecaseEntity.SpendingStatsResponse{
+		Currency: currency,
+		// Kedua tanggal sudah tengah malam UTC, selisihnya selalu kelipatan 24 jam
+		DayCount: int(end.Sub(start).Hours()/24) + 1,
+	}
+
+	for _, row := range rows {
+		if row.Currency != currency || row.Type != myentity.TransactionTypeExpense {
+			continue
+		}
+		result.TotalExpense += row.TotalAmount
+
+		// Satu baris per hari, mata uang, dan tipe, sehingga tiap baris expense adalah total satu hari
+		day := usecaseEntity.SpendingDay{Date: row.TransactionDay, Amount: math.Round(row.TotalAmount*100) / 100}
+		if result.MaxDay == nil || day.Amount > result.MaxDay.Amount {
+			maxDay := day
+			result.MaxDay = &maxDay
+		}
+		if result.MinDay == nil || day.Amount < result.MinDay.Amount {
+			minDay := day
+			result.MinDay = &minDay
+		}
+	}
+
+	result.AvgPerDay = math.Round(result.TotalExpense/float64(result.DayCount)*100) / 100
+	result.AvgPerWeek = math.Round(result.TotalExpense/float64(result.DayCount)*7*100) / 100
+	result.TotalExpense = math.Round(result.TotalExpense*100) / 100
+
 	return result, nil
 }
 
-// GetSummaryByCategoryAndType mengambil ringkasan transaksi per kategori dan tipe untuk user tertentu.
-func (u *CrudTransaction) GetSummaryByCategoryAndType(ctx context.Context, userID int64, startDate, endDate string) ([]usecaseEntity.TransactionSummaryResponse, error) {
+// GetDailySummary mengambil ringkasan transaksi per hari untuk user tertentu.
+// Jika granularity kosong, rentang yang panjang otomatis diringkas menjadi mingguan atau bulanan
+// sesuai threshold di SummaryOption agar ukuran respons tetap terbatas.
+// Transaksi pada kategori exclude_from_totals tidak dihitung kecuali includeAll true.
+func (u *CrudTransaction) GetDailySummary(ctx context.Context, userID int64, startDate, endDate string, granularity helper.Granularity, includeAll bool) (*usecaseEntity.DailySummaryResponse, error) {
+	funcName := "CrudTransaction.GetDailySummary"
+	logFields := generalEntity.CaptureFields{
+		"user_id":     strconv.FormatInt(userID, 10),
+		"start_date":  startDate,
+		"end_date":    endDate,
+		"granularity": string(granularity),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if granularity != "" && !helper.IsValidGranularity(granularity) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid granularity. Use day, week, or month.")
+	}
+
+	// Validasi tanggal
+	start, err := helper.ParseDateStrict(startDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid start_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid start_date: " + err.Error())
+	}
+	end, err := helper.ParseDateStrict(endDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid end_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid end_date: " + err.Error())
+	}
+
+	// Batas jumlah bucket dicek setelah granularity efektif dipilih agar granularity eksplisit tidak melewatinya
+	effective := helper.ResolveGranularity(granularity, start, end, u.SummaryOption.WeeklyThresholdDays, u.SummaryOption.MonthlyThresholdDays)
+	if helper.CountBuckets(start, end, effective) > helper.MaxBuckets {
+		return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Date range is too large for %s granularity, at most %d buckets are allowed.", effective, helper.MaxBuckets))
+	}
+
+	result, err := u.TransactionRepo.GetDailySummaryByUserID(ctx, userID, startDate, endDate, includeAll)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetDailySummaryByUserID", err, logFields, "")
+		return nil, err
+	}
+
+	// Gabungkan baris harian ke dalam bucket per mata uang dan tipe, urutan mengikuti urutan hasil query (tanggal, mata uang, lalu tipe)
+	data := []usecaseEntity.DailySummaryRow{}
+	index := make(map[usecaseEntity.DailySummaryRow]int)
+	for _, row := range result {
+		period := row.TransactionDay
+		if effective != helper.GranularityDay {
+			day, err := helper.ParseDateStrict(row.TransactionDay)
+			if err != nil {
+				helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid transaction_day from daily summary")
+				return nil, err
+			}
+			period = helper.BucketLabel(helper.BucketStart(day, effective), effective)
+		}
+
+		key := usecaseEntity.DailySummaryRow{Day: period, Currency: row.Currency, Type: usecaseEntity.TransactionTypeString(row.Type)}
+		i, ok := index[key]
+		if !ok {
+			i = len(data)
+			index[key] = i
+			data = append(data, key)
+		}
+		data[i].TotalAmount += row.TotalAmount
+	}
+
+	return &usecaseEntity.DailySummaryResponse{Granularity: string(effective), Data: data}, nil
+}
+
+// GetSummaryByCategoryAndType mengambil ringkasan transaksi per kategori, mata uang, dan tipe untuk user tertentu.
+// txType kosong mengembalikan income dan expense, selain itu hanya tipe tersebut.
+func (u *CrudTransaction) GetSummaryByCategoryAndType(ctx context.Context, userID int64, startDate, endDate string, txType usecaseEntity.TransactionTypeString) ([]usecaseEntity.TransactionSummaryResponse, error) {
 	funcName := "CrudTransaction.GetSummaryByCategoryAndType"
 	logFields := generalEntity.CaptureFields{
 		"user_id":    strconv.FormatInt(userID, 10),
 		"start_date": startDate,
 		"end_date":   endDate,
+		"type":       string(txType),
 	}
 
 	if userID == 0 {
@@ -325,19 +1654,22 @@ func (u *CrudTransaction) GetSummaryByCategoryAndType(ctx context.Context, userI
 	}
 
 	// Validasi tanggal
-	_, err := time.Parse("2006-01-02", startDate)
+	_, err := helper.ParseDateStrict(startDate)
 	if err != nil {
-		helper.LogError(funcName, "time.Parse", err, logFields, "Invalid start_date format")
-		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid start_date format. Use YYYY-MM-DD.")
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid start_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid start_date: " + err.Error())
 	}
-	_, err = time.Parse("2006-01-02", endDate)
+	_, err = helper.ParseDateStrict(endDate)
 	if err != nil {
-		helper.LogError(funcName, "time.Parse", err, logFields, "Invalid end_date format")
-		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid end_date format. Use YYYY-MM-DD.")
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid end_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid end_date: " + err.Error())
+	}
+	if txType != "" && txType != usecaseEntity.TransactionTypeIncomeStr && txType != usecaseEntity.TransactionTypeExpenseStr {
+		return nil, apperr.ErrInvalidRequest().SetDetail("type must be income or expense")
 	}
 
 	// Panggil repository untuk mendapatkan data summary
-	data, err := u.TransactionRepo.GetSummaryByCategoryAndTypeByUserID(ctx, userID, startDate, endDate)
+	data, err := u.TransactionRepo.GetSummaryByCategoryAndTypeByUserID(ctx, userID, startDate, endDate, myentity.TransactionType(txType))
 	if err != nil {
 		helper.LogError(funcName, "TransactionRepo.GetSummaryByCategoryAndTypeByUserID", err, logFields, "")
 		return nil, err
@@ -352,10 +1684,146 @@ func (u *CrudTransaction) GetSummaryByCategoryAndType(ctx context.Context, userI
 		}
 		result = append(result, usecaseEntity.TransactionSummaryResponse{
 			CategoryName: categoryName,
+			Currency:     row.Currency,
 			Type:         usecaseEntity.TransactionTypeString(row.Type), // Konversi ke DTO type
 			TotalAmount:  row.TotalAmount,
 		})
 	}
 
 	return result, nil
-}
\ No newline at end of file
+}
+
+const (
+	defaultTopCategories = 5
+	maxTopCategories     = 50
+)
+
+// GetTopCategories mengambil maksimal limit kategori dengan pengeluaran terbesar dalam rentang tanggal.
+// Income tidak dihitung dan hanya transaksi dalam mata uang dasar yang dijumlahkan, pengeluaran tanpa kategori
+// dikelompokkan sebagai "Uncategorized". limit 0 berarti defaultTopCategories.
+func (u *CrudTransaction) GetTopCategories(ctx context.Context, userID int64, startDate, endDate string, limit int) (*usecaseEntity.TopCategoriesResponse, error) {
+	funcName := "CrudTransaction.GetTopCategories"
+	logFields := generalEntity.CaptureFields{
+		"user_id":    strconv.FormatInt(userID, 10),
+		"start_date": startDate,
+		"end_date":   endDate,
+		"limit":      strconv.Itoa(limit),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	start, err := helper.ParseDateStrict(startDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid start_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid start_date: " + err.Error())
+	}
+	end, err := helper.ParseDateStrict(endDate)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseDateStrict", err, logFields, "Invalid end_date")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid end_date: " + err.Error())
+	}
+	if end.Before(start) {
+		return nil, apperr.ErrInvalidRequest().SetDetail("end_date must not be before start_date")
+	}
+
+	if limit == 0 {
+		limit = defaultTopCategories
+	}
+	if limit < 1 || limit > maxTopCategories {
+		return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("limit must be between 1 and %d", maxTopCategories))
+	}
+
+	currency := helper.BaseCurrency(u.CurrencyOption.Code)
+	data, err := u.TransactionRepo.GetTopExpenseCategories(ctx, userID, currency, startDate, endDate, limit)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetTopExpenseCategories", err, logFields, "")
+		return nil, err
+	}
+
+	result := &usecaseEntity.TopCategoriesResponse{
+		Currency:   currency,
+		Categories: make([]usecaseEntity.TopCategoryResponse, 0, len(data)),
+	}
+	for _, row := range data {
+		item := usecaseEntity.TopCategoryResponse{
+			CategoryName:     row.CategoryName,
+			TotalAmount:      row.TotalAmount,
+			TransactionCount: row.TransactionCount,
+		}
+		if row.CategoryID.Valid {
+			categoryID := row.CategoryID.Int64
+			item.CategoryID = &categoryID
+		}
+		result.Categories = append(result.Categories, item)
+	}
+
+	return result, nil
+}
+
+// GetCalendar mengambil transaksi satu bulan (YYYY-MM) dan mengelompokkannya per hari untuk tampilan kalender.
+// transaction_date adalah tanggal kalender (kolom DATE), sehingga hari transaksi dipakai apa adanya tanpa konversi zona waktu.
+// Hari tanpa transaksi tetap dikembalikan dengan total 0.
+func (u *CrudTransaction) GetCalendar(ctx context.Context, userID int64, month string, format usecaseEntity.ResponseFormatReq) (*usecaseEntity.CalendarResponse, error) {
+	funcName := "CrudTransaction.GetCalendar"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"month":   month,
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	start, err := helper.ParseMonthStrict(month)
+	if err != nil {
+		helper.LogError(funcName, "helper.ParseMonthStrict", err, logFields, "Invalid month")
+		return nil, apperr.ErrInvalidRequest().SetDetail("Invalid month: " + err.Error())
+	}
+	end := start.AddDate(0, 1, -1)
+
+	responseFormat, err := u.responseFormat(ctx, userID, format)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := u.TransactionRepo.GetByUserIDAndDateRange(ctx, userID, start.Format(helper.DateLayout), end.Format(helper.DateLayout))
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetByUserIDAndDateRange", err, logFields, "")
+		return nil, err
+	}
+
+	days := make([]usecaseEntity.CalendarDay, 0, end.Day())
+	index := make(map[string]int, end.Day())
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		date := d.Format(helper.DateLayout)
+		index[date] = len(days)
+		days = append(days, usecaseEntity.CalendarDay{Date: date, Transactions: []usecaseEntity.TransactionResponse{}})
+	}
+
+	for _, row := range rows {
+		trx := toTransactionResponse(row, responseFormat)
+		// Key hari selalu YYYY-MM-DD, terlepas dari date_format response
+		i, ok := index[row.TransactionDate.Format(helper.DateLayout)]
+		if !ok {
+			continue
+		}
+
+		day := &days[i]
+		switch trx.Type {
+		case usecaseEntity.TransactionTypeIncomeStr:
+			day.TotalIncome += trx.Amount
+		case usecaseEntity.TransactionTypeExpenseStr:
+			day.TotalExpense += trx.Amount
+		}
+		day.Net = day.TotalIncome - day.TotalExpense
+		day.Transactions = append(day.Transactions, trx)
+	}
+
+	return &usecaseEntity.CalendarResponse{Month: month, Days: days}, nil
+}
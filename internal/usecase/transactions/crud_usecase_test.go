@@ -0,0 +1,2785 @@
+package transactions_usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/config"
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/queue"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	period_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/period"
+	transactions_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/transactions"
+	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CrudTransactionTestSuite struct {
+	suite.Suite
+
+	transactionRepo *mocks.ITransactionRepository
+	categoryRepo    *mocks.ICategoryRepository
+	userStatus      *mocks.IUserStatusChecker
+	userTimezone    *mocks.IUserTimezone
+	periodLock      *mocks.IPeriodLockChecker
+	publisher       *mocks.Publisher
+	usecase         transactions_usecase.ICrudTransaction
+	ctx             context.Context
+}
+
+func (s *CrudTransactionTestSuite) SetupTest() {
+	s.transactionRepo = &mocks.ITransactionRepository{}
+	s.transactionRepo.On("FindPossibleDuplicate", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	s.categoryRepo = &mocks.ICategoryRepository{}
+	s.userStatus = &mocks.IUserStatusChecker{}
+	s.userStatus.On("EnsureActive", mock.Anything, int64(1)).Return(nil).Maybe()
+	s.userTimezone = &mocks.IUserTimezone{}
+	s.userTimezone.On("Location", mock.Anything, int64(1)).Return(helper.LoadTimezone(helper.DefaultTimezone), nil).Maybe()
+	s.periodLock = &mocks.IPeriodLockChecker{}
+	s.periodLock.On("EnsureUnlocked", mock.Anything, int64(1), mock.Anything).Return(nil).Maybe()
+	s.publisher = &mocks.Publisher{}
+	s.publisher.On("Publish", mock.Anything, queue.ProcessTransactionCreated, mock.Anything).Return(nil).Maybe()
+	s.ctx = context.Background()
+
+	s.usecase = transactions_usecase.NewCrudTransaction(s.transactionRepo, s.categoryRepo, config.SummaryOption{
+		WeeklyThresholdDays:  90,
+		MonthlyThresholdDays: 730,
+	}, config.CurrencyOption{Code: "IDR"}, config.ResponseOption{}, config.SearchOption{MinQueryLength: 2}, config.SpendingCapOption{}, s.userStatus, s.userTimezone, s.periodLock, s.publisher)
+}
+
+func TestCrudTransaction(t *testing.T) {
+	suite.Run(t, new(CrudTransactionTestSuite))
+}
+
+// ptr mengembalikan pointer ke v untuk field opsional TransactionReq.
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func (s *CrudTransactionTestSuite) TestGetDailySummary() {
+	rows := []*mysql.DailySummaryRow{
+		{TransactionDay: "2024-01-01", Type: myentity.TransactionTypeExpense, TotalAmount: 1000},
+		{TransactionDay: "2024-01-03", Type: myentity.TransactionTypeExpense, TotalAmount: 2000},
+		{TransactionDay: "2024-01-03", Type: myentity.TransactionTypeIncome, TotalAmount: 5000},
+		{TransactionDay: "2024-02-15", Type: myentity.TransactionTypeExpense, TotalAmount: 4000},
+	}
+
+	s.Run("short range stays daily", func() {
+		s.transactionRepo.On("GetDailySummaryByUserID", mock.Anything, int64(1), "2024-01-01", "2024-03-30", false).Return(rows, nil).Once()
+
+		result, err := s.usecase.GetDailySummary(s.ctx, 1, "2024-01-01", "2024-03-30", "", false)
+		s.Require().NoError(err)
+
+		s.Equal("day", result.Granularity)
+		s.Len(result.Data, 4)
+	})
+
+	s.Run("range over weekly threshold", func() {
+		s.transactionRepo.On("GetDailySummaryByUserID", mock.Anything, int64(1), "2024-01-01", "2024-03-31", false).Return(rows, nil).Once()
+
+		result, err := s.usecase.GetDailySummary(s.ctx, 1, "2024-01-01", "2024-03-31", "", false)
+		s.Require().NoError(err)
+
+		s.Equal("week", result.Granularity)
+		s.Equal([]usecaseEntity.DailySummaryRow{
+			{Day: "2024-01-01", Type: usecaseEntity.TransactionTypeExpenseStr, TotalAmount: 3000},
+			{Day: "2024-01-01", Type: usecaseEntity.TransactionTypeIncomeStr, TotalAmount: 5000},
+			{Day: "2024-02-12", Type: usecaseEntity.TransactionTypeExpenseStr, TotalAmount: 4000},
+		}, result.Data)
+	})
+
+	s.Run("range over monthly threshold", func() {
+		s.transactionRepo.On("GetDailySummaryByUserID", mock.Anything, int64(1), "2023-01-01", "2024-12-31", false).Return(rows, nil).Once()
+
+		result, err := s.usecase.GetDailySummary(s.ctx, 1, "2023-01-01", "2024-12-31", "", false)
+		s.Require().NoError(err)
+
+		s.Equal("month", result.Granularity)
+		s.Equal([]usecaseEntity.DailySummaryRow{
+			{Day: "2024-01", Type: usecaseEntity.TransactionTypeExpenseStr, TotalAmount: 3000},
+			{Day: "2024-01", Type: usecaseEntity.TransactionTypeIncomeStr, TotalAmount: 5000},
+			{Day: "2024-02", Type: usecaseEntity.TransactionTypeExpenseStr, TotalAmount: 4000},
+		}, result.Data)
+	})
+
+	s.Run("explicit granularity is honored", func() {
+		s.transactionRepo.On("GetDailySummaryByUserID", mock.Anything, int64(1), "2023-01-01", "2024-12-31", false).Return(rows, nil).Once()
+
+		result, err := s.usecase.GetDailySummary(s.ctx, 1, "2023-01-01", "2024-12-31", helper.GranularityDay, false)
+		s.Require().NoError(err)
+
+		s.Equal("day", result.Granularity)
+		s.Len(result.Data, 4)
+	})
+
+	s.Run("explicit granularity over the bucket limit", func() {
+		_, err := s.usecase.GetDailySummary(s.ctx, 1, "2000-01-01", "2024-12-31", helper.GranularityDay, false)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "GetDailySummaryByUserID", mock.Anything, int64(1), "2000-01-01", "2024-12-31", false)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestGetMonthlySummary() {
+	s.Run("months without transactions are omitted", func() {
+		s.transactionRepo.On("GetMonthlySummaryByUserID", mock.Anything, int64(1), "2024-01-01", "2024-12-31", false).
+			Return([]*mysql.MonthlySummaryRow{
+				{Month: "2024-01", Type: myentity.TransactionTypeExpense, TotalAmount: 3000},
+				{Month: "2024-03", Type: myentity.TransactionTypeIncome, TotalAmount: 5000},
+			}, nil).Once()
+
+		result, err := s.usecase.GetMonthlySummary(s.ctx, 1, 2024, false)
+		s.Require().NoError(err)
+
+		s.Equal([]usecaseEntity.MonthlySummaryRow{
+			{Month: "2024-01", Type: usecaseEntity.TransactionTypeExpenseStr, TotalAmount: 3000},
+			{Month: "2024-03", Type: usecaseEntity.TransactionTypeIncomeStr, TotalAmount: 5000},
+		}, result)
+	})
+
+	s.Run("empty year is an empty list", func() {
+		s.transactionRepo.On("GetMonthlySummaryByUserID", mock.Anything, int64(1), "2023-01-01", "2023-12-31", true).
+			Return([]*mysql.MonthlySummaryRow{}, nil).Once()
+
+		result, err := s.usecase.GetMonthlySummary(s.ctx, 1, 2023, true)
+		s.Require().NoError(err)
+		s.NotNil(result)
+		s.Empty(result)
+	})
+
+	s.Run("invalid year", func() {
+		_, err := s.usecase.GetMonthlySummary(s.ctx, 1, 24, false)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+
+	s.transactionRepo.AssertExpectations(s.T())
+}
+
+func (s *CrudTransactionTestSuite) TestGetYearlyReport() {
+	s.Run("twelve months zero-filled with annual totals", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetMonthlySummaryByUserID", mock.Anything, int64(1), "2024-01-01", "2024-12-31", false).
+			Return([]*mysql.MonthlySummaryRow{
+				{Month: "2024-01", Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 3000.1},
+				{Month: "2024-01", Currency: "IDR", Type: myentity.TransactionTypeIncome, TotalAmount: 10000},
+				{Month: "2024-01", Currency: "USD", Type: myentity.TransactionTypeIncome, TotalAmount: 50},
+				{Month: "2024-12", Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 0.2},
+			}, nil).Once()
+
+		result, err := s.usecase.GetYearlyReport(s.ctx, 1, 2024)
+		s.Require().NoError(err)
+
+		s.Equal(2024, result.Year)
+		s.Equal("IDR", result.Currency)
+		s.Require().Len(result.Months, 12)
+		s.Equal(usecaseEntity.YearlyReportMonth{Month: "2024-01", Income: 10000, Expense: 3000.1, Net: 6999.9}, result.Months[0])
+		s.Equal(usecaseEntity.YearlyReportMonth{Month: "2024-06"}, result.Months[5])
+		s.Equal(usecaseEntity.YearlyReportMonth{Month: "2024-12", Expense: 0.2, Net: -0.2}, result.Months[11])
+		// Transaksi USD tidak dijumlahkan ke mata uang dasar
+		s.Equal(10000.0, result.TotalIncome)
+		s.Equal(3000.3, result.TotalExpense)
+		s.Equal(6999.7, result.Net)
+	})
+
+	s.Run("year out of range", func() {
+		for _, year := range []int{1969, helper.DatetimeNowJakarta().Year() + 2} {
+			_, err := s.usecase.GetYearlyReport(s.ctx, 1, year)
+
+			var appErr apperr.CustomErrorResponse
+			s.Require().ErrorAs(err, &appErr)
+			s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		}
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestGetBalanceTimeline() {
+	s.Run("running balance with zero-filled gaps", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetDailySummaryByUserID", mock.Anything, int64(1), "2024-01-01", "2024-01-31", false).
+			Return([]*mysql.DailySummaryRow{
+				{TransactionDay: "2024-01-03", Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 0.1},
+				{TransactionDay: "2024-01-03", Currency: "IDR", Type: myentity.TransactionTypeIncome, TotalAmount: 100},
+				{TransactionDay: "2024-01-03", Currency: "USD", Type: myentity.TransactionTypeIncome, TotalAmount: 50},
+				{TransactionDay: "2024-01-06", Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 0.2},
+			}, nil).Once()
+
+		result, err := s.usecase.GetBalanceTimeline(s.ctx, 1, "2024-01-01", "2024-01-31")
+		s.Require().NoError(err)
+
+		s.Equal("IDR", result.Currency)
+		// Hari sebelum transaksi pertama dan setelah transaksi terakhir tidak ditampilkan, USD diabaikan
+		s.Equal([]usecaseEntity.BalanceTimelineDay{
+			{Date: "2024-01-03", Income: 100, Expense: 0.1, RunningBalance: 99.9},
+			{Date: "2024-01-04", RunningBalance: 99.9},
+			{Date: "2024-01-05", RunningBalance: 99.9},
+			{Date: "2024-01-06", Expense: 0.2, RunningBalance: 99.7},
+		}, result.Days)
+	})
+
+	s.Run("no activity returns empty days", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetDailySummaryByUserID", mock.Anything, int64(1), "2024-02-01", "2024-02-29", false).
+			Return([]*mysql.DailySummaryRow{}, nil).Once()
+
+		result, err := s.usecase.GetBalanceTimeline(s.ctx, 1, "2024-02-01", "2024-02-29")
+		s.Require().NoError(err)
+		s.NotNil(result.Days)
+		s.Empty(result.Days)
+	})
+
+	s.Run("end before start", func() {
+		s.SetupTest()
+		_, err := s.usecase.GetBalanceTimeline(s.ctx, 1, "2024-02-01", "2024-01-01")
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestGetSpendingStats() {
+	s.Run("average over the whole range", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetDailySummaryByUserID", mock.Anything, int64(1), "2024-01-01", "2024-01-10", false).
+			Return([]*mysql.DailySummaryRow{
+				{TransactionDay: "2024-01-02", Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 30000},
+				{TransactionDay: "2024-01-02", Currency: "IDR", Type: myentity.TransactionTypeIncome, TotalAmount: 900000},
+				{TransactionDay: "2024-01-05", Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 5000},
+				{TransactionDay: "2024-01-05", Currency: "USD", Type: myentity.TransactionTypeExpense, TotalAmount: 99},
+				{TransactionDay: "2024-01-09", Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 15000},
+			}, nil).Once()
+
+		result, err := s.usecase.GetSpendingStats(s.ctx, 1, "2024-01-01", "2024-01-10")
+		s.Require().NoError(err)
+
+		s.Equal(&usecaseEntity.SpendingStatsResponse{
+			Currency:     "IDR",
+			TotalExpense: 50000,
+			DayCount:     10,
+			AvgPerDay:    5000,
+			AvgPerWeek:   35000,
+			MaxDay:       &usecaseEntity.SpendingDay{Date: "2024-01-02", Amount: 30000},
+			MinDay:       &usecaseEntity.SpendingDay{Date: "2024-01-05", Amount: 5000},
+		}, result)
+	})
+
+	s.Run("single day range", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetDailySummaryByUserID", mock.Anything, int64(1), "2024-03-10", "2024-03-10", false).
+			Return([]*mysql.DailySummaryRow{
+				{TransactionDay: "2024-03-10", Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 12000},
+			}, nil).Once()
+
+		result, err := s.usecase.GetSpendingStats(s.ctx, 1, "2024-03-10", "2024-03-10")
+		s.Require().NoError(err)
+
+		s.Equal(1, result.DayCount)
+		s.Equal(12000.0, result.AvgPerDay)
+		s.Equal(result.MaxDay, result.MinDay)
+	})
+
+	s.Run("no expenses", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetDailySummaryByUserID", mock.Anything, int64(1), "2024-02-01", "2024-02-29", false).
+			Return([]*mysql.DailySummaryRow{}, nil).Once()
+
+		result, err := s.usecase.GetSpendingStats(s.ctx, 1, "2024-02-01", "2024-02-29")
+		s.Require().NoError(err)
+
+		s.Equal(29, result.DayCount)
+		s.Zero(result.AvgPerDay)
+		s.Nil(result.MaxDay)
+		s.Nil(result.MinDay)
+	})
+
+	s.Run("end before start", func() {
+		s.SetupTest()
+		_, err := s.usecase.GetSpendingStats(s.ctx, 1, "2024-02-01", "2024-01-01")
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestGetSummaryByCategoryAndType() {
+	s.Run("type filter is passed to the repository", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetSummaryByCategoryAndTypeByUserID", mock.Anything, int64(1), "2024-01-01", "2024-01-31", myentity.TransactionTypeExpense).
+			Return([]*mysql.TransactionSummaryByCategory{
+				{CategoryName: sql.NullString{String: "Food", Valid: true}, Currency: "IDR", Type: "expense", TotalAmount: 3000},
+			}, nil).Once()
+
+		result, err := s.usecase.GetSummaryByCategoryAndType(s.ctx, 1, "2024-01-01", "2024-01-31", usecaseEntity.TransactionTypeExpenseStr)
+		s.Require().NoError(err)
+		s.Require().Len(result, 1)
+		s.Equal(usecaseEntity.TransactionTypeExpenseStr, result[0].Type)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("without type both types are requested", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetSummaryByCategoryAndTypeByUserID", mock.Anything, int64(1), "2024-01-01", "2024-01-31", myentity.TransactionType("")).
+			Return([]*mysql.TransactionSummaryByCategory{}, nil).Once()
+
+		_, err := s.usecase.GetSummaryByCategoryAndType(s.ctx, 1, "2024-01-01", "2024-01-31", "")
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("invalid type", func() {
+		s.SetupTest()
+		_, err := s.usecase.GetSummaryByCategoryAndType(s.ctx, 1, "2024-01-01", "2024-01-31", "transfer")
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestGetBalance() {
+	s.Run("income minus expense", func() {
+		s.transactionRepo.On("GetTotalsByType", mock.Anything, int64(1), "2024-01-01", "2024-01-31", false).
+			Return([]*mysql.TypeTotal{
+				{Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 1250.3},
+				{Currency: "IDR", Type: myentity.TransactionTypeIncome, TotalAmount: 5000.1},
+			}, nil).Once()
+
+		result, err := s.usecase.GetBalance(s.ctx, 1, "2024-01-01", "2024-01-31", false)
+		s.Require().NoError(err)
+		s.Equal([]usecaseEntity.BalanceResponse{{Currency: "IDR", TotalIncome: 5000.1, TotalExpense: 1250.3, Net: 3749.8}}, result)
+	})
+
+	s.Run("each currency has its own subtotal", func() {
+		s.transactionRepo.On("GetTotalsByType", mock.Anything, int64(1), "2024-03-01", "2024-03-31", false).
+			Return([]*mysql.TypeTotal{
+				{Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: 150000},
+				{Currency: "IDR", Type: myentity.TransactionTypeIncome, TotalAmount: 500000},
+				{Currency: "USD", Type: myentity.TransactionTypeExpense, TotalAmount: 42.5},
+			}, nil).Once()
+
+		result, err := s.usecase.GetBalance(s.ctx, 1, "2024-03-01", "2024-03-31", false)
+		s.Require().NoError(err)
+		s.Equal([]usecaseEntity.BalanceResponse{
+			{Currency: "IDR", TotalIncome: 500000, TotalExpense: 150000, Net: 350000},
+			{Currency: "USD", TotalExpense: 42.5, Net: -42.5},
+		}, result)
+	})
+
+	s.Run("empty period returns zeros", func() {
+		s.transactionRepo.On("GetTotalsByType", mock.Anything, int64(1), "2024-02-01", "2024-02-29", true).
+			Return([]*mysql.TypeTotal{}, nil).Once()
+
+		result, err := s.usecase.GetBalance(s.ctx, 1, "2024-02-01", "2024-02-29", true)
+		s.Require().NoError(err)
+		s.Equal([]usecaseEntity.BalanceResponse{{Currency: "IDR"}}, result)
+	})
+
+	s.Run("invalid date", func() {
+		_, err := s.usecase.GetBalance(s.ctx, 1, "2024-02-30", "2024-03-31", false)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+
+	s.transactionRepo.AssertExpectations(s.T())
+}
+
+func (s *CrudTransactionTestSuite) TestCreateRejectsDecimalsOnZeroDecimalCurrency() {
+	err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{
+		Amount:          ptr(1500.5),
+		Type:            ptr(usecaseEntity.TransactionTypeExpenseStr),
+		TransactionDate: "2024-01-05",
+	})
+
+	var appErr apperr.CustomErrorResponse
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	s.transactionRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *CrudTransactionTestSuite) TestCurrency() {
+	s.Run("defaults to the base currency", func() {
+		s.transactionRepo.On("Create", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return t.Currency == "IDR" && t.Amount == 15000
+		}), false).Return(nil).Once()
+
+		err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{
+			Amount:          ptr(15000.0),
+			Type:            ptr(usecaseEntity.TransactionTypeExpenseStr),
+			TransactionDate: "2024-01-05",
+		})
+		s.Require().NoError(err)
+	})
+
+	s.Run("amount scale follows the transaction currency", func() {
+		s.transactionRepo.On("Create", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return t.Currency == "USD" && t.Amount == 12.5
+		}), false).Return(nil).Once()
+
+		err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{
+			Amount:          ptr(12.5),
+			Currency:        ptr("USD"),
+			Type:            ptr(usecaseEntity.TransactionTypeExpenseStr),
+			TransactionDate: "2024-01-05",
+		})
+		s.Require().NoError(err)
+	})
+
+	s.Run("lowercase code is rejected", func() {
+		err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{
+			Amount:          ptr(12.5),
+			Currency:        ptr("usd"),
+			Type:            ptr(usecaseEntity.TransactionTypeExpenseStr),
+			TransactionDate: "2024-01-05",
+		})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+
+	s.Run("changing currency revalidates the stored amount", func() {
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(9), int64(1)).
+			Return(&myentity.Transaction{ID: 9, UserID: 1, Amount: 12.5, Currency: "USD", Type: myentity.TransactionTypeExpense,
+				TransactionDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)}, nil).Once()
+
+		err := s.usecase.Update(s.ctx, 9, 1, usecaseEntity.TransactionReq{Currency: ptr("JPY")})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+
+	s.transactionRepo.AssertExpectations(s.T())
+}
+
+func (s *CrudTransactionTestSuite) TestCreatePublishesEvent() {
+	req := usecaseEntity.TransactionReq{
+		Amount:          ptr(15000.0),
+		Type:            ptr(usecaseEntity.TransactionTypeExpenseStr),
+		TransactionDate: "2024-01-05",
+	}
+
+	s.Run("event carries the stored transaction", func() {
+		s.SetupTest()
+		s.publisher = &mocks.Publisher{}
+		s.usecase = transactions_usecase.NewCrudTransaction(s.transactionRepo, s.categoryRepo, config.SummaryOption{},
+			config.CurrencyOption{Code: "IDR"}, config.ResponseOption{}, config.SearchOption{MinQueryLength: 2}, config.SpendingCapOption{}, s.userStatus, s.userTimezone, s.periodLock, s.publisher)
+		s.transactionRepo.On("Create", mock.Anything, nil, mock.Anything, false).
+			Run(func(args mock.Arguments) { args.Get(2).(*myentity.Transaction).ID = 42 }).Return(nil).Once()
+		s.publisher.On("Publish", mock.Anything, queue.ProcessTransactionCreated, map[string]interface{}{
+			"event_type":       queue.EventTransactionCreated,
+			"transaction_id":   int64(42),
+			"user_id":          int64(1),
+			"category_id":      nil,
+			"amount":           15000.0,
+			"currency":         "IDR",
+			"type":             "expense",
+			"transaction_date": "2024-01-05",
+		}).Return(nil).Once()
+
+		s.Require().NoError(s.usecase.Create(s.ctx, 1, req))
+		s.publisher.AssertExpectations(s.T())
+	})
+
+	s.Run("publish failure does not fail the request", func() {
+		s.SetupTest()
+		s.publisher = &mocks.Publisher{}
+		s.usecase = transactions_usecase.NewCrudTransaction(s.transactionRepo, s.categoryRepo, config.SummaryOption{},
+			config.CurrencyOption{Code: "IDR"}, config.ResponseOption{}, config.SearchOption{MinQueryLength: 2}, config.SpendingCapOption{}, s.userStatus, s.userTimezone, s.periodLock, s.publisher)
+		s.transactionRepo.On("Create", mock.Anything, nil, mock.Anything, false).Return(nil).Once()
+		s.publisher.On("Publish", mock.Anything, queue.ProcessTransactionCreated, mock.Anything).Return(queue.ErrPublisherFull).Once()
+
+		s.Require().NoError(s.usecase.Create(s.ctx, 1, req))
+		s.publisher.AssertExpectations(s.T())
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestCreateSpendingCap() {
+	expense := usecaseEntity.TransactionReq{Amount: ptr(15000.0), Type: ptr(usecaseEntity.TransactionTypeExpenseStr), TransactionDate: "2024-01-05"}
+	setup := func() {
+		s.SetupTest()
+		s.publisher = &mocks.Publisher{}
+		s.publisher.On("Publish", mock.Anything, queue.ProcessTransactionCreated, mock.MatchedBy(func(event map[string]interface{}) bool {
+			return event["event_type"] == queue.EventTransactionCreated
+		})).Return(nil).Once()
+		s.usecase = transactions_usecase.NewCrudTransaction(s.transactionRepo, s.categoryRepo, config.SummaryOption{},
+			config.CurrencyOption{Code: "IDR"}, config.ResponseOption{}, config.SearchOption{MinQueryLength: 2}, config.SpendingCapOption{MonthlyCap: 5000000},
+			s.userStatus, s.userTimezone, s.periodLock, s.publisher)
+		s.transactionRepo.On("Create", mock.Anything, nil, mock.Anything, false).
+			Run(func(args mock.Arguments) { args.Get(2).(*myentity.Transaction).ID = 42 }).Return(nil).Once()
+	}
+	monthTotals := func(expenseTotal float64) []*mysql.TypeTotal {
+		return []*mysql.TypeTotal{
+			{Currency: "IDR", Type: myentity.TransactionTypeExpense, TotalAmount: expenseTotal},
+			{Currency: "IDR", Type: myentity.TransactionTypeIncome, TotalAmount: 9000000},
+			{Currency: "USD", Type: myentity.TransactionTypeExpense, TotalAmount: 9000000},
+		}
+	}
+
+	s.Run("expense crossing the cap publishes an alert", func() {
+		setup()
+		s.transactionRepo.On("GetTotalsByType", mock.Anything, int64(1), "2024-01-01", "2024-01-31", false).Return(monthTotals(5010000), nil).Once()
+		s.publisher.On("Publish", mock.Anything, queue.ProcessTransactionCreated, map[string]interface{}{
+			"event_type":     queue.EventSpendingCapExceeded,
+			"user_id":        int64(1),
+			"transaction_id": int64(42),
+			"month":          "2024-01",
+			"currency":       "IDR",
+			"cap":            5000000.0,
+			"total_expense":  5010000.0,
+		}).Return(nil).Once()
+
+		s.Require().NoError(s.usecase.Create(s.ctx, 1, expense))
+		s.publisher.AssertExpectations(s.T())
+	})
+
+	s.Run("no alert when the month was already over the cap", func() {
+		setup()
+		s.transactionRepo.On("GetTotalsByType", mock.Anything, int64(1), "2024-01-01", "2024-01-31", false).Return(monthTotals(5100000), nil).Once()
+
+		s.Require().NoError(s.usecase.Create(s.ctx, 1, expense))
+		s.publisher.AssertExpectations(s.T())
+		s.publisher.AssertNumberOfCalls(s.T(), "Publish", 1)
+	})
+
+	s.Run("no alert below the cap", func() {
+		setup()
+		s.transactionRepo.On("GetTotalsByType", mock.Anything, int64(1), "2024-01-01", "2024-01-31", false).Return(monthTotals(4000000), nil).Once()
+
+		s.Require().NoError(s.usecase.Create(s.ctx, 1, expense))
+		s.publisher.AssertNumberOfCalls(s.T(), "Publish", 1)
+	})
+
+	s.Run("income is not checked", func() {
+		setup()
+
+		s.Require().NoError(s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{Amount: ptr(15000.0),
+			Type: ptr(usecaseEntity.TransactionTypeIncomeStr), TransactionDate: "2024-01-05"}))
+		s.transactionRepo.AssertNotCalled(s.T(), "GetTotalsByType", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("failed check does not fail the request", func() {
+		setup()
+		s.transactionRepo.On("GetTotalsByType", mock.Anything, int64(1), "2024-01-01", "2024-01-31", false).Return(nil, errors.New("db down")).Once()
+
+		s.Require().NoError(s.usecase.Create(s.ctx, 1, expense))
+		s.publisher.AssertNumberOfCalls(s.T(), "Publish", 1)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestTransactionDateFuture() {
+	today := helper.DatetimeNowJakarta()
+
+	for _, tt := range []struct {
+		name  string
+		date  time.Time
+		valid bool
+	}{
+		{name: "today", date: today, valid: true},
+		{name: "yesterday", date: today.AddDate(0, 0, -1), valid: true},
+		{name: "two years out", date: today.AddDate(2, 0, 0), valid: false},
+	} {
+		s.Run(tt.name, func() {
+			if tt.valid {
+				s.transactionRepo.On("Create", mock.Anything, nil, mock.Anything, false).Return(nil).Once()
+			}
+
+			err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{
+				Amount:          ptr(15000.0),
+				Type:            ptr(usecaseEntity.TransactionTypeExpenseStr),
+				TransactionDate: tt.date.Format(helper.DateLayout),
+			})
+
+			if tt.valid {
+				s.Require().NoError(err)
+				return
+			}
+			var appErr apperr.CustomErrorResponse
+			s.Require().ErrorAs(err, &appErr)
+			s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		})
+	}
+
+	s.Run("update to a far future date is rejected", func() {
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(9), int64(1)).
+			Return(&myentity.Transaction{ID: 9, UserID: 1, Amount: 15000, Currency: "IDR", Type: myentity.TransactionTypeExpense,
+				TransactionDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)}, nil).Once()
+
+		err := s.usecase.Update(s.ctx, 9, 1, usecaseEntity.TransactionReq{TransactionDate: "2202-01-05"})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+
+	s.transactionRepo.AssertExpectations(s.T())
+}
+
+func (s *CrudTransactionTestSuite) TestGetTopCategories() {
+	s.Run("uncategorized spending has no category id", func() {
+		s.transactionRepo.On("GetTopExpenseCategories", mock.Anything, int64(1), "IDR", "2024-01-01", "2024-01-31", 5).
+			Return([]*mysql.CategorySpending{
+				{CategoryID: sql.NullInt64{Int64: 7, Valid: true}, CategoryName: "Makan", TotalAmount: 150000, TransactionCount: 12},
+				{CategoryName: "Uncategorized", TotalAmount: 40000, TransactionCount: 3},
+			}, nil).Once()
+
+		result, err := s.usecase.GetTopCategories(s.ctx, 1, "2024-01-01", "2024-01-31", 0)
+		s.Require().NoError(err)
+
+		s.Equal(&usecaseEntity.TopCategoriesResponse{
+			Currency: "IDR",
+			Categories: []usecaseEntity.TopCategoryResponse{
+				{CategoryID: ptr(int64(7)), CategoryName: "Makan", TotalAmount: 150000, TransactionCount: 12},
+				{CategoryName: "Uncategorized", TotalAmount: 40000, TransactionCount: 3},
+			},
+		}, result)
+	})
+
+	s.Run("limit out of range", func() {
+		_, err := s.usecase.GetTopCategories(s.ctx, 1, "2024-01-01", "2024-01-31", 51)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+
+	s.Run("end before start", func() {
+		_, err := s.usecase.GetTopCategories(s.ctx, 1, "2024-02-01", "2024-01-31", 5)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+
+	s.transactionRepo.AssertExpectations(s.T())
+}
+
+func (s *CrudTransactionTestSuite) TestGetCalendar() {
+	trx := func(id int64, date string, txType myentity.TransactionType, amount float64) *mysql.TransactionWithCategory {
+		row := &mysql.TransactionWithCategory{}
+		row.ID = id
+		row.UserID = 1
+		row.Type = txType
+		row.Amount = amount
+		row.TransactionDate, _ = time.Parse(helper.DateLayout, date)
+		return row
+	}
+
+	s.Run("groups by day including month boundaries and empty days", func() {
+		s.transactionRepo.On("GetByUserIDAndDateRange", mock.Anything, int64(1), "2024-02-01", "2024-02-29").
+			Return([]*mysql.TransactionWithCategory{
+				trx(1, "2024-02-01", myentity.TransactionTypeIncome, 5000),
+				trx(2, "2024-02-01", myentity.TransactionTypeExpense, 1500),
+				trx(3, "2024-02-29", myentity.TransactionTypeExpense, 2000),
+			}, nil).Once()
+
+		result, err := s.usecase.GetCalendar(s.ctx, 1, "2024-02", usecaseEntity.ResponseFormatReq{})
+		s.Require().NoError(err)
+
+		s.Equal("2024-02", result.Month)
+		s.Require().Len(result.Days, 29)
+
+		first := result.Days[0]
+		s.Equal("2024-02-01", first.Date)
+		s.Equal(5000.0, first.TotalIncome)
+		s.Equal(1500.0, first.TotalExpense)
+		s.Equal(3500.0, first.Net)
+		s.Len(first.Transactions, 2)
+
+		last := result.Days[28]
+		s.Equal("2024-02-29", last.Date)
+		s.Equal(2000.0, last.TotalExpense)
+		s.Equal(-2000.0, last.Net)
+
+		empty := result.Days[14]
+		s.Equal("2024-02-15", empty.Date)
+		s.Zero(empty.Net)
+		s.NotNil(empty.Transactions)
+		s.Empty(empty.Transactions)
+	})
+
+	s.Run("invalid month", func() {
+		_, err := s.usecase.GetCalendar(s.ctx, 1, "2024-13", usecaseEntity.ResponseFormatReq{})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestImport() {
+	rows := []usecaseEntity.ImportTransactionRow{
+		{CategoryName: "  makan ", Amount: 15000, Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-01-05"},
+		{CategoryName: "Transport  Umum", Amount: 5000, Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-01-05"},
+		{CategoryName: "transport umum", Amount: 7000, Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-01-06"},
+		{CategoryName: "", Amount: 100000, Type: usecaseEntity.TransactionTypeIncomeStr, TransactionDate: "2024-01-07"},
+	}
+	existing := []*myentity.Category{{ID: 10, Name: "Makan", CreatedBy: 1}}
+
+	s.Run("creates missing categories", func() {
+		trx := &mocks.TrxObj{}
+		trx.On("Commit").Return(nil).Once()
+		s.transactionRepo.On("Begin").Return(trx, nil).Once()
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return(existing, nil).Once()
+		s.categoryRepo.On("Create", mock.Anything, trx, mock.MatchedBy(func(c *myentity.Category) bool {
+			return c.Name == "Transport Umum" && c.CreatedBy == 1
+		}), false).Run(func(args mock.Arguments) {
+			args.Get(2).(*myentity.Category).ID = 20
+		}).Return(nil).Once()
+
+		var categoryIDs []sql.NullInt64
+		s.transactionRepo.On("Create", mock.Anything, trx, mock.Anything, false).Run(func(args mock.Arguments) {
+			categoryIDs = append(categoryIDs, args.Get(2).(*myentity.Transaction).CategoryID)
+		}).Return(nil).Times(4)
+
+		result, err := s.usecase.Import(s.ctx, 1, usecaseEntity.ImportTransactionReq{Rows: rows}, true)
+		s.Require().NoError(err)
+
+		s.Equal(4, result.Imported)
+		s.Require().Len(result.Categories, 2)
+		s.Equal("Makan", result.Categories[0].Name)
+		s.Equal(usecaseEntity.CategoryMappingMatched, result.Categories[0].Status)
+		s.Equal(int64(10), *result.Categories[0].CategoryID)
+		s.Equal("Transport Umum", result.Categories[1].Name)
+		s.Equal(usecaseEntity.CategoryMappingCreated, result.Categories[1].Status)
+		s.Equal(int64(20), *result.Categories[1].CategoryID)
+
+		s.Equal([]sql.NullInt64{
+			{Int64: 10, Valid: true},
+			{Int64: 20, Valid: true},
+			{Int64: 20, Valid: true},
+			{},
+		}, categoryIDs)
+		trx.AssertExpectations(s.T())
+	})
+
+	s.Run("without create_categories unknown names are left uncategorized", func() {
+		trx := &mocks.TrxObj{}
+		trx.On("Commit").Return(nil).Once()
+		s.transactionRepo.On("Begin").Return(trx, nil).Once()
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return(existing, nil).Once()
+		s.transactionRepo.On("Create", mock.Anything, trx, mock.Anything, false).Return(nil).Times(4)
+
+		result, err := s.usecase.Import(s.ctx, 1, usecaseEntity.ImportTransactionReq{Rows: rows}, false)
+		s.Require().NoError(err)
+
+		s.Require().Len(result.Categories, 2)
+		s.Equal(usecaseEntity.CategoryMappingUnmatched, result.Categories[1].Status)
+		s.Nil(result.Categories[1].CategoryID)
+	})
+
+	s.Run("category creation failure rolls back", func() {
+		trx := &mocks.TrxObj{}
+		trx.On("Rollback").Return(nil).Once()
+		s.transactionRepo.On("Begin").Return(trx, nil).Once()
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return(existing, nil).Once()
+		s.categoryRepo.On("Create", mock.Anything, trx, mock.Anything, false).Return(errors.New("db down")).Once()
+
+		_, err := s.usecase.Import(s.ctx, 1, usecaseEntity.ImportTransactionReq{Rows: rows}, true)
+		s.Require().Error(err)
+		trx.AssertExpectations(s.T())
+	})
+
+	s.Run("invalid row is rejected before writing", func() {
+		invalid := []usecaseEntity.ImportTransactionRow{
+			{CategoryName: "Makan", Amount: 15000, Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-02-30"},
+		}
+
+		_, err := s.usecase.Import(s.ctx, 1, usecaseEntity.ImportTransactionReq{Rows: invalid}, true)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+
+	s.Run("rows keep their own currency", func() {
+		trx := &mocks.TrxObj{}
+		trx.On("Commit").Return(nil).Once()
+		s.transactionRepo.On("Begin").Return(trx, nil).Once()
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return(existing, nil).Once()
+
+		var currencies []string
+		s.transactionRepo.On("Create", mock.Anything, trx, mock.Anything, false).Run(func(args mock.Arguments) {
+			currencies = append(currencies, args.Get(2).(*myentity.Transaction).Currency)
+		}).Return(nil).Times(2)
+
+		_, err := s.usecase.Import(s.ctx, 1, usecaseEntity.ImportTransactionReq{Rows: []usecaseEntity.ImportTransactionRow{
+			{CategoryName: "Makan", Amount: 15000, Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-01-05"},
+			{CategoryName: "Makan", Amount: 12.75, Currency: ptr("USD"), Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-01-05"},
+		}}, false)
+		s.Require().NoError(err)
+		s.Equal([]string{"IDR", "USD"}, currencies)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestImportCSV() {
+	existing := []*myentity.Category{{ID: 10, Name: "Makan", CreatedBy: 1}}
+
+	s.Run("valid rows are saved and invalid rows reported by line", func() {
+		s.SetupTest()
+		// Header mengikuti export CSV: kolom id diabaikan, currency opsional
+		file := strings.Join([]string{
+			"id,date,type,category_name,amount,currency,description",
+			"1,2024-01-05,expense,makan,15000,,Nasi goreng",
+			"2,2024-01-06,transfer,Makan,5000,,",
+			"3,2024-01-07,expense,Makan,abc,,",
+			"4,2024-01-08,expense,Makan",
+			"5,2024-01-09,income,Gaji,12.5,USD,\"Bonus, Januari\"",
+		}, "\n")
+
+		trx := &mocks.TrxObj{}
+		trx.On("Commit").Return(nil).Once()
+		s.transactionRepo.On("Begin").Return(trx, nil).Once()
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return(existing, nil).Once()
+
+		var saved []*myentity.Transaction
+		s.transactionRepo.On("Create", mock.Anything, trx, mock.Anything, false).Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(2).(*myentity.Transaction))
+		}).Return(nil).Times(2)
+
+		result, err := s.usecase.ImportCSV(s.ctx, 1, strings.NewReader(file), false)
+		s.Require().NoError(err)
+
+		s.Equal(2, result.Imported)
+		s.Equal([]usecaseEntity.ImportRowError{
+			{Line: 3, Message: "type must be income or expense"},
+			{Line: 4, Message: "amount must be a number"},
+			{Line: 5, Message: "number of columns does not match the header"},
+		}, result.Errors)
+
+		s.Require().Len(saved, 2)
+		s.Equal(sql.NullInt64{Int64: 10, Valid: true}, saved[0].CategoryID)
+		s.Equal("Nasi goreng", saved[0].Description.String)
+		s.Equal("USD", saved[1].Currency)
+		s.Equal("Bonus, Januari", saved[1].Description.String)
+		// Gaji belum ada dan create_missing false, transaksi disimpan tanpa kategori
+		s.False(saved[1].CategoryID.Valid)
+		trx.AssertExpectations(s.T())
+	})
+
+	s.Run("create_missing creates unknown categories", func() {
+		s.SetupTest()
+		trx := &mocks.TrxObj{}
+		trx.On("Commit").Return(nil).Once()
+		s.transactionRepo.On("Begin").Return(trx, nil).Once()
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return(existing, nil).Once()
+		s.categoryRepo.On("Create", mock.Anything, trx, mock.MatchedBy(func(c *myentity.Category) bool {
+			return c.Name == "Gaji"
+		}), false).Run(func(args mock.Arguments) {
+			args.Get(2).(*myentity.Category).ID = 20
+		}).Return(nil).Once()
+		s.transactionRepo.On("Create", mock.Anything, trx, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return t.CategoryID == sql.NullInt64{Int64: 20, Valid: true}
+		}), false).Return(nil).Once()
+
+		result, err := s.usecase.ImportCSV(s.ctx, 1, strings.NewReader("date,type,category_name,amount,description\n2024-01-09,income,Gaji,5000000,\n"), true)
+		s.Require().NoError(err)
+		s.Equal(1, result.Imported)
+		s.Empty(result.Errors)
+		s.Require().Len(result.Categories, 1)
+		s.Equal(usecaseEntity.CategoryMappingCreated, result.Categories[0].Status)
+	})
+
+	s.Run("rows in a locked period are reported", func() {
+		s.SetupTest()
+		periodLock := &mocks.IPeriodLockChecker{}
+		locked := apperr.ErrConflict().SetDetail("Transactions dated on or before 2024-01-31 are locked. Unlock the period to change them.")
+		periodLock.On("EnsureUnlocked", mock.Anything, int64(1), mock.MatchedBy(func(d time.Time) bool { return d.Format(helper.DateLayout) <= "2024-01-31" })).Return(locked)
+		periodLock.On("EnsureUnlocked", mock.Anything, int64(1), mock.Anything).Return(nil).Once()
+		usecase := transactions_usecase.NewCrudTransaction(s.transactionRepo, s.categoryRepo, config.SummaryOption{},
+			config.CurrencyOption{Code: "IDR"}, config.ResponseOption{}, config.SearchOption{MinQueryLength: 2}, config.SpendingCapOption{}, s.userStatus, s.userTimezone, periodLock, s.publisher)
+
+		trx := &mocks.TrxObj{}
+		trx.On("Commit").Return(nil).Once()
+		s.transactionRepo.On("Begin").Return(trx, nil).Once()
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return(existing, nil).Once()
+		s.transactionRepo.On("Create", mock.Anything, trx, mock.Anything, false).Return(nil).Once()
+
+		file := "date,type,amount\n2024-02-01,expense,100\n2024-01-20,expense,200\n2024-01-20,expense,300\n"
+		result, err := usecase.ImportCSV(s.ctx, 1, strings.NewReader(file), false)
+		s.Require().NoError(err)
+
+		s.Equal(1, result.Imported)
+		s.Require().Len(result.Errors, 2)
+		s.Equal(3, result.Errors[0].Line)
+		s.Equal(locked.Detail, result.Errors[0].Message)
+		s.Equal(4, result.Errors[1].Line)
+		// Satu pengecekan untuk tanggal terkunci dan satu untuk tanggal pertama yang tidak terkunci
+		periodLock.AssertNumberOfCalls(s.T(), "EnsureUnlocked", 2)
+	})
+
+	s.Run("no valid rows writes nothing", func() {
+		s.SetupTest()
+		result, err := s.usecase.ImportCSV(s.ctx, 1, strings.NewReader("date,type,amount\n2024-02-30,expense,100\n"), false)
+		s.Require().NoError(err)
+
+		s.Equal(0, result.Imported)
+		s.Equal([]usecaseEntity.ImportRowError{{Line: 2, Message: `invalid transaction_date: "2024-02-30" is not a valid calendar date in YYYY-MM-DD format`}}, result.Errors)
+		s.transactionRepo.AssertNotCalled(s.T(), "Begin")
+	})
+
+	s.Run("invalid files", func() {
+		for name, file := range map[string]string{
+			"empty":          "",
+			"missing column": "date,type,category_name\n2024-01-05,expense,Makan\n",
+			"header only":    "date,type,amount\n",
+		} {
+			s.SetupTest()
+			_, err := s.usecase.ImportCSV(s.ctx, 1, strings.NewReader(file), false)
+
+			var appErr apperr.CustomErrorResponse
+			s.Require().ErrorAs(err, &appErr, name)
+			s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode, name)
+		}
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestList() {
+	filter := mysql.TransactionFilter{Type: myentity.TransactionTypeExpense}
+
+	s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), filter).Return(int64(45), nil).Once()
+	s.transactionRepo.On("ListByUserID", mock.Anything, int64(1), filter, 20, 40).
+		Return([]*mysql.TransactionWithCategory{{}}, nil).Once()
+
+	result, err := s.usecase.List(s.ctx, 1, usecaseEntity.TransactionListReq{Page: 3, Type: usecaseEntity.TransactionTypeExpenseStr})
+	s.Require().NoError(err)
+
+	s.Equal(generalEntity.PaginationMeta{Page: 3, PerPage: 20, Total: 45, TotalPages: 3, HasNext: false}, result.Meta)
+	s.Len(result.Data, 1)
+	s.transactionRepo.AssertExpectations(s.T())
+}
+
+func (s *CrudTransactionTestSuite) TestListByDateColumn() {
+	s.Run("by created_at", func() {
+		filter := mysql.TransactionFilter{StartDate: "2024-01-10", EndDate: "2024-01-10", DateColumn: mysql.DateColumnCreatedAt}
+
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), filter).Return(int64(1), nil).Once()
+		s.transactionRepo.On("ListByUserID", mock.Anything, int64(1), filter, 20, 0).
+			Return([]*mysql.TransactionWithCategory{{}}, nil).Once()
+
+		result, err := s.usecase.List(s.ctx, 1, usecaseEntity.TransactionListReq{Page: 1, StartDate: "2024-01-10", EndDate: "2024-01-10", By: "created_at"})
+		s.Require().NoError(err)
+		s.Len(result.Data, 1)
+	})
+
+	s.Run("by transaction_date", func() {
+		filter := mysql.TransactionFilter{StartDate: "2024-01-10", DateColumn: mysql.DateColumnTransactionDate}
+
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), filter).Return(int64(0), nil).Once()
+		s.transactionRepo.On("ListByUserID", mock.Anything, int64(1), filter, 20, 0).
+			Return([]*mysql.TransactionWithCategory{}, nil).Once()
+
+		_, err := s.usecase.List(s.ctx, 1, usecaseEntity.TransactionListReq{Page: 1, StartDate: "2024-01-10", By: "transaction_date"})
+		s.Require().NoError(err)
+	})
+
+	s.Run("unknown column", func() {
+		_, err := s.usecase.List(s.ctx, 1, usecaseEntity.TransactionListReq{Page: 1, By: "updated_at"})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+
+	s.transactionRepo.AssertExpectations(s.T())
+}
+
+func (s *CrudTransactionTestSuite) TestSync() {
+	categoryID := int64(10)
+	description := "Transfer"
+	date, _ := time.Parse(helper.DateLayout, "2024-01-05")
+
+	existing := []*myentity.Transaction{
+		{
+			ID: 100, UserID: 1, Amount: 50000, Currency: "IDR", Type: myentity.TransactionTypeExpense,
+			CategoryID:      sql.NullInt64{Int64: 10, Valid: true},
+			Description:     sql.NullString{String: "Transfer", Valid: true},
+			Reference:       sql.NullString{String: "REF-SAME", Valid: true},
+			TransactionDate: date,
+		},
+		{
+			ID: 101, UserID: 1, Amount: 75000, Currency: "IDR", Type: myentity.TransactionTypeExpense,
+			CategoryID:      sql.NullInt64{Int64: 10, Valid: true},
+			Reference:       sql.NullString{String: "REF-CHANGED", Valid: true},
+			TransactionDate: date,
+		},
+	}
+
+	trx := &mocks.TrxObj{}
+	trx.On("Commit").Return(nil).Once()
+	s.transactionRepo.On("Begin").Return(trx, nil).Once()
+	s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return([]*myentity.Category{{ID: 10, CreatedBy: 1}}, nil).Once()
+	s.transactionRepo.On("GetByUserIDAndReferences", mock.Anything, int64(1), []string{"REF-NEW", "REF-CHANGED", "REF-SAME"}).
+		Return(existing, nil).Once()
+	s.transactionRepo.On("Create", mock.Anything, trx, mock.MatchedBy(func(t *myentity.Transaction) bool {
+		return t.Reference.String == "REF-NEW" && t.UserID == 1
+	}), false).Run(func(args mock.Arguments) {
+		args.Get(2).(*myentity.Transaction).ID = 200
+	}).Return(nil).Once()
+	s.transactionRepo.On("Update", mock.Anything, trx, mock.MatchedBy(func(t *myentity.Transaction) bool {
+		// Kategori dikosongkan harus ikut tersimpan sebagai NULL
+		return t.ID == 101 && t.Amount == 80000 && !t.CategoryID.Valid
+	}), (*myentity.Transaction)(nil)).Return(nil).Once()
+
+	result, err := s.usecase.Sync(s.ctx, 1, usecaseEntity.SyncTransactionReq{
+		Rows: []usecaseEntity.SyncTransactionRow{
+			{Reference: "REF-NEW", Amount: 10000, Type: usecaseEntity.TransactionTypeIncomeStr, TransactionDate: "2024-01-06"},
+			{Reference: "REF-CHANGED", Amount: 80000, Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-01-05"},
+			{Reference: "REF-SAME", CategoryID: &categoryID, Description: &description, Amount: 50000, Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-01-05"},
+		},
+	})
+	s.Require().NoError(err)
+
+	s.Equal([]usecaseEntity.SyncResult{
+		{Reference: "REF-NEW", ID: 200, Action: usecaseEntity.SyncActionCreated},
+		{Reference: "REF-CHANGED", ID: 101, Action: usecaseEntity.SyncActionUpdated},
+		{Reference: "REF-SAME", ID: 100, Action: usecaseEntity.SyncActionUnchanged},
+	}, result.Results)
+	s.transactionRepo.AssertExpectations(s.T())
+	trx.AssertExpectations(s.T())
+}
+
+func (s *CrudTransactionTestSuite) TestSyncCurrency() {
+	date, _ := time.Parse(helper.DateLayout, "2024-01-05")
+	existing := []*myentity.Transaction{
+		{
+			ID: 100, UserID: 1, Amount: 12.5, Currency: "IDR", Type: myentity.TransactionTypeExpense,
+			Reference:       sql.NullString{String: "REF-USD", Valid: true},
+			TransactionDate: date,
+		},
+	}
+
+	s.Run("currency change is an update", func() {
+		trx := &mocks.TrxObj{}
+		trx.On("Commit").Return(nil).Once()
+		s.transactionRepo.On("Begin").Return(trx, nil).Once()
+		s.transactionRepo.On("GetByUserIDAndReferences", mock.Anything, int64(1), []string{"REF-USD"}).Return(existing, nil).Once()
+		s.transactionRepo.On("Update", mock.Anything, trx, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return t.ID == 100 && t.Currency == "USD"
+		}), (*myentity.Transaction)(nil)).Return(nil).Once()
+
+		result, err := s.usecase.Sync(s.ctx, 1, usecaseEntity.SyncTransactionReq{
+			Rows: []usecaseEntity.SyncTransactionRow{
+				{Reference: "REF-USD", Amount: 12.5, Currency: ptr("USD"), Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-01-05"},
+			},
+		})
+		s.Require().NoError(err)
+		s.Equal(usecaseEntity.SyncActionUpdated, result.Results[0].Action)
+		trx.AssertExpectations(s.T())
+	})
+
+	s.Run("invalid currency is rejected before writing", func() {
+		_, err := s.usecase.Sync(s.ctx, 1, usecaseEntity.SyncTransactionReq{
+			Rows: []usecaseEntity.SyncTransactionRow{
+				{Reference: "REF-X", Amount: 12.5, Currency: ptr("US"), Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-01-05"},
+			},
+		})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+
+	s.transactionRepo.AssertExpectations(s.T())
+}
+
+func (s *CrudTransactionTestSuite) TestSyncRequiresReference() {
+	_, err := s.usecase.Sync(s.ctx, 1, usecaseEntity.SyncTransactionReq{
+		Rows: []usecaseEntity.SyncTransactionRow{
+			{Amount: 10000, Type: usecaseEntity.TransactionTypeIncomeStr, TransactionDate: "2024-01-06"},
+		},
+	})
+
+	var appErr apperr.CustomErrorResponse
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+}
+
+func (s *CrudTransactionTestSuite) TestSyncCategoryType() {
+	categories := []*myentity.Category{
+		{ID: 3, CreatedBy: 1, Name: "Gaji", Type: myentity.TransactionTypeIncome},
+		{ID: 4, CreatedBy: 1, Name: "Lainnya"},
+	}
+
+	s.Run("income on an expense category is rejected", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return([]*myentity.Category{{ID: 5, CreatedBy: 1, Name: "Makan", Type: myentity.TransactionTypeExpense}}, nil).Once()
+
+		_, err := s.usecase.Sync(s.ctx, 1, usecaseEntity.SyncTransactionReq{Rows: []usecaseEntity.SyncTransactionRow{
+			{Reference: "REF-1", CategoryID: ptr(int64(5)), Amount: 10000, Type: usecaseEntity.TransactionTypeIncomeStr, TransactionDate: "2024-01-06"},
+		}})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "GetByUserIDAndReferences", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("matching and untyped categories are accepted", func() {
+		s.SetupTest()
+		trx := &mocks.TrxObj{}
+		trx.On("Commit").Return(nil).Once()
+		s.transactionRepo.On("Begin").Return(trx, nil).Once()
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return(categories, nil).Once()
+		s.transactionRepo.On("GetByUserIDAndReferences", mock.Anything, int64(1), []string{"REF-1", "REF-2"}).Return([]*myentity.Transaction{}, nil).Once()
+		s.transactionRepo.On("Create", mock.Anything, trx, mock.Anything, false).Return(nil).Twice()
+
+		result, err := s.usecase.Sync(s.ctx, 1, usecaseEntity.SyncTransactionReq{Rows: []usecaseEntity.SyncTransactionRow{
+			{Reference: "REF-1", CategoryID: ptr(int64(3)), Amount: 10000, Type: usecaseEntity.TransactionTypeIncomeStr, TransactionDate: "2024-01-06"},
+			{Reference: "REF-2", CategoryID: ptr(int64(4)), Amount: 5000, Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-01-06"},
+		}})
+		s.Require().NoError(err)
+		s.Len(result.Results, 2)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestCreateBySuspendedUser() {
+	s.userStatus.On("EnsureActive", mock.Anything, int64(2)).
+		Return(apperr.ErrUnauthorized().SetDetail("User account is not active.")).Once()
+
+	err := s.usecase.Create(s.ctx, 2, usecaseEntity.TransactionReq{
+		Amount:          ptr(15000.0),
+		Type:            ptr(usecaseEntity.TransactionTypeExpenseStr),
+		TransactionDate: "2024-01-05",
+	})
+
+	var appErr apperr.CustomErrorResponse
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(http.StatusUnauthorized, appErr.HTTPCode)
+	s.transactionRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *CrudTransactionTestSuite) TestGetAllNullRepresentation() {
+	rows := []*mysql.TransactionWithCategory{
+		{Transaction: myentity.Transaction{ID: 5, UserID: 1, Amount: 15000, Type: myentity.TransactionTypeExpense, TransactionDate: time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)}},
+	}
+	enabled, disabled := true, false
+
+	s.Run("null by default", func() {
+		s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}, (*mysql.TransactionCursor)(nil), 21).Return(rows, nil).Once()
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}).Return(int64(3), nil).Once()
+
+		result, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{})
+		s.Require().NoError(err)
+		s.Require().Len(result.Data, 1)
+
+		s.Nil(result.Data[0].Description)
+		s.Nil(result.Data[0].CategoryName)
+	})
+
+	s.Run("empty string when requested", func() {
+		s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}, (*mysql.TransactionCursor)(nil), 21).Return(rows, nil).Once()
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}).Return(int64(3), nil).Once()
+
+		result, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{Format: usecaseEntity.ResponseFormatReq{NullAsEmpty: &enabled}})
+		s.Require().NoError(err)
+		s.Require().Len(result.Data, 1)
+
+		s.Require().NotNil(result.Data[0].Description)
+		s.Equal("", *result.Data[0].Description)
+		s.Require().NotNil(result.Data[0].CategoryName)
+		s.Equal("", *result.Data[0].CategoryName)
+	})
+
+	s.Run("request overrides config default", func() {
+		usecase := transactions_usecase.NewCrudTransaction(s.transactionRepo, s.categoryRepo, config.SummaryOption{},
+			config.CurrencyOption{Code: "IDR"}, config.ResponseOption{NullAsEmpty: true}, config.SearchOption{MinQueryLength: 2}, config.SpendingCapOption{}, s.userStatus, s.userTimezone, s.periodLock, queue.NoopPublisher{})
+		s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}, (*mysql.TransactionCursor)(nil), 21).Return(rows, nil).Twice()
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}).Return(int64(3), nil).Twice()
+
+		result, err := usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{})
+		s.Require().NoError(err)
+		s.Require().NotNil(result.Data[0].Description)
+		s.Equal("", *result.Data[0].Description)
+
+		result, err = usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{Format: usecaseEntity.ResponseFormatReq{NullAsEmpty: &disabled}})
+		s.Require().NoError(err)
+		s.Nil(result.Data[0].Description)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestGetYears() {
+	s.Run("years across a year boundary", func() {
+		s.transactionRepo.On("GetYearsByUserID", mock.Anything, int64(1)).Return([]int{2025, 2024}, nil).Once()
+
+		result, err := s.usecase.GetYears(s.ctx, 1)
+		s.Require().NoError(err)
+		s.Equal([]int{2025, 2024}, result)
+	})
+
+	s.Run("empty array without transactions", func() {
+		s.transactionRepo.On("GetYearsByUserID", mock.Anything, int64(2)).Return(nil, nil).Once()
+
+		result, err := s.usecase.GetYears(s.ctx, 2)
+		s.Require().NoError(err)
+		s.NotNil(result)
+		s.Empty(result)
+	})
+
+	s.Run("missing user", func() {
+		_, err := s.usecase.GetYears(s.ctx, 0)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestGetDateRange() {
+	s.Run("earliest and latest", func() {
+		s.transactionRepo.On("GetDateRangeByUserID", mock.Anything, int64(1)).Return(&mysql.TransactionDateRange{
+			Earliest: sql.NullString{String: "2021-03-04", Valid: true},
+			Latest:   sql.NullString{String: "2024-01-31", Valid: true},
+		}, nil).Once()
+
+		result, err := s.usecase.GetDateRange(s.ctx, 1)
+		s.Require().NoError(err)
+		s.Require().NotNil(result.Earliest)
+		s.Require().NotNil(result.Latest)
+		s.Equal("2021-03-04", *result.Earliest)
+		s.Equal("2024-01-31", *result.Latest)
+	})
+
+	s.Run("nulls without transactions", func() {
+		s.transactionRepo.On("GetDateRangeByUserID", mock.Anything, int64(2)).Return(&mysql.TransactionDateRange{}, nil).Once()
+
+		result, err := s.usecase.GetDateRange(s.ctx, 2)
+		s.Require().NoError(err)
+		s.Nil(result.Earliest)
+		s.Nil(result.Latest)
+	})
+
+	s.Run("missing user", func() {
+		_, err := s.usecase.GetDateRange(s.ctx, 0)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestGetAllCursor() {
+	date := time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)
+	page := func(ids ...int64) []*mysql.TransactionWithCategory {
+		rows := make([]*mysql.TransactionWithCategory, 0, len(ids))
+		for _, id := range ids {
+			rows = append(rows, &mysql.TransactionWithCategory{Transaction: myentity.Transaction{ID: id, UserID: 1, Amount: float64(id) * 1000, TransactionDate: date}})
+		}
+		return rows
+	}
+
+	s.Run("next cursor carries the last row position", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}, (*mysql.TransactionCursor)(nil), 3).
+			Return(page(12, 9, 4), nil).Once()
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}).Return(int64(3), nil).Once()
+
+		result, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{Limit: 2})
+		s.Require().NoError(err)
+
+		s.Require().Len(result.Data, 2)
+		s.Equal(int64(12), result.Data[0].ID)
+		s.Equal(int64(9), result.Data[1].ID)
+		s.Require().NotNil(result.NextCursor)
+		cursor, err := mysql.DecodeTransactionCursor(*result.NextCursor)
+		s.Require().NoError(err)
+		s.Equal(int64(9), cursor.ID)
+		s.Equal(9000.0, cursor.Amount)
+		s.True(date.Equal(cursor.TransactionDate))
+	})
+
+	s.Run("cursor is decoded without reading the transaction again", func() {
+		s.SetupTest()
+		after := &mysql.TransactionCursor{TransactionDate: date, ID: 9}
+		s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}, mock.MatchedBy(func(cursor *mysql.TransactionCursor) bool {
+			return cursor.ID == 9 && cursor.TransactionDate.Equal(date)
+		}), 3).Return(page(4), nil).Once()
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}).Return(int64(3), nil).Once()
+
+		result, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{Limit: 2, Cursor: after.Encode()})
+		s.Require().NoError(err)
+
+		s.Require().Len(result.Data, 1)
+		s.Nil(result.NextCursor)
+		s.transactionRepo.AssertNotCalled(s.T(), "GetByIDAndUserID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("cursor carries amount for amount sort", func() {
+		s.SetupTest()
+		after := &mysql.TransactionCursor{TransactionDate: date, Amount: 15000, ID: 9}
+		s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), mysql.TransactionFilter{Sort: mysql.TransactionSortAmountDesc}, mock.MatchedBy(func(cursor *mysql.TransactionCursor) bool {
+			return cursor.ID == 9 && cursor.Amount == 15000
+		}), 3).Return(page(4), nil).Once()
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), mysql.TransactionFilter{Sort: mysql.TransactionSortAmountDesc}).Return(int64(3), nil).Once()
+
+		_, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{Limit: 2, Cursor: after.Encode(), Sort: "amount_desc"})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("by created_at filters and pages on created_at", func() {
+		s.SetupTest()
+		createdAt := time.Date(2024, time.January, 10, 8, 30, 0, 0, time.UTC)
+		after := &mysql.TransactionCursor{TransactionDate: date, CreatedAt: createdAt, ID: 9}
+		filter := mysql.TransactionFilter{StartDate: "2024-01-10", DateColumn: mysql.DateColumnCreatedAt}
+		s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), filter, mock.MatchedBy(func(cursor *mysql.TransactionCursor) bool {
+			return cursor.ID == 9 && cursor.CreatedAt.Equal(createdAt)
+		}), 3).Return(page(4), nil).Once()
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), filter).Return(int64(3), nil).Once()
+
+		_, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{Limit: 2, Cursor: after.Encode(), StartDate: "2024-01-10", By: "created_at"})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("limit is capped", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}, (*mysql.TransactionCursor)(nil), 101).
+			Return(page(), nil).Once()
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}).Return(int64(3), nil).Once()
+
+		result, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{Limit: 500})
+		s.Require().NoError(err)
+		s.Empty(result.Data)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	for _, cursor := range []string{"77", "not base64!", "eyJkIjoxfQ"} {
+		s.Run("malformed cursor "+cursor, func() {
+			s.SetupTest()
+
+			_, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{Cursor: cursor})
+
+			var appErr apperr.CustomErrorResponse
+			s.Require().ErrorAs(err, &appErr)
+			s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+			s.transactionRepo.AssertNotCalled(s.T(), "GetAllByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func (s *CrudTransactionTestSuite) TestGetAllIncludeDeleted() {
+	date := time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)
+	deletedAt := time.Date(2024, time.January, 6, 3, 0, 0, 0, time.UTC)
+
+	s.Run("deleted rows carry deleted_at", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), mysql.TransactionFilter{IncludeDeleted: true}, (*mysql.TransactionCursor)(nil), 21).
+			Return([]*mysql.TransactionWithCategory{
+				{Transaction: myentity.Transaction{ID: 9, UserID: 1, TransactionDate: date, DeletedAt: sql.NullTime{Time: deletedAt, Valid: true}}},
+				{Transaction: myentity.Transaction{ID: 4, UserID: 1, TransactionDate: date}},
+			}, nil).Once()
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), mysql.TransactionFilter{IncludeDeleted: true}).Return(int64(3), nil).Once()
+
+		result, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{IncludeDeleted: true})
+		s.Require().NoError(err)
+		s.Require().Len(result.Data, 2)
+
+		// Zona waktu default Asia/Jakarta (UTC+7)
+		s.Require().NotNil(result.Data[0].DeletedAt)
+		s.Equal("2024-01-06 10:00:00", *result.Data[0].DeletedAt)
+		s.Nil(result.Data[1].DeletedAt)
+	})
+
+	s.Run("cursor of a transaction deleted after the previous page still works", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}, mock.MatchedBy(func(cursor *mysql.TransactionCursor) bool {
+			return cursor.ID == 9
+		}), 3).Return([]*mysql.TransactionWithCategory{}, nil).Once()
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}).Return(int64(2), nil).Once()
+
+		after := mysql.TransactionCursor{TransactionDate: date, ID: 9}
+		_, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{Limit: 2, Cursor: after.Encode()})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestGetAllTotal() {
+	date := time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)
+	rows := func(types ...myentity.TransactionType) []*mysql.TransactionWithCategory {
+		result := make([]*mysql.TransactionWithCategory, 0, len(types))
+		for i, txType := range types {
+			result = append(result, &mysql.TransactionWithCategory{Transaction: myentity.Transaction{ID: int64(10 - i), UserID: 1, Type: txType, TransactionDate: date}})
+		}
+		return result
+	}
+
+	// Tanpa filter: 347 transaksi, halaman pertama 2 baris
+	s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}, (*mysql.TransactionCursor)(nil), 3).
+		Return(rows(myentity.TransactionTypeExpense, myentity.TransactionTypeIncome, myentity.TransactionTypeExpense), nil).Once()
+	s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}).Return(int64(347), nil).Once()
+	// Dengan filter type=income: baris dan total sama-sama mengikuti filter
+	incomeFilter := mysql.TransactionFilter{Type: myentity.TransactionTypeIncome}
+	s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), incomeFilter, (*mysql.TransactionCursor)(nil), 3).
+		Return(rows(myentity.TransactionTypeIncome), nil).Once()
+	s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), incomeFilter).Return(int64(1), nil).Once()
+
+	all, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{Limit: 2})
+	s.Require().NoError(err)
+	s.Len(all.Data, 2)
+	s.Equal(int64(347), all.Total)
+
+	income, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{Limit: 2, Type: usecaseEntity.TransactionTypeIncomeStr})
+	s.Require().NoError(err)
+	s.Len(income.Data, 1)
+	s.Equal(usecaseEntity.TransactionTypeIncomeStr, income.Data[0].Type)
+	s.Equal(int64(1), income.Total)
+	s.Nil(income.NextCursor)
+
+	s.transactionRepo.AssertExpectations(s.T())
+}
+
+func (s *CrudTransactionTestSuite) TestGetAllFilter() {
+	categoryID := int64(7)
+
+	s.Run("filters are passed to the repository", func() {
+		s.SetupTest()
+		filter := mysql.TransactionFilter{StartDate: "2024-01-01", EndDate: "2024-01-31", Type: myentity.TransactionTypeExpense, CategoryID: &categoryID}
+		s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), filter, (*mysql.TransactionCursor)(nil), 21).
+			Return([]*mysql.TransactionWithCategory{}, nil).Once()
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), filter).Return(int64(3), nil).Once()
+
+		_, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{
+			StartDate:  "2024-01-01",
+			EndDate:    "2024-01-31",
+			Type:       usecaseEntity.TransactionTypeExpenseStr,
+			CategoryID: &categoryID,
+		})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	testCases := []struct {
+		name string
+		req  usecaseEntity.TransactionCursorReq
+	}{
+		{name: "unknown type", req: usecaseEntity.TransactionCursorReq{Type: "transfer"}},
+		{name: "invalid start_date", req: usecaseEntity.TransactionCursorReq{StartDate: "2024-13-01"}},
+		{name: "invalid end_date", req: usecaseEntity.TransactionCursorReq{EndDate: "31-01-2024"}},
+		{name: "unknown by", req: usecaseEntity.TransactionCursorReq{By: "updated_at"}},
+	}
+	for _, tt := range testCases {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+
+			_, err := s.usecase.GetAll(s.ctx, 1, tt.req)
+
+			var appErr apperr.CustomErrorResponse
+			s.Require().ErrorAs(err, &appErr)
+			s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+			s.transactionRepo.AssertNotCalled(s.T(), "GetAllByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func (s *CrudTransactionTestSuite) TestStreamAllFilter() {
+	s.Run("date range is passed to the repository", func() {
+		s.SetupTest()
+		s.transactionRepo.On("StreamAllByUserID", mock.Anything, int64(1), mysql.TransactionFilter{StartDate: "2024-01-01", EndDate: "2024-01-31"}, mock.Anything).
+			Return(nil).Once()
+
+		err := s.usecase.StreamAll(s.ctx, 1, usecaseEntity.TransactionExportReq{StartDate: "2024-01-01", EndDate: "2024-01-31"}, func(usecaseEntity.TransactionResponse) error {
+			return nil
+		})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("invalid date is rejected", func() {
+		s.SetupTest()
+
+		err := s.usecase.StreamAll(s.ctx, 1, usecaseEntity.TransactionExportReq{StartDate: "2024-02-30", EndDate: "2024-03-31"}, func(usecaseEntity.TransactionResponse) error {
+			return nil
+		})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "StreamAllByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestGetAllDateFormat() {
+	createdAt := time.Date(2024, time.March, 4, 2, 30, 0, 0, time.UTC)
+	rows := []*mysql.TransactionWithCategory{
+		{Transaction: myentity.Transaction{ID: 5, UserID: 1, Amount: 15000, Type: myentity.TransactionTypeExpense,
+			TransactionDate: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), CreatedAt: createdAt, UpdatedAt: createdAt}},
+	}
+
+	s.Run("ISO by default", func() {
+		s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}, (*mysql.TransactionCursor)(nil), 21).Return(rows, nil).Once()
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}).Return(int64(3), nil).Once()
+
+		result, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{})
+		s.Require().NoError(err)
+		s.Require().Len(result.Data, 1)
+
+		s.Equal("2024-03-04", result.Data[0].TransactionDate)
+		s.Equal("2024-03-04 09:30:00", result.Data[0].CreatedAt)
+		s.Equal("2024-03-04 09:30:00", result.Data[0].UpdatedAt)
+	})
+
+	s.Run("DD/MM/YYYY when requested", func() {
+		s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}, (*mysql.TransactionCursor)(nil), 21).Return(rows, nil).Once()
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}).Return(int64(3), nil).Once()
+
+		result, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{Format: usecaseEntity.ResponseFormatReq{DateFormat: string(helper.DateFormatDMYSlash)}})
+		s.Require().NoError(err)
+		s.Require().Len(result.Data, 1)
+
+		s.Equal("04/03/2024", result.Data[0].TransactionDate)
+		s.Equal("04/03/2024 09:30:00", result.Data[0].CreatedAt)
+		s.Equal("04/03/2024 09:30:00", result.Data[0].UpdatedAt)
+	})
+
+	s.Run("created_at in the user's timezone", func() {
+		s.SetupTest()
+		s.userTimezone = &mocks.IUserTimezone{}
+		s.userTimezone.On("Location", mock.Anything, int64(1)).Return(helper.LoadTimezone("Europe/Berlin"), nil).Once()
+		s.usecase = transactions_usecase.NewCrudTransaction(s.transactionRepo, s.categoryRepo, config.SummaryOption{},
+			config.CurrencyOption{Code: "IDR"}, config.ResponseOption{}, config.SearchOption{MinQueryLength: 2}, config.SpendingCapOption{}, s.userStatus, s.userTimezone, s.periodLock, s.publisher)
+		s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}, (*mysql.TransactionCursor)(nil), 21).Return(rows, nil).Once()
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), mysql.TransactionFilter{}).Return(int64(3), nil).Once()
+
+		result, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{})
+		s.Require().NoError(err)
+		s.Require().Len(result.Data, 1)
+
+		// transaction_date adalah tanggal kalender, tidak ikut dikonversi
+		s.Equal("2024-03-04", result.Data[0].TransactionDate)
+		s.Equal("2024-03-04 03:30:00", result.Data[0].CreatedAt)
+	})
+
+	s.Run("timezone lookup failure", func() {
+		s.SetupTest()
+		s.userTimezone = &mocks.IUserTimezone{}
+		s.userTimezone.On("Location", mock.Anything, int64(1)).Return(nil, errors.New("db down")).Once()
+		s.usecase = transactions_usecase.NewCrudTransaction(s.transactionRepo, s.categoryRepo, config.SummaryOption{},
+			config.CurrencyOption{Code: "IDR"}, config.ResponseOption{}, config.SearchOption{MinQueryLength: 2}, config.SpendingCapOption{}, s.userStatus, s.userTimezone, s.periodLock, s.publisher)
+
+		_, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{})
+		s.Error(err)
+		s.transactionRepo.AssertNotCalled(s.T(), "GetAllByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("layout outside allowlist", func() {
+		_, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{Format: usecaseEntity.ResponseFormatReq{DateFormat: "2006-01-02"}})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestCreateValidatesCoordinates() {
+	latitude, longitude := -6.2088, 106.8456
+	outOfRange := 95.0
+
+	testCases := []struct {
+		name      string
+		latitude  *float64
+		longitude *float64
+		wantErr   bool
+	}{
+		{name: "latitude out of range", latitude: &outOfRange, longitude: &longitude, wantErr: true},
+		{name: "longitude without latitude", longitude: &longitude, wantErr: true},
+		{name: "valid coordinates", latitude: &latitude, longitude: &longitude},
+	}
+
+	for _, tt := range testCases {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			if !tt.wantErr {
+				s.transactionRepo.On("Create", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+					return t.Latitude.Valid && t.Latitude.Float64 == latitude && t.Longitude.Float64 == longitude
+				}), false).Return(nil).Once()
+			}
+
+			err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{
+				Amount:          ptr(15000.0),
+				Type:            ptr(usecaseEntity.TransactionTypeExpenseStr),
+				TransactionDate: "2024-01-05",
+				Latitude:        tt.latitude,
+				Longitude:       tt.longitude,
+			})
+
+			if tt.wantErr {
+				var appErr apperr.CustomErrorResponse
+				s.Require().ErrorAs(err, &appErr)
+				s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+				s.transactionRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			s.NoError(err)
+			s.transactionRepo.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *CrudTransactionTestSuite) TestAmountColumnRange() {
+	testCases := []struct {
+		name     string
+		amount   float64
+		currency string
+		wantErr  bool
+	}{
+		{name: "max decimal(15,2) value", amount: 9999999999999.99, currency: "USD"},
+		{name: "one cent over max", amount: 10000000000000.00, currency: "USD", wantErr: true},
+		{name: "astronomically large", amount: 1e20, currency: "IDR", wantErr: true},
+		// BHD mengizinkan 3 desimal, tetapi kolom hanya menyimpan 2 sehingga ditolak daripada dibulatkan
+		{name: "three decimals", amount: 1.125, currency: "BHD", wantErr: true},
+	}
+
+	for _, tt := range testCases {
+		s.Run("create with "+tt.name, func() {
+			s.SetupTest()
+			if !tt.wantErr {
+				s.transactionRepo.On("Create", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+					return t.Amount == tt.amount
+				}), false).Return(nil).Once()
+			}
+
+			err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{
+				Amount:          ptr(tt.amount),
+				Currency:        ptr(tt.currency),
+				Type:            ptr(usecaseEntity.TransactionTypeExpenseStr),
+				TransactionDate: "2024-01-05",
+			})
+
+			if tt.wantErr {
+				var appErr apperr.CustomErrorResponse
+				s.Require().ErrorAs(err, &appErr)
+				s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+				s.Contains(appErr.Detail, "Invalid amount")
+				s.transactionRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			s.NoError(err)
+			s.transactionRepo.AssertExpectations(s.T())
+		})
+	}
+
+	s.Run("update one cent over max", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(20), int64(1)).
+			Return(&myentity.Transaction{ID: 20, UserID: 1, Amount: 15000, Type: myentity.TransactionTypeExpense, Currency: "USD",
+				TransactionDate: time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)}, nil).Once()
+
+		err := s.usecase.Update(s.ctx, 20, 1, usecaseEntity.TransactionReq{Amount: ptr(10000000000000.00)})
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestDescriptionLength() {
+	testCases := []struct {
+		name        string
+		description *string
+		wantErr     bool
+	}{
+		{name: "nil description", description: nil},
+		{name: "exactly 255 characters", description: ptr(strings.Repeat("x", 255))},
+		{name: "256 characters", description: ptr(strings.Repeat("x", 256)), wantErr: true},
+	}
+
+	for _, tt := range testCases {
+		s.Run("create with "+tt.name, func() {
+			s.SetupTest()
+			if !tt.wantErr {
+				s.transactionRepo.On("Create", mock.Anything, nil, mock.Anything, false).Return(nil).Once()
+			}
+
+			err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{
+				Amount:          ptr(15000.0),
+				Type:            ptr(usecaseEntity.TransactionTypeExpenseStr),
+				TransactionDate: "2024-01-05",
+				Description:     tt.description,
+			})
+
+			if tt.wantErr {
+				var appErr apperr.CustomErrorResponse
+				s.Require().ErrorAs(err, &appErr)
+				s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+				s.transactionRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			s.NoError(err)
+			s.transactionRepo.AssertExpectations(s.T())
+		})
+	}
+
+	s.Run("update with 256 characters", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(20), int64(1)).
+			Return(&myentity.Transaction{ID: 20, UserID: 1, Amount: 15000, Type: myentity.TransactionTypeExpense, Currency: "IDR",
+				TransactionDate: time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)}, nil).Once()
+
+		err := s.usecase.Update(s.ctx, 20, 1, usecaseEntity.TransactionReq{Description: ptr(strings.Repeat("x", 256))})
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestMetadata() {
+	s.Run("stored as JSON object", func() {
+		s.SetupTest()
+		s.transactionRepo.On("Create", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return t.Metadata == sql.NullString{String: `{"project":"PRJ-7","trip":"bali"}`, Valid: true}
+		}), false).Return(nil).Once()
+
+		err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{
+			Amount:          ptr(15000.0),
+			Type:            ptr(usecaseEntity.TransactionTypeExpenseStr),
+			TransactionDate: "2024-01-05",
+			Metadata:        map[string]string{"trip": "bali", "project": "PRJ-7"},
+		})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("empty map stored as NULL", func() {
+		s.SetupTest()
+		s.transactionRepo.On("Create", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return !t.Metadata.Valid
+		}), false).Return(nil).Once()
+
+		err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{
+			Amount:          ptr(15000.0),
+			Type:            ptr(usecaseEntity.TransactionTypeExpenseStr),
+			TransactionDate: "2024-01-05",
+			Metadata:        map[string]string{},
+		})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("invalid key is rejected", func() {
+		s.SetupTest()
+
+		err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{
+			Amount:          ptr(15000.0),
+			Type:            ptr(usecaseEntity.TransactionTypeExpenseStr),
+			TransactionDate: "2024-01-05",
+			Metadata:        map[string]string{"trip.name": "bali"},
+		})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("filtered list returns metadata", func() {
+		s.SetupTest()
+		filter := mysql.TransactionFilter{Metadata: map[string]string{"trip": "bali"}}
+		rows := []*mysql.TransactionWithCategory{
+			{Transaction: myentity.Transaction{ID: 1, Metadata: sql.NullString{String: `{"trip":"bali","project":"PRJ-7"}`, Valid: true}}},
+		}
+
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), filter).Return(int64(1), nil).Once()
+		s.transactionRepo.On("ListByUserID", mock.Anything, int64(1), filter, 20, 0).Return(rows, nil).Once()
+
+		result, err := s.usecase.List(s.ctx, 1, usecaseEntity.TransactionListReq{Page: 1, Metadata: map[string]string{"trip": "bali"}})
+		s.Require().NoError(err)
+
+		s.Require().Len(result.Data, 1)
+		s.Equal(map[string]string{"trip": "bali", "project": "PRJ-7"}, result.Data[0].Metadata)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("invalid filter key is rejected", func() {
+		s.SetupTest()
+
+		_, err := s.usecase.List(s.ctx, 1, usecaseEntity.TransactionListReq{Page: 1, Metadata: map[string]string{`trip"`: "bali"}})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "ListByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("cursor list filters by metadata", func() {
+		s.SetupTest()
+		filter := mysql.TransactionFilter{Metadata: map[string]string{"trip": "bali"}}
+		s.transactionRepo.On("GetAllByUserID", mock.Anything, int64(1), filter, (*mysql.TransactionCursor)(nil), 21).
+			Return([]*mysql.TransactionWithCategory{}, nil).Once()
+		s.transactionRepo.On("CountByUserID", mock.Anything, int64(1), filter).Return(int64(0), nil).Once()
+
+		_, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{Metadata: map[string]string{"trip": "bali"}})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("invalid filter key is rejected on the cursor list", func() {
+		s.SetupTest()
+
+		_, err := s.usecase.GetAll(s.ctx, 1, usecaseEntity.TransactionCursorReq{Metadata: map[string]string{`trip"`: "bali"}})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "GetAllByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestPartialUpdate() {
+	existing := func() *myentity.Transaction {
+		return &myentity.Transaction{
+			ID:              20,
+			UserID:          1,
+			CategoryID:      sql.NullInt64{Int64: 7, Valid: true},
+			Amount:          15000,
+			Type:            myentity.TransactionTypeExpense,
+			Description:     sql.NullString{String: "Makan siang", Valid: true},
+			TransactionDate: time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC),
+		}
+	}
+
+	s.Run("only amount changes", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(20), int64(1)).Return(existing(), nil).Once()
+		s.transactionRepo.On("Update", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return t.ID == 20 && t.Amount == 17500 &&
+				t.Type == myentity.TransactionTypeExpense &&
+				t.Description == sql.NullString{String: "Makan siang", Valid: true} &&
+				t.CategoryID == sql.NullInt64{Int64: 7, Valid: true} &&
+				t.TransactionDate.Equal(time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC))
+		}), (*myentity.Transaction)(nil)).Return(nil).Once()
+
+		err := s.usecase.Update(s.ctx, 20, 1, usecaseEntity.TransactionReq{Amount: ptr(17500.0)})
+		s.Require().NoError(err)
+		s.categoryRepo.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("category_id 0 removes the category", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(20), int64(1)).Return(existing(), nil).Once()
+		s.transactionRepo.On("Update", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return !t.CategoryID.Valid && t.Amount == 15000 && t.Description.Valid
+		}), (*myentity.Transaction)(nil)).Return(nil).Once()
+
+		err := s.usecase.Update(s.ctx, 20, 1, usecaseEntity.TransactionReq{CategoryID: ptr(int64(0))})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("invalid type is rejected", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(20), int64(1)).Return(existing(), nil).Once()
+
+		err := s.usecase.Update(s.ctx, 20, 1, usecaseEntity.TransactionReq{Type: ptr(usecaseEntity.TransactionTypeString("transfer"))})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("malformed transaction_date is rejected by the struct tags", func() {
+		s.SetupTest()
+
+		err := s.usecase.Update(s.ctx, 20, 1, usecaseEntity.TransactionReq{TransactionDate: "05/01/2024"})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "GetByIDAndUserID", mock.Anything, int64(20), int64(1))
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestUpdateVersion() {
+	existing := func() *myentity.Transaction {
+		return &myentity.Transaction{ID: 20, UserID: 1, Amount: 15000, Type: myentity.TransactionTypeExpense, Version: 4,
+			TransactionDate: time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)}
+	}
+
+	s.Run("version read at the start is used without a version in the request", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(20), int64(1)).Return(existing(), nil).Once()
+		s.transactionRepo.On("Update", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return t.Version == 4 && t.Amount == 17500
+		}), (*myentity.Transaction)(nil)).Return(nil).Once()
+
+		err := s.usecase.Update(s.ctx, 20, 1, usecaseEntity.TransactionReq{Amount: ptr(17500.0)})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("stale version from another device is a conflict", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(20), int64(1)).Return(existing(), nil).Once()
+		// Device lain sudah menyimpan perubahan (version 4), device ini masih memegang version 3
+		s.transactionRepo.On("Update", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return t.Version == 3
+		}), (*myentity.Transaction)(nil)).Return(apperr.ErrConflict().SetDetail("Transaction was modified by another request")).Once()
+
+		err := s.usecase.Update(s.ctx, 20, 1, usecaseEntity.TransactionReq{Amount: ptr(17500.0), Version: ptr(3)})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusConflict, appErr.HTTPCode)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("version must be positive", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(20), int64(1)).Return(existing(), nil).Once()
+
+		err := s.usecase.Update(s.ctx, 20, 1, usecaseEntity.TransactionReq{Amount: ptr(17500.0), Version: ptr(0)})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestAttachmentURL() {
+	receipt := "https://storage.example.com/receipts/abc.jpg"
+	assertInvalid := func(err error) {
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	}
+
+	s.Run("create stores the url", func() {
+		s.SetupTest()
+		s.transactionRepo.On("Create", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return t.AttachmentURL.Valid && t.AttachmentURL.String == receipt
+		}), false).Return(nil).Once()
+
+		err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{Amount: ptr(15000.0), Type: ptr(usecaseEntity.TransactionTypeExpenseStr),
+			TransactionDate: "2024-01-05", AttachmentURL: ptr(receipt)})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("create rejects a non http url", func() {
+		s.SetupTest()
+		err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{Amount: ptr(15000.0), Type: ptr(usecaseEntity.TransactionTypeExpenseStr),
+			TransactionDate: "2024-01-05", AttachmentURL: ptr("file:///tmp/receipt.jpg")})
+		assertInvalid(err)
+		s.transactionRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	existing := func() *myentity.Transaction {
+		return &myentity.Transaction{ID: 20, UserID: 1, Amount: 15000, Type: myentity.TransactionTypeExpense, Version: 2,
+			Description:     sql.NullString{String: "Makan siang", Valid: true},
+			AttachmentURL:   sql.NullString{String: receipt, Valid: true},
+			TransactionDate: time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)}
+	}
+
+	s.Run("update with an empty string clears only the attachment", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(20), int64(1)).Return(existing(), nil).Once()
+		s.transactionRepo.On("Update", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return !t.AttachmentURL.Valid && t.Amount == 15000 && t.Description.String == "Makan siang"
+		}), (*myentity.Transaction)(nil)).Return(nil).Once()
+
+		err := s.usecase.Update(s.ctx, 20, 1, usecaseEntity.TransactionReq{AttachmentURL: ptr("")})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("update without attachment_url keeps the attachment", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(20), int64(1)).Return(existing(), nil).Once()
+		s.transactionRepo.On("Update", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return t.AttachmentURL.String == receipt && t.Amount == 17500
+		}), (*myentity.Transaction)(nil)).Return(nil).Once()
+
+		err := s.usecase.Update(s.ctx, 20, 1, usecaseEntity.TransactionReq{Amount: ptr(17500.0)})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("update rejects an invalid url", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(20), int64(1)).Return(existing(), nil).Once()
+
+		err := s.usecase.Update(s.ctx, 20, 1, usecaseEntity.TransactionReq{AttachmentURL: ptr("not a url")})
+		assertInvalid(err)
+		s.transactionRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestNotes() {
+	memo := strings.Repeat("Dibayar patungan dengan tim. ", 20)
+
+	s.Run("create stores notes longer than description allows", func() {
+		s.SetupTest()
+		s.transactionRepo.On("Create", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return t.Notes.Valid && t.Notes.String == memo
+		}), false).Return(nil).Once()
+
+		err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{Amount: ptr(15000.0), Type: ptr(usecaseEntity.TransactionTypeExpenseStr),
+			TransactionDate: "2024-01-05", Notes: ptr(memo)})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("create without notes stores null", func() {
+		s.SetupTest()
+		s.transactionRepo.On("Create", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return !t.Notes.Valid
+		}), false).Return(nil).Once()
+
+		err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{Amount: ptr(15000.0), Type: ptr(usecaseEntity.TransactionTypeExpenseStr),
+			TransactionDate: "2024-01-05"})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	existing := func() *myentity.Transaction {
+		return &myentity.Transaction{ID: 20, UserID: 1, Amount: 15000, Type: myentity.TransactionTypeExpense, Version: 2,
+			Description:     sql.NullString{String: "Makan siang", Valid: true},
+			Notes:           sql.NullString{String: memo, Valid: true},
+			TransactionDate: time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)}
+	}
+
+	s.Run("update with an empty string clears only the notes", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(20), int64(1)).Return(existing(), nil).Once()
+		s.transactionRepo.On("Update", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return !t.Notes.Valid && t.Description.String == "Makan siang"
+		}), (*myentity.Transaction)(nil)).Return(nil).Once()
+
+		err := s.usecase.Update(s.ctx, 20, 1, usecaseEntity.TransactionReq{Notes: ptr("")})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("update without notes keeps the notes", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(20), int64(1)).Return(existing(), nil).Once()
+		s.transactionRepo.On("Update", mock.Anything, nil, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			return t.Notes.String == memo && t.Description.String == "Makan malam"
+		}), (*myentity.Transaction)(nil)).Return(nil).Once()
+
+		err := s.usecase.Update(s.ctx, 20, 1, usecaseEntity.TransactionReq{Description: ptr("Makan malam")})
+		s.Require().NoError(err)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("update rejects notes over the column size", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(20), int64(1)).Return(existing(), nil).Once()
+
+		err := s.usecase.Update(s.ctx, 20, 1, usecaseEntity.TransactionReq{Notes: ptr(strings.Repeat("x", helper.NotesMaxBytes+1))})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestCreateDuplicate() {
+	req := func(force bool) usecaseEntity.TransactionReq {
+		return usecaseEntity.TransactionReq{Amount: ptr(15000.0), Type: ptr(usecaseEntity.TransactionTypeExpenseStr),
+			TransactionDate: "2024-01-05", Force: force}
+	}
+	setup := func() {
+		s.SetupTest()
+		// Ganti default FindPossibleDuplicate dari SetupTest
+		s.transactionRepo.ExpectedCalls = nil
+	}
+
+	s.Run("same transaction within the window is a conflict", func() {
+		setup()
+		var since time.Time
+		s.transactionRepo.On("FindPossibleDuplicate", mock.Anything, mock.MatchedBy(func(t *myentity.Transaction) bool {
+			since = t.CreatedAt.Add(-5 * time.Minute)
+			return t.UserID == 1 && t.Amount == 15000 && t.Type == myentity.TransactionTypeExpense && !t.CategoryID.Valid
+		}), mock.MatchedBy(func(createdSince time.Time) bool {
+			return createdSince.Equal(since)
+		})).Return(&myentity.Transaction{ID: 42}, nil).Once()
+
+		err := s.usecase.Create(s.ctx, 1, req(false))
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusConflict, appErr.HTTPCode)
+		s.Contains(appErr.Detail, "id 42")
+		s.transactionRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("no duplicate is created", func() {
+		setup()
+		s.transactionRepo.On("FindPossibleDuplicate", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+		s.transactionRepo.On("Create", mock.Anything, nil, mock.Anything, false).Return(nil).Once()
+
+		s.Require().NoError(s.usecase.Create(s.ctx, 1, req(false)))
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("force skips the check", func() {
+		setup()
+		s.transactionRepo.On("Create", mock.Anything, nil, mock.Anything, false).Return(nil).Once()
+
+		s.Require().NoError(s.usecase.Create(s.ctx, 1, req(true)))
+		s.transactionRepo.AssertNotCalled(s.T(), "FindPossibleDuplicate", mock.Anything, mock.Anything, mock.Anything)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestCategoryOfAnotherUserIsForbidden() {
+	s.categoryRepo.On("GetByID", mock.Anything, int64(5)).
+		Return(&myentity.Category{ID: 5, CreatedBy: 2, Name: "Makan"}, nil).Once()
+
+	err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{Amount: ptr(1000.0), Type: ptr(usecaseEntity.TransactionTypeExpenseStr),
+		CategoryID: ptr(int64(5)), TransactionDate: "2024-01-05"})
+
+	var appErr apperr.CustomErrorResponse
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(http.StatusForbidden, appErr.HTTPCode)
+	s.transactionRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *CrudTransactionTestSuite) TestCategoryTypeMismatch() {
+	assertInvalid := func(err error) {
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	}
+
+	s.Run("create with a category of another type", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(3)).
+			Return(&myentity.Category{ID: 3, CreatedBy: 1, Name: "Gaji", Type: myentity.TransactionTypeIncome}, nil).Once()
+
+		err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{Amount: ptr(1000.0), Type: ptr(usecaseEntity.TransactionTypeExpenseStr),
+			CategoryID: ptr(int64(3)), TransactionDate: "2024-01-05"})
+		assertInvalid(err)
+		s.transactionRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("untyped category is usable for both types", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(4)).
+			Return(&myentity.Category{ID: 4, CreatedBy: 1, Name: "Lainnya"}, nil).Twice()
+		s.transactionRepo.On("Create", mock.Anything, nil, mock.Anything, false).Return(nil).Twice()
+
+		for _, txType := range []usecaseEntity.TransactionTypeString{usecaseEntity.TransactionTypeIncomeStr, usecaseEntity.TransactionTypeExpenseStr} {
+			err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{Amount: ptr(1000.0), Type: ptr(txType),
+				CategoryID: ptr(int64(4)), TransactionDate: "2024-01-05"})
+			s.Require().NoError(err)
+		}
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("update changing only the type is checked against the current category", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(20), int64(1)).Return(&myentity.Transaction{
+			ID: 20, UserID: 1, CategoryID: sql.NullInt64{Int64: 5, Valid: true}, Amount: 15000, Type: myentity.TransactionTypeExpense,
+		}, nil).Once()
+		s.categoryRepo.On("GetByID", mock.Anything, int64(5)).
+			Return(&myentity.Category{ID: 5, CreatedBy: 1, Name: "Makan", Type: myentity.TransactionTypeExpense}, nil).Once()
+
+		err := s.usecase.Update(s.ctx, 20, 1, usecaseEntity.TransactionReq{Type: ptr(usecaseEntity.TransactionTypeIncomeStr)})
+		assertInvalid(err)
+		s.transactionRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestCreateRequiresAmountAndType() {
+	err := s.usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{Type: ptr(usecaseEntity.TransactionTypeExpenseStr), TransactionDate: "2024-01-05"})
+
+	var appErr apperr.CustomErrorResponse
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	s.transactionRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *CrudTransactionTestSuite) TestSuggestCategory() {
+	s.Run("clear majority category", func() {
+		s.transactionRepo.On("GetTopCategoryByDescription", mock.Anything, int64(1), "starbucks latte").
+			Return(&mysql.CategoryUsage{CategoryID: 3, CategoryName: "Kopi", UsageCount: 12}, nil).Once()
+
+		result, err := s.usecase.SuggestCategory(s.ctx, 1, "  starbucks   latte ")
+		s.Require().NoError(err)
+
+		s.Require().NotNil(result.CategoryID)
+		s.Equal(int64(3), *result.CategoryID)
+		s.Equal("Kopi", *result.CategoryName)
+		s.Equal(int64(12), result.UsageCount)
+	})
+
+	s.Run("no matching history", func() {
+		s.transactionRepo.On("GetTopCategoryByDescription", mock.Anything, int64(1), "warung baru").
+			Return(nil, nil).Once()
+
+		result, err := s.usecase.SuggestCategory(s.ctx, 1, "warung baru")
+		s.Require().NoError(err)
+
+		s.Equal(&usecaseEntity.CategorySuggestionResponse{}, result)
+	})
+
+	s.Run("empty description", func() {
+		_, err := s.usecase.SuggestCategory(s.ctx, 1, "   ")
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+
+	s.transactionRepo.AssertExpectations(s.T())
+}
+
+func (s *CrudTransactionTestSuite) TestPeriodLock() {
+	lockRepo := &mocks.IPeriodLockRepository{}
+	lockedThrough := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+	lockRepo.On("GetByUserID", mock.Anything, int64(1)).Return(&myentity.PeriodLock{UserID: 1, LockedThroughDate: lockedThrough}, nil)
+
+	usecase := transactions_usecase.NewCrudTransaction(s.transactionRepo, s.categoryRepo, config.SummaryOption{},
+		config.CurrencyOption{Code: "IDR"}, config.ResponseOption{}, config.SearchOption{MinQueryLength: 2}, config.SpendingCapOption{}, s.userStatus, s.userTimezone, period_usecase.NewPeriodLock(lockRepo, s.userStatus), queue.NoopPublisher{})
+
+	assertConflict := func(err error) {
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusConflict, appErr.HTTPCode)
+	}
+	existing := func(id int64, date string) *myentity.Transaction {
+		transactionDate, _ := time.Parse(helper.DateLayout, date)
+		return &myentity.Transaction{ID: id, UserID: 1, Amount: 1000, Type: myentity.TransactionTypeExpense, TransactionDate: transactionDate}
+	}
+
+	s.Run("create inside locked window", func() {
+		err := usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{Amount: ptr(1000.0), Type: ptr(usecaseEntity.TransactionTypeExpenseStr), TransactionDate: "2024-01-31"})
+		assertConflict(err)
+		s.transactionRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("create outside locked window", func() {
+		s.transactionRepo.On("Create", mock.Anything, nil, mock.Anything, false).Return(nil).Once()
+
+		err := usecase.Create(s.ctx, 1, usecaseEntity.TransactionReq{Amount: ptr(1000.0), Type: ptr(usecaseEntity.TransactionTypeExpenseStr), TransactionDate: "2024-02-01"})
+		s.Require().NoError(err)
+	})
+
+	s.Run("update moving a transaction into the locked window", func() {
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(10), int64(1)).Return(existing(10, "2024-02-10"), nil).Once()
+
+		err := usecase.Update(s.ctx, 10, 1, usecaseEntity.TransactionReq{Amount: ptr(1000.0), Type: ptr(usecaseEntity.TransactionTypeExpenseStr), TransactionDate: "2024-01-20"})
+		assertConflict(err)
+	})
+
+	s.Run("update a locked transaction", func() {
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(11), int64(1)).Return(existing(11, "2024-01-05"), nil).Once()
+
+		err := usecase.Update(s.ctx, 11, 1, usecaseEntity.TransactionReq{Amount: ptr(2000.0), Type: ptr(usecaseEntity.TransactionTypeExpenseStr), TransactionDate: "2024-02-05"})
+		assertConflict(err)
+		s.transactionRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("update outside locked window", func() {
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(12), int64(1)).Return(existing(12, "2024-02-10"), nil).Once()
+		s.transactionRepo.On("Update", mock.Anything, nil, mock.Anything, mock.Anything).Return(nil).Once()
+
+		err := usecase.Update(s.ctx, 12, 1, usecaseEntity.TransactionReq{Amount: ptr(2000.0), Type: ptr(usecaseEntity.TransactionTypeExpenseStr)})
+		s.Require().NoError(err)
+	})
+
+	s.Run("delete inside locked window", func() {
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(13), int64(1)).Return(existing(13, "2024-01-10"), nil).Once()
+
+		assertConflict(usecase.Delete(s.ctx, 13, 1, false))
+		s.transactionRepo.AssertNotCalled(s.T(), "DeleteByIDAndUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("delete inside locked window with admin override", func() {
+		s.transactionRepo.On("GetByIDAndUserID", mock.Anything, int64(13), int64(1)).Return(existing(13, "2024-01-10"), nil).Once()
+		s.transactionRepo.On("DeleteByIDAndUserID", mock.Anything, nil, int64(13), int64(1)).Return(nil).Once()
+
+		s.Require().NoError(usecase.Delete(s.ctx, 13, 1, true))
+	})
+
+	s.transactionRepo.AssertExpectations(s.T())
+}
+
+func (s *CrudTransactionTestSuite) TestRestore() {
+	deletedDate := time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC)
+
+	s.Run("restores a deleted transaction", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetDeletedByIDAndUserID", mock.Anything, int64(5), int64(1)).
+			Return(&myentity.Transaction{ID: 5, UserID: 1, TransactionDate: deletedDate}, nil).Once()
+		s.transactionRepo.On("RestoreByIDAndUserID", mock.Anything, nil, int64(5), int64(1)).Return(nil).Once()
+
+		s.Require().NoError(s.usecase.Restore(s.ctx, 5, 1))
+		s.periodLock.AssertCalled(s.T(), "EnsureUnlocked", mock.Anything, int64(1), deletedDate)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("transaction that is not deleted is not found", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetDeletedByIDAndUserID", mock.Anything, int64(6), int64(1)).Return(nil, apperr.ErrRecordNotFound()).Once()
+
+		err := s.usecase.Restore(s.ctx, 6, 1)
+		s.ErrorIs(err, apperr.ErrRecordNotFound())
+		s.transactionRepo.AssertNotCalled(s.T(), "RestoreByIDAndUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestSearch() {
+	described := func(id int64, description string) *mysql.TransactionWithCategory {
+		return &mysql.TransactionWithCategory{Transaction: myentity.Transaction{
+			ID: id, UserID: 1, Amount: 10000, Type: myentity.TransactionTypeExpense,
+			Description: sql.NullString{String: description, Valid: true},
+		}}
+	}
+	ids := func(result []usecaseEntity.TransactionResponse) []int64 {
+		out := make([]int64, 0, len(result))
+		for _, row := range result {
+			out = append(out, row.ID)
+		}
+		return out
+	}
+
+	s.Run("fuzzy matches a misspelled query ranked by closeness", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetRecentWithDescription", mock.Anything, int64(1), 1000).Return([]*mysql.TransactionWithCategory{
+			described(4, "Weekly groceries"),
+			described(3, "Bensin"),
+			described(2, "groceries"),
+			described(1, "Grocries di pasar"),
+		}, nil).Once()
+
+		result, err := s.usecase.Search(s.ctx, 1, usecaseEntity.TransactionSearchReq{Query: "grocries", Fuzzy: true})
+		s.Require().NoError(err)
+
+		// Kata yang sama persis berjarak 0, "groceries" berjarak 1 (terbaru lebih dulu), "bensin" tidak cocok
+		s.Equal([]int64{1, 4, 2}, ids(result))
+		s.transactionRepo.AssertNotCalled(s.T(), "SearchByDescription", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("fuzzy result is cut to limit", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetRecentWithDescription", mock.Anything, int64(1), 1000).Return([]*mysql.TransactionWithCategory{
+			described(3, "groceries"),
+			described(2, "groceries"),
+			described(1, "grocries"),
+		}, nil).Once()
+
+		result, err := s.usecase.Search(s.ctx, 1, usecaseEntity.TransactionSearchReq{Query: "grocries", Fuzzy: true, Limit: 2})
+		s.Require().NoError(err)
+
+		s.Equal([]int64{1, 3}, ids(result))
+	})
+
+	s.Run("without fuzzy falls back to LIKE", func() {
+		s.SetupTest()
+		s.transactionRepo.On("SearchByDescription", mock.Anything, int64(1), "groceries", 20).
+			Return([]*mysql.TransactionWithCategory{described(1, "Weekly groceries")}, nil).Once()
+
+		result, err := s.usecase.Search(s.ctx, 1, usecaseEntity.TransactionSearchReq{Query: "  groceries "})
+		s.Require().NoError(err)
+
+		s.Equal([]int64{1}, ids(result))
+		s.transactionRepo.AssertNotCalled(s.T(), "GetRecentWithDescription", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("empty query", func() {
+		_, err := s.usecase.Search(s.ctx, 1, usecaseEntity.TransactionSearchReq{Query: "  "})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+
+	s.Run("query shorter than minimum length", func() {
+		s.SetupTest()
+		_, err := s.usecase.Search(s.ctx, 1, usecaseEntity.TransactionSearchReq{Query: " k "})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "SearchByDescription", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("query at minimum length is accepted", func() {
+		s.SetupTest()
+		s.transactionRepo.On("SearchByDescription", mock.Anything, int64(1), "ko", 20).
+			Return([]*mysql.TransactionWithCategory{described(1, "Kopi")}, nil).Once()
+
+		result, err := s.usecase.Search(s.ctx, 1, usecaseEntity.TransactionSearchReq{Query: "ko"})
+		s.Require().NoError(err)
+
+		s.Equal([]int64{1}, ids(result))
+	})
+
+	s.Run("limit over maximum", func() {
+		_, err := s.usecase.Search(s.ctx, 1, usecaseEntity.TransactionSearchReq{Query: "kopi", Limit: 101})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestReplaceDescription() {
+	rows := func() []*myentity.Transaction {
+		return []*myentity.Transaction{
+			{ID: 1, UserID: 1, Description: sql.NullString{String: "Grocries weekly", Valid: true}, TransactionDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+			{ID: 2, UserID: 1, Description: sql.NullString{String: "grocries + GROCRIES", Valid: true}, TransactionDate: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)},
+		}
+	}
+
+	s.Run("case insensitive replaces every casing", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByDescriptionContains", mock.Anything, int64(1), "grocries", false).Return(rows(), nil).Once()
+		trx := &mocks.TrxObj{}
+		trx.On("Commit").Return(nil).Once()
+		s.transactionRepo.On("Begin").Return(trx, nil).Once()
+
+		saved := map[int64]string{}
+		s.transactionRepo.On("Update", mock.Anything, trx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved[args.Get(2).(*myentity.Transaction).ID] = args.Get(3).(*myentity.Transaction).Description.String
+		}).Return(nil).Twice()
+
+		result, err := s.usecase.ReplaceDescription(s.ctx, 1, usecaseEntity.ReplaceDescriptionReq{Find: "grocries", Replace: "groceries"})
+		s.Require().NoError(err)
+
+		s.Equal(2, result.Changed)
+		s.False(result.DryRun)
+		s.Equal(map[int64]string{1: "groceries weekly", 2: "groceries + groceries"}, saved)
+		s.Equal(usecaseEntity.ReplaceDescriptionRow{ID: 2, TransactionDate: "2024-01-06", Before: "grocries + GROCRIES", After: "groceries + groceries"}, result.Rows[1])
+		s.periodLock.AssertNumberOfCalls(s.T(), "EnsureUnlocked", 1)
+		s.periodLock.AssertCalled(s.T(), "EnsureUnlocked", mock.Anything, int64(1), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))
+	})
+
+	s.Run("case sensitive only replaces exact casing", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByDescriptionContains", mock.Anything, int64(1), "grocries", true).Return(rows()[1:], nil).Once()
+		trx := &mocks.TrxObj{}
+		trx.On("Commit").Return(nil).Once()
+		s.transactionRepo.On("Begin").Return(trx, nil).Once()
+		s.transactionRepo.On("Update", mock.Anything, trx, mock.MatchedBy(func(t *myentity.Transaction) bool { return t.ID == 2 }),
+			mock.MatchedBy(func(t *myentity.Transaction) bool { return t.Description.String == "groceries + GROCRIES" })).Return(nil).Once()
+
+		result, err := s.usecase.ReplaceDescription(s.ctx, 1, usecaseEntity.ReplaceDescriptionReq{Find: "grocries", Replace: "groceries", CaseSensitive: true})
+		s.Require().NoError(err)
+
+		s.Equal(1, result.Changed)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("dry run does not save", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByDescriptionContains", mock.Anything, int64(1), "grocries", false).Return(rows(), nil).Once()
+
+		result, err := s.usecase.ReplaceDescription(s.ctx, 1, usecaseEntity.ReplaceDescriptionReq{Find: "grocries", Replace: "groceries", DryRun: true})
+		s.Require().NoError(err)
+
+		s.True(result.DryRun)
+		s.Equal(2, result.Changed)
+		s.Equal("groceries weekly", result.Rows[0].After)
+		s.transactionRepo.AssertNotCalled(s.T(), "Begin")
+		s.transactionRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("locked period rejects the whole replacement", func() {
+		s.SetupTest()
+		s.periodLock = &mocks.IPeriodLockChecker{}
+		s.periodLock.On("EnsureUnlocked", mock.Anything, int64(1), mock.Anything).Return(apperr.ErrConflict().SetDetail("locked")).Once()
+		s.usecase = transactions_usecase.NewCrudTransaction(s.transactionRepo, s.categoryRepo, config.SummaryOption{}, config.CurrencyOption{Code: "IDR"}, config.ResponseOption{}, config.SearchOption{MinQueryLength: 2}, config.SpendingCapOption{}, s.userStatus, s.userTimezone, s.periodLock, queue.NoopPublisher{})
+		s.transactionRepo.On("GetByDescriptionContains", mock.Anything, int64(1), "grocries", false).Return(rows(), nil).Once()
+
+		_, err := s.usecase.ReplaceDescription(s.ctx, 1, usecaseEntity.ReplaceDescriptionReq{Find: "grocries", Replace: "groceries"})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusConflict, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "Begin")
+	})
+
+	s.Run("replacement longer than the description column", func() {
+		s.SetupTest()
+		s.transactionRepo.On("GetByDescriptionContains", mock.Anything, int64(1), "grocries", false).Return(rows(), nil).Once()
+
+		_, err := s.usecase.ReplaceDescription(s.ctx, 1, usecaseEntity.ReplaceDescriptionReq{Find: "grocries", Replace: strings.Repeat("x", 250)})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "Begin")
+	})
+
+	s.Run("empty find", func() {
+		_, err := s.usecase.ReplaceDescription(s.ctx, 1, usecaseEntity.ReplaceDescriptionReq{Replace: "x"})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestGetFilterOptions() {
+	s.Run("each section populated", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return([]*myentity.Category{
+			{ID: 3, Name: "Makan", CreatedBy: 1},
+			{ID: 5, Name: "Gaji", CreatedBy: 1},
+		}, nil).Once()
+		s.transactionRepo.On("GetBoundsByUserID", mock.Anything, int64(1)).Return(&mysql.TransactionBounds{
+			MinAmount: sql.NullFloat64{Float64: 2500, Valid: true},
+			MaxAmount: sql.NullFloat64{Float64: 8000000, Valid: true},
+			MinDate:   sql.NullString{String: "2023-11-02", Valid: true},
+			MaxDate:   sql.NullString{String: "2024-06-30", Valid: true},
+		}, nil).Once()
+		s.transactionRepo.On("GetTypesByUserID", mock.Anything, int64(1)).
+			Return([]myentity.TransactionType{myentity.TransactionTypeExpense, myentity.TransactionTypeIncome}, nil).Once()
+
+		result, err := s.usecase.GetFilterOptions(s.ctx, 1)
+		s.Require().NoError(err)
+
+		s.Equal([]usecaseEntity.FilterCategory{{ID: 3, Name: "Makan"}, {ID: 5, Name: "Gaji"}}, result.Categories)
+		s.Equal(&usecaseEntity.FilterAmountRange{Min: 2500, Max: 8000000}, result.Amount)
+		s.Equal(&usecaseEntity.FilterDateRange{StartDate: "2023-11-02", EndDate: "2024-06-30"}, result.DateRange)
+		s.Equal([]string{"expense", "income"}, result.Types)
+	})
+
+	s.Run("user without data gets empties", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return([]*myentity.Category{}, nil).Once()
+		s.transactionRepo.On("GetBoundsByUserID", mock.Anything, int64(1)).Return(&mysql.TransactionBounds{}, nil).Once()
+		s.transactionRepo.On("GetTypesByUserID", mock.Anything, int64(1)).Return(nil, nil).Once()
+
+		result, err := s.usecase.GetFilterOptions(s.ctx, 1)
+		s.Require().NoError(err)
+
+		s.Equal([]usecaseEntity.FilterCategory{}, result.Categories)
+		s.Nil(result.Amount)
+		s.Nil(result.DateRange)
+		s.Equal([]string{}, result.Types)
+	})
+
+	s.Run("repository error is returned", func() {
+		s.SetupTest()
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return([]*myentity.Category{}, nil).Maybe()
+		s.transactionRepo.On("GetBoundsByUserID", mock.Anything, int64(1)).Return(nil, errors.New("db down")).Once()
+		s.transactionRepo.On("GetTypesByUserID", mock.Anything, int64(1)).Return(nil, nil).Maybe()
+
+		_, err := s.usecase.GetFilterOptions(s.ctx, 1)
+		s.EqualError(err, "db down")
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestCleanupOrphanedCategories() {
+	s.Run("clears orphaned category ids", func() {
+		s.SetupTest()
+		s.transactionRepo.On("CountOrphanedTransactions", mock.Anything, int64(7)).Return(int64(3), nil).Once()
+		trx := &mocks.TrxObj{}
+		trx.On("Commit").Return(nil).Once()
+		s.transactionRepo.On("Begin").Return(trx, nil).Once()
+		s.transactionRepo.On("ClearOrphanedCategories", mock.Anything, trx, int64(7)).Return(int64(3), nil).Once()
+
+		result, err := s.usecase.CleanupOrphanedCategories(s.ctx, 7, false)
+		s.Require().NoError(err)
+
+		s.Equal(usecaseEntity.OrphanCleanupResponse{UserID: 7, Orphaned: 3, Fixed: 3}, *result)
+		s.transactionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("dry run only counts", func() {
+		s.SetupTest()
+		s.transactionRepo.On("CountOrphanedTransactions", mock.Anything, int64(7)).Return(int64(3), nil).Once()
+
+		result, err := s.usecase.CleanupOrphanedCategories(s.ctx, 7, true)
+		s.Require().NoError(err)
+
+		s.Equal(usecaseEntity.OrphanCleanupResponse{UserID: 7, DryRun: true, Orphaned: 3}, *result)
+		s.transactionRepo.AssertNotCalled(s.T(), "Begin")
+	})
+
+	s.Run("nothing to fix", func() {
+		s.SetupTest()
+		s.transactionRepo.On("CountOrphanedTransactions", mock.Anything, int64(7)).Return(int64(0), nil).Once()
+
+		result, err := s.usecase.CleanupOrphanedCategories(s.ctx, 7, false)
+		s.Require().NoError(err)
+
+		s.Equal(int64(0), result.Fixed)
+		s.transactionRepo.AssertNotCalled(s.T(), "Begin")
+	})
+
+	s.Run("missing user", func() {
+		_, err := s.usecase.CleanupOrphanedCategories(s.ctx, 0, false)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+	})
+}
+
+// lockThrough membuat ulang usecase dengan kunci periode sampai tanggal lockedThrough (YYYY-MM-DD).
+func (s *CrudTransactionTestSuite) lockThrough(lockedThrough string) {
+	s.periodLock = &mocks.IPeriodLockChecker{}
+	s.periodLock.On("EnsureUnlocked", mock.Anything, int64(1), mock.MatchedBy(func(date time.Time) bool {
+		return date.Format(helper.DateLayout) <= lockedThrough
+	})).Return(apperr.ErrConflict().SetDetail("locked"))
+	s.periodLock.On("EnsureUnlocked", mock.Anything, int64(1), mock.Anything).Return(nil)
+	s.usecase = transactions_usecase.NewCrudTransaction(s.transactionRepo, s.categoryRepo, config.SummaryOption{}, config.CurrencyOption{Code: "IDR"}, config.ResponseOption{}, config.SearchOption{MinQueryLength: 2}, config.SpendingCapOption{}, s.userStatus, s.userTimezone, s.periodLock, queue.NoopPublisher{})
+}
+
+func (s *CrudTransactionTestSuite) TestImportPeriodLock() {
+	rows := []usecaseEntity.ImportTransactionRow{
+		{Amount: 15000, Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-02-03"},
+		{Amount: 5000, Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-01-31"},
+	}
+
+	s.Run("any row in the locked period rejects the import", func() {
+		s.SetupTest()
+		s.lockThrough("2024-01-31")
+
+		_, err := s.usecase.Import(s.ctx, 1, usecaseEntity.ImportTransactionReq{Rows: rows}, false)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusConflict, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "Begin")
+	})
+
+	s.Run("rows after the lock are imported", func() {
+		s.SetupTest()
+		s.lockThrough("2024-01-30")
+		trx := &mocks.TrxObj{}
+		trx.On("Commit").Return(nil).Once()
+		s.transactionRepo.On("Begin").Return(trx, nil).Once()
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return([]*myentity.Category{}, nil).Once()
+		s.transactionRepo.On("Create", mock.Anything, trx, mock.Anything, false).Return(nil).Twice()
+
+		result, err := s.usecase.Import(s.ctx, 1, usecaseEntity.ImportTransactionReq{Rows: rows}, false)
+		s.Require().NoError(err)
+		s.Equal(2, result.Imported)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestSyncPeriodLock() {
+	locked, _ := time.Parse(helper.DateLayout, "2024-01-05")
+	existing := func() []*myentity.Transaction {
+		return []*myentity.Transaction{{
+			ID: 100, UserID: 1, Amount: 50000, Currency: "IDR", Type: myentity.TransactionTypeExpense,
+			Reference:       sql.NullString{String: "REF-OLD", Valid: true},
+			TransactionDate: locked,
+		}}
+	}
+	assertConflict := func(err error) {
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusConflict, appErr.HTTPCode)
+		s.transactionRepo.AssertNotCalled(s.T(), "Begin")
+	}
+
+	s.Run("moving a locked transaction out of the period is rejected", func() {
+		s.SetupTest()
+		s.lockThrough("2024-01-31")
+		s.transactionRepo.On("GetByUserIDAndReferences", mock.Anything, int64(1), []string{"REF-OLD"}).Return(existing(), nil).Once()
+
+		_, err := s.usecase.Sync(s.ctx, 1, usecaseEntity.SyncTransactionReq{Rows: []usecaseEntity.SyncTransactionRow{
+			{Reference: "REF-OLD", Amount: 50000, Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-02-05"},
+		}})
+		assertConflict(err)
+	})
+
+	s.Run("new transaction in the locked period is rejected", func() {
+		s.SetupTest()
+		s.lockThrough("2024-01-31")
+		s.transactionRepo.On("GetByUserIDAndReferences", mock.Anything, int64(1), []string{"REF-NEW"}).Return([]*myentity.Transaction{}, nil).Once()
+
+		_, err := s.usecase.Sync(s.ctx, 1, usecaseEntity.SyncTransactionReq{Rows: []usecaseEntity.SyncTransactionRow{
+			{Reference: "REF-NEW", Amount: 10000, Type: usecaseEntity.TransactionTypeIncomeStr, TransactionDate: "2024-01-20"},
+		}})
+		assertConflict(err)
+	})
+
+	s.Run("unchanged locked transaction does not block the sync", func() {
+		s.SetupTest()
+		s.lockThrough("2024-01-31")
+		trx := &mocks.TrxObj{}
+		trx.On("Commit").Return(nil).Once()
+		s.transactionRepo.On("Begin").Return(trx, nil).Once()
+		s.transactionRepo.On("GetByUserIDAndReferences", mock.Anything, int64(1), []string{"REF-OLD", "REF-NEW"}).Return(existing(), nil).Once()
+		s.transactionRepo.On("Create", mock.Anything, trx, mock.Anything, false).Return(nil).Once()
+
+		result, err := s.usecase.Sync(s.ctx, 1, usecaseEntity.SyncTransactionReq{Rows: []usecaseEntity.SyncTransactionRow{
+			{Reference: "REF-OLD", Amount: 50000, Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-01-05"},
+			{Reference: "REF-NEW", Amount: 10000, Type: usecaseEntity.TransactionTypeIncomeStr, TransactionDate: "2024-02-01"},
+		}})
+		s.Require().NoError(err)
+		s.Equal(usecaseEntity.SyncActionUnchanged, result.Results[0].Action)
+		s.Equal(usecaseEntity.SyncActionCreated, result.Results[1].Action)
+	})
+
+	s.Run("deleted transaction in the locked period is skipped", func() {
+		s.SetupTest()
+		s.lockThrough("2024-01-31")
+		deleted := existing()
+		deleted[0].DeletedAt = sql.NullTime{Time: locked, Valid: true}
+		trx := &mocks.TrxObj{}
+		trx.On("Commit").Return(nil).Once()
+		s.transactionRepo.On("Begin").Return(trx, nil).Once()
+		s.transactionRepo.On("GetByUserIDAndReferences", mock.Anything, int64(1), []string{"REF-OLD"}).Return(deleted, nil).Once()
+
+		result, err := s.usecase.Sync(s.ctx, 1, usecaseEntity.SyncTransactionReq{Rows: []usecaseEntity.SyncTransactionRow{
+			{Reference: "REF-OLD", Amount: 75000, Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-01-05"},
+		}})
+		s.Require().NoError(err)
+		s.Equal(usecaseEntity.SyncActionSkipped, result.Results[0].Action)
+	})
+}
+
+func (s *CrudTransactionTestSuite) TestSyncDeletedReference() {
+	trx := &mocks.TrxObj{}
+	trx.On("Commit").Return(nil).Once()
+	s.transactionRepo.On("Begin").Return(trx, nil).Once()
+	s.transactionRepo.On("GetByUserIDAndReferences", mock.Anything, int64(1), []string{"REF-DELETED", "REF-NEW"}).Return([]*myentity.Transaction{{
+		ID:              8,
+		UserID:          1,
+		Amount:          50000,
+		Currency:        "IDR",
+		Type:            myentity.TransactionTypeExpense,
+		Reference:       sql.NullString{String: "REF-DELETED", Valid: true},
+		TransactionDate: time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC),
+		DeletedAt:       sql.NullTime{Time: time.Date(2024, time.January, 6, 0, 0, 0, 0, time.UTC), Valid: true},
+	}}, nil).Once()
+	s.transactionRepo.On("Create", mock.Anything, trx, mock.MatchedBy(func(t *myentity.Transaction) bool {
+		return t.Reference.String == "REF-NEW"
+	}), false).Return(nil).Once()
+
+	result, err := s.usecase.Sync(s.ctx, 1, usecaseEntity.SyncTransactionReq{Rows: []usecaseEntity.SyncTransactionRow{
+		{Reference: "REF-DELETED", Amount: 65000, Type: usecaseEntity.TransactionTypeExpenseStr, TransactionDate: "2024-01-05"},
+		{Reference: "REF-NEW", Amount: 10000, Type: usecaseEntity.TransactionTypeIncomeStr, TransactionDate: "2024-01-07"},
+	}})
+	s.Require().NoError(err)
+
+	s.Equal(usecaseEntity.SyncResult{Reference: "REF-DELETED", ID: 8, Action: usecaseEntity.SyncActionSkipped}, result.Results[0])
+	s.Equal(usecaseEntity.SyncActionCreated, result.Results[1].Action)
+	s.transactionRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.transactionRepo.AssertExpectations(s.T())
+}
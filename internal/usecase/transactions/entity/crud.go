@@ -2,6 +2,7 @@
 
 package entity
 
+import generalEntity "github.com/rakahikmah/finance-tracking/entity"
 
 
 // TransactionTypeString dan konstanta tetap sama
@@ -12,14 +13,31 @@ const (
 	TransactionTypeExpenseStr TransactionTypeString = "expense"
 )
 
-// TransactionReq tetap sama
+// TransactionReq adalah body request untuk membuat dan memperbarui transaksi.
+// Pada update, field pointer/map yang nil (tidak ada di body) tidak diubah dan category_id 0 menghapus kategori.
+// Karena itu tag validate hanya memeriksa field yang diisi, field wajib pada create dicek oleh usecase.
 type TransactionReq struct {
-	UserID          int64                 `json:"user_id,omitempty"`
-	CategoryID      *int64                `json:"category_id"`
-	Amount          float64               `json:"amount" validate:"required,gt=0" name:"Jumlah Transaksi"`
-	Type            TransactionTypeString `json:"type" validate:"required,oneof=income expense" name:"Tipe Transaksi"`
-	Description     *string               `json:"description"`
-	TransactionDate string                `json:"transaction_date" validate:"required,datetime=2006-01-02" name:"Tanggal Transaksi"`
+	UserID          int64                  `json:"user_id,omitempty"`
+	CategoryID      *int64                 `json:"category_id"`
+	Amount          *float64               `json:"amount" validate:"omitempty,gt=0" name:"Jumlah Transaksi"`
+	// Currency adalah kode ISO 4217 (huruf besar), kosong pada create berarti mata uang dasar
+	Currency        *string                `json:"currency"`
+	Type            *TransactionTypeString `json:"type" validate:"omitempty,oneof=income expense" name:"Tipe Transaksi"`
+	Description     *string               `json:"description" validate:"omitempty,max=255" name:"Deskripsi"`
+	Latitude        *float64              `json:"latitude"`
+	Longitude       *float64              `json:"longitude"`
+	Metadata        map[string]string     `json:"metadata"`
+	TransactionDate string                `json:"transaction_date" validate:"omitempty,datetime=2006-01-02" name:"Tanggal Transaksi"`
+	// Notes adalah catatan pribadi yang lebih panjang dari description, string kosong menghapus catatan
+	Notes *string `json:"notes"`
+	// AttachmentURL adalah URL foto struk (http/https), string kosong pada update menghapus lampiran
+	AttachmentURL *string `json:"attachment_url"`
+	// Version adalah version transaksi yang terakhir dibaca client, update ditolak (409) jika sudah berubah
+	Version *int `json:"version"`
+	// Force melewati pengecekan duplikat pada create, untuk pembayaran yang memang dilakukan berulang
+	Force bool `json:"force"`
+	// OverridePeriodLock hanya diisi handler untuk admin, tidak pernah dari request body
+	OverridePeriodLock bool `json:"-"`
 }
 
 // TransactionResponse adalah struktur data untuk output (response body) saat mengembalikan data transaksi.
@@ -29,21 +47,267 @@ type TransactionResponse struct {
 	CategoryID      *int64                `json:"category_id"`
 	CategoryName    *string               `json:"category_name"` 
 	Amount          float64               `json:"amount"`
+	Currency        string                `json:"currency"`
 	Type            TransactionTypeString `json:"type"`
 	Description     *string               `json:"description"`
+	Notes           *string               `json:"notes"`
+	Latitude        *float64              `json:"latitude"`
+	Longitude       *float64              `json:"longitude"`
+	Metadata        map[string]string     `json:"metadata"`
+	AttachmentURL   *string               `json:"attachment_url"`
 	TransactionDate string                `json:"transaction_date"`
 	CreatedAt       string                `json:"created_at"`
 	UpdatedAt       string                `json:"updated_at"`
+	// DeletedAt hanya terisi untuk transaksi yang sudah di-soft delete (include_deleted oleh admin)
+	DeletedAt *string `json:"deleted_at,omitempty"`
+	// Version dikirim kembali pada update untuk mendeteksi perubahan dari request lain
+	Version int `json:"version"`
 }
 
-// TransactionSummaryResponse adalah struktur data untuk respons ringkasan transaksi per kategori dan tipe.
+// TransactionSummaryResponse adalah struktur data untuk respons ringkasan transaksi per kategori, mata uang, dan tipe.
 type TransactionSummaryResponse struct {
 	CategoryName *string               `json:"category_name"`
+	Currency     string                `json:"currency"`
 	Type         TransactionTypeString `json:"type"`
 	TotalAmount  float64               `json:"total_amount"`
 }
 
+// TopCategoryResponse adalah total pengeluaran satu kategori, CategoryID nil untuk transaksi tanpa kategori (Uncategorized).
+type TopCategoryResponse struct {
+	CategoryID       *int64  `json:"category_id"`
+	CategoryName     string  `json:"category_name"`
+	TotalAmount      float64 `json:"total_amount"`
+	TransactionCount int64   `json:"transaction_count"`
+}
+
+// TopCategoriesResponse adalah kategori dengan pengeluaran terbesar dalam mata uang dasar, urut dari total terbesar.
+type TopCategoriesResponse struct {
+	Currency   string                `json:"currency"`
+	Categories []TopCategoryResponse `json:"categories"`
+}
+
+// BalanceResponse adalah total income, total expense, dan net (income dikurangi expense) satu mata uang dalam satu periode.
+type BalanceResponse struct {
+	Currency     string  `json:"currency"`
+	TotalIncome  float64 `json:"total_income"`
+	TotalExpense float64 `json:"total_expense"`
+	Net          float64 `json:"net"`
+}
+
+// DailySummaryRow adalah total amount satu tipe transaksi dan satu mata uang dalam satu periode.
+// Untuk granularity week/month, Day berisi label periode (awal minggu atau YYYY-MM).
+type DailySummaryRow struct {
+	Day         string                `json:"transaction_day"`
+	Currency    string                `json:"currency"`
+	Type        TransactionTypeString `json:"type"`
+	TotalAmount float64               `json:"total_amount"`
+}
+
+// MonthlySummaryRow adalah total amount satu tipe transaksi dan satu mata uang dalam satu bulan (YYYY-MM).
+type MonthlySummaryRow struct {
+	Month       string                `json:"month"`
+	Currency    string                `json:"currency"`
+	Type        TransactionTypeString `json:"type"`
+	TotalAmount float64               `json:"total_amount"`
+}
+
+// YearlyReportMonth adalah total income dan expense satu bulan (YYYY-MM) pada laporan tahunan.
+type YearlyReportMonth struct {
+	Month   string  `json:"month"`
+	Income  float64 `json:"income"`
+	Expense float64 `json:"expense"`
+	Net     float64 `json:"net"`
+}
+
+// YearlyReportResponse adalah laporan satu tahun: selalu 12 bulan (bulan kosong bernilai 0) dan total tahunan,
+// dalam mata uang dasar.
+type YearlyReportResponse struct {
+	Year         int                 `json:"year"`
+	Currency     string              `json:"currency"`
+	Months       []YearlyReportMonth `json:"months"`
+	TotalIncome  float64             `json:"total_income"`
+	TotalExpense float64             `json:"total_expense"`
+	Net          float64             `json:"net"`
+}
+
+// BalanceTimelineDay adalah total income dan expense satu hari beserta saldo berjalan sejak start_date.
+type BalanceTimelineDay struct {
+	Date           string  `json:"date"`
+	Income         float64 `json:"income"`
+	Expense        float64 `json:"expense"`
+	RunningBalance float64 `json:"running_balance"`
+}
+
+// BalanceTimelineResponse adalah saldo berjalan harian dalam mata uang dasar.
+type BalanceTimelineResponse struct {
+	Currency string               `json:"currency"`
+	Days     []BalanceTimelineDay `json:"days"`
+}
+
+// SpendingDay adalah total expense satu hari.
+type SpendingDay struct {
+	Date   string  `json:"date"`
+	Amount float64 `json:"amount"`
+}
+
+// SpendingStatsResponse adalah statistik expense dalam rentang tanggal pada mata uang dasar.
+// MaxDay dan MinDay null jika tidak ada expense dalam rentang.
+type SpendingStatsResponse struct {
+	Currency     string       `json:"currency"`
+	TotalExpense float64      `json:"total_expense"`
+	DayCount     int          `json:"day_count"`
+	AvgPerDay    float64      `json:"avg_per_day"`
+	AvgPerWeek   float64      `json:"avg_per_week"`
+	MaxDay       *SpendingDay `json:"max_day"`
+	MinDay       *SpendingDay `json:"min_day"`
+}
+
+// DailySummaryResponse adalah ringkasan transaksi per periode beserta granularity yang dipakai.
+type DailySummaryResponse struct {
+	Granularity string            `json:"granularity"`
+	Data        []DailySummaryRow `json:"data"`
+}
+
+// TransactionListReq adalah parameter daftar transaksi dengan pagination dan filter opsional.
+type TransactionListReq struct {
+	Page       int
+	PerPage    int
+	StartDate  string
+	EndDate    string
+	Type       TransactionTypeString
+	CategoryID *int64
+	// Metadata adalah filter meta.<key>=<value>, semua pasangan harus cocok
+	Metadata map[string]string
+	// By adalah kolom tanggal untuk filter dan urutan: transaction_date (default) atau created_at
+	By string
+	// Sort adalah urutan daftar: date_desc (default), date_asc, amount_desc, atau amount_asc
+	Sort string
+	// Format adalah opsi representasi response (null_as_empty, date_format)
+	Format ResponseFormatReq
+	// IncludeDeleted ikut menampilkan transaksi yang sudah di-soft delete, handler hanya mengizinkannya untuk admin
+	IncludeDeleted bool
+}
+
+// TransactionCursorReq adalah parameter daftar transaksi berbasis cursor dengan filter opsional.
+type TransactionCursorReq struct {
+	// Limit adalah jumlah transaksi per halaman, 0 berarti default
+	Limit int
+	// Cursor adalah next_cursor dari halaman sebelumnya, kosong berarti halaman pertama
+	Cursor     string
+	StartDate  string
+	EndDate    string
+	Type       TransactionTypeString
+	CategoryID *int64
+	// Metadata adalah filter meta.<key>=<value>, semua pasangan harus cocok
+	Metadata map[string]string
+	// By adalah kolom tanggal untuk filter dan urutan: transaction_date (default) atau created_at
+	By string
+	// Sort adalah urutan daftar: date_desc (default), date_asc, amount_desc, atau amount_asc
+	Sort string
+	// Format adalah opsi representasi response (null_as_empty, date_format)
+	Format ResponseFormatReq
+	// IncludeDeleted ikut menampilkan transaksi yang sudah di-soft delete, handler hanya mengizinkannya untuk admin
+	IncludeDeleted bool
+}
+
+// TransactionCursorResponse adalah satu halaman transaksi beserta cursor halaman berikutnya.
+// NextCursor adalah token opaque untuk query cursor halaman berikutnya, null jika tidak ada halaman berikutnya. Total adalah jumlah seluruh transaksi yang cocok dengan filter
+// (bukan hanya halaman ini), untuk tampilan seperti "20 dari 347".
+type TransactionCursorResponse struct {
+	Data       []TransactionResponse `json:"data"`
+	NextCursor *string               `json:"next_cursor"`
+	Total      int64                 `json:"total"`
+}
+
+// TransactionExportReq adalah filter untuk export seluruh transaksi user. Tanggal kosong tidak difilter.
+type TransactionExportReq struct {
+	StartDate string
+	EndDate   string
+	// Format adalah opsi representasi response (null_as_empty, date_format)
+	Format ResponseFormatReq
+}
+
+// TransactionSearchReq adalah parameter pencarian transaksi berdasarkan description.
+type TransactionSearchReq struct {
+	Query string
+	// Fuzzy mengaktifkan pencocokan yang toleran typo dan mengurutkan hasil dari yang paling mirip
+	Fuzzy bool
+	Limit int
+	// Format adalah opsi representasi response (null_as_empty, date_format)
+	Format ResponseFormatReq
+}
+
+// ResponseFormatReq adalah opsi representasi response transaksi per request.
+type ResponseFormatReq struct {
+	// NullAsEmpty meng-override default ResponseOption untuk representasi field NULL, nil berarti pakai default
+	NullAsEmpty *bool
+	// DateFormat adalah salah satu helper.DateFormat untuk transaction_date, created_at, dan updated_at, kosong berarti YYYY-MM-DD
+	DateFormat string
+}
+
+// TransactionListResponse adalah satu halaman transaksi beserta meta pagination.
+type TransactionListResponse struct {
+	Data []TransactionResponse `json:"data"`
+	Meta generalEntity.PaginationMeta `json:"meta"`
+}
+
+// CalendarDay adalah transaksi dan total per hari untuk satu sel kalender.
+type CalendarDay struct {
+	Date         string                `json:"date"`
+	TotalIncome  float64               `json:"total_income"`
+	TotalExpense float64               `json:"total_expense"`
+	Net          float64               `json:"net"`
+	Transactions []TransactionResponse `json:"transactions"`
+}
+
+// CalendarResponse adalah transaksi satu bulan yang dikelompokkan per hari, termasuk hari tanpa transaksi.
+type CalendarResponse struct {
+	Month string        `json:"month"`
+	Days  []CalendarDay `json:"days"`
+}
+
 // SetUserID method tetap sama
 func (r *TransactionReq) SetUserID(userID int64) {
 	r.UserID = userID
-}
\ No newline at end of file
+}
+// FilterCategory adalah kategori yang bisa dipilih pada filter.
+type FilterCategory struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// FilterAmountRange adalah amount terkecil dan terbesar dari transaksi user.
+type FilterAmountRange struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// FilterDateRange adalah transaction_date paling awal dan paling akhir dari transaksi user.
+type FilterDateRange struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// DateRangeResponse adalah transaction_date paling awal dan paling akhir dari transaksi user, untuk default pemilih tanggal.
+// Keduanya null jika user belum memiliki transaksi.
+type DateRangeResponse struct {
+	Earliest *string `json:"earliest"`
+	Latest   *string `json:"latest"`
+}
+
+// FilterOptionsResponse adalah pilihan yang tersedia untuk kontrol filter transaksi.
+// Amount dan DateRange null jika user belum memiliki transaksi.
+type FilterOptionsResponse struct {
+	Categories []FilterCategory   `json:"categories"`
+	Amount     *FilterAmountRange `json:"amount"`
+	DateRange  *FilterDateRange   `json:"date_range"`
+	Types      []string           `json:"types"`
+}
+
+// CategorySuggestionResponse adalah kategori yang paling sering dipakai untuk description serupa pada riwayat user.
+// CategoryID dan CategoryName nil jika tidak ada riwayat yang cocok.
+type CategorySuggestionResponse struct {
+	CategoryID   *int64  `json:"category_id"`
+	CategoryName *string `json:"category_name"`
+	UsageCount   int64   `json:"usage_count"`
+}
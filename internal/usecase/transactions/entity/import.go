@@ -0,0 +1,69 @@
+package entity
+
+// CategoryMappingStatus menunjukkan bagaimana nama kategori pada data import dipetakan.
+type CategoryMappingStatus string
+
+const (
+	CategoryMappingMatched   CategoryMappingStatus = "matched"   // Cocok dengan kategori yang sudah ada
+	CategoryMappingCreated   CategoryMappingStatus = "created"   // Kategori baru dibuat saat import
+	CategoryMappingUnmatched CategoryMappingStatus = "unmatched" // Tidak ditemukan, transaksi disimpan tanpa kategori
+)
+
+// ImportTransactionRow adalah satu baris transaksi yang akan di-import. Kategori diisi dengan nama, bukan ID.
+type ImportTransactionRow struct {
+	CategoryName    string                `json:"category_name"`
+	Amount          float64               `json:"amount"`
+	Currency        *string               `json:"currency"` // kosong berarti mata uang dasar
+	Type            TransactionTypeString `json:"type"`
+	Description     *string               `json:"description"`
+	TransactionDate string                `json:"transaction_date"`
+}
+
+// ImportTransactionReq adalah request body untuk import transaksi.
+type ImportTransactionReq struct {
+	UserID int64                  `json:"user_id,omitempty"`
+	Rows   []ImportTransactionRow `json:"rows"`
+}
+
+// CategoryMapping adalah hasil pemetaan satu nama kategori pada data import.
+type CategoryMapping struct {
+	Name       string                `json:"name"`
+	CategoryID *int64                `json:"category_id"`
+	Status     CategoryMappingStatus `json:"status"`
+}
+
+// ImportTransactionResponse adalah laporan hasil import transaksi.
+type ImportTransactionResponse struct {
+	Imported   int               `json:"imported"`
+	Categories []CategoryMapping `json:"categories"`
+}
+
+// ImportRowError adalah satu baris CSV yang tidak disimpan, Line adalah nomor baris di file (header adalah baris 1).
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// ImportCSVResponse adalah laporan hasil import CSV: jumlah transaksi yang disimpan, baris yang gagal,
+// dan pemetaan kategori untuk baris yang disimpan.
+type ImportCSVResponse struct {
+	Imported   int               `json:"imported"`
+	Errors     []ImportRowError  `json:"errors"`
+	Categories []CategoryMapping `json:"categories"`
+}
+
+// SetUserID mengisi UserID dari token.
+func (r *ImportTransactionReq) SetUserID(userID int64) {
+	r.UserID = userID
+}
+
+// ImportJobResponse adalah status dan progress job import yang diproses di background.
+type ImportJobResponse struct {
+	ID        int64    `json:"id"`
+	Status    string   `json:"status"`
+	Total     int      `json:"total"`
+	Processed int      `json:"processed"`
+	Errors    []string `json:"errors"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
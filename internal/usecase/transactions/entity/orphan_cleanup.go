@@ -0,0 +1,11 @@
+package entity
+
+// OrphanCleanupResponse adalah hasil pembersihan transaksi yang kategorinya sudah tidak ada.
+// Orphaned adalah jumlah transaksi yatim sebelum dibersihkan, Fixed adalah jumlah yang category_id-nya dikosongkan
+// (selalu 0 jika DryRun).
+type OrphanCleanupResponse struct {
+	UserID   int64 `json:"user_id"`
+	DryRun   bool  `json:"dry_run"`
+	Orphaned int64 `json:"orphaned"`
+	Fixed    int64 `json:"fixed"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+// ReplaceDescriptionReq adalah request body untuk find-and-replace description transaksi milik user.
+// DryRun hanya menampilkan baris yang akan berubah tanpa menyimpan apa pun.
+type ReplaceDescriptionReq struct {
+	UserID        int64  `json:"user_id,omitempty"`
+	Find          string `json:"find"`
+	Replace       string `json:"replace"`
+	CaseSensitive bool   `json:"case_sensitive"`
+	DryRun        bool   `json:"dry_run"`
+}
+
+// SetUserID mengisi UserID dari token.
+func (r *ReplaceDescriptionReq) SetUserID(userID int64) {
+	r.UserID = userID
+}
+
+// ReplaceDescriptionRow adalah description satu transaksi sebelum dan sesudah penggantian.
+type ReplaceDescriptionRow struct {
+	ID              int64  `json:"id"`
+	TransactionDate string `json:"transaction_date"`
+	Before          string `json:"before"`
+	After           string `json:"after"`
+}
+
+// ReplaceDescriptionResponse adalah jumlah dan daftar transaksi yang berubah (atau akan berubah jika DryRun).
+type ReplaceDescriptionResponse struct {
+	DryRun  bool                    `json:"dry_run"`
+	Changed int                     `json:"changed"`
+	Rows    []ReplaceDescriptionRow `json:"rows"`
+}
@@ -0,0 +1,46 @@
+package entity
+
+// SyncAction adalah aksi yang dilakukan untuk satu baris sinkronisasi.
+type SyncAction string
+
+const (
+	SyncActionCreated   SyncAction = "created"
+	SyncActionUpdated   SyncAction = "updated"
+	SyncActionUnchanged SyncAction = "unchanged"
+	// SyncActionSkipped berarti reference milik transaksi yang sudah dihapus user, transaksi tidak dipulihkan atau dibuat ulang
+	SyncActionSkipped SyncAction = "skipped"
+)
+
+// SyncTransactionRow adalah satu transaksi dari export bank, dicocokkan berdasarkan Reference.
+type SyncTransactionRow struct {
+	Reference       string                `json:"reference"`
+	CategoryID      *int64                `json:"category_id"`
+	Amount          float64               `json:"amount"`
+	Currency        *string               `json:"currency"` // kosong berarti mata uang dasar
+	Type            TransactionTypeString `json:"type"`
+	Description     *string               `json:"description"`
+	TransactionDate string                `json:"transaction_date"`
+}
+
+// SyncTransactionReq adalah request body untuk sinkronisasi transaksi.
+type SyncTransactionReq struct {
+	UserID int64                `json:"user_id,omitempty"`
+	Rows   []SyncTransactionRow `json:"rows"`
+}
+
+// SyncResult adalah aksi yang dilakukan untuk satu reference.
+type SyncResult struct {
+	Reference string     `json:"reference"`
+	ID        int64      `json:"id"`
+	Action    SyncAction `json:"action"`
+}
+
+// SyncTransactionResponse adalah hasil sinkronisasi per baris, urut sesuai request.
+type SyncTransactionResponse struct {
+	Results []SyncResult `json:"results"`
+}
+
+// SetUserID mengisi UserID dari token.
+func (r *SyncTransactionReq) SetUserID(userID int64) {
+	r.UserID = userID
+}
@@ -0,0 +1,78 @@
+package transactions_usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
+	"golang.org/x/sync/errgroup"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// GetFilterOptions mengumpulkan pilihan filter transaksi user dalam satu response: kategori, rentang amount,
+// rentang tanggal, dan tipe yang pernah dicatat. Ketiga query dijalankan bersamaan.
+func (u *CrudTransaction) GetFilterOptions(ctx context.Context, userID int64) (*usecaseEntity.FilterOptionsResponse, error) {
+	funcName := "CrudTransaction.GetFilterOptions"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	var (
+		categories []*myentity.Category
+		bounds     *mysql.TransactionBounds
+		types      []myentity.TransactionType
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		if categories, err = u.CategoryRepo.GetAll(gctx, userID); err != nil {
+			helper.LogError(funcName, "CategoryRepo.GetAll", err, logFields, "")
+		}
+		return err
+	})
+	g.Go(func() (err error) {
+		if bounds, err = u.TransactionRepo.GetBoundsByUserID(gctx, userID); err != nil {
+			helper.LogError(funcName, "TransactionRepo.GetBoundsByUserID", err, logFields, "")
+		}
+		return err
+	})
+	g.Go(func() (err error) {
+		if types, err = u.TransactionRepo.GetTypesByUserID(gctx, userID); err != nil {
+			helper.LogError(funcName, "TransactionRepo.GetTypesByUserID", err, logFields, "")
+		}
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	result := &usecaseEntity.FilterOptionsResponse{
+		Categories: make([]usecaseEntity.FilterCategory, 0, len(categories)),
+		Types:      make([]string, 0, len(types)),
+	}
+	for _, category := range categories {
+		result.Categories = append(result.Categories, usecaseEntity.FilterCategory{ID: category.ID, Name: category.Name})
+	}
+	for _, txType := range types {
+		result.Types = append(result.Types, string(txType))
+	}
+	if bounds != nil && bounds.MinAmount.Valid && bounds.MaxAmount.Valid {
+		result.Amount = &usecaseEntity.FilterAmountRange{Min: bounds.MinAmount.Float64, Max: bounds.MaxAmount.Float64}
+	}
+	if bounds != nil && bounds.MinDate.Valid && bounds.MaxDate.Valid {
+		result.DateRange = &usecaseEntity.FilterDateRange{StartDate: bounds.MinDate.String, EndDate: bounds.MaxDate.String}
+	}
+
+	return result, nil
+}
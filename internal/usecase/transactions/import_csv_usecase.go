@@ -0,0 +1,266 @@
+package transactions_usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// maxImportCSVRows membatasi jumlah baris data per file agar satu DB transaction tetap kecil.
+const maxImportCSVRows = 5000
+
+// Kolom CSV import. date, type, dan amount wajib ada; kolom lain di file (misalnya id dari export CSV) diabaikan.
+var (
+	importCSVRequiredColumns = []string{"date", "type", "amount"}
+	importCSVOptionalColumns = []string{"category_name", "description", "currency"}
+)
+
+// ImportCSV menyimpan transaksi dari file CSV dengan header date, type, category_name, amount, description
+// (currency opsional, kosong berarti mata uang dasar). Setiap baris divalidasi sendiri: baris yang valid disimpan
+// dalam satu DB transaction, baris yang tidak valid dilaporkan beserta nomor barisnya di file sehingga bisa
+// diperbaiki dan di-upload ulang. Kategori dicocokkan berdasarkan nama seperti Import; jika createMissing true,
+// nama yang belum ada dibuat sebagai kategori baru, jika false transaksinya disimpan tanpa kategori.
+func (u *CrudTransaction) ImportCSV(ctx context.Context, userID int64, file io.Reader, createMissing bool) (*usecaseEntity.ImportCSVResponse, error) {
+	funcName := "CrudTransaction.ImportCSV"
+	logFields := generalEntity.CaptureFields{
+		"user_id":        strconv.FormatInt(userID, 10),
+		"create_missing": strconv.FormatBool(createMissing),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// 1. Baca dan validasi setiap baris, baris yang tidak valid dicatat tanpa menghentikan proses
+	rows, lines, rowErrors, err := parseImportCSV(file)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &usecaseEntity.ImportCSVResponse{Errors: rowErrors, Categories: []usecaseEntity.CategoryMapping{}}
+	validRows := make([]usecaseEntity.ImportTransactionRow, 0, len(rows))
+	validLines := make([]int, 0, len(rows))
+	transactions := make([]*myentity.Transaction, 0, len(rows))
+	for i, row := range rows {
+		data, err := buildImportTransaction(userID, row, u.CurrencyOption.Code)
+		if err != nil {
+			result.Errors = append(result.Errors, usecaseEntity.ImportRowError{Line: lines[i], Message: err.Error()})
+			continue
+		}
+		validRows = append(validRows, row)
+		validLines = append(validLines, lines[i])
+		transactions = append(transactions, data)
+	}
+
+	// 2. Baris di periode terkunci ikut dilaporkan sebagai error
+	validRows, validLines, transactions, err = u.rejectLockedImportRows(ctx, userID, validRows, validLines, transactions, result)
+	if err != nil {
+		helper.LogError(funcName, "PeriodLock.EnsureUnlocked", err, logFields, "")
+		return nil, err
+	}
+	sort.SliceStable(result.Errors, func(i, j int) bool { return result.Errors[i].Line < result.Errors[j].Line })
+
+	if len(transactions) == 0 {
+		return result, nil
+	}
+
+	// 3. Petakan nama kategori ke kategori milik user
+	existing, err := u.CategoryRepo.GetAll(ctx, userID)
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.GetAll", err, logFields, "")
+		return nil, err
+	}
+	plan := planImportCategories(userID, existing, validRows, createMissing)
+
+	// 4. Buat kategori baru dan simpan transaksi yang valid dalam satu DB transaction
+	err = mysql.DBTransaction(u.TransactionRepo, func(trx mysql.TrxObj) error {
+		if err := plan.createCategories(ctx, trx, u.CategoryRepo); err != nil {
+			helper.LogError(funcName, "CategoryRepo.Create", err, logFields, "")
+			return err
+		}
+
+		for i, data := range transactions {
+			data.CategoryID = plan.categoryID(validRows[i].CategoryName)
+
+			if err := u.TransactionRepo.Create(ctx, trx, data, false); err != nil {
+				helper.LogError(funcName, "TransactionRepo.Create", err, logFields, "line "+strconv.Itoa(validLines[i]))
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Imported = len(transactions)
+	result.Categories = plan.mappings
+	return result, nil
+}
+
+// rejectLockedImportRows memindahkan baris yang bertanggal di periode terkunci ke result.Errors.
+// Kunci periode berlaku untuk semua tanggal sampai tanggal kunci, jadi tanggal unik dicek dari yang paling awal
+// dan pengecekan berhenti pada tanggal pertama yang tidak terkunci; tanggal setelahnya pasti juga tidak terkunci.
+func (u *CrudTransaction) rejectLockedImportRows(ctx context.Context, userID int64, rows []usecaseEntity.ImportTransactionRow, lines []int,
+	transactions []*myentity.Transaction, result *usecaseEntity.ImportCSVResponse) ([]usecaseEntity.ImportTransactionRow, []int, []*myentity.Transaction, error) {
+	dates := make([]time.Time, 0, len(transactions))
+	seen := make(map[string]bool, len(transactions))
+	for _, data := range transactions {
+		key := data.TransactionDate.Format(helper.DateLayout)
+		if !seen[key] {
+			seen[key] = true
+			dates = append(dates, data.TransactionDate)
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	locked := make(map[string]string)
+	for _, date := range dates {
+		err := u.PeriodLock.EnsureUnlocked(ctx, userID, date)
+		if err == nil {
+			break
+		}
+		detail, ok := periodLockDetail(err)
+		if !ok {
+			return nil, nil, nil, err
+		}
+		locked[date.Format(helper.DateLayout)] = detail
+	}
+	if len(locked) == 0 {
+		return rows, lines, transactions, nil
+	}
+
+	keptRows := make([]usecaseEntity.ImportTransactionRow, 0, len(rows))
+	keptLines := make([]int, 0, len(lines))
+	kept := make([]*myentity.Transaction, 0, len(transactions))
+	for i, data := range transactions {
+		if detail, ok := locked[data.TransactionDate.Format(helper.DateLayout)]; ok {
+			result.Errors = append(result.Errors, usecaseEntity.ImportRowError{Line: lines[i], Message: detail})
+			continue
+		}
+		keptRows = append(keptRows, rows[i])
+		keptLines = append(keptLines, lines[i])
+		kept = append(kept, data)
+	}
+
+	return keptRows, keptLines, kept, nil
+}
+
+// periodLockDetail mengembalikan detail error jika err adalah penolakan karena periode terkunci (409).
+func periodLockDetail(err error) (string, bool) {
+	var appErr apperr.CustomErrorResponse
+	if errors.As(err, &appErr) && appErr.ErrCode == apperr.ErrConflict().ErrCode {
+		return appErr.Detail, true
+	}
+	return "", false
+}
+
+// parseImportCSV membaca header dan baris data CSV import. lines berisi nomor baris di file (header adalah baris 1)
+// untuk tiap baris di rows. Baris yang formatnya tidak bisa dibaca (jumlah kolom salah, amount bukan angka)
+// dikembalikan sebagai rowErrors; error hanya untuk file yang tidak bisa diproses sama sekali.
+func parseImportCSV(file io.Reader) (rows []usecaseEntity.ImportTransactionRow, lines []int, rowErrors []usecaseEntity.ImportRowError, err error) {
+	// FieldsPerRecord 0 membuat jumlah kolom header menjadi acuan untuk semua baris
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, nil, apperr.ErrInvalidRequest().SetDetail("CSV file is empty")
+	}
+	if err != nil {
+		return nil, nil, nil, apperr.ErrInvalidRequest().SetDetail("Invalid CSV header: " + err.Error())
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+	for _, name := range importCSVRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, nil, nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("CSV header must contain %s (optional: %s)",
+				strings.Join(importCSVRequiredColumns, ", "), strings.Join(importCSVOptionalColumns, ", ")))
+		}
+	}
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rowErrors = []usecaseEntity.ImportRowError{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+			// Jumlah kolom tidak sama dengan header, baris lain tetap bisa dibaca
+			rowErrors = append(rowErrors, usecaseEntity.ImportRowError{Line: parseErr.StartLine, Message: "number of columns does not match the header"})
+			continue
+		}
+		if err != nil {
+			return nil, nil, nil, apperr.ErrInvalidRequest().SetDetail("Invalid CSV: " + err.Error())
+		}
+		line, _ := reader.FieldPos(0)
+
+		if len(rows)+len(rowErrors) >= maxImportCSVRows {
+			return nil, nil, nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("CSV must not contain more than %d rows", maxImportCSVRows))
+		}
+
+		amount, err := strconv.ParseFloat(field(record, "amount"), 64)
+		if err != nil {
+			rowErrors = append(rowErrors, usecaseEntity.ImportRowError{Line: line, Message: "amount must be a number"})
+			continue
+		}
+
+		row := usecaseEntity.ImportTransactionRow{
+			CategoryName:    field(record, "category_name"),
+			Amount:          amount,
+			Type:            usecaseEntity.TransactionTypeString(strings.ToLower(field(record, "type"))),
+			TransactionDate: field(record, "date"),
+		}
+		if description := field(record, "description"); description != "" {
+			row.Description = &description
+		}
+		if currency := field(record, "currency"); currency != "" {
+			row.Currency = &currency
+		}
+
+		rows = append(rows, row)
+		lines = append(lines, line)
+	}
+
+	if len(rows) == 0 && len(rowErrors) == 0 {
+		return nil, nil, nil, apperr.ErrInvalidRequest().SetDetail("CSV file has no data rows")
+	}
+
+	return rows, lines, rowErrors, nil
+}
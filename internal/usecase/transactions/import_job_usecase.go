@@ -0,0 +1,372 @@
+package transactions_usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/rakahikmah/finance-tracking/config"
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/queue"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
+	period_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/period"
+	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// ImportJob adalah usecase import transaksi besar secara asynchronous: baris divalidasi dan disimpan sebagai job,
+// lalu worker menyimpan transaksinya per batch melalui queue.ProcessImportJob.
+type ImportJob struct {
+	ImportJobRepo   mysql.IImportJobRepository
+	TransactionRepo mysql.ITransactionRepository
+	CategoryRepo    mysql.ICategoryRepository
+	CurrencyOption  config.CurrencyOption  // Mata uang untuk validasi jumlah desimal amount
+	ImportJobOption config.ImportJobOption // Ukuran batch per DB transaction
+	UserStatus      usecase.IUserStatusChecker
+	PeriodLock      period_usecase.IPeriodLockChecker // Menolak baris di periode yang terkunci
+	Queue           queue.Queue
+}
+
+// NewImportJob adalah konstruktor untuk ImportJob.
+func NewImportJob(
+	ImportJobRepo mysql.IImportJobRepository,
+	TransactionRepo mysql.ITransactionRepository,
+	CategoryRepo mysql.ICategoryRepository,
+	CurrencyOption config.CurrencyOption,
+	ImportJobOption config.ImportJobOption,
+	UserStatus usecase.IUserStatusChecker,
+	PeriodLock period_usecase.IPeriodLockChecker,
+	Queue queue.Queue,
+) *ImportJob {
+	return &ImportJob{
+		ImportJobRepo:   ImportJobRepo,
+		TransactionRepo: TransactionRepo,
+		CategoryRepo:    CategoryRepo,
+		CurrencyOption:  CurrencyOption,
+		ImportJobOption: ImportJobOption,
+		UserStatus:      UserStatus,
+		PeriodLock:      PeriodLock,
+		Queue:           Queue,
+	}
+}
+
+// IImportJob mendefinisikan interface untuk usecase job import.
+type IImportJob interface {
+	Create(ctx context.Context, userID int64, req usecaseEntity.ImportTransactionReq, createCategories bool) (*usecaseEntity.ImportJobResponse, error)
+	GetByID(ctx context.Context, id int64, userID int64) (*usecaseEntity.ImportJobResponse, error)
+	Resume(ctx context.Context, id int64, userID int64) (*usecaseEntity.ImportJobResponse, error)
+	Process(ctx context.Context, id int64) error
+}
+
+// ImportJobPayload adalah pesan yang dipublish ke queue.ProcessImportJob.
+type ImportJobPayload struct {
+	JobID int64 `json:"job_id"`
+}
+
+// Create memvalidasi semua baris, menyimpannya sebagai job berstatus pending, lalu mengirim job ke worker.
+// Jika publish gagal, job ditandai failed agar dapat dilanjutkan dengan Resume.
+func (u *ImportJob) Create(ctx context.Context, userID int64, req usecaseEntity.ImportTransactionReq, createCategories bool) (*usecaseEntity.ImportJobResponse, error) {
+	funcName := "ImportJob.Create"
+	logFields := generalEntity.CaptureFields{
+		"user_id":           strconv.FormatInt(userID, 10),
+		"rows":              strconv.Itoa(len(req.Rows)),
+		"create_categories": strconv.FormatBool(createCategories),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if len(req.Rows) == 0 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("rows must not be empty")
+	}
+
+	// Validasi semua baris di awal supaya worker hanya menerima data yang valid
+	transactions, err := buildImportTransactions(userID, req.Rows, u.CurrencyOption.Code)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureTransactionsUnlocked(ctx, u.PeriodLock, userID, transactions); err != nil {
+		helper.LogError(funcName, "ensureTransactionsUnlocked", err, logFields, "")
+		return nil, err
+	}
+
+	rows, err := json.Marshal(req.Rows)
+	if err != nil {
+		helper.LogError(funcName, "json.Marshal", err, logFields, "")
+		return nil, err
+	}
+
+	job := &myentity.ImportJob{
+		UserID:           userID,
+		Status:           myentity.ImportJobStatusPending,
+		CreateCategories: createCategories,
+		Total:            len(req.Rows),
+		Errors:           "[]",
+		Rows:             string(rows),
+		CreatedAt:        helper.DatetimeNowJakarta(),
+		UpdatedAt:        helper.DatetimeNowJakarta(),
+	}
+	if err := u.ImportJobRepo.Create(ctx, nil, job); err != nil {
+		helper.LogError(funcName, "ImportJobRepo.Create", err, logFields, "")
+		return nil, err
+	}
+
+	if err := u.enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return toImportJobResponse(job), nil
+}
+
+// GetByID mengambil status dan progress job import milik user.
+func (u *ImportJob) GetByID(ctx context.Context, id int64, userID int64) (*usecaseEntity.ImportJobResponse, error) {
+	funcName := "ImportJob.GetByID"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"job_id":  strconv.FormatInt(id, 10),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	job, err := u.ImportJobRepo.GetByIDAndUserID(ctx, id, userID)
+	if err != nil {
+		helper.LogError(funcName, "ImportJobRepo.GetByIDAndUserID", err, logFields, "")
+		return nil, err
+	}
+
+	return toImportJobResponse(job), nil
+}
+
+// Resume mengirim ulang job import yang gagal ke worker. Worker melanjutkan dari offset processed terakhir,
+// sehingga baris yang sudah tersimpan tidak di-insert ulang. Hanya job berstatus failed yang dapat dilanjutkan.
+func (u *ImportJob) Resume(ctx context.Context, id int64, userID int64) (*usecaseEntity.ImportJobResponse, error) {
+	funcName := "ImportJob.Resume"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"job_id":  strconv.FormatInt(id, 10),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	var job *myentity.ImportJob
+	err := mysql.DBTransaction(u.ImportJobRepo, func(trx mysql.TrxObj) error {
+		var err error
+		job, err = u.ImportJobRepo.LockByID(ctx, trx, id)
+		if err != nil {
+			helper.LogError(funcName, "ImportJobRepo.LockByID", err, logFields, "")
+			return err
+		}
+		if job.UserID != userID {
+			return apperr.ErrRecordNotFound()
+		}
+		if job.Status != myentity.ImportJobStatusFailed {
+			return apperr.ErrConflict().SetDetail(fmt.Sprintf("Only failed import jobs can be resumed, job is %s.", job.Status))
+		}
+
+		changes := &myentity.ImportJob{Status: myentity.ImportJobStatusPending, UpdatedAt: helper.DatetimeNowJakarta()}
+		if err := u.ImportJobRepo.Update(ctx, trx, job, changes); err != nil {
+			helper.LogError(funcName, "ImportJobRepo.Update", err, logFields, "")
+			return err
+		}
+		job.Status, job.UpdatedAt = changes.Status, changes.UpdatedAt
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return toImportJobResponse(job), nil
+}
+
+// Process menyimpan baris job import per batch mulai dari offset processed. Setiap batch disimpan bersama
+// kenaikan offset dalam satu DB transaction, sehingga percobaan ulang tidak pernah meng-insert baris yang sama.
+// Job yang gagal ditandai failed beserta pesan error-nya dan error dikembalikan agar queue mencoba ulang.
+func (u *ImportJob) Process(ctx context.Context, id int64) error {
+	funcName := "ImportJob.Process"
+	logFields := generalEntity.CaptureFields{
+		"job_id": strconv.FormatInt(id, 10),
+	}
+
+	job, err := u.ImportJobRepo.GetByID(ctx, id)
+	if err != nil {
+		helper.LogError(funcName, "ImportJobRepo.GetByID", err, logFields, "")
+		return err
+	}
+	if job.Status == myentity.ImportJobStatusCompleted {
+		return nil
+	}
+
+	if err := u.process(ctx, job); err != nil {
+		helper.LogError(funcName, "process", err, logFields, "")
+		u.markFailed(ctx, job, err)
+		return err
+	}
+
+	return nil
+}
+
+// process menjalankan isi job mulai dari job.Processed sampai selesai.
+func (u *ImportJob) process(ctx context.Context, job *myentity.ImportJob) error {
+	var rows []usecaseEntity.ImportTransactionRow
+	if err := json.Unmarshal([]byte(job.Rows), &rows); err != nil {
+		return err
+	}
+	transactions, err := buildImportTransactions(job.UserID, rows, u.CurrencyOption.Code)
+	if err != nil {
+		return err
+	}
+
+	// Periode bisa dikunci setelah job dibuat, cek ulang baris yang belum tersimpan sebelum memproses
+	if err := ensureTransactionsUnlocked(ctx, u.PeriodLock, job.UserID, transactions[job.Processed:]); err != nil {
+		return err
+	}
+
+	if err := u.ImportJobRepo.Update(ctx, nil, job, &myentity.ImportJob{Status: myentity.ImportJobStatusProcessing, UpdatedAt: helper.DatetimeNowJakarta()}); err != nil {
+		return err
+	}
+
+	// Kategori dipetakan ulang di setiap percobaan; kategori yang dibuat percobaan sebelumnya sudah menjadi matched
+	existing, err := u.CategoryRepo.GetAll(ctx, job.UserID)
+	if err != nil {
+		return err
+	}
+	plan := planImportCategories(job.UserID, existing, rows, job.CreateCategories)
+	if err := mysql.DBTransaction(u.CategoryRepo, func(trx mysql.TrxObj) error {
+		return plan.createCategories(ctx, trx, u.CategoryRepo)
+	}); err != nil {
+		return err
+	}
+
+	batchSize := u.ImportJobOption.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(transactions)
+	}
+
+	for job.Processed < job.Total {
+		from := job.Processed
+		to := min(from+batchSize, job.Total)
+
+		moved := false
+		err := mysql.DBTransaction(u.ImportJobRepo, func(trx mysql.TrxObj) error {
+			locked, err := u.ImportJobRepo.LockByID(ctx, trx, job.ID)
+			if err != nil {
+				return err
+			}
+			// Worker lain sudah memproses batch ini (misalnya pesan terkirim dua kali), hentikan percobaan ini
+			if locked.Processed != from {
+				moved = true
+				return nil
+			}
+
+			for i := from; i < to; i++ {
+				transactions[i].CategoryID = plan.categoryID(rows[i].CategoryName)
+				if err := u.TransactionRepo.Create(ctx, trx, transactions[i], false); err != nil {
+					return fmt.Errorf("row %d: %w", i+1, err)
+				}
+			}
+
+			return u.ImportJobRepo.Update(ctx, trx, job, &myentity.ImportJob{Processed: to, UpdatedAt: helper.DatetimeNowJakarta()})
+		})
+		if err != nil {
+			return err
+		}
+		if moved {
+			return nil
+		}
+		job.Processed = to
+	}
+
+	job.Status = myentity.ImportJobStatusCompleted
+	return u.ImportJobRepo.Update(ctx, nil, job, &myentity.ImportJob{Status: myentity.ImportJobStatusCompleted, UpdatedAt: helper.DatetimeNowJakarta()})
+}
+
+// enqueue mengirim job ke worker. Jika publish gagal, job ditandai failed agar dapat dilanjutkan dengan Resume.
+func (u *ImportJob) enqueue(ctx context.Context, job *myentity.ImportJob) error {
+	funcName := "ImportJob.enqueue"
+	logFields := generalEntity.CaptureFields{
+		"job_id": strconv.FormatInt(job.ID, 10),
+	}
+
+	payload, _ := helper.Serialize(ImportJobPayload{JobID: job.ID})
+	if err := u.Queue.Publish(queue.ProcessImportJob, payload, 1); err != nil {
+		helper.LogError(funcName, "Queue.Publish", err, logFields, "")
+		u.markFailed(ctx, job, err)
+		return err
+	}
+
+	return nil
+}
+
+// markFailed menandai job failed dan menambahkan pesan error ke riwayat error job.
+// Kegagalan menyimpan status hanya dicatat di log karena error asal tetap dikembalikan ke pemanggil.
+func (u *ImportJob) markFailed(ctx context.Context, job *myentity.ImportJob, cause error) {
+	funcName := "ImportJob.markFailed"
+	logFields := generalEntity.CaptureFields{
+		"job_id": strconv.FormatInt(job.ID, 10),
+	}
+
+	messages := importJobErrors(job)
+	messages = append(messages, fmt.Sprintf("rows %d-%d: %s", job.Processed+1, job.Total, cause.Error()))
+	encoded, _ := json.Marshal(messages)
+
+	changes := &myentity.ImportJob{Status: myentity.ImportJobStatusFailed, Errors: string(encoded), UpdatedAt: helper.DatetimeNowJakarta()}
+	if err := u.ImportJobRepo.Update(ctx, nil, job, changes); err != nil {
+		helper.LogError(funcName, "ImportJobRepo.Update", err, logFields, "")
+		return
+	}
+	job.Status, job.Errors, job.UpdatedAt = changes.Status, changes.Errors, changes.UpdatedAt
+}
+
+// importJobErrors membaca riwayat error job, slice kosong jika belum ada.
+func importJobErrors(job *myentity.ImportJob) []string {
+	messages := []string{}
+	if job.Errors != "" {
+		_ = json.Unmarshal([]byte(job.Errors), &messages)
+	}
+	return messages
+}
+
+// toImportJobResponse memetakan job import ke response DTO.
+func toImportJobResponse(job *myentity.ImportJob) *usecaseEntity.ImportJobResponse {
+	return &usecaseEntity.ImportJobResponse{
+		ID:        job.ID,
+		Status:    string(job.Status),
+		Total:     job.Total,
+		Processed: job.Processed,
+		Errors:    importJobErrors(job),
+		CreatedAt: helper.ConvertToJakartaTime(job.CreatedAt),
+		UpdatedAt: helper.ConvertToJakartaTime(job.UpdatedAt),
+	}
+}
@@ -0,0 +1,295 @@
+package transactions_usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/config"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/queue"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	transactions_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/transactions"
+	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportJobTestSuite struct {
+	suite.Suite
+
+	importJobRepo   *mocks.IImportJobRepository
+	transactionRepo *mocks.ITransactionRepository
+	categoryRepo    *mocks.ICategoryRepository
+	userStatus      *mocks.IUserStatusChecker
+	periodLock      *mocks.IPeriodLockChecker
+	queue           *mocks.Queue
+	trx             *mocks.TrxObj
+	usecase         transactions_usecase.IImportJob
+	ctx             context.Context
+
+	// stored meniru baris import_jobs di database: LockByID/GetByID membaca dan Update menulis ke sini
+	stored myentity.ImportJob
+}
+
+func (s *ImportJobTestSuite) SetupTest() {
+	s.importJobRepo = &mocks.IImportJobRepository{}
+	s.transactionRepo = &mocks.ITransactionRepository{}
+	s.categoryRepo = &mocks.ICategoryRepository{}
+	s.userStatus = &mocks.IUserStatusChecker{}
+	s.userStatus.On("EnsureActive", mock.Anything, int64(1)).Return(nil).Maybe()
+	s.periodLock = &mocks.IPeriodLockChecker{}
+	s.periodLock.On("EnsureUnlocked", mock.Anything, int64(1), mock.Anything).Return(nil).Maybe()
+	s.queue = &mocks.Queue{}
+	s.trx = &mocks.TrxObj{}
+	s.trx.On("Commit").Return(nil).Maybe()
+	s.trx.On("Rollback").Return(nil).Maybe()
+	s.importJobRepo.On("Begin").Return(s.trx, nil).Maybe()
+	s.categoryRepo.On("Begin").Return(s.trx, nil).Maybe()
+	s.ctx = context.Background()
+
+	s.importJobRepo.On("GetByID", mock.Anything, int64(7)).Return(func(context.Context, int64) *myentity.ImportJob {
+		job := s.stored
+		return &job
+	}, nil).Maybe()
+	s.importJobRepo.On("GetByIDAndUserID", mock.Anything, int64(7), int64(1)).Return(func(context.Context, int64, int64) *myentity.ImportJob {
+		job := s.stored
+		return &job
+	}, nil).Maybe()
+	s.importJobRepo.On("LockByID", mock.Anything, s.trx, int64(7)).Return(func(context.Context, mysql.TrxObj, int64) *myentity.ImportJob {
+		job := s.stored
+		return &job
+	}, nil).Maybe()
+	s.importJobRepo.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		changes := args.Get(3).(*myentity.ImportJob)
+		if changes.Status != "" {
+			s.stored.Status = changes.Status
+		}
+		if changes.Processed != 0 {
+			s.stored.Processed = changes.Processed
+		}
+		if changes.Errors != "" {
+			s.stored.Errors = changes.Errors
+		}
+	}).Return(nil).Maybe()
+
+	s.usecase = transactions_usecase.NewImportJob(s.importJobRepo, s.transactionRepo, s.categoryRepo, config.CurrencyOption{Code: "IDR"},
+		config.ImportJobOption{Enabled: true, BatchSize: 2}, s.userStatus, s.periodLock, s.queue)
+}
+
+func TestImportJob(t *testing.T) {
+	suite.Run(t, new(ImportJobTestSuite))
+}
+
+// importRows membuat n baris import valid dengan amount 1000, 2000, ... agar setiap baris mudah dikenali.
+func importRows(n int) []usecaseEntity.ImportTransactionRow {
+	rows := make([]usecaseEntity.ImportTransactionRow, n)
+	for i := range rows {
+		rows[i] = usecaseEntity.ImportTransactionRow{
+			Amount:          float64((i + 1) * 1000),
+			Type:            usecaseEntity.TransactionTypeExpenseStr,
+			TransactionDate: fmt.Sprintf("2024-01-%02d", i+1),
+		}
+	}
+	return rows
+}
+
+func (s *ImportJobTestSuite) storeJob(rows []usecaseEntity.ImportTransactionRow) {
+	raw, err := json.Marshal(rows)
+	s.Require().NoError(err)
+	s.stored = myentity.ImportJob{
+		ID:     7,
+		UserID: 1,
+		Status: myentity.ImportJobStatusPending,
+		Total:  len(rows),
+		Errors: "[]",
+		Rows:   string(raw),
+	}
+}
+
+func (s *ImportJobTestSuite) TestCreate() {
+	s.Run("stores a pending job and publishes it", func() {
+		s.SetupTest()
+		s.importJobRepo.On("Create", mock.Anything, nil, mock.MatchedBy(func(job *myentity.ImportJob) bool {
+			return job.UserID == 1 && job.Status == myentity.ImportJobStatusPending && job.Total == 3 && job.Processed == 0
+		})).Run(func(args mock.Arguments) {
+			args.Get(2).(*myentity.ImportJob).ID = 7
+		}).Return(nil).Once()
+
+		var payload transactions_usecase.ImportJobPayload
+		s.queue.On("Publish", queue.ProcessImportJob, mock.Anything, int32(1)).Run(func(args mock.Arguments) {
+			s.Require().NoError(json.Unmarshal(args.Get(1).([]byte), &payload))
+		}).Return(nil).Once()
+
+		result, err := s.usecase.Create(s.ctx, 1, usecaseEntity.ImportTransactionReq{Rows: importRows(3)}, false)
+		s.Require().NoError(err)
+
+		s.Equal(int64(7), result.ID)
+		s.Equal("pending", result.Status)
+		s.Equal(3, result.Total)
+		s.Equal(0, result.Processed)
+		s.Equal([]string{}, result.Errors)
+		s.Equal(int64(7), payload.JobID)
+	})
+
+	s.Run("invalid row is rejected before a job is created", func() {
+		s.SetupTest()
+		rows := importRows(2)
+		rows[1].Amount = 0
+
+		_, err := s.usecase.Create(s.ctx, 1, usecaseEntity.ImportTransactionReq{Rows: rows}, false)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		s.importJobRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *ImportJobTestSuite) TestProcessTracksProgress() {
+	s.storeJob(importRows(5))
+	s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return([]*myentity.Category{}, nil).Once()
+
+	var processedAtInsert []int
+	s.transactionRepo.On("Create", mock.Anything, s.trx, mock.Anything, false).Run(func(mock.Arguments) {
+		processedAtInsert = append(processedAtInsert, s.stored.Processed)
+	}).Return(nil).Times(5)
+
+	s.Require().NoError(s.usecase.Process(s.ctx, 7))
+
+	// Batch berisi 2 baris, offset processed naik per batch: 0, 2, lalu 4
+	s.Equal([]int{0, 0, 2, 2, 4}, processedAtInsert)
+	s.Equal(5, s.stored.Processed)
+	s.Equal(myentity.ImportJobStatusCompleted, s.stored.Status)
+	s.transactionRepo.AssertExpectations(s.T())
+
+	s.Run("completed job is not processed again", func() {
+		s.Require().NoError(s.usecase.Process(s.ctx, 7))
+		s.transactionRepo.AssertNumberOfCalls(s.T(), "Create", 5)
+	})
+}
+
+func (s *ImportJobTestSuite) TestResumeAfterFailure() {
+	s.storeJob(importRows(5))
+	s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return([]*myentity.Category{}, nil).Twice()
+
+	// Percobaan pertama: batch kedua gagal di baris ke-4, batch tersebut di-rollback
+	var inserted []float64
+	s.transactionRepo.On("Create", mock.Anything, s.trx, mock.MatchedBy(func(t *myentity.Transaction) bool {
+		return t.Amount == 4000
+	}), false).Return(errors.New("lock wait timeout")).Once()
+	s.transactionRepo.On("Create", mock.Anything, s.trx, mock.Anything, false).Run(func(args mock.Arguments) {
+		inserted = append(inserted, args.Get(2).(*myentity.Transaction).Amount)
+	}).Return(nil)
+
+	err := s.usecase.Process(s.ctx, 7)
+	s.Require().Error(err)
+
+	s.Equal(myentity.ImportJobStatusFailed, s.stored.Status)
+	s.Equal(2, s.stored.Processed)
+	var messages []string
+	s.Require().NoError(json.Unmarshal([]byte(s.stored.Errors), &messages))
+	s.Require().Len(messages, 1)
+	s.Contains(messages[0], "row 4")
+
+	status, err := s.usecase.GetByID(s.ctx, 7, 1)
+	s.Require().NoError(err)
+	s.Equal("failed", status.Status)
+
+	// Resume mengembalikan job ke pending dan mempublish ulang
+	s.queue.On("Publish", queue.ProcessImportJob, mock.Anything, int32(1)).Return(nil).Once()
+	result, err := s.usecase.Resume(s.ctx, 7, 1)
+	s.Require().NoError(err)
+	s.Equal("pending", result.Status)
+	s.Equal(2, result.Processed)
+
+	// Percobaan kedua melanjutkan dari offset 2 tanpa meng-insert ulang baris 1-2
+	inserted = nil
+	s.Require().NoError(s.usecase.Process(s.ctx, 7))
+
+	s.Equal([]float64{3000, 4000, 5000}, inserted)
+	s.Equal(5, s.stored.Processed)
+	s.Equal(myentity.ImportJobStatusCompleted, s.stored.Status)
+}
+
+func (s *ImportJobTestSuite) TestResume() {
+	s.Run("only failed jobs can be resumed", func() {
+		s.SetupTest()
+		s.storeJob(importRows(1))
+		s.stored.Status = myentity.ImportJobStatusProcessing
+
+		_, err := s.usecase.Resume(s.ctx, 7, 1)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusConflict, appErr.HTTPCode)
+		s.queue.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("job of another user is not found", func() {
+		s.SetupTest()
+		s.storeJob(importRows(1))
+		s.stored.UserID = 2
+		s.stored.Status = myentity.ImportJobStatusFailed
+
+		_, err := s.usecase.Resume(s.ctx, 7, 1)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusNotFound, appErr.HTTPCode)
+	})
+}
+
+func (s *ImportJobTestSuite) TestPeriodLock() {
+	lockThrough := func(lockedThrough string) {
+		s.periodLock.ExpectedCalls = nil
+		s.periodLock.On("EnsureUnlocked", mock.Anything, int64(1), mock.MatchedBy(func(date time.Time) bool {
+			return date.Format(helper.DateLayout) <= lockedThrough
+		})).Return(apperr.ErrConflict().SetDetail("locked"))
+		s.periodLock.On("EnsureUnlocked", mock.Anything, int64(1), mock.Anything).Return(nil)
+	}
+
+	s.Run("create rejects rows in the locked period", func() {
+		s.SetupTest()
+		lockThrough("2024-01-02")
+
+		_, err := s.usecase.Create(s.ctx, 1, usecaseEntity.ImportTransactionReq{Rows: importRows(3)}, false)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusConflict, appErr.HTTPCode)
+		s.importJobRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("period locked after the job was created fails the job", func() {
+		s.SetupTest()
+		s.storeJob(importRows(5))
+		lockThrough("2024-01-03")
+
+		err := s.usecase.Process(s.ctx, 7)
+		s.Require().Error(err)
+
+		s.Equal(myentity.ImportJobStatusFailed, s.stored.Status)
+		s.Equal(0, s.stored.Processed)
+		s.transactionRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("rows already saved are not checked again", func() {
+		s.SetupTest()
+		s.storeJob(importRows(5))
+		s.stored.Processed = 4
+		lockThrough("2024-01-04")
+		s.categoryRepo.On("GetAll", mock.Anything, int64(1)).Return([]*myentity.Category{}, nil).Once()
+		s.transactionRepo.On("Create", mock.Anything, s.trx, mock.Anything, false).Return(nil).Once()
+
+		s.Require().NoError(s.usecase.Process(s.ctx, 7))
+		s.Equal(myentity.ImportJobStatusCompleted, s.stored.Status)
+	})
+}
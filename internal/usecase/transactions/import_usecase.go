@@ -0,0 +1,246 @@
+package transactions_usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	period_usecase "github.com/rakahikmah/finance-tracking/internal/usecase/period"
+	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// Import menyimpan banyak transaksi sekaligus. Kategori dicocokkan berdasarkan nama (setelah normalisasi, tidak case-sensitive)
+// dengan kategori milik user. Jika createCategories true, nama yang belum ada dibuat sebagai kategori baru;
+// jika false, transaksinya disimpan tanpa kategori. Pembuatan kategori dan transaksi dilakukan dalam satu DB transaction.
+func (u *CrudTransaction) Import(ctx context.Context, userID int64, req usecaseEntity.ImportTransactionReq, createCategories bool) (*usecaseEntity.ImportTransactionResponse, error) {
+	funcName := "CrudTransaction.Import"
+	logFields := generalEntity.CaptureFields{
+		"user_id":           strconv.FormatInt(userID, 10),
+		"rows":              strconv.Itoa(len(req.Rows)),
+		"create_categories": strconv.FormatBool(createCategories),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if len(req.Rows) == 0 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("rows must not be empty")
+	}
+
+	// 1. Validasi semua baris sebelum menyimpan apa pun
+	transactions, err := buildImportTransactions(userID, req.Rows, u.CurrencyOption.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Import ditolak seluruhnya jika ada baris di periode yang terkunci
+	if err := ensureTransactionsUnlocked(ctx, u.PeriodLock, userID, transactions); err != nil {
+		helper.LogError(funcName, "ensureTransactionsUnlocked", err, logFields, "")
+		return nil, err
+	}
+
+	// 3. Petakan nama kategori ke kategori milik user
+	existing, err := u.CategoryRepo.GetAll(ctx, userID)
+	if err != nil {
+		helper.LogError(funcName, "CategoryRepo.GetAll", err, logFields, "")
+		return nil, err
+	}
+	plan := planImportCategories(userID, existing, req.Rows, createCategories)
+
+	// 4. Buat kategori baru dan simpan transaksi dalam satu DB transaction
+	err = mysql.DBTransaction(u.TransactionRepo, func(trx mysql.TrxObj) error {
+		if err := plan.createCategories(ctx, trx, u.CategoryRepo); err != nil {
+			helper.LogError(funcName, "CategoryRepo.Create", err, logFields, "")
+			return err
+		}
+
+		for i, data := range transactions {
+			data.CategoryID = plan.categoryID(req.Rows[i].CategoryName)
+
+			if err := u.TransactionRepo.Create(ctx, trx, data, false); err != nil {
+				helper.LogError(funcName, "TransactionRepo.Create", err, logFields, "")
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &usecaseEntity.ImportTransactionResponse{
+		Imported:   len(transactions),
+		Categories: plan.mappings,
+	}, nil
+}
+
+// ensureTransactionsUnlocked mengembalikan apperr.ErrConflict jika ada transaksi yang bertanggal di periode terkunci.
+// Kunci periode berlaku untuk semua tanggal sampai tanggal kunci, jadi cukup tanggal paling awal yang dicek.
+func ensureTransactionsUnlocked(ctx context.Context, lock period_usecase.IPeriodLockChecker, userID int64, transactions []*myentity.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+	earliest := transactions[0].TransactionDate
+	for _, data := range transactions[1:] {
+		if data.TransactionDate.Before(earliest) {
+			earliest = data.TransactionDate
+		}
+	}
+	return lock.EnsureUnlocked(ctx, userID, earliest)
+}
+
+// buildImportTransactions memvalidasi semua baris import dan memetakannya ke entity transaksi (tanpa kategori).
+// currencyCode adalah mata uang dasar untuk baris tanpa currency. Error mengembalikan nomor baris (mulai dari 1)
+// yang pertama kali tidak valid.
+func buildImportTransactions(userID int64, rows []usecaseEntity.ImportTransactionRow, currencyCode string) ([]*myentity.Transaction, error) {
+	transactions := make([]*myentity.Transaction, len(rows))
+	for i, row := range rows {
+		data, err := buildImportTransaction(userID, row, currencyCode)
+		if err != nil {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("row %d: %s", i+1, err.Error()))
+		}
+		transactions[i] = data
+	}
+
+	return transactions, nil
+}
+
+// buildImportTransaction memvalidasi satu baris import dan memetakannya ke entity transaksi (tanpa kategori).
+// Error berisi alasan tanpa nomor baris, pemanggil yang menambahkan posisi barisnya.
+func buildImportTransaction(userID int64, row usecaseEntity.ImportTransactionRow, currencyCode string) (*myentity.Transaction, error) {
+	if row.Type != usecaseEntity.TransactionTypeIncomeStr && row.Type != usecaseEntity.TransactionTypeExpenseStr {
+		return nil, errors.New("type must be income or expense")
+	}
+	if row.Amount <= 0 {
+		return nil, errors.New("amount must be greater than 0")
+	}
+	if err := helper.ValidateStoredAmount(row.Amount); err != nil {
+		return nil, fmt.Errorf("invalid amount: %s", err.Error())
+	}
+	currency, err := resolveCurrency(row.Currency, currencyCode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid currency: %s", err.Error())
+	}
+	if err := helper.ValidateAmountScale(row.Amount, currency); err != nil {
+		return nil, fmt.Errorf("invalid amount: %s", err.Error())
+	}
+	parsedDate, err := helper.ParseDateStrict(row.TransactionDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction_date: %s", err.Error())
+	}
+	if err := helper.ValidateDescription(row.Description); err != nil {
+		return nil, fmt.Errorf("invalid description: %s", err.Error())
+	}
+
+	var description sql.NullString
+	if row.Description != nil {
+		description = sql.NullString{String: *row.Description, Valid: true}
+	}
+
+	return &myentity.Transaction{
+		UserID:          userID,
+		Amount:          row.Amount,
+		Currency:        currency,
+		Type:            myentity.TransactionType(row.Type),
+		Description:     description,
+		TransactionDate: parsedDate,
+		CreatedAt:       helper.DatetimeNowJakarta(),
+		UpdatedAt:       helper.DatetimeNowJakarta(),
+	}, nil
+}
+
+// importCategoryPlan adalah hasil pemetaan nama kategori pada data import ke kategori milik user,
+// beserta kategori baru yang masih harus dibuat.
+type importCategoryPlan struct {
+	categories    map[string]*myentity.Category // key: helper.CategoryNameKey
+	mappings      []usecaseEntity.CategoryMapping
+	mappingIndex  map[string]int
+	newCategories []*myentity.Category
+}
+
+// planImportCategories mencocokkan nama kategori tiap baris dengan kategori yang sudah ada. Jika createCategories true,
+// nama yang belum ada direncanakan sebagai kategori baru; jika false, dibiarkan unmatched.
+func planImportCategories(userID int64, existing []*myentity.Category, rows []usecaseEntity.ImportTransactionRow, createCategories bool) *importCategoryPlan {
+	plan := &importCategoryPlan{
+		categories:   make(map[string]*myentity.Category, len(existing)),
+		mappings:     []usecaseEntity.CategoryMapping{},
+		mappingIndex: map[string]int{},
+	}
+	for _, category := range existing {
+		plan.categories[helper.CategoryNameKey(category.Name)] = category
+	}
+
+	for _, row := range rows {
+		name := helper.NormalizeCategoryName(row.CategoryName)
+		key := helper.CategoryNameKey(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := plan.mappingIndex[key]; ok {
+			continue
+		}
+
+		mapping := usecaseEntity.CategoryMapping{Name: name, Status: usecaseEntity.CategoryMappingUnmatched}
+		if category, ok := plan.categories[key]; ok {
+			id := category.ID
+			mapping.Name = category.Name
+			mapping.CategoryID = &id
+			mapping.Status = usecaseEntity.CategoryMappingMatched
+		} else if createCategories {
+			mapping.Status = usecaseEntity.CategoryMappingCreated
+			plan.newCategories = append(plan.newCategories, &myentity.Category{
+				Name:      name,
+				CreatedBy: userID,
+				CreatedAt: helper.DatetimeNowJakarta(),
+				UpdatedAt: helper.DatetimeNowJakarta(),
+			})
+		}
+
+		plan.mappingIndex[key] = len(plan.mappings)
+		plan.mappings = append(plan.mappings, mapping)
+	}
+
+	return plan
+}
+
+// createCategories membuat kategori baru hasil rencana di dalam trx dan melengkapi mapping dengan ID-nya.
+func (p *importCategoryPlan) createCategories(ctx context.Context, trx mysql.TrxObj, categoryRepo mysql.ICategoryRepository) error {
+	for _, category := range p.newCategories {
+		if err := categoryRepo.Create(ctx, trx, category, false); err != nil {
+			return err
+		}
+
+		key := helper.CategoryNameKey(category.Name)
+		id := category.ID
+		p.categories[key] = category
+		p.mappings[p.mappingIndex[key]].CategoryID = &id
+	}
+	p.newCategories = nil
+
+	return nil
+}
+
+// categoryID mengembalikan ID kategori untuk nama kategori pada baris import, NULL jika tidak ada kategori yang cocok.
+func (p *importCategoryPlan) categoryID(name string) sql.NullInt64 {
+	if category, ok := p.categories[helper.CategoryNameKey(name)]; ok {
+		return sql.NullInt64{Int64: category.ID, Valid: true}
+	}
+	return sql.NullInt64{}
+}
@@ -0,0 +1,57 @@
+package transactions_usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// CleanupOrphanedCategories mengosongkan category_id transaksi user yang menunjuk ke kategori yang sudah tidak ada,
+// sehingga transaksi tersebut tampil sebagai tanpa kategori. Dipakai admin untuk memperbaiki data, karena itu
+// status user dan period lock tidak dicek. Dengan dryRun hanya jumlah transaksi yatim yang dikembalikan.
+func (u *CrudTransaction) CleanupOrphanedCategories(ctx context.Context, userID int64, dryRun bool) (*usecaseEntity.OrphanCleanupResponse, error) {
+	funcName := "CrudTransaction.CleanupOrphanedCategories"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"dry_run": strconv.FormatBool(dryRun),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak diberikan")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak diberikan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	orphaned, err := u.TransactionRepo.CountOrphanedTransactions(ctx, userID)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.CountOrphanedTransactions", err, logFields, "")
+		return nil, err
+	}
+
+	result := &usecaseEntity.OrphanCleanupResponse{UserID: userID, DryRun: dryRun, Orphaned: orphaned}
+	if dryRun || orphaned == 0 {
+		return result, nil
+	}
+
+	err = mysql.DBTransaction(u.TransactionRepo, func(trx mysql.TrxObj) error {
+		fixed, err := u.TransactionRepo.ClearOrphanedCategories(ctx, trx, userID)
+		if err != nil {
+			helper.LogError(funcName, "TransactionRepo.ClearOrphanedCategories", err, logFields, "")
+			return err
+		}
+		result.Fixed = fixed
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
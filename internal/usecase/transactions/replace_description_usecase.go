@@ -0,0 +1,113 @@
+package transactions_usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// ReplaceDescription mengganti setiap kemunculan Find dengan Replace pada description transaksi user.
+// Tanpa CaseSensitive, Find dicocokkan tanpa membedakan huruf besar/kecil. Dengan DryRun hanya baris yang akan
+// berubah yang dikembalikan. Jika tidak DryRun, semua baris disimpan dalam satu DB transaction dan ditolak
+// bila ada transaksi di periode yang terkunci.
+func (u *CrudTransaction) ReplaceDescription(ctx context.Context, userID int64, req usecaseEntity.ReplaceDescriptionReq) (*usecaseEntity.ReplaceDescriptionResponse, error) {
+	funcName := "CrudTransaction.ReplaceDescription"
+	logFields := generalEntity.CaptureFields{
+		"user_id":        strconv.FormatInt(userID, 10),
+		"find":           req.Find,
+		"case_sensitive": strconv.FormatBool(req.CaseSensitive),
+		"dry_run":        strconv.FormatBool(req.DryRun),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	if req.Find == "" {
+		return nil, apperr.ErrInvalidRequest().SetDetail("find is required")
+	}
+
+	if !req.DryRun {
+		// Tolak penulisan data dari user yang sudah tidak aktif
+		if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := u.TransactionRepo.GetByDescriptionContains(ctx, userID, req.Find, req.CaseSensitive)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetByDescriptionContains", err, logFields, "")
+		return nil, err
+	}
+
+	replace := func(s string) string { return strings.ReplaceAll(s, req.Find, req.Replace) }
+	if !req.CaseSensitive {
+		pattern := regexp.MustCompile("(?i)" + regexp.QuoteMeta(req.Find))
+		replace = func(s string) string { return pattern.ReplaceAllLiteralString(s, req.Replace) }
+	}
+
+	result := &usecaseEntity.ReplaceDescriptionResponse{DryRun: req.DryRun, Rows: []usecaseEntity.ReplaceDescriptionRow{}}
+	changed := make([]*myentity.Transaction, 0, len(data))
+	for _, row := range data {
+		after := replace(row.Description.String)
+		if after == row.Description.String {
+			continue
+		}
+		if err := helper.ValidateDescription(&after); err != nil {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("Replacing would make the description of transaction %d too long: %s", row.ID, err.Error()))
+		}
+
+		result.Rows = append(result.Rows, usecaseEntity.ReplaceDescriptionRow{
+			ID:              row.ID,
+			TransactionDate: row.TransactionDate.Format(helper.DateLayout),
+			Before:          row.Description.String,
+			After:           after,
+		})
+		row.Description = sql.NullString{String: after, Valid: true}
+		changed = append(changed, row)
+	}
+	result.Changed = len(result.Rows)
+
+	if req.DryRun || len(changed) == 0 {
+		return result, nil
+	}
+
+	// Kunci periode cukup dicek sekali untuk tanggal paling awal, bukan satu query per baris
+	if err := ensureTransactionsUnlocked(ctx, u.PeriodLock, userID, changed); err != nil {
+		helper.LogError(funcName, "ensureTransactionsUnlocked", err, logFields, "")
+		return nil, err
+	}
+
+	err = mysql.DBTransaction(u.TransactionRepo, func(trx mysql.TrxObj) error {
+		for _, row := range changed {
+			changes := &myentity.Transaction{
+				Description: row.Description,
+				UpdatedAt:   helper.DatetimeNowJakarta(),
+			}
+			if err := u.TransactionRepo.Update(ctx, trx, row, changes); err != nil {
+				helper.LogError(funcName, "TransactionRepo.Update", err, logFields, "")
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
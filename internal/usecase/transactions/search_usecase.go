@@ -0,0 +1,120 @@
+package transactions_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+	// fuzzySearchCandidates membatasi jumlah transaksi terbaru yang dibandingkan pada pencarian fuzzy,
+	// karena jarak Levenshtein dihitung di aplikasi dan tidak bisa memakai index
+	fuzzySearchCandidates = 1000
+)
+
+// Search mencari transaksi user berdasarkan description. Secara default memakai LIKE dan mengurutkan dari yang terbaru.
+// Dengan Fuzzy, description dari transaksi terbaru (dibatasi fuzzySearchCandidates) dibandingkan dengan jarak Levenshtein
+// sehingga typo seperti "grocries" tetap cocok, lalu hasil diurutkan dari yang paling mirip.
+func (u *CrudTransaction) Search(ctx context.Context, userID int64, req usecaseEntity.TransactionSearchReq) ([]usecaseEntity.TransactionResponse, error) {
+	funcName := "CrudTransaction.Search"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"query":   req.Query,
+		"fuzzy":   strconv.FormatBool(req.Fuzzy),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, apperr.ErrInvalidRequest().SetDetail("q is required")
+	}
+	minLength := u.SearchOption.MinQueryLength
+	if minLength < 1 {
+		minLength = 1
+	}
+	if utf8.RuneCountInString(query) < minLength {
+		return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("q must be at least %d characters", minLength))
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultSearchLimit
+	}
+	if req.Limit < 1 || req.Limit > maxSearchLimit {
+		return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("limit must be between 1 and %d", maxSearchLimit))
+	}
+	responseFormat, err := u.responseFormat(ctx, userID, req.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []*mysql.TransactionWithCategory
+	if req.Fuzzy {
+		candidates, err := u.TransactionRepo.GetRecentWithDescription(ctx, userID, fuzzySearchCandidates)
+		if err != nil {
+			helper.LogError(funcName, "TransactionRepo.GetRecentWithDescription", err, logFields, "")
+			return nil, err
+		}
+		data = rankByFuzzyDescription(query, candidates, req.Limit)
+	} else {
+		data, err = u.TransactionRepo.SearchByDescription(ctx, userID, query, req.Limit)
+		if err != nil {
+			helper.LogError(funcName, "TransactionRepo.SearchByDescription", err, logFields, "")
+			return nil, err
+		}
+	}
+
+	result := make([]usecaseEntity.TransactionResponse, 0, len(data))
+	for _, row := range data {
+		result = append(result, toTransactionResponse(row, responseFormat))
+	}
+
+	return result, nil
+}
+
+// rankByFuzzyDescription menyaring kandidat yang description-nya berada dalam helper.FuzzyMaxDistance dari query,
+// mengurutkan dari jarak terkecil (kandidat dengan jarak sama tetap berurutan dari yang terbaru), dan memotong ke limit.
+func rankByFuzzyDescription(query string, candidates []*mysql.TransactionWithCategory, limit int) []*mysql.TransactionWithCategory {
+	type scored struct {
+		row      *mysql.TransactionWithCategory
+		distance int
+	}
+
+	maxDistance := helper.FuzzyMaxDistance(query)
+	matches := make([]scored, 0)
+	for _, row := range candidates {
+		distance := helper.FuzzyDistance(query, row.Description.String)
+		if distance <= maxDistance {
+			matches = append(matches, scored{row: row, distance: distance})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	result := make([]*mysql.TransactionWithCategory, 0, len(matches))
+	for _, match := range matches {
+		result = append(result, match.row)
+	}
+	return result
+}
@@ -0,0 +1,222 @@
+package transactions_usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	generalEntity "github.com/rakahikmah/finance-tracking/entity"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	myentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	usecaseEntity "github.com/rakahikmah/finance-tracking/internal/usecase/transactions/entity"
+
+	apperr "github.com/rakahikmah/finance-tracking/error"
+)
+
+// maxReferenceLength mengikuti panjang kolom transactions.reference.
+const maxReferenceLength = 100
+
+// Sync melakukan upsert transaksi berdasarkan (user_id, reference): reference yang sudah ada diperbarui
+// jika datanya berbeda, reference baru dibuat. Reference milik transaksi yang sudah di-soft delete dilewati (skipped)
+// agar transaksi yang sengaja dihapus user tidak muncul kembali. Semua perubahan disimpan dalam satu DB transaction
+// dan ditolak (409) jika ada perubahan di periode yang terkunci.
+func (u *CrudTransaction) Sync(ctx context.Context, userID int64, req usecaseEntity.SyncTransactionReq) (*usecaseEntity.SyncTransactionResponse, error) {
+	funcName := "CrudTransaction.Sync"
+	logFields := generalEntity.CaptureFields{
+		"user_id": strconv.FormatInt(userID, 10),
+		"rows":    strconv.Itoa(len(req.Rows)),
+	}
+
+	if userID == 0 {
+		err := errors.New("user ID tidak ditemukan di konteks request")
+		helper.LogError(funcName, "validasi request", err, logFields, "UserID tidak ditemukan")
+		return nil, apperr.ErrInvalidRequest().SetDetail("User ID is required")
+	}
+
+	// Tolak penulisan data dari user yang sudah tidak aktif
+	if err := u.UserStatus.EnsureActive(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if len(req.Rows) == 0 {
+		return nil, apperr.ErrInvalidRequest().SetDetail("rows must not be empty")
+	}
+
+	// 1. Validasi semua baris sebelum menyimpan apa pun
+	references := make([]string, 0, len(req.Rows))
+	seen := map[string]bool{}
+	usesCategory := false
+	incoming := make([]*myentity.Transaction, len(req.Rows))
+	for i, row := range req.Rows {
+		if row.Reference == "" {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("row %d: reference is required", i+1))
+		}
+		if len(row.Reference) > maxReferenceLength {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("row %d: reference must not exceed %d characters", i+1, maxReferenceLength))
+		}
+		if seen[row.Reference] {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("row %d: reference '%s' is listed more than once", i+1, row.Reference))
+		}
+		seen[row.Reference] = true
+
+		if row.Type != usecaseEntity.TransactionTypeIncomeStr && row.Type != usecaseEntity.TransactionTypeExpenseStr {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("row %d: type must be income or expense", i+1))
+		}
+		if row.Amount <= 0 {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("row %d: amount must be greater than 0", i+1))
+		}
+		if err := helper.ValidateStoredAmount(row.Amount); err != nil {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("row %d: invalid amount: %s", i+1, err.Error()))
+		}
+		currency, err := resolveCurrency(row.Currency, u.CurrencyOption.Code)
+		if err != nil {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("row %d: invalid currency: %s", i+1, err.Error()))
+		}
+		if err := helper.ValidateAmountScale(row.Amount, currency); err != nil {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("row %d: invalid amount: %s", i+1, err.Error()))
+		}
+		parsedDate, err := helper.ParseDateStrict(row.TransactionDate)
+		if err != nil {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("row %d: invalid transaction_date: %s", i+1, err.Error()))
+		}
+		if err := helper.ValidateDescription(row.Description); err != nil {
+			return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("row %d: invalid description: %s", i+1, err.Error()))
+		}
+
+		data := &myentity.Transaction{
+			UserID:          userID,
+			Amount:          row.Amount,
+			Currency:        currency,
+			Type:            myentity.TransactionType(row.Type),
+			Reference:       sql.NullString{String: row.Reference, Valid: true},
+			TransactionDate: parsedDate,
+		}
+		if row.CategoryID != nil && *row.CategoryID > 0 {
+			usesCategory = true
+			data.CategoryID = sql.NullInt64{Int64: *row.CategoryID, Valid: true}
+		}
+		if row.Description != nil {
+			data.Description = sql.NullString{String: *row.Description, Valid: true}
+		}
+
+		references = append(references, row.Reference)
+		incoming[i] = data
+	}
+
+	// 2. Pastikan kategori yang dipakai milik user dan tipenya cocok dengan tipe transaksi
+	if usesCategory {
+		categories, err := u.CategoryRepo.GetAll(ctx, userID)
+		if err != nil {
+			helper.LogError(funcName, "CategoryRepo.GetAll", err, logFields, "")
+			return nil, err
+		}
+
+		owned := make(map[int64]*myentity.Category, len(categories))
+		for _, category := range categories {
+			owned[category.ID] = category
+		}
+		for i, data := range incoming {
+			if !data.CategoryID.Valid {
+				continue
+			}
+			category, ok := owned[data.CategoryID.Int64]
+			if !ok {
+				return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("row %d: invalid category ID provided", i+1))
+			}
+			if !categoryAllowsType(category, data.Type) {
+				return nil, apperr.ErrInvalidRequest().SetDetail(fmt.Sprintf("row %d: transaction type does not match the category type", i+1))
+			}
+		}
+	}
+
+	// 3. Cocokkan dengan transaksi yang sudah ada berdasarkan reference
+	existing, err := u.TransactionRepo.GetByUserIDAndReferences(ctx, userID, references)
+	if err != nil {
+		helper.LogError(funcName, "TransactionRepo.GetByUserIDAndReferences", err, logFields, "")
+		return nil, err
+	}
+
+	byReference := make(map[string]*myentity.Transaction, len(existing))
+	for _, trx := range existing {
+		byReference[trx.Reference.String] = trx
+	}
+
+	// 4. Tolak seluruh sinkronisasi jika ada transaksi baru, atau transaksi yang berubah (tanggal lama maupun baru),
+	// di periode yang terkunci
+	changing := make([]*myentity.Transaction, 0, len(incoming))
+	for _, data := range incoming {
+		current, ok := byReference[data.Reference.String]
+		if ok && (current.DeletedAt.Valid || sameSyncedFields(current, data)) {
+			continue
+		}
+		changing = append(changing, data)
+		if ok {
+			changing = append(changing, current)
+		}
+	}
+	if err := ensureTransactionsUnlocked(ctx, u.PeriodLock, userID, changing); err != nil {
+		helper.LogError(funcName, "ensureTransactionsUnlocked", err, logFields, "")
+		return nil, err
+	}
+
+	result := &usecaseEntity.SyncTransactionResponse{Results: make([]usecaseEntity.SyncResult, len(incoming))}
+	err = mysql.DBTransaction(u.TransactionRepo, func(trx mysql.TrxObj) error {
+		for i, data := range incoming {
+			reference := data.Reference.String
+			current, ok := byReference[reference]
+
+			switch {
+			case !ok:
+				data.CreatedAt = helper.DatetimeNowJakarta()
+				data.UpdatedAt = helper.DatetimeNowJakarta()
+				if err := u.TransactionRepo.Create(ctx, trx, data, false); err != nil {
+					helper.LogError(funcName, "TransactionRepo.Create", err, logFields, "")
+					return err
+				}
+				result.Results[i] = usecaseEntity.SyncResult{Reference: reference, ID: data.ID, Action: usecaseEntity.SyncActionCreated}
+
+			case current.DeletedAt.Valid:
+				// Reference tetap unik per user walaupun transaksinya dihapus, jadi tidak bisa dibuat ulang
+				result.Results[i] = usecaseEntity.SyncResult{Reference: reference, ID: current.ID, Action: usecaseEntity.SyncActionSkipped}
+
+			case sameSyncedFields(current, data):
+				result.Results[i] = usecaseEntity.SyncResult{Reference: reference, ID: current.ID, Action: usecaseEntity.SyncActionUnchanged}
+
+			default:
+				// Update seluruh kolom agar category_id/description yang dikosongkan ikut tersimpan sebagai NULL
+				updated := *current
+				updated.CategoryID = data.CategoryID
+				updated.Amount = data.Amount
+				updated.Currency = data.Currency
+				updated.Type = data.Type
+				updated.Description = data.Description
+				updated.TransactionDate = data.TransactionDate
+				updated.UpdatedAt = helper.DatetimeNowJakarta()
+				if err := u.TransactionRepo.Update(ctx, trx, &updated, nil); err != nil {
+					helper.LogError(funcName, "TransactionRepo.Update", err, logFields, "")
+					return err
+				}
+				result.Results[i] = usecaseEntity.SyncResult{Reference: reference, ID: current.ID, Action: usecaseEntity.SyncActionUpdated}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// sameSyncedFields membandingkan field yang dikirim oleh sinkronisasi bank.
+func sameSyncedFields(current, incoming *myentity.Transaction) bool {
+	return current.Amount == incoming.Amount &&
+		current.Currency == incoming.Currency &&
+		current.Type == incoming.Type &&
+		current.CategoryID == incoming.CategoryID &&
+		current.Description == incoming.Description &&
+		current.TransactionDate.Format(helper.DateLayout) == incoming.TransactionDate.Format(helper.DateLayout)
+}
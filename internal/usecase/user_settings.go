@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/entity"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+)
+
+// UserSettings manages per-user preferences stored on the users table.
+type UserSettings struct {
+	userRepo mysql.UserRepository
+}
+
+type IUserSettings interface {
+	GetSettings(ctx context.Context, userID int64) (*entity.UserSettingsResponse, error)
+	UpdateSettings(ctx context.Context, userID int64, req entity.UpdateUserSettingsReq) (*entity.UserSettingsResponse, error)
+}
+
+// IUserTimezone resolves the zone used to format timestamps for a user.
+type IUserTimezone interface {
+	Location(ctx context.Context, userID int64) (*time.Location, error)
+}
+
+func NewUserSettings(userRepo mysql.UserRepository) *UserSettings {
+	return &UserSettings{userRepo: userRepo}
+}
+
+// GetSettings returns the effective settings, an unset timezone is reported as helper.DefaultTimezone.
+func (u *UserSettings) GetSettings(ctx context.Context, userID int64) (*entity.UserSettingsResponse, error) {
+	funcName := "UserSettings.GetSettings"
+
+	timezone, err := u.userRepo.GetTimezoneByID(ctx, userID)
+	if err != nil {
+		helper.LogError(funcName, "userRepo.GetTimezoneByID", err, entity.CaptureFields{"user_id": strconv.FormatInt(userID, 10)}, "")
+		return nil, err
+	}
+
+	return &entity.UserSettingsResponse{Timezone: effectiveTimezone(timezone)}, nil
+}
+
+// UpdateSettings validates and stores the settings sent in req.
+func (u *UserSettings) UpdateSettings(ctx context.Context, userID int64, req entity.UpdateUserSettingsReq) (*entity.UserSettingsResponse, error) {
+	funcName := "UserSettings.UpdateSettings"
+	logFields := entity.CaptureFields{"user_id": strconv.FormatInt(userID, 10)}
+
+	if req.Timezone != nil {
+		timezone := strings.TrimSpace(*req.Timezone)
+		if timezone != "" {
+			if err := helper.ValidateTimezone(timezone); err != nil {
+				return nil, apperr.ErrInvalidRequest().SetDetail(err.Error())
+			}
+		}
+
+		if err := u.userRepo.UpdateTimezone(ctx, userID, timezone); err != nil {
+			helper.LogError(funcName, "userRepo.UpdateTimezone", err, logFields, "")
+			return nil, err
+		}
+	}
+
+	return u.GetSettings(ctx, userID)
+}
+
+// Location returns the user's zone. A missing or invalid stored zone falls back to helper.DefaultTimezone.
+func (u *UserSettings) Location(ctx context.Context, userID int64) (*time.Location, error) {
+	funcName := "UserSettings.Location"
+
+	timezone, err := u.userRepo.GetTimezoneByID(ctx, userID)
+	if err != nil {
+		helper.LogError(funcName, "userRepo.GetTimezoneByID", err, entity.CaptureFields{"user_id": strconv.FormatInt(userID, 10)}, "")
+		return nil, err
+	}
+
+	return helper.LoadTimezone(timezone), nil
+}
+
+func effectiveTimezone(timezone string) string {
+	if helper.ValidateTimezone(timezone) != nil {
+		return helper.DefaultTimezone
+	}
+	return timezone
+}
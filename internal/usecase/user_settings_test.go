@@ -0,0 +1,92 @@
+package usecase_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rakahikmah/finance-tracking/entity"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type UserSettingsTestSuite struct {
+	suite.Suite
+
+	userRepo *mocks.UserRepository
+	settings *usecase.UserSettings
+	ctx      context.Context
+}
+
+func (s *UserSettingsTestSuite) SetupTest() {
+	s.userRepo = &mocks.UserRepository{}
+	s.settings = usecase.NewUserSettings(s.userRepo)
+	s.ctx = context.Background()
+}
+
+func TestUserSettings(t *testing.T) {
+	suite.Run(t, new(UserSettingsTestSuite))
+}
+
+func (s *UserSettingsTestSuite) TestLocation() {
+	testcases := []struct {
+		name   string
+		stored string
+		want   string
+	}{
+		{name: "stored zone", stored: "Europe/Berlin", want: "Europe/Berlin"},
+		{name: "unset falls back to Jakarta", stored: "", want: "Asia/Jakarta"},
+		{name: "invalid stored zone falls back to Jakarta", stored: "Mars/Olympus", want: "Asia/Jakarta"},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.userRepo.On("GetTimezoneByID", mock.Anything, int64(1)).Return(tc.stored, nil).Once()
+
+			loc, err := s.settings.Location(s.ctx, 1)
+			s.Require().NoError(err)
+			s.Equal(tc.want, loc.String())
+		})
+	}
+}
+
+func (s *UserSettingsTestSuite) TestUpdateSettings() {
+	s.Run("stores a valid zone", func() {
+		s.SetupTest()
+		s.userRepo.On("UpdateTimezone", mock.Anything, int64(1), "Asia/Makassar").Return(nil).Once()
+		s.userRepo.On("GetTimezoneByID", mock.Anything, int64(1)).Return("Asia/Makassar", nil).Once()
+
+		result, err := s.settings.UpdateSettings(s.ctx, 1, entity.UpdateUserSettingsReq{Timezone: strPtr(" Asia/Makassar ")})
+		s.Require().NoError(err)
+		s.Equal("Asia/Makassar", result.Timezone)
+	})
+
+	s.Run("empty zone resets to default", func() {
+		s.SetupTest()
+		s.userRepo.On("UpdateTimezone", mock.Anything, int64(1), "").Return(nil).Once()
+		s.userRepo.On("GetTimezoneByID", mock.Anything, int64(1)).Return("", nil).Once()
+
+		result, err := s.settings.UpdateSettings(s.ctx, 1, entity.UpdateUserSettingsReq{Timezone: strPtr("")})
+		s.Require().NoError(err)
+		s.Equal("Asia/Jakarta", result.Timezone)
+	})
+
+	s.Run("invalid zone", func() {
+		s.SetupTest()
+
+		_, err := s.settings.UpdateSettings(s.ctx, 1, entity.UpdateUserSettingsReq{Timezone: strPtr("Asia/Nowhere")})
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(http.StatusUnprocessableEntity, appErr.HTTPCode)
+		s.userRepo.AssertNotCalled(s.T(), "UpdateTimezone", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func strPtr(v string) *string {
+	return &v
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rakahikmah/finance-tracking/entity"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/repository/mysql"
+	mentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+)
+
+// UserStatusChecker rejects writes from users that are no longer active, even when their token is still valid.
+// Status is cached in process memory for ttl so it is not loaded on every request.
+type UserStatusChecker struct {
+	userRepo mysql.UserRepository
+	ttl      time.Duration
+
+	mu    sync.Mutex
+	cache map[int64]cachedUserStatus
+}
+
+type cachedUserStatus struct {
+	status    mentity.UserStatus
+	expiredAt time.Time
+}
+
+type IUserStatusChecker interface {
+	EnsureActive(ctx context.Context, userID int64) error
+}
+
+func NewUserStatusChecker(userRepo mysql.UserRepository, ttl time.Duration) *UserStatusChecker {
+	return &UserStatusChecker{
+		userRepo: userRepo,
+		ttl:      ttl,
+		cache:    make(map[int64]cachedUserStatus),
+	}
+}
+
+// EnsureActive returns apperr.ErrUnauthorized when the user is not active.
+func (u *UserStatusChecker) EnsureActive(ctx context.Context, userID int64) error {
+	funcName := "UserStatusChecker.EnsureActive"
+
+	status, err := u.status(ctx, userID)
+	if isUserNotFound(err) {
+		return apperr.ErrUnauthorized().SetDetail("User account is not active.")
+	}
+	if err != nil {
+		helper.LogError(funcName, "userRepo.GetStatusByID", err, entity.CaptureFields{"user_id": strconv.FormatInt(userID, 10)}, "")
+		return err
+	}
+
+	if status != mentity.UserStatusActive {
+		return apperr.ErrUnauthorized().SetDetail("User account is not active.")
+	}
+
+	return nil
+}
+
+// isUserNotFound matches by code rather than message, so a not found error
+// that was wrapped or given a detail by the repository still maps to 401.
+func isUserNotFound(err error) bool {
+	var appErr apperr.CustomErrorResponse
+	if !errors.As(err, &appErr) {
+		return false
+	}
+
+	notFound := apperr.ErrUserNotFound()
+	return appErr.ErrCode == notFound.ErrCode && appErr.HTTPCode == notFound.HTTPCode
+}
+
+func (u *UserStatusChecker) status(ctx context.Context, userID int64) (mentity.UserStatus, error) {
+	now := time.Now()
+
+	u.mu.Lock()
+	cached, ok := u.cache[userID]
+	if ok && !now.Before(cached.expiredAt) {
+		// Evict on read so users that stop calling do not stay in memory forever.
+		delete(u.cache, userID)
+		ok = false
+	}
+	u.mu.Unlock()
+	if ok {
+		return cached.status, nil
+	}
+
+	status, err := u.userRepo.GetStatusByID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	u.mu.Lock()
+	u.cache[userID] = cachedUserStatus{status: status, expiredAt: now.Add(u.ttl)}
+	u.mu.Unlock()
+
+	return status, nil
+}
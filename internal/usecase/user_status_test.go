@@ -0,0 +1,111 @@
+package usecase_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	errwrap "github.com/pkg/errors"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	mentity "github.com/rakahikmah/finance-tracking/internal/repository/mysql/entity"
+	"github.com/rakahikmah/finance-tracking/internal/usecase"
+	"github.com/rakahikmah/finance-tracking/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type UserStatusCheckerTestSuite struct {
+	suite.Suite
+
+	userRepo *mocks.UserRepository
+	checker  *usecase.UserStatusChecker
+	ctx      context.Context
+}
+
+func (s *UserStatusCheckerTestSuite) SetupTest() {
+	s.userRepo = &mocks.UserRepository{}
+	s.checker = usecase.NewUserStatusChecker(s.userRepo, time.Minute)
+	s.ctx = context.Background()
+}
+
+func TestUserStatusChecker(t *testing.T) {
+	suite.Run(t, new(UserStatusCheckerTestSuite))
+}
+
+func (s *UserStatusCheckerTestSuite) TestEnsureActive() {
+	testcases := []struct {
+		name     string
+		mockFunc func()
+		wantCode int
+	}{
+		{
+			name: "active user",
+			mockFunc: func() {
+				s.userRepo.On("GetStatusByID", mock.Anything, int64(1)).Return(mentity.UserStatusActive, nil).Once()
+			},
+		},
+		{
+			name: "suspended user",
+			mockFunc: func() {
+				s.userRepo.On("GetStatusByID", mock.Anything, int64(1)).Return(mentity.UserStatusSuspended, nil).Once()
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "deleted user",
+			mockFunc: func() {
+				s.userRepo.On("GetStatusByID", mock.Anything, int64(1)).Return(mentity.UserStatus(0), apperr.ErrUserNotFound()).Once()
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "deleted user with wrapped error",
+			mockFunc: func() {
+				err := errwrap.Wrap(apperr.ErrUserNotFound().SetDetail("user 1 not found"), "UserRepository.GetStatusByID")
+				s.userRepo.On("GetStatusByID", mock.Anything, int64(1)).Return(mentity.UserStatus(0), err).Once()
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			tc.mockFunc()
+
+			err := s.checker.EnsureActive(s.ctx, 1)
+			if tc.wantCode == 0 {
+				s.NoError(err)
+				return
+			}
+
+			var appErr apperr.CustomErrorResponse
+			s.Require().ErrorAs(err, &appErr)
+			s.Equal(tc.wantCode, appErr.HTTPCode)
+		})
+	}
+}
+
+func (s *UserStatusCheckerTestSuite) TestEnsureActiveCachesStatus() {
+	s.userRepo.On("GetStatusByID", mock.Anything, int64(1)).Return(mentity.UserStatusActive, nil).Once()
+
+	s.NoError(s.checker.EnsureActive(s.ctx, 1))
+	s.NoError(s.checker.EnsureActive(s.ctx, 1))
+
+	s.userRepo.AssertNumberOfCalls(s.T(), "GetStatusByID", 1)
+}
+
+func (s *UserStatusCheckerTestSuite) TestEnsureActiveReloadsExpiredStatus() {
+	s.checker = usecase.NewUserStatusChecker(s.userRepo, time.Nanosecond)
+	s.userRepo.On("GetStatusByID", mock.Anything, int64(1)).Return(mentity.UserStatusActive, nil).Once()
+	s.userRepo.On("GetStatusByID", mock.Anything, int64(1)).Return(mentity.UserStatusSuspended, nil).Once()
+
+	s.NoError(s.checker.EnsureActive(s.ctx, 1))
+	time.Sleep(time.Millisecond)
+
+	var appErr apperr.CustomErrorResponse
+	s.Require().ErrorAs(s.checker.EnsureActive(s.ctx, 1), &appErr)
+	s.Equal(http.StatusUnauthorized, appErr.HTTPCode)
+	s.userRepo.AssertNumberOfCalls(s.T(), "GetStatusByID", 2)
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/rakahikmah/finance-tracking/entity"
+	apperr "github.com/rakahikmah/finance-tracking/error"
+	"github.com/rakahikmah/finance-tracking/internal/helper"
+	"github.com/rakahikmah/finance-tracking/internal/queue"
+)
+
+// Webhook is a usecase for forwarding verified inbound webhook to Queue (Message Broker)
+type Webhook struct {
+	queue queue.Queue
+}
+
+func NewWebhookUsecase(
+	queue queue.Queue,
+) *Webhook {
+	return &Webhook{queue}
+}
+
+type WebhookUsecase interface {
+	EnqueueBankEvent(ctx context.Context, payload []byte) error
+}
+
+// EnqueueBankEvent validate payload is a JSON object then publish it to bank webhook consumer.
+// Payload is processed asynchronously by worker with topic queue.ProcessBankWebhook.
+func (w *Webhook) EnqueueBankEvent(ctx context.Context, payload []byte) error {
+	funcName := "WebhookUsecase.EnqueueBankEvent"
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(payload, &body); err != nil {
+		helper.LogError("json.Unmarshal", funcName, err, entity.CaptureFields{}, "")
+
+		return apperr.ErrInvalidRequest().SetDetail("Webhook payload must be a JSON object.")
+	}
+
+	if err := w.queue.Publish(queue.ProcessBankWebhook, payload, 1); err != nil {
+		helper.LogError("queue.Publish", funcName, err, entity.CaptureFields{}, "")
+
+		return err
+	}
+
+	return nil
+}
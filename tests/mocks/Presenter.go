@@ -5,6 +5,9 @@ package mocks
 import (
 	fiber "github.com/gofiber/fiber/v2"
 	mock "github.com/stretchr/testify/mock"
+
+	entity "github.com/rakahikmah/finance-tracking/entity"
+	json "github.com/rakahikmah/finance-tracking/internal/presenter/json"
 )
 
 // Presenter is an autogenerated mock type for the Presenter type
@@ -40,6 +43,34 @@ func (_m *Presenter) BuildSuccess(c *fiber.Ctx, data interface{}, message string
 	return r0
 }
 
+// BuildSuccessWithMeta provides a mock function with given fields: c, data, meta, message, code
+func (_m *Presenter) BuildSuccessWithMeta(c *fiber.Ctx, data interface{}, meta entity.PaginationMeta, message string, code int) error {
+	ret := _m.Called(c, data, meta, message, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx, interface{}, entity.PaginationMeta, string, int) error); ok {
+		r0 = rf(c, data, meta, message, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BuildSuccessStream provides a mock function with given fields: c, message, code, stream
+func (_m *Presenter) BuildSuccessStream(c *fiber.Ctx, message string, code int, stream json.StreamFunc) error {
+	ret := _m.Called(c, message, code, stream)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx, string, int, json.StreamFunc) error); ok {
+		r0 = rf(c, message, code, stream)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewPresenter interface {
 	mock.TestingT
 	Cleanup(func())